@@ -0,0 +1,198 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"backend/config"
+	"backend/health"
+	"backend/lifecycle"
+	"backend/repositories"
+	"backend/services"
+	"backend/utils"
+)
+
+// command is a subcommand of the main binary
+type command struct {
+	name    string
+	usage   string
+	summary string
+	run     func(args []string) error
+}
+
+func commands() []command {
+	return []command{
+		{"serve", "", "start the HTTP server (default)", serve},
+		{"migrate", "status | up | down | to VERSION", "show, apply or revert schema migrations", runMigrate},
+		{"seed", "", "create the default menus when there are none", runSeed},
+		{"create-admin", "--name NAME --email EMAIL [--password-stdin] [--actor EMAIL]", "create a verified administrator", runCreateAdmin},
+		{"reset-password", "--email EMAIL --actor EMAIL [--password-stdin]", "set a new password and revoke the user's sessions", runResetPassword},
+		{"verify-user", "--email EMAIL --actor EMAIL [--notes TEXT]", "verify a pending user", runVerifyUser},
+		{"set-role", "--email EMAIL --role ROLE --actor EMAIL", "change a user's role", runSetRole},
+		{"revoke-sessions", "--email EMAIL --actor EMAIL", "revoke every refresh token of a user", runRevokeSessions},
+		{"export-policy", "[--output FILE]", "write the menus and role grants as JSON", runExportPolicy},
+		{"import-policy", "--input FILE --actor EMAIL [--prune]", "apply menus and role grants from JSON", runImportPolicy},
+		{"cleanup-tokens", "", "delete expired refresh tokens", runCleanupTokens},
+	}
+}
+
+// runCommand runs the command named by the first argument
+func runCommand(args []string) error {
+	name := "serve"
+	if len(args) > 0 {
+		name, args = args[0], args[1:]
+	}
+
+	switch name {
+	case "help", "-h", "-help", "--help":
+		printUsage(os.Stdout)
+		return nil
+	}
+
+	for _, cmd := range commands() {
+		if cmd.name == name {
+			return cmd.run(args)
+		}
+	}
+
+	printUsage(os.Stderr)
+	return fmt.Errorf("unknown command %q", name)
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintf(w, "Usage: %s COMMAND [FLAGS]\n\nCommands:\n", filepath.Base(os.Args[0]))
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, cmd := range commands() {
+		fmt.Fprintf(tw, "  %s\t%s\n", cmd.name, cmd.summary)
+	}
+	tw.Flush()
+	fmt.Fprintf(w, "\nRun '%s COMMAND -h' for the flags of a command.\n", filepath.Base(os.Args[0]))
+}
+
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.Usage = func() {
+		for _, cmd := range commands() {
+			if cmd.name == name {
+				fmt.Fprintf(fs.Output(), "Usage: %s %s %s\n\n%s\n", filepath.Base(os.Args[0]), name, cmd.usage, cmd.summary)
+			}
+		}
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parseFlags parses the flags of a command that takes no positional
+// arguments and checks that the named flags are set
+func parseFlags(fs *flag.FlagSet, args []string, required ...string) error {
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("%s: unexpected argument %q", fs.Name(), fs.Arg(0))
+	}
+
+	for _, name := range required {
+		if fs.Lookup(name).Value.String() == "" {
+			return fmt.Errorf("%s: --%s is required", fs.Name(), name)
+		}
+	}
+	return nil
+}
+
+// cli holds the services used by the administrative commands. They are
+// built as in serve, so commands apply the same rules as the API.
+type cli struct {
+	repos *repositories.Repositories
+	users *services.UserService
+	auth  *services.AuthService
+	admin *services.AdminService
+	menus *services.MenuService
+	lc    *lifecycle.Lifecycle
+}
+
+// openCLI connects the configured storage backend. Like serve, it refuses to
+// work on a schema with pending migrations.
+func openCLI() (*cli, error) {
+	if config.AppConfig.StorageBackend == config.StorageMemory {
+		return nil, errors.New("the memory storage backend keeps no data between processes")
+	}
+
+	lc := lifecycle.New()
+	repos := openStorage(lc, health.NewChecker())
+
+	auditService := services.NewAuditService(repos.Audit, repos.Users)
+	// Commands only queue emails and webhook deliveries; the server's
+	// workers send them
+	webhookService := services.NewWebhookService(repos.Webhooks, auditService)
+	mailTransport, _ := openMailTransport()
+	emailService := services.NewEmailService(mailTransport, loadMailTemplates())
+	outboxService := services.NewOutboxService(repos.Outbox, emailService, auditService)
+	notificationService := services.NewNotificationService(repos.Notifications, repos.Devices, repos.Users, emailService, outboxService)
+	// Commands publish realtime events without subscribing; with the local
+	// broker they reach no one, as no stream is open in this process
+	realtimeService := services.NewRealtimeService(openBroker(), repos.Users)
+	return &cli{
+		repos: repos,
+		users: services.NewUserService(repos.Users, webhookService, realtimeService),
+		auth:  services.NewAuthService(repos.Users, repos.Tokens, emailService, outboxService, auditService, notificationService, realtimeService, repos.Transactor),
+		admin: services.NewAdminService(repos.Users, repos.Tokens, auditService, notificationService, webhookService, realtimeService),
+		menus: services.NewMenuService(repos.Menus, repos.Permissions, repos.Users, auditService, notificationService, webhookService, realtimeService, repos.Transactor),
+		lc:    lc,
+	}, nil
+}
+
+func (c *cli) close() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_ = c.lc.Stop(ctx)
+}
+
+// userID returns the ID of the user with the given email
+func (c *cli) userID(ctx context.Context, email string) (string, error) {
+	user, err := c.users.GetUserByEmail(ctx, email)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", email, err)
+	}
+	return user.ID.Hex(), nil
+}
+
+// actorID returns the ID of the administrator a command acts on behalf of;
+// audit events and grants are attributed to them
+func (c *cli) actorID(ctx context.Context, email string) (string, error) {
+	actor, err := c.users.GetUserByEmail(ctx, email)
+	if err != nil {
+		return "", fmt.Errorf("actor %s: %w", email, err)
+	}
+	if actor.Role != "admin" {
+		return "", fmt.Errorf("actor %s is not an administrator", email)
+	}
+	return actor.ID.Hex(), nil
+}
+
+// readPassword reads the password from the first line of standard input, or
+// generates one when fromStdin is false
+func readPassword(fromStdin bool) (password string, generated bool, err error) {
+	if !fromStdin {
+		password, err = utils.GenerateSecurePassword(config.AppConfig.PasswordResetLength)
+		if err != nil {
+			return "", false, utils.ErrPasswordGenerationFailed
+		}
+		return password, true, nil
+	}
+
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && !(errors.Is(err, io.EOF) && line != "") {
+		return "", false, fmt.Errorf("reading password from standard input: %w", err)
+	}
+	return strings.TrimRight(line, "\r\n"), false, nil
+}
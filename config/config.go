@@ -1,17 +1,50 @@
 package config
 
 import (
+	"fmt"
 	"log"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
+// Storage backends selectable with STORAGE_BACKEND
+const (
+	StorageMongoDB  = "mongodb"
+	StorageMemory   = "memory"
+	StoragePostgres = "postgres"
+)
+
+// Mail transports selectable with MAIL_TRANSPORT
+const (
+	MailSendGrid = "sendgrid"
+	MailSMTP     = "smtp"
+	MailCapture  = "capture"
+)
+
+// Realtime brokers selectable with REALTIME_BROKER
+const (
+	BrokerLocal    = "local"
+	BrokerMongoDB  = "mongodb"
+	BrokerPostgres = "postgres"
+)
+
+// Rate limit stores selectable with RATE_LIMIT_STORE
+const (
+	RateLimitLocal    = "local"
+	RateLimitMongoDB  = "mongodb"
+	RateLimitPostgres = "postgres"
+)
+
 type Config struct {
 	Port             string
 	Host             string
+	StorageBackend   string
 	MongoDBURI       string
+	PostgresURL      string
 	JWTAccessSecret  string
 	JWTRefreshSecret string
 	JWTAccessExpiry  string
@@ -19,16 +52,94 @@ type Config struct {
 	BcryptRounds     int
 	AppEnv           string
 
-	// SendGrid Email Configuration
-	SendGridAPIKey       string
-	SendGridFromEmail    string
-	SendGridFromName     string
-	ResetPasswordSubject string
+	// Email Configuration
+	MailTransport     string
+	MailFromEmail     string
+	MailFromName      string
+	MailCaptureDir    string
+	SendGridAPIKey    string
+	SMTPHost          string
+	SMTPPort          int
+	SMTPUsername      string
+	SMTPPassword      string
+	SMTPTLS           string
+	MailTemplateDir   string
+	MailDefaultLocale string
+	BrandProductName  string
+	BrandLogoURL      string
+	BrandSupportEmail string
 
 	// Password Reset Configuration
 	PasswordResetLength   int
 	PasswordResetAttempts int
 
+	// Bulk Administration Configuration
+	BulkMaxItems       int
+	BulkAsyncThreshold int
+
+	// User Import Configuration
+	ImportMaxRows int
+	// ImportMaxUnzippedSize bounds the uncompressed size of an XLSX upload in
+	// bytes. Like the other limits, 0 means unlimited.
+	ImportMaxUnzippedSize int64
+
+	// Logging Configuration
+	LogLevel  string
+	LogFormat string
+
+	// Metrics Configuration
+	MetricsEnabled bool
+	MetricsToken   string
+
+	// Tracing Configuration
+	TracingEnabled     bool
+	TracingServiceName string
+	TracingSampleRatio float64
+
+	// Admin Statistics Configuration
+	StatsCacheTTL time.Duration
+
+	// Request Deadline Configuration
+	RequestTimeout     time.Duration
+	LongRequestTimeout time.Duration
+
+	// Lifecycle Configuration
+	ShutdownTimeout        time.Duration
+	ShutdownReadinessDelay time.Duration
+
+	// Scheduler Configuration
+	SchedulerEnabled     bool
+	TokenCleanupSchedule string
+	JobHistoryRetention  time.Duration
+
+	// Email Outbox Configuration
+	OutboxPollInterval time.Duration
+	OutboxMaxAttempts  int
+	OutboxRetryBase    time.Duration
+	OutboxRetention    time.Duration
+
+	// Notification Configuration
+	NotificationRetention time.Duration
+
+	// Webhook Configuration
+	WebhookPollInterval         time.Duration
+	WebhookTimeout              time.Duration
+	WebhookMaxAttempts          int
+	WebhookRetryBase            time.Duration
+	WebhookDisableAfterFailures int
+	WebhookRetention            time.Duration
+	WebhookAllowPrivateTargets  bool
+
+	// Realtime Configuration
+	RealtimeBroker            string
+	RealtimeMaxStreamsPerUser int
+
+	// Rate Limit Configuration
+	RateLimitEnabled bool
+	RateLimitStore   string
+	ProxyHeader      string
+	TrustedProxies   []string
+
 	// Swagger Configuration
 	SwaggerEnabled  bool
 	SwaggerHost     string
@@ -55,7 +166,9 @@ func LoadConfig() {
 	AppConfig = &Config{
 		Port:             getEnv("PORT", "3000"),
 		Host:             getEnv("HOST", "localhost"),
+		StorageBackend:   getEnv("STORAGE_BACKEND", StorageMongoDB),
 		MongoDBURI:       getEnv("MONGODB_URI", "mongodb://localhost:27017/esp_backend_db"),
+		PostgresURL:      getEnv("POSTGRES_URL", "postgres://localhost:5432/esp_backend_db?sslmode=disable"),
 		JWTAccessSecret:  getEnv("JWT_ACCESS_SECRET", "your-access-secret"),
 		JWTRefreshSecret: getEnv("JWT_REFRESH_SECRET", "your-refresh-secret"),
 		JWTAccessExpiry:  getEnv("JWT_ACCESS_EXPIRY", "15m"),
@@ -63,16 +176,92 @@ func LoadConfig() {
 		BcryptRounds:     bcryptRounds,
 		AppEnv:           getEnv("APP_ENV", "development"),
 
-		// SendGrid Email Configuration
-		SendGridAPIKey:       getEnv("SENDGRID_API_KEY", ""),
-		SendGridFromEmail:    getEnv("SENDGRID_FROM_EMAIL", ""),
-		SendGridFromName:     getEnv("SENDGRID_FROM_NAME", ""),
-		ResetPasswordSubject: getEnv("RESET_PASSWORD_SUBJECT", "Reset Password"),
+		// Email Configuration; the SENDGRID_FROM_* names are still honoured
+		MailTransport:     getEnv("MAIL_TRANSPORT", MailSendGrid),
+		MailFromEmail:     getEnv("MAIL_FROM_EMAIL", getEnv("SENDGRID_FROM_EMAIL", "")),
+		MailFromName:      getEnv("MAIL_FROM_NAME", getEnv("SENDGRID_FROM_NAME", "")),
+		MailCaptureDir:    getEnv("MAIL_CAPTURE_DIR", ""),
+		SendGridAPIKey:    getEnv("SENDGRID_API_KEY", ""),
+		SMTPHost:          getEnv("SMTP_HOST", ""),
+		SMTPPort:          getEnvInt("SMTP_PORT", 587),
+		SMTPUsername:      getEnv("SMTP_USERNAME", ""),
+		SMTPPassword:      getEnv("SMTP_PASSWORD", ""),
+		SMTPTLS:           getEnv("SMTP_TLS", "starttls"),
+		MailTemplateDir:   getEnv("MAIL_TEMPLATE_DIR", ""),
+		MailDefaultLocale: getEnv("MAIL_DEFAULT_LOCALE", "en"),
+		BrandProductName:  getEnv("BRAND_PRODUCT_NAME", "Support"),
+		BrandLogoURL:      getEnv("BRAND_LOGO_URL", ""),
+		BrandSupportEmail: getEnv("BRAND_SUPPORT_EMAIL", ""),
 
 		// Password Reset Configuration
 		PasswordResetLength:   getEnvInt("PASSWORD_RESET_LENGTH", 10),
 		PasswordResetAttempts: getEnvInt("PASSWORD_RESET_ATTEMPTS", 3),
 
+		// Bulk Administration Configuration
+		BulkMaxItems:       getEnvInt("BULK_MAX_ITEMS", 5000),
+		BulkAsyncThreshold: getEnvInt("BULK_ASYNC_THRESHOLD", 50),
+
+		// User Import Configuration
+		ImportMaxRows:         getEnvInt("IMPORT_MAX_ROWS", 500),
+		ImportMaxUnzippedSize: int64(getEnvInt("IMPORT_MAX_UNZIPPED_MB", 20)) << 20,
+
+		// Logging Configuration
+		LogLevel:  getEnv("LOG_LEVEL", "info"),
+		LogFormat: getEnv("LOG_FORMAT", "json"),
+
+		// Metrics Configuration
+		MetricsEnabled: getEnvBool("METRICS_ENABLED", false),
+		MetricsToken:   getEnv("METRICS_TOKEN", ""),
+
+		// Tracing Configuration
+		TracingEnabled:     getEnvBool("TRACING_ENABLED", false),
+		TracingServiceName: getEnv("TRACING_SERVICE_NAME", "backend"),
+		TracingSampleRatio: getEnvFloat("TRACING_SAMPLE_RATIO", 1.0),
+
+		// Admin Statistics Configuration
+		StatsCacheTTL: time.Duration(getEnvInt("STATS_CACHE_TTL_SECONDS", 60)) * time.Second,
+
+		// Request Deadline Configuration
+		RequestTimeout:     time.Duration(getEnvInt("REQUEST_TIMEOUT_SECONDS", 10)) * time.Second,
+		LongRequestTimeout: time.Duration(getEnvInt("LONG_REQUEST_TIMEOUT_SECONDS", 300)) * time.Second,
+
+		// Lifecycle Configuration
+		ShutdownTimeout:        time.Duration(getEnvInt("SHUTDOWN_TIMEOUT_SECONDS", 30)) * time.Second,
+		ShutdownReadinessDelay: time.Duration(getEnvInt("SHUTDOWN_READINESS_DELAY_SECONDS", 0)) * time.Second,
+
+		// Scheduler Configuration
+		SchedulerEnabled:     getEnvBool("SCHEDULER_ENABLED", true),
+		TokenCleanupSchedule: getEnv("TOKEN_CLEANUP_SCHEDULE", "@hourly"),
+		JobHistoryRetention:  time.Duration(getEnvInt("JOB_HISTORY_RETENTION_DAYS", 30)) * 24 * time.Hour,
+
+		// Email Outbox Configuration
+		OutboxPollInterval: time.Duration(getEnvInt("OUTBOX_POLL_INTERVAL_SECONDS", 5)) * time.Second,
+		OutboxMaxAttempts:  getEnvInt("OUTBOX_MAX_ATTEMPTS", 8),
+		OutboxRetryBase:    time.Duration(getEnvInt("OUTBOX_RETRY_BASE_SECONDS", 30)) * time.Second,
+		OutboxRetention:    time.Duration(getEnvInt("OUTBOX_RETENTION_DAYS", 7)) * 24 * time.Hour,
+
+		// Notification Configuration
+		NotificationRetention: time.Duration(getEnvInt("NOTIFICATION_RETENTION_DAYS", 90)) * 24 * time.Hour,
+
+		// Webhook Configuration
+		WebhookPollInterval:         time.Duration(getEnvInt("WEBHOOK_POLL_INTERVAL_SECONDS", 5)) * time.Second,
+		WebhookTimeout:              time.Duration(getEnvInt("WEBHOOK_TIMEOUT_SECONDS", 10)) * time.Second,
+		WebhookMaxAttempts:          getEnvInt("WEBHOOK_MAX_ATTEMPTS", 8),
+		WebhookRetryBase:            time.Duration(getEnvInt("WEBHOOK_RETRY_BASE_SECONDS", 30)) * time.Second,
+		WebhookDisableAfterFailures: getEnvInt("WEBHOOK_DISABLE_AFTER_FAILURES", 20),
+		WebhookRetention:            time.Duration(getEnvInt("WEBHOOK_DELIVERY_RETENTION_DAYS", 30)) * 24 * time.Hour,
+		WebhookAllowPrivateTargets:  getEnvBool("WEBHOOK_ALLOW_PRIVATE_TARGETS", false),
+
+		// Realtime Configuration
+		RealtimeBroker:            getEnv("REALTIME_BROKER", BrokerLocal),
+		RealtimeMaxStreamsPerUser: getEnvInt("REALTIME_MAX_STREAMS_PER_USER", 5),
+
+		// Rate Limit Configuration
+		RateLimitEnabled: getEnvBool("RATE_LIMIT_ENABLED", true),
+		RateLimitStore:   getEnv("RATE_LIMIT_STORE", RateLimitLocal),
+		ProxyHeader:      getEnv("PROXY_HEADER", ""),
+		TrustedProxies:   getEnvList("TRUSTED_PROXIES"),
+
 		// Swagger Configuration
 		SwaggerEnabled:  getEnvBool("SWAGGER_ENABLED", true),
 		SwaggerHost:     getEnv("SWAGGER_HOST", "localhost:3000"),
@@ -113,6 +302,27 @@ func getEnvInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+func getEnvFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		floatValue, err := strconv.ParseFloat(value, 64)
+		if err == nil {
+			return floatValue
+		}
+	}
+	return defaultValue
+}
+
+// getEnvList splits a comma-separated value, dropping empty items
+func getEnvList(key string) []string {
+	var items []string
+	for _, item := range strings.Split(os.Getenv(key), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 // ShouldEnableSwagger determines if Swagger should be enabled based on environment and configuration
 func (c *Config) ShouldEnableSwagger() bool {
 	// Rule 1: Explicit configuration override
@@ -121,7 +331,7 @@ func (c *Config) ShouldEnableSwagger() bool {
 	}
 
 	// Rule 2: Development environment default
-	if c.AppEnv == "development" || c.AppEnv == "dev" {
+	if c.IsDevelopment() {
 		return true
 	}
 
@@ -138,3 +348,27 @@ func (c *Config) ShouldEnableSwagger() bool {
 	// Default: disabled
 	return false
 }
+
+// Validate rejects settings that would make no sense at runtime
+func (c *Config) Validate() error {
+	limits := []struct {
+		name  string
+		value int64
+	}{
+		{"BULK_MAX_ITEMS", int64(c.BulkMaxItems)},
+		{"IMPORT_MAX_ROWS", int64(c.ImportMaxRows)},
+		{"IMPORT_MAX_UNZIPPED_MB", c.ImportMaxUnzippedSize},
+	}
+	for _, limit := range limits {
+		if limit.value < 0 {
+			return fmt.Errorf("%s must be 0 (unlimited) or positive", limit.name)
+		}
+	}
+	return nil
+}
+
+// IsDevelopment reports whether the server runs in a development environment,
+// where development-only endpoints are exposed
+func (c *Config) IsDevelopment() bool {
+	return c.AppEnv == "development" || c.AppEnv == "dev"
+}
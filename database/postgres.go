@@ -0,0 +1,92 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"time"
+
+	"backend/config"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Pool is the PostgreSQL connection pool used when STORAGE_BACKEND=postgres
+var Pool *pgxpool.Pool
+
+func ConnectPostgres() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	pool, err := OpenPostgres(ctx, config.AppConfig.PostgresURL)
+	if err != nil {
+		slog.Error("Failed to connect to PostgreSQL", "error", err)
+		os.Exit(1)
+	}
+
+	slog.Info("Connected to PostgreSQL")
+	Pool = pool
+}
+
+// OpenPostgres connects to url and checks the connection. The schema is
+// managed by the migrations package.
+func OpenPostgres(ctx context.Context, url string) (*pgxpool.Pool, error) {
+	poolConfig, err := pgxpool.ParseConfig(url)
+	if err != nil {
+		return nil, err
+	}
+	// Scan timestamps in UTC, as the MongoDB driver does
+	poolConfig.AfterConnect = func(ctx context.Context, conn *pgx.Conn) error {
+		conn.TypeMap().RegisterType(&pgtype.Type{
+			Name:  "timestamptz",
+			OID:   pgtype.TimestamptzOID,
+			Codec: &pgtype.TimestamptzCodec{ScanLocation: time.UTC},
+		})
+		return nil
+	}
+
+	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	// Test the connection
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, err
+	}
+
+	return pool, nil
+}
+
+// PingPostgres checks that PostgreSQL is reachable
+func PingPostgres(ctx context.Context) error {
+	if Pool == nil {
+		return errors.New("database not connected")
+	}
+	return Pool.Ping(ctx)
+}
+
+// DisconnectPostgres closes the pool, waiting for acquired connections to be
+// released until ctx expires
+func DisconnectPostgres(ctx context.Context) error {
+	if Pool == nil {
+		return nil
+	}
+
+	closed := make(chan struct{})
+	go func() {
+		Pool.Close()
+		close(closed)
+	}()
+
+	select {
+	case <-closed:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -1,9 +1,6 @@
 package handlers
 
 import (
-	"context"
-	"time"
-
 	"backend/models"
 	"backend/services"
 	"backend/utils"
@@ -34,12 +31,11 @@ func NewAdminHandler(adminService *services.AdminService) *AdminHandler {
 // @Failure      500  {object}  models.SwaggerErrorResponse
 // @Router       /admin/users/pending [get]
 func (h *AdminHandler) GetPendingUsers(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	ctx := c.UserContext()
 
 	pendingUsers, err := h.adminService.GetPendingUsers(ctx)
 	if err != nil {
-		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get pending users", err.Error())
+		return utils.HandleError(c, err, "Failed to get pending users")
 	}
 
 	return utils.SuccessResponse(c, fiber.StatusOK, "Pending users retrieved successfully", pendingUsers)
@@ -75,21 +71,11 @@ func (h *AdminHandler) VerifyUser(c *fiber.Ctx) error {
 		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	ctx := c.UserContext()
 
 	err := h.adminService.VerifyUser(ctx, userID, adminID, &req)
 	if err != nil {
-		if utils.IsValidationError(err) {
-			return utils.ValidationErrorResponse(c, err)
-		}
-		if err == utils.ErrUserNotFound {
-			return utils.ErrorResponse(c, fiber.StatusNotFound, "User not found")
-		}
-		if err == utils.ErrUserAlreadyVerified {
-			return utils.ErrorResponse(c, fiber.StatusConflict, "User already verified")
-		}
-		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to verify user", err.Error())
+		return utils.HandleError(c, err, "Failed to verify user")
 	}
 
 	return utils.SuccessResponse(c, fiber.StatusOK, "User verified successfully", nil)
@@ -115,15 +101,11 @@ func (h *AdminHandler) GetUserDetails(c *fiber.Ctx) error {
 		return utils.ErrorResponse(c, fiber.StatusBadRequest, "User ID is required")
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	ctx := c.UserContext()
 
 	user, err := h.adminService.GetUserByID(ctx, userID)
 	if err != nil {
-		if err == utils.ErrUserNotFound {
-			return utils.ErrorResponse(c, fiber.StatusNotFound, "User not found")
-		}
-		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get user details", err.Error())
+		return utils.HandleError(c, err, "Failed to get user details")
 	}
 
 	return utils.SuccessResponse(c, fiber.StatusOK, "User details retrieved successfully", user.ToResponse())
@@ -152,26 +134,18 @@ func (h *AdminHandler) UpdateUserRole(c *fiber.Ctx) error {
 		return utils.ErrorResponse(c, fiber.StatusBadRequest, "User ID is required")
 	}
 
+	adminID := c.Locals("userID").(string)
+
 	var req models.AdminUserRoleUpdateRequest
 	if err := c.BodyParser(&req); err != nil {
 		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	ctx := c.UserContext()
 
-	user, err := h.adminService.UpdateUserRole(ctx, userID, &req)
+	user, err := h.adminService.UpdateUserRole(ctx, userID, adminID, &req)
 	if err != nil {
-		if utils.IsValidationError(err) {
-			return utils.ValidationErrorResponse(c, err)
-		}
-		if err == utils.ErrUserNotFound {
-			return utils.ErrorResponse(c, fiber.StatusNotFound, "User not found")
-		}
-		if err == utils.ErrLastAdminDemotion {
-			return utils.ErrorResponse(c, fiber.StatusConflict, "Cannot demote the last admin user")
-		}
-		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to update user role", err.Error())
+		return utils.HandleError(c, err, "Failed to update user role")
 	}
 
 	response := models.AdminUserRoleUpdateResponse{
@@ -181,3 +155,108 @@ func (h *AdminHandler) UpdateUserRole(c *fiber.Ctx) error {
 
 	return utils.SuccessResponse(c, fiber.StatusOK, "User role updated successfully", response)
 }
+
+// SuspendUser godoc
+// @Summary      Suspend user
+// @Description  Suspend a user account and revoke all of its sessions (admin only)
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string                   true  "User ID"
+// @Param        request  body      models.SuspensionRequest  true  "Suspension data"
+// @Success      200      {object}  models.SwaggerResponse
+// @Failure      400      {object}  models.SwaggerErrorResponse
+// @Failure      401      {object}  models.SwaggerErrorResponse
+// @Failure      403      {object}  models.SwaggerErrorResponse
+// @Failure      404      {object}  models.SwaggerErrorResponse
+// @Failure      409      {object}  models.SwaggerErrorResponse
+// @Failure      500      {object}  models.SwaggerErrorResponse
+// @Router       /admin/users/{id}/suspend [post]
+func (h *AdminHandler) SuspendUser(c *fiber.Ctx) error {
+	userID := c.Params("id")
+	if userID == "" {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "User ID is required")
+	}
+
+	adminID := c.Locals("userID").(string)
+
+	var req models.SuspensionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	ctx := c.UserContext()
+
+	err := h.adminService.SuspendUser(ctx, userID, adminID, &req)
+	if err != nil {
+		return utils.HandleError(c, err, "Failed to suspend user")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "User suspended successfully", nil)
+}
+
+// ReinstateUser godoc
+// @Summary      Reinstate user
+// @Description  Lift a user's suspension so they can log in again (admin only)
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "User ID"
+// @Success      200  {object}  models.SwaggerResponse
+// @Failure      401  {object}  models.SwaggerErrorResponse
+// @Failure      403  {object}  models.SwaggerErrorResponse
+// @Failure      404  {object}  models.SwaggerErrorResponse
+// @Failure      409  {object}  models.SwaggerErrorResponse
+// @Failure      500  {object}  models.SwaggerErrorResponse
+// @Router       /admin/users/{id}/reinstate [post]
+func (h *AdminHandler) ReinstateUser(c *fiber.Ctx) error {
+	userID := c.Params("id")
+	if userID == "" {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "User ID is required")
+	}
+
+	adminID := c.Locals("userID").(string)
+
+	ctx := c.UserContext()
+
+	err := h.adminService.ReinstateUser(ctx, userID, adminID)
+	if err != nil {
+		return utils.HandleError(c, err, "Failed to reinstate user")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "User reinstated successfully", nil)
+}
+
+// RevokeUserSessions godoc
+// @Summary      Revoke user sessions
+// @Description  Revoke all refresh tokens of a user (admin only)
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "User ID"
+// @Success      200  {object}  models.SwaggerResponse
+// @Failure      401  {object}  models.SwaggerErrorResponse
+// @Failure      403  {object}  models.SwaggerErrorResponse
+// @Failure      404  {object}  models.SwaggerErrorResponse
+// @Failure      500  {object}  models.SwaggerErrorResponse
+// @Router       /admin/users/{id}/revoke-sessions [post]
+func (h *AdminHandler) RevokeUserSessions(c *fiber.Ctx) error {
+	userID := c.Params("id")
+	if userID == "" {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "User ID is required")
+	}
+
+	adminID := c.Locals("userID").(string)
+
+	ctx := c.UserContext()
+
+	err := h.adminService.RevokeUserSessions(ctx, userID, adminID)
+	if err != nil {
+		return utils.HandleError(c, err, "Failed to revoke user sessions")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "User sessions revoked successfully", nil)
+}
@@ -1,9 +1,6 @@
 package handlers
 
 import (
-	"context"
-	"time"
-
 	"backend/models"
 	"backend/services"
 	"backend/utils"
@@ -29,7 +26,7 @@ func NewAuthHandler(authService *services.AuthService) *AuthHandler {
 // @Produce      json
 // @Param        request  body      models.UserCreateRequest  true  "User registration data"
 // @Success      201      {object}  models.SwaggerRegisterPendingResponse
-// @Failure      400      {object}  models.SwaggerErrorResponse
+// @Failure      400      {object}  models.SwaggerValidationErrorResponse
 // @Failure      409      {object}  models.SwaggerErrorResponse
 // @Failure      500      {object}  models.SwaggerErrorResponse
 // @Router       /auth/register [post]
@@ -40,18 +37,11 @@ func (h *AuthHandler) Register(c *fiber.Ctx) error {
 		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	ctx := c.UserContext()
 
 	response, err := h.authService.Register(ctx, &req)
 	if err != nil {
-		if utils.IsValidationError(err) {
-			return utils.ValidationErrorResponse(c, err)
-		}
-		if err == utils.ErrUserAlreadyExists {
-			return utils.ErrorResponse(c, fiber.StatusConflict, "User already exists")
-		}
-		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to register user", err.Error())
+		return utils.HandleError(c, err, "Failed to register user")
 	}
 
 	return utils.SuccessResponse(c, fiber.StatusCreated, response.Message, response)
@@ -77,21 +67,11 @@ func (h *AuthHandler) Login(c *fiber.Ctx) error {
 		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	ctx := c.UserContext()
 
 	response, err := h.authService.Login(ctx, &req)
 	if err != nil {
-		if utils.IsValidationError(err) {
-			return utils.ValidationErrorResponse(c, err)
-		}
-		if err == utils.ErrInvalidCredentials {
-			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Invalid credentials")
-		}
-		if err == utils.ErrUserNotVerified {
-			return utils.ErrorResponse(c, fiber.StatusForbidden, "Account not verified by admin")
-		}
-		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to login", err.Error())
+		return utils.HandleError(c, err, "Failed to login")
 	}
 
 	return utils.SuccessResponse(c, fiber.StatusOK, "Login successful", response)
@@ -116,15 +96,11 @@ func (h *AuthHandler) RefreshToken(c *fiber.Ctx) error {
 		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	ctx := c.UserContext()
 
 	tokens, err := h.authService.RefreshToken(ctx, req.RefreshToken)
 	if err != nil {
-		if utils.IsAuthError(err) {
-			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Invalid or expired refresh token")
-		}
-		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to refresh token", err.Error())
+		return utils.HandleError(c, err, "Failed to refresh token")
 	}
 
 	return utils.SuccessResponse(c, fiber.StatusOK, "Token refreshed successfully", tokens)
@@ -148,15 +124,11 @@ func (h *AuthHandler) Logout(c *fiber.Ctx) error {
 		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	ctx := c.UserContext()
 
 	err := h.authService.Logout(ctx, req.RefreshToken)
 	if err != nil {
-		if err == utils.ErrTokenNotFound {
-			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid refresh token")
-		}
-		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to logout", err.Error())
+		return utils.HandleError(c, err, "Failed to logout")
 	}
 
 	return utils.SuccessResponse(c, fiber.StatusOK, "Logout successful", nil)
@@ -176,12 +148,11 @@ func (h *AuthHandler) Logout(c *fiber.Ctx) error {
 func (h *AuthHandler) LogoutAll(c *fiber.Ctx) error {
 	userID := c.Locals("userID").(string)
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	ctx := c.UserContext()
 
 	err := h.authService.LogoutAll(ctx, userID)
 	if err != nil {
-		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to logout from all devices", err.Error())
+		return utils.HandleError(c, err, "Failed to logout from all devices")
 	}
 
 	return utils.SuccessResponse(c, fiber.StatusOK, "Logged out from all devices successfully", nil)
@@ -206,24 +177,11 @@ func (h *AuthHandler) ForgotPassword(c *fiber.Ctx) error {
 		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
+	ctx := c.UserContext()
 
 	response, err := h.authService.ForgotPassword(ctx, &req)
 	if err != nil {
-		if utils.IsValidationError(err) {
-			return utils.ValidationErrorResponse(c, err)
-		}
-		if err == utils.ErrUserNotEligibleForReset {
-			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Account not eligible for password reset")
-		}
-		if err == utils.ErrPasswordResetLimitExceeded {
-			return utils.ErrorResponse(c, fiber.StatusTooManyRequests, "Too many password reset attempts. Please try again later.")
-		}
-		if err == utils.ErrEmailDeliveryFailed {
-			return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to send reset email. Please try again later.")
-		}
-		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to process password reset request", err.Error())
+		return utils.HandleError(c, err, "Failed to process password reset request")
 	}
 
 	return utils.SuccessResponse(c, fiber.StatusOK, "Password reset processed successfully", response)
@@ -0,0 +1,208 @@
+package handlers
+
+import (
+	"backend/models"
+	"backend/services"
+	"backend/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type BulkAdminHandler struct {
+	bulkService *services.BulkAdminService
+}
+
+func NewBulkAdminHandler(bulkService *services.BulkAdminService) *BulkAdminHandler {
+	return &BulkAdminHandler{
+		bulkService: bulkService,
+	}
+}
+
+// BulkVerifyUsers godoc
+// @Summary      Bulk verify users
+// @Description  Verify a list of users or every user matching a filter (admin only). Large batches run as an asynchronous job.
+// @Tags         Admin Bulk Operations
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      models.BulkVerifyRequest  true  "Target users and verification notes"
+// @Success      200      {object}  models.SwaggerResponse{data=models.BulkOperationReport}
+// @Success      202      {object}  models.SwaggerResponse{data=models.BulkJobResponse}
+// @Failure      400      {object}  models.SwaggerErrorResponse
+// @Failure      401      {object}  models.SwaggerErrorResponse
+// @Failure      403      {object}  models.SwaggerErrorResponse
+// @Failure      500      {object}  models.SwaggerErrorResponse
+// @Router       /admin/users/bulk/verify [post]
+func (h *BulkAdminHandler) BulkVerifyUsers(c *fiber.Ctx) error {
+	adminID := c.Locals("userID").(string)
+
+	var req models.BulkVerifyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	ctx := c.UserContext()
+
+	result, err := h.bulkService.VerifyUsers(ctx, adminID, &req)
+	return h.respond(c, result, err, "Bulk verification")
+}
+
+// BulkUpdateUserRoles godoc
+// @Summary      Bulk change user roles
+// @Description  Change the role of a list of users or every user matching a filter (admin only). The last admin cannot be demoted.
+// @Tags         Admin Bulk Operations
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      models.BulkRoleUpdateRequest  true  "Target users and new role"
+// @Success      200      {object}  models.SwaggerResponse{data=models.BulkOperationReport}
+// @Success      202      {object}  models.SwaggerResponse{data=models.BulkJobResponse}
+// @Failure      400      {object}  models.SwaggerErrorResponse
+// @Failure      401      {object}  models.SwaggerErrorResponse
+// @Failure      403      {object}  models.SwaggerErrorResponse
+// @Failure      500      {object}  models.SwaggerErrorResponse
+// @Router       /admin/users/bulk/role [post]
+func (h *BulkAdminHandler) BulkUpdateUserRoles(c *fiber.Ctx) error {
+	adminID := c.Locals("userID").(string)
+
+	var req models.BulkRoleUpdateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	ctx := c.UserContext()
+
+	result, err := h.bulkService.UpdateUserRoles(ctx, adminID, &req)
+	return h.respond(c, result, err, "Bulk role update")
+}
+
+// BulkSuspendUsers godoc
+// @Summary      Bulk suspend users
+// @Description  Suspend a list of users or every user matching a filter and revoke their sessions (admin only)
+// @Tags         Admin Bulk Operations
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      models.BulkSuspendRequest  true  "Target users and suspension reason"
+// @Success      200      {object}  models.SwaggerResponse{data=models.BulkOperationReport}
+// @Success      202      {object}  models.SwaggerResponse{data=models.BulkJobResponse}
+// @Failure      400      {object}  models.SwaggerErrorResponse
+// @Failure      401      {object}  models.SwaggerErrorResponse
+// @Failure      403      {object}  models.SwaggerErrorResponse
+// @Failure      500      {object}  models.SwaggerErrorResponse
+// @Router       /admin/users/bulk/suspend [post]
+func (h *BulkAdminHandler) BulkSuspendUsers(c *fiber.Ctx) error {
+	adminID := c.Locals("userID").(string)
+
+	var req models.BulkSuspendRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	ctx := c.UserContext()
+
+	result, err := h.bulkService.SuspendUsers(ctx, adminID, &req)
+	return h.respond(c, result, err, "Bulk suspension")
+}
+
+// BulkRevokeSessions godoc
+// @Summary      Bulk revoke sessions
+// @Description  Revoke all refresh tokens for a list of users or every user matching a filter (admin only)
+// @Tags         Admin Bulk Operations
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      models.BulkRevokeSessionsRequest  true  "Target users"
+// @Success      200      {object}  models.SwaggerResponse{data=models.BulkOperationReport}
+// @Success      202      {object}  models.SwaggerResponse{data=models.BulkJobResponse}
+// @Failure      400      {object}  models.SwaggerErrorResponse
+// @Failure      401      {object}  models.SwaggerErrorResponse
+// @Failure      403      {object}  models.SwaggerErrorResponse
+// @Failure      500      {object}  models.SwaggerErrorResponse
+// @Router       /admin/users/bulk/revoke-sessions [post]
+func (h *BulkAdminHandler) BulkRevokeSessions(c *fiber.Ctx) error {
+	adminID := c.Locals("userID").(string)
+
+	var req models.BulkRevokeSessionsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	ctx := c.UserContext()
+
+	result, err := h.bulkService.RevokeSessions(ctx, adminID, &req)
+	return h.respond(c, result, err, "Bulk session revocation")
+}
+
+// GetBulkJobs godoc
+// @Summary      List bulk jobs
+// @Description  List the most recent asynchronous bulk jobs without their per-item results (admin only)
+// @Tags         Admin Bulk Operations
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        limit  query     int  false  "Maximum number of jobs (default 20, max 100)"
+// @Success      200    {object}  models.SwaggerResponse{data=[]models.BulkJobResponse}
+// @Failure      401    {object}  models.SwaggerErrorResponse
+// @Failure      403    {object}  models.SwaggerErrorResponse
+// @Failure      500    {object}  models.SwaggerErrorResponse
+// @Router       /admin/users/bulk/jobs [get]
+func (h *BulkAdminHandler) GetBulkJobs(c *fiber.Ctx) error {
+	limit := c.QueryInt("limit", 20)
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	ctx := c.UserContext()
+
+	jobs, err := h.bulkService.GetRecentJobs(ctx, int64(limit))
+	if err != nil {
+		return utils.HandleError(c, err, "Failed to fetch bulk jobs")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Bulk jobs fetched successfully", jobs)
+}
+
+// GetBulkJob godoc
+// @Summary      Get bulk job
+// @Description  Poll the progress and per-item results of an asynchronous bulk job (admin only)
+// @Tags         Admin Bulk Operations
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Job ID"
+// @Success      200  {object}  models.SwaggerResponse{data=models.BulkJobResponse}
+// @Failure      400  {object}  models.SwaggerErrorResponse
+// @Failure      401  {object}  models.SwaggerErrorResponse
+// @Failure      403  {object}  models.SwaggerErrorResponse
+// @Failure      404  {object}  models.SwaggerErrorResponse
+// @Failure      500  {object}  models.SwaggerErrorResponse
+// @Router       /admin/users/bulk/jobs/{id} [get]
+func (h *BulkAdminHandler) GetBulkJob(c *fiber.Ctx) error {
+	jobID := c.Params("id")
+	if jobID == "" {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Job ID is required")
+	}
+
+	ctx := c.UserContext()
+
+	job, err := h.bulkService.GetJob(ctx, jobID)
+	if err != nil {
+		return utils.HandleError(c, err, "Failed to fetch bulk job")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Bulk job fetched successfully", job)
+}
+
+// respond writes either the synchronous report or the accepted job
+func (h *BulkAdminHandler) respond(c *fiber.Ctx, result *services.BulkResult, err error, operation string) error {
+	if err != nil {
+		return utils.HandleError(c, err, operation+" failed")
+	}
+
+	if result.Job != nil {
+		return utils.SuccessResponse(c, fiber.StatusAccepted, operation+" job started", result.Job)
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, operation+" completed", result.Report)
+}
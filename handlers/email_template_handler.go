@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"backend/services"
+	"backend/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type EmailTemplateHandler struct {
+	emailService *services.EmailService
+}
+
+func NewEmailTemplateHandler(emailService *services.EmailService) *EmailTemplateHandler {
+	return &EmailTemplateHandler{
+		emailService: emailService,
+	}
+}
+
+// GetEmailTemplates godoc
+// @Summary      List email templates
+// @Description  List the email templates with the locales each is available in (admin only)
+// @Tags         Admin Email Templates
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  models.SwaggerResponse{data=models.EmailTemplatesResponse}
+// @Failure      401  {object}  models.SwaggerErrorResponse
+// @Failure      403  {object}  models.SwaggerErrorResponse
+// @Router       /admin/email-templates [get]
+func (h *EmailTemplateHandler) GetEmailTemplates(c *fiber.Ctx) error {
+	return utils.SuccessResponse(c, fiber.StatusOK, "Email templates fetched successfully", h.emailService.GetTemplates())
+}
+
+// PreviewEmailTemplate godoc
+// @Summary      Preview an email template
+// @Description  Render an email template with sample data and the configured branding (admin only). A locale without its own variant falls back to its base language, then to the default locale; the response reports the locale used. With format=html or format=text the body is returned as is for viewing.
+// @Tags         Admin Email Templates
+// @Accept       json
+// @Produce      json
+// @Produce      html
+// @Produce      plain
+// @Security     BearerAuth
+// @Param        kind    path      string  true   "Template kind"  example(password_reset)
+// @Param        locale  query     string  false  "Locale, such as en or id"
+// @Param        format  query     string  false  "Response format (default json)"  Enums(json, html, text)
+// @Success      200     {object}  models.SwaggerResponse{data=models.EmailPreviewResponse}
+// @Failure      400     {object}  models.SwaggerErrorResponse
+// @Failure      401     {object}  models.SwaggerErrorResponse
+// @Failure      403     {object}  models.SwaggerErrorResponse
+// @Failure      404     {object}  models.SwaggerErrorResponse
+// @Failure      500     {object}  models.SwaggerErrorResponse
+// @Router       /admin/email-templates/{kind}/preview [get]
+func (h *EmailTemplateHandler) PreviewEmailTemplate(c *fiber.Ctx) error {
+	format := c.Query("format", "json")
+	if format != "json" && format != "html" && format != "text" {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid format", "format must be json, html or text")
+	}
+
+	preview, err := h.emailService.Preview(c.Params("kind"), c.Query("locale"))
+	if err != nil {
+		return utils.HandleError(c, err, "Failed to render email template")
+	}
+
+	switch format {
+	case "html":
+		c.Type("html", "utf-8")
+		return c.SendString(preview.HTMLBody)
+	case "text":
+		c.Type("txt", "utf-8")
+		return c.SendString(preview.TextBody)
+	}
+	return utils.SuccessResponse(c, fiber.StatusOK, "Email template rendered successfully", preview)
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"backend/health"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type HealthHandler struct {
+	checker *health.Checker
+}
+
+func NewHealthHandler(checker *health.Checker) *HealthHandler {
+	return &HealthHandler{
+		checker: checker,
+	}
+}
+
+// The probes are served outside /api/v1, so they are not part of the Swagger spec.
+
+// Livez reports that the process is running. It does not check dependencies,
+// so a failing database never gets the pod restarted.
+func (h *HealthHandler) Livez(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{
+		"status": "ok",
+	})
+}
+
+// Readyz runs the readiness checks and returns 503 when any component is
+// down or the server is shutting down. The probe is public, so it reports
+// which components are down but not why; GetSystemHealth has the errors.
+func (h *HealthHandler) Readyz(c *fiber.Ctx) error {
+	report := h.checker.Check(c.UserContext())
+	return c.Status(readinessStatus(report)).JSON(report.WithoutErrors())
+}
+
+// GetSystemHealth godoc
+// @Summary      Verbose readiness report
+// @Description  Readiness report including version and build information (admin only)
+// @Tags         Admin
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  health.Report
+// @Failure      401  {object}  models.SwaggerErrorResponse
+// @Failure      403  {object}  models.SwaggerErrorResponse
+// @Failure      503  {object}  health.Report
+// @Router       /admin/health [get]
+func (h *HealthHandler) GetSystemHealth(c *fiber.Ctx) error {
+	report := h.checker.Check(c.UserContext())
+	report.Build = h.checker.BuildInfo()
+	return c.Status(readinessStatus(report)).JSON(report)
+}
+
+func readinessStatus(report *health.Report) int {
+	if report.Status != health.StatusReady {
+		return fiber.StatusServiceUnavailable
+	}
+	return fiber.StatusOK
+}
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"backend/scheduler"
+	"backend/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type JobHandler struct {
+	scheduler *scheduler.Scheduler
+}
+
+func NewJobHandler(scheduler *scheduler.Scheduler) *JobHandler {
+	return &JobHandler{
+		scheduler: scheduler,
+	}
+}
+
+// GetJobs godoc
+// @Summary      List scheduled jobs
+// @Description  List the maintenance jobs with their schedule, next run and most recent run on any replica (admin only)
+// @Tags         Admin Jobs
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  models.SwaggerResponse{data=[]models.JobResponse}
+// @Failure      401  {object}  models.SwaggerErrorResponse
+// @Failure      403  {object}  models.SwaggerErrorResponse
+// @Failure      500  {object}  models.SwaggerErrorResponse
+// @Router       /admin/jobs [get]
+func (h *JobHandler) GetJobs(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	jobs, err := h.scheduler.Jobs(ctx)
+	if err != nil {
+		return utils.HandleError(c, err, "Failed to fetch jobs")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Jobs fetched successfully", jobs)
+}
+
+// GetJobRuns godoc
+// @Summary      Get job history
+// @Description  List the most recent runs of a scheduled job, newest first (admin only)
+// @Tags         Admin Jobs
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        name   path      string  true   "Job name"
+// @Param        limit  query     int     false  "Maximum number of runs (default 20, max 100)"
+// @Success      200    {object}  models.SwaggerResponse{data=[]models.JobRunResponse}
+// @Failure      401    {object}  models.SwaggerErrorResponse
+// @Failure      403    {object}  models.SwaggerErrorResponse
+// @Failure      404    {object}  models.SwaggerErrorResponse
+// @Failure      500    {object}  models.SwaggerErrorResponse
+// @Router       /admin/jobs/{name}/runs [get]
+func (h *JobHandler) GetJobRuns(c *fiber.Ctx) error {
+	limit := c.QueryInt("limit", 20)
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	ctx := c.UserContext()
+
+	runs, err := h.scheduler.Runs(ctx, c.Params("name"), int64(limit))
+	if err != nil {
+		return utils.HandleError(c, err, "Failed to fetch job runs")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Job runs fetched successfully", runs)
+}
+
+// RunJob godoc
+// @Summary      Run a job now
+// @Description  Start a scheduled job immediately on this replica. The run is recorded in the job history (admin only).
+// @Tags         Admin Jobs
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        name  path      string  true  "Job name"
+// @Success      202   {object}  models.SwaggerResponse
+// @Failure      401   {object}  models.SwaggerErrorResponse
+// @Failure      403   {object}  models.SwaggerErrorResponse
+// @Failure      404   {object}  models.SwaggerErrorResponse
+// @Failure      409   {object}  models.SwaggerErrorResponse
+// @Failure      500   {object}  models.SwaggerErrorResponse
+// @Failure      503   {object}  models.SwaggerErrorResponse
+// @Router       /admin/jobs/{name}/run [post]
+func (h *JobHandler) RunJob(c *fiber.Ctx) error {
+	adminID := c.Locals("userID").(string)
+
+	ctx := c.UserContext()
+
+	if err := h.scheduler.Trigger(ctx, c.Params("name"), adminID); err != nil {
+		return utils.HandleError(c, err, "Failed to start job")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusAccepted, "Job started", nil)
+}
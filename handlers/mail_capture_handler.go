@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"backend/mailer"
+	"backend/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// MailCaptureHandler serves the messages kept by the capture transport. Its
+// routes exist only in development and are not part of the API documentation.
+type MailCaptureHandler struct {
+	capture *mailer.Capture
+}
+
+func NewMailCaptureHandler(capture *mailer.Capture) *MailCaptureHandler {
+	return &MailCaptureHandler{
+		capture: capture,
+	}
+}
+
+// GetMessages lists captured emails without their bodies, newest first
+func (h *MailCaptureHandler) GetMessages(c *fiber.Ctx) error {
+	return utils.SuccessResponse(c, fiber.StatusOK, "Captured emails fetched successfully", h.capture.Messages())
+}
+
+// GetMessage returns a captured email with both bodies
+func (h *MailCaptureHandler) GetMessage(c *fiber.Ctx) error {
+	message, err := h.capture.Get(c.Params("id"))
+	if err != nil {
+		return utils.HandleError(c, err, "Failed to fetch captured email")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Captured email fetched successfully", message)
+}
+
+// GetMessageHTML renders the HTML body of a captured email for viewing in a browser
+func (h *MailCaptureHandler) GetMessageHTML(c *fiber.Ctx) error {
+	message, err := h.capture.Get(c.Params("id"))
+	if err != nil {
+		return utils.HandleError(c, err, "Failed to fetch captured email")
+	}
+
+	c.Type("html", "utf-8")
+	return c.SendString(message.HTMLBody)
+}
+
+// ClearMessages forgets every captured email
+func (h *MailCaptureHandler) ClearMessages(c *fiber.Ctx) error {
+	h.capture.Clear()
+	return utils.SuccessResponse(c, fiber.StatusOK, "Captured emails cleared", nil)
+}
@@ -1,9 +1,6 @@
 package handlers
 
 import (
-	"context"
-	"time"
-
 	"backend/models"
 	"backend/services"
 	"backend/utils"
@@ -35,6 +32,7 @@ func NewMenuHandler(menuService *services.MenuService) *MenuHandler {
 // @Failure      400      {object}  models.SwaggerErrorResponse
 // @Failure      401      {object}  models.SwaggerErrorResponse
 // @Failure      403      {object}  models.SwaggerErrorResponse
+// @Failure      409      {object}  models.SwaggerErrorResponse
 // @Failure      500      {object}  models.SwaggerErrorResponse
 // @Router       /admin/menus [post]
 func (h *MenuHandler) CreateMenu(c *fiber.Ctx) error {
@@ -44,15 +42,11 @@ func (h *MenuHandler) CreateMenu(c *fiber.Ctx) error {
 		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	ctx := c.UserContext()
 
 	response, err := h.menuService.CreateMenu(ctx, &req)
 	if err != nil {
-		if utils.IsValidationError(err) {
-			return utils.ValidationErrorResponse(c, err)
-		}
-		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to create menu", err.Error())
+		return utils.HandleError(c, err, "Failed to create menu")
 	}
 
 	return utils.SuccessResponse(c, fiber.StatusCreated, "Menu created successfully", response)
@@ -71,12 +65,11 @@ func (h *MenuHandler) CreateMenu(c *fiber.Ctx) error {
 // @Failure      500      {object}  models.SwaggerErrorResponse
 // @Router       /admin/menus [get]
 func (h *MenuHandler) GetAllMenus(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	ctx := c.UserContext()
 
 	response, err := h.menuService.GetAllMenus(ctx)
 	if err != nil {
-		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to fetch menus", err.Error())
+		return utils.HandleError(c, err, "Failed to fetch menus")
 	}
 
 	return utils.SuccessResponse(c, fiber.StatusOK, "Menus fetched successfully", response)
@@ -103,18 +96,11 @@ func (h *MenuHandler) GetMenuByID(c *fiber.Ctx) error {
 		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Menu ID is required")
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	ctx := c.UserContext()
 
 	response, err := h.menuService.GetMenuByID(ctx, id)
 	if err != nil {
-		if err == utils.ErrMenuNotFound {
-			return utils.ErrorResponse(c, fiber.StatusNotFound, "Menu not found")
-		}
-		if err == utils.ErrInvalidID {
-			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid menu ID format")
-		}
-		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to fetch menu", err.Error())
+		return utils.HandleError(c, err, "Failed to fetch menu")
 	}
 
 	return utils.SuccessResponse(c, fiber.StatusOK, "Menu fetched successfully", response)
@@ -134,6 +120,7 @@ func (h *MenuHandler) GetMenuByID(c *fiber.Ctx) error {
 // @Failure      401      {object}  models.SwaggerErrorResponse
 // @Failure      403      {object}  models.SwaggerErrorResponse
 // @Failure      404      {object}  models.SwaggerErrorResponse
+// @Failure      409      {object}  models.SwaggerErrorResponse
 // @Failure      500      {object}  models.SwaggerErrorResponse
 // @Router       /admin/menus/{id} [put]
 func (h *MenuHandler) UpdateMenu(c *fiber.Ctx) error {
@@ -147,21 +134,11 @@ func (h *MenuHandler) UpdateMenu(c *fiber.Ctx) error {
 		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	ctx := c.UserContext()
 
 	response, err := h.menuService.UpdateMenu(ctx, id, &req)
 	if err != nil {
-		if utils.IsValidationError(err) {
-			return utils.ValidationErrorResponse(c, err)
-		}
-		if err == utils.ErrMenuNotFound {
-			return utils.ErrorResponse(c, fiber.StatusNotFound, "Menu not found")
-		}
-		if err == utils.ErrInvalidID {
-			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid menu ID format")
-		}
-		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to update menu", err.Error())
+		return utils.HandleError(c, err, "Failed to update menu")
 	}
 
 	return utils.SuccessResponse(c, fiber.StatusOK, "Menu updated successfully", response)
@@ -188,18 +165,11 @@ func (h *MenuHandler) DeleteMenu(c *fiber.Ctx) error {
 		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Menu ID is required")
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	ctx := c.UserContext()
 
 	err := h.menuService.DeleteMenu(ctx, id)
 	if err != nil {
-		if err == utils.ErrMenuNotFound {
-			return utils.ErrorResponse(c, fiber.StatusNotFound, "Menu not found")
-		}
-		if err == utils.ErrInvalidID {
-			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid menu ID format")
-		}
-		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to delete menu", err.Error())
+		return utils.HandleError(c, err, "Failed to delete menu")
 	}
 
 	return utils.SuccessResponse(c, fiber.StatusOK, "Menu deleted successfully", nil)
@@ -233,21 +203,11 @@ func (h *MenuHandler) GrantPermission(c *fiber.Ctx) error {
 		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Role and menu ID are required")
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	ctx := c.UserContext()
 
 	err := h.menuService.GrantPermission(ctx, role, menuID, adminID)
 	if err != nil {
-		if err == utils.ErrMenuNotFound {
-			return utils.ErrorResponse(c, fiber.StatusNotFound, "Menu not found")
-		}
-		if err == utils.ErrPermissionAlreadyExists {
-			return utils.ErrorResponse(c, fiber.StatusConflict, "Permission already exists")
-		}
-		if err == utils.ErrInvalidID {
-			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid ID format")
-		}
-		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to grant permission", err.Error())
+		return utils.HandleError(c, err, "Failed to grant permission")
 	}
 
 	return utils.SuccessResponse(c, fiber.StatusOK, "Permission granted successfully", nil)
@@ -272,23 +232,17 @@ func (h *MenuHandler) GrantPermission(c *fiber.Ctx) error {
 func (h *MenuHandler) RevokePermission(c *fiber.Ctx) error {
 	role := c.Params("role")
 	menuID := c.Params("menuId")
+	adminID := c.Locals("userID").(string)
 
 	if role == "" || menuID == "" {
 		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Role and menu ID are required")
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	ctx := c.UserContext()
 
-	err := h.menuService.RevokePermission(ctx, role, menuID)
+	err := h.menuService.RevokePermission(ctx, role, menuID, adminID)
 	if err != nil {
-		if err == utils.ErrPermissionNotFound {
-			return utils.ErrorResponse(c, fiber.StatusNotFound, "Permission not found")
-		}
-		if err == utils.ErrInvalidID {
-			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid ID format")
-		}
-		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to revoke permission", err.Error())
+		return utils.HandleError(c, err, "Failed to revoke permission")
 	}
 
 	return utils.SuccessResponse(c, fiber.StatusOK, "Permission revoked successfully", nil)
@@ -314,12 +268,11 @@ func (h *MenuHandler) GetPermissionsByRole(c *fiber.Ctx) error {
 		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Role is required")
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	ctx := c.UserContext()
 
 	response, err := h.menuService.GetPermissionsByRole(ctx, role)
 	if err != nil {
-		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to fetch permissions", err.Error())
+		return utils.HandleError(c, err, "Failed to fetch permissions")
 	}
 
 	return utils.SuccessResponse(c, fiber.StatusOK, "Permissions fetched successfully", response)
@@ -346,18 +299,11 @@ func (h *MenuHandler) GetRolesByMenu(c *fiber.Ctx) error {
 		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Menu ID is required")
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	ctx := c.UserContext()
 
 	response, err := h.menuService.GetRolesByMenu(ctx, menuID)
 	if err != nil {
-		if err == utils.ErrMenuNotFound {
-			return utils.ErrorResponse(c, fiber.StatusNotFound, "Menu not found")
-		}
-		if err == utils.ErrInvalidID {
-			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid menu ID format")
-		}
-		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to fetch roles", err.Error())
+		return utils.HandleError(c, err, "Failed to fetch roles")
 	}
 
 	return utils.SuccessResponse(c, fiber.StatusOK, "Roles fetched successfully", response)
@@ -376,12 +322,11 @@ func (h *MenuHandler) GetRolesByMenu(c *fiber.Ctx) error {
 // @Failure      500  {object}  models.SwaggerErrorResponse
 // @Router       /admin/roles/permissions [get]
 func (h *MenuHandler) GetAllPermissions(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	ctx := c.UserContext()
 
 	response, err := h.menuService.GetAllPermissions(ctx)
 	if err != nil {
-		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to fetch permissions", err.Error())
+		return utils.HandleError(c, err, "Failed to fetch permissions")
 	}
 
 	return utils.SuccessResponse(c, fiber.StatusOK, "Permissions fetched successfully", response)
@@ -400,12 +345,11 @@ func (h *MenuHandler) GetAllPermissions(c *fiber.Ctx) error {
 // @Failure      500  {object}  models.SwaggerErrorResponse
 // @Router       /admin/roles/summary [get]
 func (h *MenuHandler) GetRolePermissionSummary(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	ctx := c.UserContext()
 
 	response, err := h.menuService.GetRolePermissionSummary(ctx)
 	if err != nil {
-		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to fetch role summary", err.Error())
+		return utils.HandleError(c, err, "Failed to fetch role summary")
 	}
 
 	return utils.SuccessResponse(c, fiber.StatusOK, "Role summary fetched successfully", response)
@@ -427,18 +371,17 @@ func (h *MenuHandler) GetRolePermissionSummary(c *fiber.Ctx) error {
 func (h *MenuHandler) GetUserMenus(c *fiber.Ctx) error {
 	userID := c.Locals("userID").(string)
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	ctx := c.UserContext()
 
 	// Get user to determine role
 	user, err := h.menuService.GetUserByID(ctx, userID)
 	if err != nil {
-		return utils.ErrorResponse(c, fiber.StatusUnauthorized, "User not found")
+		return utils.HandleError(c, err, "Failed to fetch user menus")
 	}
 
 	response, err := h.menuService.GetUserMenus(ctx, user.Role)
 	if err != nil {
-		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to fetch user menus", err.Error())
+		return utils.HandleError(c, err, "Failed to fetch user menus")
 	}
 
 	return utils.SuccessResponse(c, fiber.StatusOK, "User menus fetched successfully", response)
@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"backend/models"
+	"backend/services"
+	"backend/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type NotificationHandler struct {
+	notificationService *services.NotificationService
+}
+
+func NewNotificationHandler(notificationService *services.NotificationService) *NotificationHandler {
+	return &NotificationHandler{
+		notificationService: notificationService,
+	}
+}
+
+// GetNotifications godoc
+// @Summary      List notifications
+// @Description  List the current user's in-app notifications, newest first. Notifications are kept for NOTIFICATION_RETENTION_DAYS.
+// @Tags         Notifications
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        unread  query     bool  false  "Only unread notifications"
+// @Param        limit   query     int   false  "Maximum number of notifications (default 50, max 200)"
+// @Success      200     {object}  models.SwaggerResponse{data=[]models.NotificationResponse}
+// @Failure      401     {object}  models.SwaggerErrorResponse
+// @Failure      500     {object}  models.SwaggerErrorResponse
+// @Router       /users/notifications [get]
+func (h *NotificationHandler) GetNotifications(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(string)
+
+	limit := c.QueryInt("limit", 50)
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+
+	ctx := c.UserContext()
+
+	notifications, err := h.notificationService.GetNotifications(ctx, userID, c.QueryBool("unread"), int64(limit))
+	if err != nil {
+		return utils.HandleError(c, err, "Failed to fetch notifications")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Notifications fetched successfully", notifications)
+}
+
+// GetUnreadCount godoc
+// @Summary      Count unread notifications
+// @Description  Count the current user's unread notifications, for a badge
+// @Tags         Notifications
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  models.SwaggerResponse{data=models.UnreadCountResponse}
+// @Failure      401  {object}  models.SwaggerErrorResponse
+// @Failure      500  {object}  models.SwaggerErrorResponse
+// @Router       /users/notifications/unread-count [get]
+func (h *NotificationHandler) GetUnreadCount(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(string)
+
+	ctx := c.UserContext()
+
+	count, err := h.notificationService.CountUnread(ctx, userID)
+	if err != nil {
+		return utils.HandleError(c, err, "Failed to count unread notifications")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Unread notifications counted successfully", count)
+}
+
+// MarkNotificationRead godoc
+// @Summary      Mark a notification read
+// @Description  Mark one of the current user's notifications read. Marking a read notification again keeps the time it was first read.
+// @Tags         Notifications
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Notification ID"
+// @Success      200  {object}  models.SwaggerResponse
+// @Failure      400  {object}  models.SwaggerErrorResponse
+// @Failure      401  {object}  models.SwaggerErrorResponse
+// @Failure      404  {object}  models.SwaggerErrorResponse
+// @Failure      500  {object}  models.SwaggerErrorResponse
+// @Router       /users/notifications/{id}/read [post]
+func (h *NotificationHandler) MarkNotificationRead(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(string)
+
+	ctx := c.UserContext()
+
+	if err := h.notificationService.MarkRead(ctx, userID, c.Params("id")); err != nil {
+		return utils.HandleError(c, err, "Failed to mark notification read")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Notification marked read", nil)
+}
+
+// MarkAllNotificationsRead godoc
+// @Summary      Mark all notifications read
+// @Description  Mark every unread notification of the current user read
+// @Tags         Notifications
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  models.SwaggerResponse{data=models.MarkAllReadResponse}
+// @Failure      401  {object}  models.SwaggerErrorResponse
+// @Failure      500  {object}  models.SwaggerErrorResponse
+// @Router       /users/notifications/read-all [post]
+func (h *NotificationHandler) MarkAllNotificationsRead(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(string)
+
+	ctx := c.UserContext()
+
+	result, err := h.notificationService.MarkAllRead(ctx, userID)
+	if err != nil {
+		return utils.HandleError(c, err, "Failed to mark notifications read")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Notifications marked read", result)
+}
+
+// GetNotificationPreferences godoc
+// @Summary      Get notification preferences
+// @Description  Get the channels the current user receives each notification type on. Types the user has not chosen for show their defaults.
+// @Tags         Notifications
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  models.SwaggerResponse{data=models.NotificationPreferencesResponse}
+// @Failure      401  {object}  models.SwaggerErrorResponse
+// @Failure      500  {object}  models.SwaggerErrorResponse
+// @Router       /users/notifications/preferences [get]
+func (h *NotificationHandler) GetNotificationPreferences(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(string)
+
+	ctx := c.UserContext()
+
+	preferences, err := h.notificationService.GetPreferences(ctx, userID)
+	if err != nil {
+		return utils.HandleError(c, err, "Failed to fetch notification preferences")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Notification preferences fetched successfully", preferences)
+}
+
+// UpdateNotificationPreferences godoc
+// @Summary      Update notification preferences
+// @Description  Choose the channels the current user receives the given notification types on; other types keep their current channels. Turning both channels off mutes a type.
+// @Tags         Notifications
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      models.NotificationPreferencesRequest  true  "Channels per notification type"
+// @Success      200      {object}  models.SwaggerResponse{data=models.NotificationPreferencesResponse}
+// @Failure      400      {object}  models.SwaggerErrorResponse
+// @Failure      401      {object}  models.SwaggerErrorResponse
+// @Failure      500      {object}  models.SwaggerErrorResponse
+// @Router       /users/notifications/preferences [put]
+func (h *NotificationHandler) UpdateNotificationPreferences(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(string)
+
+	var req models.NotificationPreferencesRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	ctx := c.UserContext()
+
+	preferences, err := h.notificationService.UpdatePreferences(ctx, userID, &req)
+	if err != nil {
+		return utils.HandleError(c, err, "Failed to update notification preferences")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Notification preferences updated successfully", preferences)
+}
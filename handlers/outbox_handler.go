@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"backend/services"
+	"backend/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type OutboxHandler struct {
+	outboxService *services.OutboxService
+}
+
+func NewOutboxHandler(outboxService *services.OutboxService) *OutboxHandler {
+	return &OutboxHandler{
+		outboxService: outboxService,
+	}
+}
+
+// GetOutboxMessages godoc
+// @Summary      List outbound emails
+// @Description  List queued, sent and dead-lettered emails, newest first, without their bodies (admin only). Pending messages with attempts are being retried.
+// @Tags         Admin Email Outbox
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        status  query     string  false  "Filter by status"  Enums(pending, sent, dead)
+// @Param        limit   query     int     false  "Maximum number of messages (default 50, max 200)"
+// @Success      200     {object}  models.SwaggerResponse{data=[]models.OutboxMessageResponse}
+// @Failure      400     {object}  models.SwaggerErrorResponse
+// @Failure      401     {object}  models.SwaggerErrorResponse
+// @Failure      403     {object}  models.SwaggerErrorResponse
+// @Failure      500     {object}  models.SwaggerErrorResponse
+// @Router       /admin/outbox [get]
+func (h *OutboxHandler) GetOutboxMessages(c *fiber.Ctx) error {
+	limit := c.QueryInt("limit", 50)
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+
+	ctx := c.UserContext()
+
+	messages, err := h.outboxService.GetMessages(ctx, c.Query("status"), int64(limit))
+	if err != nil {
+		return utils.HandleError(c, err, "Failed to fetch outbound emails")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Outbound emails fetched successfully", messages)
+}
+
+// GetOutboxSummary godoc
+// @Summary      Summarize outbound emails
+// @Description  Count outbound emails by status and report when the oldest pending one was queued (admin only)
+// @Tags         Admin Email Outbox
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  models.SwaggerResponse{data=models.OutboxSummary}
+// @Failure      401  {object}  models.SwaggerErrorResponse
+// @Failure      403  {object}  models.SwaggerErrorResponse
+// @Failure      500  {object}  models.SwaggerErrorResponse
+// @Router       /admin/outbox/summary [get]
+func (h *OutboxHandler) GetOutboxSummary(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	summary, err := h.outboxService.GetSummary(ctx)
+	if err != nil {
+		return utils.HandleError(c, err, "Failed to summarize outbound emails")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Outbound email summary fetched successfully", summary)
+}
+
+// ResendOutboxMessage godoc
+// @Summary      Resend an outbound email
+// @Description  Queue a pending or dead-lettered email for immediate delivery with a fresh attempt count (admin only)
+// @Tags         Admin Email Outbox
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Message ID"
+// @Success      200  {object}  models.SwaggerResponse
+// @Failure      400  {object}  models.SwaggerErrorResponse
+// @Failure      401  {object}  models.SwaggerErrorResponse
+// @Failure      403  {object}  models.SwaggerErrorResponse
+// @Failure      404  {object}  models.SwaggerErrorResponse
+// @Failure      409  {object}  models.SwaggerErrorResponse
+// @Failure      500  {object}  models.SwaggerErrorResponse
+// @Router       /admin/outbox/{id}/resend [post]
+func (h *OutboxHandler) ResendOutboxMessage(c *fiber.Ctx) error {
+	adminID := c.Locals("userID").(string)
+
+	ctx := c.UserContext()
+
+	if err := h.outboxService.Resend(ctx, c.Params("id"), adminID); err != nil {
+		return utils.HandleError(c, err, "Failed to resend email")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Email queued for delivery", nil)
+}
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"fmt"
+	"time"
+
+	"backend/services"
+	"backend/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type PrivacyHandler struct {
+	privacyService *services.PrivacyService
+}
+
+func NewPrivacyHandler(privacyService *services.PrivacyService) *PrivacyHandler {
+	return &PrivacyHandler{
+		privacyService: privacyService,
+	}
+}
+
+// ExportMyData godoc
+// @Summary      Export my data
+// @Description  Download a machine-readable archive of the current user's profile, sessions, audit events and permission history
+// @Tags         User
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  models.DataSubjectExport
+// @Failure      401  {object}  models.SwaggerErrorResponse
+// @Failure      404  {object}  models.SwaggerErrorResponse
+// @Failure      500  {object}  models.SwaggerErrorResponse
+// @Router       /users/profile/export [get]
+func (h *PrivacyHandler) ExportMyData(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(string)
+
+	ctx := c.UserContext()
+
+	export, err := h.privacyService.ExportUserData(ctx, userID)
+	if err != nil {
+		return utils.HandleError(c, err, "Failed to export user data")
+	}
+
+	filename := fmt.Sprintf("data-export-%s.json", time.Now().UTC().Format("20060102"))
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, filename))
+	return c.Status(fiber.StatusOK).JSON(export)
+}
+
+// EraseUser godoc
+// @Summary      Erase user
+// @Description  Anonymize a user's personal data while keeping audit and grant references intact (admin only)
+// @Tags         Admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "User ID"
+// @Success      200  {object}  models.SwaggerResponse{data=models.ErasureResponse}
+// @Failure      400  {object}  models.SwaggerErrorResponse
+// @Failure      401  {object}  models.SwaggerErrorResponse
+// @Failure      403  {object}  models.SwaggerErrorResponse
+// @Failure      404  {object}  models.SwaggerErrorResponse
+// @Failure      409  {object}  models.SwaggerErrorResponse
+// @Failure      500  {object}  models.SwaggerErrorResponse
+// @Router       /admin/users/{id}/erase [post]
+func (h *PrivacyHandler) EraseUser(c *fiber.Ctx) error {
+	userID := c.Params("id")
+	if userID == "" {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "User ID is required")
+	}
+
+	adminID := c.Locals("userID").(string)
+
+	ctx := c.UserContext()
+
+	result, err := h.privacyService.EraseUser(ctx, userID, adminID)
+	if err != nil {
+		return utils.HandleError(c, err, "Failed to erase user")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "User erased successfully", result)
+}
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"backend/realtime"
+	"backend/services"
+	"backend/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// realtimePingInterval keeps idle streams from being closed by proxies
+	realtimePingInterval = 25 * time.Second
+	// realtimeRetry is how long clients wait before reconnecting, in
+	// milliseconds
+	realtimeRetry = 3000
+)
+
+type RealtimeHandler struct {
+	realtimeService *services.RealtimeService
+}
+
+func NewRealtimeHandler(realtimeService *services.RealtimeService) *RealtimeHandler {
+	return &RealtimeHandler{
+		realtimeService: realtimeService,
+	}
+}
+
+// StreamEvents godoc
+// @Summary      Stream real-time events
+// @Description  Open a server-sent events stream of changes affecting the current user. The first event is `ready`, carrying the user's role; clients should reload their menus on it, since events sent while they were disconnected are not replayed. `menus.changed` asks the client to reload its menus, `role.changed` carries the user's new role, and `logout` (with a `reason`) asks it to discard its tokens, after which the stream closes. EventSource cannot set headers, so the access token may be passed as the `access_token` query parameter instead.
+// @Tags         Users
+// @Produce      text/event-stream
+// @Security     BearerAuth
+// @Param        access_token  query     string  false  "Access token, for clients that cannot set the Authorization header"
+// @Success      200           {string}  string  "Event stream"
+// @Failure      401           {object}  models.SwaggerErrorResponse
+// @Failure      403           {object}  models.SwaggerErrorResponse
+// @Failure      429           {object}  models.SwaggerErrorResponse
+// @Failure      503           {object}  models.SwaggerErrorResponse
+// @Router       /users/events [get]
+func (h *RealtimeHandler) StreamEvents(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(string)
+
+	ctx := c.UserContext()
+
+	stream, err := h.realtimeService.Connect(ctx, userID)
+	if err != nil {
+		return utils.HandleError(c, err, "Failed to open event stream")
+	}
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	// Stop nginx from buffering the stream
+	c.Set("X-Accel-Buffering", "no")
+
+	// The writer runs after the handler returns, so it must not use c
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer h.realtimeService.Disconnect(stream)
+
+		fmt.Fprintf(w, "retry: %d\n\n", realtimeRetry)
+		if w.Flush() != nil {
+			return
+		}
+
+		ping := time.NewTicker(realtimePingInterval)
+		defer ping.Stop()
+
+		for {
+			select {
+			case event, ok := <-stream.Events():
+				if !ok {
+					return
+				}
+				writeEvent(w, event)
+			case <-ping.C:
+				fmt.Fprint(w, ": ping\n\n")
+			}
+			// Flushing fails once the client has gone
+			if w.Flush() != nil {
+				return
+			}
+		}
+	})
+	return nil
+}
+
+// writeEvent writes an event in the server-sent events format
+func writeEvent(w *bufio.Writer, event *realtime.Event) {
+	data := event.Data
+	if data == nil {
+		data = map[string]string{}
+	}
+	payload, _ := json.Marshal(data)
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, payload)
+}
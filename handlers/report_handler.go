@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"backend/config"
+	"backend/models"
+	"backend/services"
+	"backend/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ReportHandler struct {
+	reportService *services.ReportService
+}
+
+func NewReportHandler(reportService *services.ReportService) *ReportHandler {
+	return &ReportHandler{
+		reportService: reportService,
+	}
+}
+
+// GetAccessReview godoc
+// @Summary      Access review export
+// @Description  Stream one row per user x effective menu with role, verification status, verifier, grant details and last login (admin only)
+// @Tags         Reports
+// @Produce      text/csv
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce      json
+// @Security     BearerAuth
+// @Param        format  query     string  false  "Export format"  Enums(csv, xlsx, json)  default(csv)
+// @Success      200     {array}   models.AccessReviewRow
+// @Failure      400     {object}  models.SwaggerErrorResponse
+// @Failure      401     {object}  models.SwaggerErrorResponse
+// @Failure      403     {object}  models.SwaggerErrorResponse
+// @Router       /admin/reports/access-review [get]
+func (h *ReportHandler) GetAccessReview(c *fiber.Ctx) error {
+	format := strings.ToLower(c.Query("format", utils.ExportFormatCSV))
+	if format != utils.ExportFormatCSV && format != utils.ExportFormatXLSX && format != utils.ExportFormatJSON {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Unsupported format, expected csv, xlsx or json")
+	}
+
+	filename := fmt.Sprintf("access-review-%s.%s", time.Now().UTC().Format("20060102"), format)
+	c.Set(fiber.HeaderContentType, utils.ExportContentType(format))
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, filename))
+
+	// The body is produced after the handler returns, so the export cannot
+	// report errors through the status code. On failure the file is left
+	// incomplete and the connection dropped before the end of the chunked
+	// body, so the client sees a failed download rather than a short export.
+	// It keeps the request's values but not its deadline, which ends with the handler.
+	base := context.WithoutCancel(c.UserContext())
+	conn := c.Context().Conn()
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		ctx, cancel := context.WithTimeout(base, config.AppConfig.LongRequestTimeout)
+		defer cancel()
+
+		writer, err := utils.NewExportWriter(format, w, models.AccessReviewColumns)
+		if err != nil {
+			slog.ErrorContext(ctx, "Access review export failed to start", "error", err)
+			_ = conn.Close()
+			return
+		}
+
+		err = h.reportService.StreamAccessReview(ctx, func(row *models.AccessReviewRow) error {
+			return writer.WriteRow(row, row.Values())
+		})
+		if err != nil {
+			slog.ErrorContext(ctx, "Access review export aborted", "error", err)
+			writer.Abort()
+			_ = conn.Close()
+			return
+		}
+
+		if err := writer.Close(); err != nil {
+			slog.ErrorContext(ctx, "Access review export failed to finish", "error", err)
+			_ = conn.Close()
+			return
+		}
+		_ = w.Flush()
+	})
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"time"
+
+	"backend/models"
+	"backend/services"
+	"backend/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// statsDateLayout is the format of the from/to query parameters
+const statsDateLayout = "2006-01-02"
+
+type StatsHandler struct {
+	statsService *services.StatsService
+}
+
+func NewStatsHandler(statsService *services.StatsService) *StatsHandler {
+	return &StatsHandler{
+		statsService: statsService,
+	}
+}
+
+// GetStats godoc
+// @Summary      Admin dashboard statistics
+// @Description  Users by role and status, pending verifications by age, registrations and logins over time, active sessions, password resets in the last 24 hours and menus without grants (admin only). Results are cached briefly.
+// @Tags         Admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        from    query     string  false  "First day of the time series (YYYY-MM-DD, UTC); defaults to 30 days before to"
+// @Param        to      query     string  false  "Last day of the time series, inclusive (YYYY-MM-DD, UTC); defaults to today"
+// @Param        bucket  query     string  false  "Time series bucket size"  Enums(day, week, month)  default(day)
+// @Success      200     {object}  models.SwaggerResponse{data=models.AdminStatsResponse}
+// @Failure      400     {object}  models.SwaggerErrorResponse
+// @Failure      401     {object}  models.SwaggerErrorResponse
+// @Failure      403     {object}  models.SwaggerErrorResponse
+// @Failure      500     {object}  models.SwaggerErrorResponse
+// @Router       /admin/stats [get]
+func (h *StatsHandler) GetStats(c *fiber.Ctx) error {
+	query := services.DefaultStatsQuery()
+	query.Bucket = c.Query("bucket", models.StatsBucketDay)
+
+	if to := c.Query("to"); to != "" {
+		day, err := time.Parse(statsDateLayout, to)
+		if err != nil {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid to date, expected YYYY-MM-DD")
+		}
+		// The last day is inclusive, so the range ends at the following midnight
+		query.To = day.AddDate(0, 0, 1)
+		query.From = query.To.AddDate(0, 0, -30)
+	}
+
+	if from := c.Query("from"); from != "" {
+		day, err := time.Parse(statsDateLayout, from)
+		if err != nil {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid from date, expected YYYY-MM-DD")
+		}
+		query.From = day
+	}
+
+	ctx := c.UserContext()
+
+	stats, err := h.statsService.GetStats(ctx, query)
+	if err != nil {
+		return utils.HandleError(c, err, "Failed to get statistics")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Statistics retrieved successfully", stats)
+}
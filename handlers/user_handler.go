@@ -1,9 +1,6 @@
 package handlers
 
 import (
-	"context"
-	"time"
-
 	"backend/models"
 	"backend/services"
 	"backend/utils"
@@ -36,15 +33,11 @@ func NewUserHandler(userService *services.UserService) *UserHandler {
 func (h *UserHandler) GetProfile(c *fiber.Ctx) error {
 	userID := c.Locals("userID").(string)
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	ctx := c.UserContext()
 
 	user, err := h.userService.GetUserByID(ctx, userID)
 	if err != nil {
-		if err == utils.ErrUserNotFound {
-			return utils.ErrorResponse(c, fiber.StatusNotFound, "User not found")
-		}
-		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get user profile", err.Error())
+		return utils.HandleError(c, err, "Failed to get user profile")
 	}
 
 	return utils.SuccessResponse(c, fiber.StatusOK, "Profile retrieved successfully", user.ToResponse())
@@ -73,21 +66,11 @@ func (h *UserHandler) UpdateProfile(c *fiber.Ctx) error {
 		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	ctx := c.UserContext()
 
 	user, err := h.userService.UpdateUser(ctx, userID, &req)
 	if err != nil {
-		if utils.IsValidationError(err) {
-			return utils.ValidationErrorResponse(c, err)
-		}
-		if err == utils.ErrUserNotFound {
-			return utils.ErrorResponse(c, fiber.StatusNotFound, "User not found")
-		}
-		if err == utils.ErrUserAlreadyExists {
-			return utils.ErrorResponse(c, fiber.StatusConflict, "Email already taken")
-		}
-		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to update profile", err.Error())
+		return utils.HandleError(c, err, "Failed to update profile")
 	}
 
 	return utils.SuccessResponse(c, fiber.StatusOK, "Profile updated successfully", user.ToResponse())
@@ -108,15 +91,11 @@ func (h *UserHandler) UpdateProfile(c *fiber.Ctx) error {
 func (h *UserHandler) DeleteProfile(c *fiber.Ctx) error {
 	userID := c.Locals("userID").(string)
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	ctx := c.UserContext()
 
 	err := h.userService.DeleteUser(ctx, userID)
 	if err != nil {
-		if err == utils.ErrUserNotFound {
-			return utils.ErrorResponse(c, fiber.StatusNotFound, "User not found")
-		}
-		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to delete profile", err.Error())
+		return utils.HandleError(c, err, "Failed to delete profile")
 	}
 
 	return utils.SuccessResponse(c, fiber.StatusOK, "Profile deleted successfully", nil)
@@ -131,7 +110,7 @@ func (h *UserHandler) DeleteProfile(c *fiber.Ctx) error {
 // @Security     BearerAuth
 // @Param        request  body      models.ChangePasswordRequest  true  "Password change data"
 // @Success      200      {object}  models.SwaggerChangePasswordResponse
-// @Failure      400      {object}  models.SwaggerErrorResponse
+// @Failure      400      {object}  models.SwaggerValidationErrorResponse
 // @Failure      401      {object}  models.SwaggerErrorResponse
 // @Failure      404      {object}  models.SwaggerErrorResponse
 // @Failure      500      {object}  models.SwaggerErrorResponse
@@ -144,24 +123,11 @@ func (h *UserHandler) ChangePassword(c *fiber.Ctx) error {
 		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	ctx := c.UserContext()
 
 	err := h.userService.ChangePassword(ctx, userID, &req)
 	if err != nil {
-		if utils.IsValidationError(err) {
-			return utils.ValidationErrorResponse(c, err)
-		}
-		if err == utils.ErrInvalidCredentials {
-			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Current password is incorrect")
-		}
-		if err == utils.ErrUserNotFound {
-			return utils.ErrorResponse(c, fiber.StatusNotFound, "User not found")
-		}
-		if err.Error() == "password confirmation does not match" || err.Error() == "new password must be different from current password" {
-			return utils.ErrorResponse(c, fiber.StatusBadRequest, err.Error())
-		}
-		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to change password", err.Error())
+		return utils.HandleError(c, err, "Failed to change password")
 	}
 
 	response := models.ChangePasswordResponse{
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"strconv"
+
+	"backend/models"
+	"backend/services"
+	"backend/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type UserImportHandler struct {
+	importService *services.UserImportService
+}
+
+func NewUserImportHandler(importService *services.UserImportService) *UserImportHandler {
+	return &UserImportHandler{
+		importService: importService,
+	}
+}
+
+// ImportUsers godoc
+// @Summary      Import users from CSV or XLSX
+// @Description  Create verified users from a CSV or XLSX file with name, email and role columns (admin only). Use dry_run to get a row-by-row validation report without creating anything.
+// @Tags         Admin
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        file      formData  file    true   "CSV or XLSX file"
+// @Param        dry_run   formData  bool    false  "Validate only, do not create users"
+// @Param        delivery  formData  string  false  "Credential delivery: invite (default), reset or return"  Enums(invite, reset, return)
+// @Param        notes     formData  string  false  "Verification notes stored on each created user"
+// @Success      200       {object}  models.SwaggerResponse{data=models.UserImportReport}
+// @Failure      400       {object}  models.SwaggerErrorResponse
+// @Failure      401       {object}  models.SwaggerErrorResponse
+// @Failure      403       {object}  models.SwaggerErrorResponse
+// @Failure      500       {object}  models.SwaggerErrorResponse
+// @Router       /admin/users/import [post]
+func (h *UserImportHandler) ImportUsers(c *fiber.Ctx) error {
+	adminID := c.Locals("userID").(string)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "File is required", err.Error())
+	}
+
+	dryRun := false
+	if value := c.FormValue("dry_run"); value != "" {
+		dryRun, err = strconv.ParseBool(value)
+		if err != nil {
+			return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid dry_run value", err.Error())
+		}
+	}
+
+	opts := models.UserImportOptions{
+		DryRun:   dryRun,
+		Delivery: c.FormValue("delivery"),
+		Notes:    c.FormValue("notes"),
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Failed to read uploaded file", err.Error())
+	}
+	defer file.Close()
+
+	// Committing hashes a password per row, so allow considerably more time than usual
+	ctx := c.UserContext()
+
+	report, err := h.importService.ImportUsers(ctx, adminID, fileHeader.Filename, file, &opts)
+	if err != nil {
+		return utils.HandleError(c, err, "Failed to import users")
+	}
+
+	message := "Users imported successfully"
+	if report.DryRun {
+		message = "Import validated successfully"
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, message, report)
+}
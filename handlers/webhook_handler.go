@@ -0,0 +1,238 @@
+package handlers
+
+import (
+	"backend/models"
+	"backend/services"
+	"backend/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type WebhookHandler struct {
+	webhookService *services.WebhookService
+}
+
+func NewWebhookHandler(webhookService *services.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+	}
+}
+
+// GetWebhookEvents godoc
+// @Summary      List webhook event types
+// @Description  List the event types webhooks can subscribe to (admin only). Subscribing to * receives every type, including ones added later.
+// @Tags         Admin Webhooks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  models.SwaggerResponse{data=models.WebhookEventsResponse}
+// @Failure      401  {object}  models.SwaggerErrorResponse
+// @Failure      403  {object}  models.SwaggerErrorResponse
+// @Router       /admin/webhooks/events [get]
+func (h *WebhookHandler) GetWebhookEvents(c *fiber.Ctx) error {
+	return utils.SuccessResponse(c, fiber.StatusOK, "Webhook event types fetched successfully", h.webhookService.GetEvents())
+}
+
+// CreateWebhook godoc
+// @Summary      Create a webhook
+// @Description  Subscribe an HTTP endpoint to event types (admin only). Deliveries are signed with the secret, which is generated when not given and is only returned in this response.
+// @Tags         Admin Webhooks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      models.WebhookCreateRequest  true  "Webhook"
+// @Success      201      {object}  models.SwaggerResponse{data=models.WebhookCreatedResponse}
+// @Failure      400      {object}  models.SwaggerErrorResponse
+// @Failure      401      {object}  models.SwaggerErrorResponse
+// @Failure      403      {object}  models.SwaggerErrorResponse
+// @Failure      500      {object}  models.SwaggerErrorResponse
+// @Router       /admin/webhooks [post]
+func (h *WebhookHandler) CreateWebhook(c *fiber.Ctx) error {
+	adminID := c.Locals("userID").(string)
+
+	var req models.WebhookCreateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	ctx := c.UserContext()
+
+	webhook, err := h.webhookService.CreateWebhook(ctx, &req, adminID)
+	if err != nil {
+		return utils.HandleError(c, err, "Failed to create webhook")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusCreated, "Webhook created successfully", webhook)
+}
+
+// GetWebhooks godoc
+// @Summary      List webhooks
+// @Description  List every webhook, newest first, without secrets (admin only)
+// @Tags         Admin Webhooks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  models.SwaggerResponse{data=[]models.WebhookResponse}
+// @Failure      401  {object}  models.SwaggerErrorResponse
+// @Failure      403  {object}  models.SwaggerErrorResponse
+// @Failure      500  {object}  models.SwaggerErrorResponse
+// @Router       /admin/webhooks [get]
+func (h *WebhookHandler) GetWebhooks(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	webhooks, err := h.webhookService.GetWebhooks(ctx)
+	if err != nil {
+		return utils.HandleError(c, err, "Failed to fetch webhooks")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Webhooks fetched successfully", webhooks)
+}
+
+// GetWebhook godoc
+// @Summary      Get a webhook
+// @Description  Get a webhook without its secret (admin only). A webhook disabled after repeated failures reports why and when.
+// @Tags         Admin Webhooks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Webhook ID"
+// @Success      200  {object}  models.SwaggerResponse{data=models.WebhookResponse}
+// @Failure      400  {object}  models.SwaggerErrorResponse
+// @Failure      401  {object}  models.SwaggerErrorResponse
+// @Failure      403  {object}  models.SwaggerErrorResponse
+// @Failure      404  {object}  models.SwaggerErrorResponse
+// @Failure      500  {object}  models.SwaggerErrorResponse
+// @Router       /admin/webhooks/{id} [get]
+func (h *WebhookHandler) GetWebhook(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	webhook, err := h.webhookService.GetWebhook(ctx, c.Params("id"))
+	if err != nil {
+		return utils.HandleError(c, err, "Failed to fetch webhook")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Webhook fetched successfully", webhook)
+}
+
+// UpdateWebhook godoc
+// @Summary      Update a webhook
+// @Description  Change a webhook's URL, description, event types or secret, or enable or disable it; omitted fields are unchanged (admin only). Enabling a webhook clears its failure count.
+// @Tags         Admin Webhooks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string                       true  "Webhook ID"
+// @Param        request  body      models.WebhookUpdateRequest  true  "Fields to change"
+// @Success      200      {object}  models.SwaggerResponse{data=models.WebhookResponse}
+// @Failure      400      {object}  models.SwaggerErrorResponse
+// @Failure      401      {object}  models.SwaggerErrorResponse
+// @Failure      403      {object}  models.SwaggerErrorResponse
+// @Failure      404      {object}  models.SwaggerErrorResponse
+// @Failure      500      {object}  models.SwaggerErrorResponse
+// @Router       /admin/webhooks/{id} [put]
+func (h *WebhookHandler) UpdateWebhook(c *fiber.Ctx) error {
+	adminID := c.Locals("userID").(string)
+
+	var req models.WebhookUpdateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	ctx := c.UserContext()
+
+	webhook, err := h.webhookService.UpdateWebhook(ctx, c.Params("id"), &req, adminID)
+	if err != nil {
+		return utils.HandleError(c, err, "Failed to update webhook")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Webhook updated successfully", webhook)
+}
+
+// DeleteWebhook godoc
+// @Summary      Delete a webhook
+// @Description  Delete a webhook and its delivery log; pending deliveries are dropped (admin only)
+// @Tags         Admin Webhooks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Webhook ID"
+// @Success      200  {object}  models.SwaggerResponse
+// @Failure      400  {object}  models.SwaggerErrorResponse
+// @Failure      401  {object}  models.SwaggerErrorResponse
+// @Failure      403  {object}  models.SwaggerErrorResponse
+// @Failure      404  {object}  models.SwaggerErrorResponse
+// @Failure      500  {object}  models.SwaggerErrorResponse
+// @Router       /admin/webhooks/{id} [delete]
+func (h *WebhookHandler) DeleteWebhook(c *fiber.Ctx) error {
+	adminID := c.Locals("userID").(string)
+
+	ctx := c.UserContext()
+
+	if err := h.webhookService.DeleteWebhook(ctx, c.Params("id"), adminID); err != nil {
+		return utils.HandleError(c, err, "Failed to delete webhook")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Webhook deleted successfully", nil)
+}
+
+// GetWebhookDeliveries godoc
+// @Summary      List webhook deliveries
+// @Description  List a webhook's deliveries, newest first, with the payload and the response to the latest attempt (admin only). Finished deliveries are kept for WEBHOOK_DELIVERY_RETENTION_DAYS.
+// @Tags         Admin Webhooks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id     path      string  true   "Webhook ID"
+// @Param        limit  query     int     false  "Maximum number of deliveries (default 50, max 200)"
+// @Success      200    {object}  models.SwaggerResponse{data=[]models.WebhookDeliveryResponse}
+// @Failure      400    {object}  models.SwaggerErrorResponse
+// @Failure      401    {object}  models.SwaggerErrorResponse
+// @Failure      403    {object}  models.SwaggerErrorResponse
+// @Failure      404    {object}  models.SwaggerErrorResponse
+// @Failure      500    {object}  models.SwaggerErrorResponse
+// @Router       /admin/webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) GetWebhookDeliveries(c *fiber.Ctx) error {
+	limit := c.QueryInt("limit", 50)
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+
+	ctx := c.UserContext()
+
+	deliveries, err := h.webhookService.GetDeliveries(ctx, c.Params("id"), int64(limit))
+	if err != nil {
+		return utils.HandleError(c, err, "Failed to fetch webhook deliveries")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Webhook deliveries fetched successfully", deliveries)
+}
+
+// RedeliverWebhookDelivery godoc
+// @Summary      Redeliver a webhook event
+// @Description  Queue a new delivery of the same event and payload for immediate delivery (admin only). The event ID is unchanged, so receivers that already processed it can ignore it.
+// @Tags         Admin Webhooks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id          path      string  true  "Webhook ID"
+// @Param        deliveryId  path      string  true  "Delivery ID"
+// @Success      202         {object}  models.SwaggerResponse{data=models.WebhookDeliveryResponse}
+// @Failure      400         {object}  models.SwaggerErrorResponse
+// @Failure      401         {object}  models.SwaggerErrorResponse
+// @Failure      403         {object}  models.SwaggerErrorResponse
+// @Failure      404         {object}  models.SwaggerErrorResponse
+// @Failure      409         {object}  models.SwaggerErrorResponse
+// @Failure      500         {object}  models.SwaggerErrorResponse
+// @Router       /admin/webhooks/{id}/deliveries/{deliveryId}/redeliver [post]
+func (h *WebhookHandler) RedeliverWebhookDelivery(c *fiber.Ctx) error {
+	adminID := c.Locals("userID").(string)
+
+	ctx := c.UserContext()
+
+	delivery, err := h.webhookService.Redeliver(ctx, c.Params("id"), c.Params("deliveryId"), adminID)
+	if err != nil {
+		return utils.HandleError(c, err, "Failed to redeliver webhook event")
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusAccepted, "Webhook event queued for redelivery", delivery)
+}
@@ -0,0 +1,179 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Version is the application version, set at build time with
+// -ldflags "-X backend/health.Version=1.2.3"
+var Version = "dev"
+
+// checkTimeout bounds each individual dependency check
+const checkTimeout = 2 * time.Second
+
+// ErrShuttingDown is reported while the server drains during shutdown
+var ErrShuttingDown = errors.New("server is shutting down")
+
+// Report statuses
+const (
+	StatusReady    = "ready"
+	StatusNotReady = "not_ready"
+	StatusUp       = "up"
+	StatusDown     = "down"
+)
+
+// CheckFunc returns nil when the component is healthy
+type CheckFunc func(ctx context.Context) error
+
+type ComponentReport struct {
+	Status    string  `json:"status" example:"up"`
+	LatencyMs float64 `json:"latency_ms" example:"1.25"`
+	Error     string  `json:"error,omitempty" example:""`
+}
+
+type Report struct {
+	Status     string                     `json:"status" example:"ready"`
+	Components map[string]ComponentReport `json:"components"`
+	Build      *BuildInfo                 `json:"build,omitempty"`
+}
+
+type BuildInfo struct {
+	Version   string    `json:"version" example:"1.2.3"`
+	Revision  string    `json:"revision,omitempty" example:"b136bac"`
+	BuildTime string    `json:"build_time,omitempty" example:"2024-01-01T00:00:00Z"`
+	GoVersion string    `json:"go_version" example:"go1.23.0"`
+	StartedAt time.Time `json:"started_at" example:"2024-01-01T00:00:00Z"`
+}
+
+type namedCheck struct {
+	name  string
+	check CheckFunc
+}
+
+// Checker runs the registered readiness checks
+type Checker struct {
+	mu           sync.RWMutex
+	checks       []namedCheck
+	shuttingDown atomic.Bool
+	startedAt    time.Time
+}
+
+func NewChecker() *Checker {
+	return &Checker{startedAt: time.Now()}
+}
+
+// Register adds a named readiness check
+func (c *Checker) Register(name string, check CheckFunc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.checks = append(c.checks, namedCheck{name: name, check: check})
+}
+
+// SetShuttingDown makes every following readiness report not ready
+func (c *Checker) SetShuttingDown() {
+	c.shuttingDown.Store(true)
+}
+
+// Check runs all checks concurrently and reports the result per component.
+// The report is ready only if every component is up and the server is not
+// shutting down.
+func (c *Checker) Check(ctx context.Context) *Report {
+	c.mu.RLock()
+	checks := append([]namedCheck(nil), c.checks...)
+	c.mu.RUnlock()
+
+	report := &Report{
+		Status:     StatusReady,
+		Components: make(map[string]ComponentReport, len(checks)+1),
+	}
+
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+	)
+	for _, nc := range checks {
+		wg.Add(1)
+		go func(nc namedCheck) {
+			defer wg.Done()
+
+			component := run(ctx, nc.check)
+
+			mu.Lock()
+			report.Components[nc.name] = component
+			mu.Unlock()
+		}(nc)
+	}
+	wg.Wait()
+
+	if c.shuttingDown.Load() {
+		report.Components["server"] = ComponentReport{Status: StatusDown, Error: ErrShuttingDown.Error()}
+	}
+
+	for _, component := range report.Components {
+		if component.Status != StatusUp {
+			report.Status = StatusNotReady
+			break
+		}
+	}
+
+	return report
+}
+
+// WithoutErrors returns a copy of the report with only the status and
+// latency of each component. Check errors can name hosts and carry driver
+// or provider messages, so only the admin report includes them.
+func (r *Report) WithoutErrors() *Report {
+	public := &Report{
+		Status:     r.Status,
+		Components: make(map[string]ComponentReport, len(r.Components)),
+		Build:      r.Build,
+	}
+	for name, component := range r.Components {
+		component.Error = ""
+		public.Components[name] = component
+	}
+	return public
+}
+
+// BuildInfo describes the running binary
+func (c *Checker) BuildInfo() *BuildInfo {
+	info := &BuildInfo{
+		Version:   Version,
+		GoVersion: runtime.Version(),
+		StartedAt: c.startedAt,
+	}
+
+	if build, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range build.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				info.Revision = setting.Value
+			case "vcs.time":
+				info.BuildTime = setting.Value
+			}
+		}
+	}
+
+	return info
+}
+
+func run(ctx context.Context, check CheckFunc) ComponentReport {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check(ctx)
+	latency := float64(time.Since(start).Microseconds()) / 1000
+
+	if err != nil {
+		return ComponentReport{Status: StatusDown, LatencyMs: latency, Error: err.Error()}
+	}
+	return ComponentReport{Status: StatusUp, LatencyMs: latency}
+}
@@ -0,0 +1,93 @@
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"backend/utils"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Output formats
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+// Setup installs the default slog logger. Records are redacted and enriched
+// with the request ID, route, user ID and trace IDs found in the logging context. The
+// standard log package is routed through the same handler.
+func Setup(level, format string) {
+	slog.SetDefault(slog.New(NewHandler(os.Stdout, level, format)))
+}
+
+// NewHandler builds the redacting, context-aware handler used by Setup
+func NewHandler(w io.Writer, level, format string) slog.Handler {
+	opts := &slog.HandlerOptions{
+		Level:       ParseLevel(level),
+		ReplaceAttr: redactAttr,
+	}
+
+	var handler slog.Handler
+	if strings.ToLower(format) == FormatText {
+		handler = slog.NewTextHandler(w, opts)
+	} else {
+		handler = slog.NewJSONHandler(w, opts)
+	}
+
+	return &contextHandler{Handler: handler}
+}
+
+// ParseLevel maps debug, info, warn and error to slog levels, defaulting to info
+func ParseLevel(level string) slog.Level {
+	switch strings.ToLower(level) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn", "warning":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// contextHandler adds request-scoped attributes and redacts the message
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h *contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if ctx != nil {
+		if requestID := utils.RequestIDFromContext(ctx); requestID != "" {
+			record.AddAttrs(slog.String("request_id", requestID))
+		}
+		if route := utils.RouteFromContext(ctx); route != "" {
+			record.AddAttrs(slog.String("route", route))
+		}
+		if userID := utils.UserIDFromContext(ctx); userID != "" {
+			record.AddAttrs(slog.String("user_id", userID))
+		}
+		if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+			record.AddAttrs(
+				slog.String("trace_id", spanContext.TraceID().String()),
+				slog.String("span_id", spanContext.SpanID().String()),
+			)
+		}
+	}
+
+	record.Message = RedactString(record.Message)
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithGroup(name)}
+}
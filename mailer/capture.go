@@ -0,0 +1,130 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"backend/utils"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// captureLimit is how many messages the capture transport keeps in memory;
+// older ones are dropped
+const captureLimit = 200
+
+// CapturedMessage is a message kept by the capture transport
+type CapturedMessage struct {
+	ID         string    `json:"id"`
+	From       string    `json:"from"`
+	To         string    `json:"to"`
+	Subject    string    `json:"subject"`
+	TextBody   string    `json:"text_body,omitempty"`
+	HTMLBody   string    `json:"html_body,omitempty"`
+	CapturedAt time.Time `json:"captured_at"`
+}
+
+// Capture keeps messages instead of sending them, for development. When a
+// directory is set, every message is also written there as an .eml file that
+// any mail client can open.
+type Capture struct {
+	dir string
+
+	mu       sync.RWMutex
+	messages []CapturedMessage
+}
+
+func NewCapture(dir string) (*Capture, error) {
+	if dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, err
+		}
+	}
+	return &Capture{dir: dir}, nil
+}
+
+func (c *Capture) Name() string {
+	return "capture"
+}
+
+func (c *Capture) Check(ctx context.Context) error {
+	return nil
+}
+
+func (c *Capture) Send(ctx context.Context, message *Message) error {
+	captured := CapturedMessage{
+		ID:         primitive.NewObjectID().Hex(),
+		From:       display(message.FromName, message.FromEmail),
+		To:         display(message.ToName, message.ToEmail),
+		Subject:    message.Subject,
+		TextBody:   message.TextBody,
+		HTMLBody:   message.HTMLBody,
+		CapturedAt: time.Now(),
+	}
+
+	if c.dir != "" {
+		data, err := message.Bytes()
+		if err != nil {
+			return err
+		}
+		name := fmt.Sprintf("%s-%s.eml", captured.CapturedAt.UTC().Format("20060102T150405Z"), captured.ID)
+		if err := os.WriteFile(filepath.Join(c.dir, name), data, 0o600); err != nil {
+			return err
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.messages = append(c.messages, captured)
+	if len(c.messages) > captureLimit {
+		c.messages = c.messages[len(c.messages)-captureLimit:]
+	}
+	return nil
+}
+
+// display formats an address for people rather than for mail headers
+func display(name, address string) string {
+	if name == "" {
+		return address
+	}
+	return fmt.Sprintf("%s <%s>", name, address)
+}
+
+// Messages lists the kept messages without their bodies, newest first
+func (c *Capture) Messages() []CapturedMessage {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	messages := make([]CapturedMessage, 0, len(c.messages))
+	for i := len(c.messages) - 1; i >= 0; i-- {
+		message := c.messages[i]
+		message.TextBody, message.HTMLBody = "", ""
+		messages = append(messages, message)
+	}
+	return messages
+}
+
+func (c *Capture) Get(id string) (*CapturedMessage, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, message := range c.messages {
+		if message.ID == id {
+			return &message, nil
+		}
+	}
+	return nil, utils.ErrCapturedEmailNotFound
+}
+
+// Clear forgets the kept messages; files already written are left alone
+func (c *Capture) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.messages = nil
+}
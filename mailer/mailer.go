@@ -0,0 +1,105 @@
+// Package mailer renders localized emails from templates and delivers them
+// through a configurable transport: the SendGrid API, an SMTP relay, or a
+// capture transport that keeps messages for inspection during development
+// instead of sending them.
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Message is a single email with a plain text and an HTML body
+type Message struct {
+	FromEmail string
+	FromName  string
+	ToEmail   string
+	ToName    string
+	Subject   string
+	TextBody  string
+	HTMLBody  string
+}
+
+// Transport sends messages to a mail provider
+type Transport interface {
+	// Name identifies the transport in logs, spans and metrics
+	Name() string
+	Send(ctx context.Context, message *Message) error
+	// Check reports missing configuration without contacting the provider
+	Check(ctx context.Context) error
+}
+
+func (m *Message) from() string {
+	return (&mail.Address{Name: m.FromName, Address: m.FromEmail}).String()
+}
+
+func (m *Message) to() string {
+	return (&mail.Address{Name: m.ToName, Address: m.ToEmail}).String()
+}
+
+// Bytes renders the message as a multipart/alternative RFC 5322 message.
+// Header values are encoded, so a subject or name cannot inject headers.
+func (m *Message) Bytes() ([]byte, error) {
+	var buf bytes.Buffer
+	body := multipart.NewWriter(&buf)
+
+	headers := []struct{ name, value string }{
+		{"From", m.from()},
+		{"To", m.to()},
+		{"Subject", mime.QEncoding.Encode("utf-8", m.Subject)},
+		{"Date", time.Now().Format(time.RFC1123Z)},
+		{"Message-ID", fmt.Sprintf("<%s@%s>", primitive.NewObjectID().Hex(), domain(m.FromEmail))},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", "multipart/alternative; boundary=" + body.Boundary()},
+	}
+	var header bytes.Buffer
+	for _, h := range headers {
+		fmt.Fprintf(&header, "%s: %s\r\n", h.name, h.value)
+	}
+	header.WriteString("\r\n")
+
+	for _, part := range []struct{ contentType, content string }{
+		{"text/plain; charset=utf-8", m.TextBody},
+		{"text/html; charset=utf-8", m.HTMLBody},
+	} {
+		w, err := body.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		qp := quotedprintable.NewWriter(w)
+		if _, err := qp.Write([]byte(part.content)); err != nil {
+			return nil, err
+		}
+		if err := qp.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := body.Close(); err != nil {
+		return nil, err
+	}
+
+	return append(header.Bytes(), buf.Bytes()...), nil
+}
+
+// domain returns the domain of an address, used to make Message-IDs unique
+// to the sender
+func domain(address string) string {
+	if at := strings.LastIndex(address, "@"); at >= 0 && at < len(address)-1 {
+		return address[at+1:]
+	}
+	return "localhost"
+}
@@ -0,0 +1,117 @@
+package mailer
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+	"testing"
+)
+
+func TestMessageBytesHeaders(t *testing.T) {
+	tests := []struct {
+		name    string
+		message Message
+	}{
+		{name: "plain ASCII", message: Message{FromName: "Example", ToName: "Jane Doe", Subject: "Welcome"}},
+		{name: "non-ASCII subject and name", message: Message{ToName: "José Ñúñez", Subject: "Selamat datang, José ✓"}},
+		{name: "header injection in the subject", message: Message{Subject: "Hi\r\nBcc: attacker@example.com"}},
+		{name: "header injection in the name", message: Message{ToName: "Jane\r\nBcc: attacker@example.com", Subject: "Hi"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message := tt.message
+			message.FromEmail = "noreply@example.com"
+			message.ToEmail = "jane@example.com"
+			message.TextBody = "Hello"
+			message.HTMLBody = "<p>Hello</p>"
+
+			data, err := message.Bytes()
+			if err != nil {
+				t.Fatal(err)
+			}
+			parsed, err := mail.ReadMessage(bytes.NewReader(data))
+			if err != nil {
+				t.Fatalf("parse message: %v", err)
+			}
+
+			if bcc := parsed.Header.Get("Bcc"); bcc != "" {
+				t.Fatalf("injected Bcc header: %q", bcc)
+			}
+			for _, field := range []struct{ header, name, address string }{
+				{"From", message.FromName, message.FromEmail},
+				{"To", message.ToName, message.ToEmail},
+			} {
+				address, err := mail.ParseAddress(parsed.Header.Get(field.header))
+				if err != nil {
+					t.Fatalf("parse %s: %v", field.header, err)
+				}
+				if address.Name != field.name || address.Address != field.address {
+					t.Fatalf("%s: expected %q <%s>, got %q <%s>", field.header, field.name, field.address, address.Name, address.Address)
+				}
+			}
+
+			subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+			if err != nil {
+				t.Fatalf("decode subject: %v", err)
+			}
+			if subject != message.Subject {
+				t.Fatalf("expected subject %q, got %q", message.Subject, subject)
+			}
+			if !strings.HasSuffix(parsed.Header.Get("Message-ID"), "@example.com>") {
+				t.Fatalf("expected a Message-ID in the sender's domain, got %q", parsed.Header.Get("Message-ID"))
+			}
+		})
+	}
+}
+
+func TestMessageBytesBodies(t *testing.T) {
+	message := Message{
+		FromEmail: "noreply@example.com",
+		ToEmail:   "jane@example.com",
+		Subject:   "Hello",
+		TextBody:  "Héllo Jane, " + strings.Repeat("long line ", 20),
+		HTMLBody:  `<p style="color: red">Héllo Jane</p>`,
+	}
+
+	data, err := message.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := mail.ReadMessage(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mediaType, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("expected multipart/alternative, got %q (%v)", mediaType, err)
+	}
+
+	// The multipart reader decodes quoted-printable parts
+	reader := multipart.NewReader(parsed.Body, params["boundary"])
+	for _, want := range []struct{ contentType, body string }{
+		{"text/plain; charset=utf-8", message.TextBody},
+		{"text/html; charset=utf-8", message.HTMLBody},
+	} {
+		part, err := reader.NextPart()
+		if err != nil {
+			t.Fatalf("read %s part: %v", want.contentType, err)
+		}
+		if got := part.Header.Get("Content-Type"); got != want.contentType {
+			t.Fatalf("expected %s, got %s", want.contentType, got)
+		}
+		body, err := io.ReadAll(part)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(body) != want.body {
+			t.Fatalf("expected body %q, got %q", want.body, body)
+		}
+	}
+	if _, err := reader.NextPart(); err != io.EOF {
+		t.Fatalf("expected two parts, got another (%v)", err)
+	}
+}
@@ -0,0 +1,50 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+
+	"backend/utils"
+
+	"github.com/sendgrid/sendgrid-go"
+	sgmail "github.com/sendgrid/sendgrid-go/helpers/mail"
+)
+
+type sendGridTransport struct {
+	apiKey string
+	client *sendgrid.Client
+}
+
+// NewSendGrid sends through the SendGrid v3 mail API
+func NewSendGrid(apiKey string) Transport {
+	return &sendGridTransport{
+		apiKey: apiKey,
+		client: sendgrid.NewSendClient(apiKey),
+	}
+}
+
+func (t *sendGridTransport) Name() string {
+	return "sendgrid"
+}
+
+func (t *sendGridTransport) Send(ctx context.Context, message *Message) error {
+	from := sgmail.NewEmail(message.FromName, message.FromEmail)
+	to := sgmail.NewEmail(message.ToName, message.ToEmail)
+	email := sgmail.NewSingleEmail(from, message.Subject, to, message.TextBody, message.HTMLBody)
+
+	response, err := t.client.SendWithContext(ctx, email)
+	if err != nil {
+		return err
+	}
+	if response.StatusCode >= 400 {
+		return fmt.Errorf("sendgrid returned status %d: %s", response.StatusCode, response.Body)
+	}
+	return nil
+}
+
+func (t *sendGridTransport) Check(ctx context.Context) error {
+	if t.apiKey == "" {
+		return utils.ErrEmailNotConfigured
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package mailer
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"time"
+
+	"backend/utils"
+)
+
+// TLS modes for SMTP connections
+const (
+	// SMTPStartTLS upgrades a plain connection, usually on port 587, and
+	// refuses servers that do not offer STARTTLS
+	SMTPStartTLS = "starttls"
+	// SMTPImplicitTLS connects with TLS from the start, usually on port 465
+	SMTPImplicitTLS = "implicit"
+	// SMTPNoTLS sends in the clear; only for relays on a trusted network
+	SMTPNoTLS = "none"
+)
+
+const smtpDialTimeout = 10 * time.Second
+
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	TLS      string
+}
+
+type smtpTransport struct {
+	config SMTPConfig
+	// rootCAs verifies the server's certificate; nil uses the system roots
+	rootCAs *x509.CertPool
+}
+
+// NewSMTP sends through an SMTP relay, authenticating with PLAIN when a
+// username is set. net/smtp refuses PLAIN over an unencrypted connection to
+// anything but localhost.
+func NewSMTP(config SMTPConfig) (Transport, error) {
+	switch config.TLS {
+	case SMTPStartTLS, SMTPImplicitTLS, SMTPNoTLS:
+	default:
+		return nil, fmt.Errorf("unknown SMTP TLS mode %q", config.TLS)
+	}
+	return &smtpTransport{config: config}, nil
+}
+
+func (t *smtpTransport) Name() string {
+	return "smtp"
+}
+
+func (t *smtpTransport) Check(ctx context.Context) error {
+	if t.config.Host == "" {
+		return utils.ErrEmailNotConfigured
+	}
+	return nil
+}
+
+func (t *smtpTransport) Send(ctx context.Context, message *Message) error {
+	data, err := message.Bytes()
+	if err != nil {
+		return err
+	}
+
+	dialer := net.Dialer{Timeout: smtpDialTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(t.config.Host, strconv.Itoa(t.config.Port)))
+	if err != nil {
+		return err
+	}
+	// net/smtp has no context support; expire the connection instead
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	stop := context.AfterFunc(ctx, func() { conn.SetDeadline(time.Now()) })
+	defer stop()
+
+	tlsConfig := &tls.Config{ServerName: t.config.Host, RootCAs: t.rootCAs, MinVersion: tls.VersionTLS12}
+	if t.config.TLS == SMTPImplicitTLS {
+		conn = tls.Client(conn, tlsConfig)
+	}
+
+	client, err := smtp.NewClient(conn, t.config.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if t.config.TLS == SMTPStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return errors.New("smtp server does not support STARTTLS")
+		}
+		if err := client.StartTLS(tlsConfig); err != nil {
+			return err
+		}
+	}
+
+	if t.config.Username != "" {
+		if ok, _ := client.Extension("AUTH"); !ok {
+			return errors.New("smtp server does not support authentication")
+		}
+		if err := client.Auth(smtp.PlainAuth("", t.config.Username, t.config.Password, t.config.Host)); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(message.FromEmail); err != nil {
+		return err
+	}
+	if err := client.Rcpt(message.ToEmail); err != nil {
+		return err
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
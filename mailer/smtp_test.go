@@ -0,0 +1,234 @@
+package mailer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeSMTPServer accepts one connection and speaks just enough SMTP for
+// net/smtp, recording the commands it receives
+type fakeSMTPServer struct {
+	listener  net.Listener
+	cert      *tls.Certificate
+	starttls  bool
+	done      chan struct{}
+	mu        sync.Mutex
+	commands  []string
+	delivered string
+}
+
+func newFakeSMTPServer(t *testing.T, cert *tls.Certificate, starttls bool) *fakeSMTPServer {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := &fakeSMTPServer{listener: listener, cert: cert, starttls: starttls, done: make(chan struct{})}
+	t.Cleanup(func() { listener.Close() })
+
+	go server.serve()
+	return server
+}
+
+func (s *fakeSMTPServer) port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (s *fakeSMTPServer) serve() {
+	defer close(s.done)
+
+	conn, err := s.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	text := textproto.NewConn(conn)
+	text.PrintfLine("220 localhost ESMTP")
+	secure := false
+
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+		verb := strings.ToUpper(strings.Fields(line + " ")[0])
+
+		s.mu.Lock()
+		s.commands = append(s.commands, verb)
+		s.mu.Unlock()
+
+		switch verb {
+		case "EHLO":
+			if s.starttls && !secure {
+				text.PrintfLine("250-localhost")
+				text.PrintfLine("250 STARTTLS")
+			} else {
+				text.PrintfLine("250 localhost")
+			}
+		case "STARTTLS":
+			text.PrintfLine("220 Ready to start TLS")
+			tlsConn := tls.Server(conn, &tls.Config{Certificates: []tls.Certificate{*s.cert}})
+			if err := tlsConn.Handshake(); err != nil {
+				return
+			}
+			conn = tlsConn
+			text = textproto.NewConn(conn)
+			secure = true
+		case "MAIL", "RCPT":
+			text.PrintfLine("250 OK")
+		case "DATA":
+			text.PrintfLine("354 Go ahead")
+			data, err := text.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			s.mu.Lock()
+			s.delivered = string(data)
+			s.mu.Unlock()
+			text.PrintfLine("250 Queued")
+		case "QUIT":
+			text.PrintfLine("221 Bye")
+			return
+		default:
+			text.PrintfLine("502 Not implemented")
+		}
+	}
+}
+
+// received waits for the connection to end and returns what the server saw
+func (s *fakeSMTPServer) received(t *testing.T) ([]string, string) {
+	t.Helper()
+
+	select {
+	case <-s.done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("fake SMTP server did not finish")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.commands, s.delivered
+}
+
+// selfSignedCert returns a certificate for 127.0.0.1 and a pool trusting it
+func selfSignedCert(t *testing.T) (*tls.Certificate, *x509.CertPool) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "127.0.0.1"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pool := x509.NewCertPool()
+	pool.AddCert(leaf)
+	return &tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: leaf}, pool
+}
+
+func contains(commands []string, verb string) bool {
+	for _, command := range commands {
+		if command == verb {
+			return true
+		}
+	}
+	return false
+}
+
+func TestSMTPStartTLS(t *testing.T) {
+	cert, pool := selfSignedCert(t)
+
+	tests := []struct {
+		name          string
+		offerStartTLS bool
+		trusted       bool
+		wantErr       string
+		wantDelivered bool
+	}{
+		{name: "upgrades before sending", offerStartTLS: true, trusted: true, wantDelivered: true},
+		{name: "refuses a server without STARTTLS", offerStartTLS: false, trusted: true, wantErr: "does not support STARTTLS"},
+		{name: "refuses an untrusted certificate", offerStartTLS: true, trusted: false, wantErr: "certificate"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeSMTPServer(t, cert, tt.offerStartTLS)
+
+			transport, err := NewSMTP(SMTPConfig{Host: "127.0.0.1", Port: server.port(), TLS: SMTPStartTLS})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.trusted {
+				transport.(*smtpTransport).rootCAs = pool
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			err = transport.Send(ctx, &Message{
+				FromEmail: "noreply@example.com",
+				ToEmail:   "jane@example.com",
+				Subject:   "Hello",
+				TextBody:  "Hello Jane",
+			})
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("send: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("expected an error containing %q, got %v", tt.wantErr, err)
+			}
+
+			// Closing the listener ends a server still waiting for a connection
+			server.listener.Close()
+			commands, delivered := server.received(t)
+			if tt.wantDelivered {
+				if !contains(commands, "STARTTLS") || !strings.Contains(delivered, "Subject: Hello") {
+					t.Fatalf("expected the message to be delivered over TLS, got commands %v", commands)
+				}
+				return
+			}
+			if contains(commands, "MAIL") {
+				t.Fatalf("message was sent without TLS: %v", commands)
+			}
+		})
+	}
+}
+
+func TestNewSMTPRejectsUnknownTLSMode(t *testing.T) {
+	for _, mode := range []string{SMTPStartTLS, SMTPImplicitTLS, SMTPNoTLS, "tls", ""} {
+		_, err := NewSMTP(SMTPConfig{Host: "localhost", Port: 25, TLS: mode})
+		known := mode == SMTPStartTLS || mode == SMTPImplicitTLS || mode == SMTPNoTLS
+		if known != (err == nil) {
+			t.Errorf("TLS mode %s: got error %v", strconv.Quote(mode), err)
+		}
+	}
+}
@@ -0,0 +1,290 @@
+package mailer
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+
+	"backend/utils"
+)
+
+// Every email is rendered from templates/<locale>/<kind>.tmpl, which defines
+// "subject", "title", "text" and "html". The layouts wrap "text" and "html"
+// and add the locale's sign-off from templates/<locale>/common.tmpl.
+// Notification templates also define "summary", the one-line body of the
+// in-app notification.
+//
+//go:embed templates
+var embeddedTemplates embed.FS
+
+const (
+	htmlLayout   = "layout.html.tmpl"
+	textLayout   = "layout.txt.tmpl"
+	htmlPartials = "partials.html.tmpl"
+	localeCommon = "common.tmpl"
+)
+
+// Branding is the product identity shown in every email
+type Branding struct {
+	ProductName  string
+	LogoURL      string
+	SupportEmail string
+}
+
+// TemplateData is what email templates render. Brand and Locale are filled in
+// by Render. Details carries the facts of a notification event.
+type TemplateData struct {
+	Brand    Branding
+	Locale   string
+	Name     string
+	Email    string
+	Password string
+	Details  map[string]string
+}
+
+// Content is a rendered email. Summary is empty unless the template defines
+// one.
+type Content struct {
+	Locale   string
+	Subject  string
+	Summary  string
+	TextBody string
+	HTMLBody string
+}
+
+type templateSet struct {
+	text *texttemplate.Template
+	html *htmltemplate.Template
+}
+
+// Templates renders emails in the recipient's locale. Every template is
+// parsed and rendered with sample data at load time, so a broken override
+// stops the server from starting rather than failing a delivery.
+type Templates struct {
+	brand         Branding
+	defaultLocale string
+	// kind -> locale -> templates
+	sets map[string]map[string]*templateSet
+}
+
+// LoadTemplates parses the embedded templates. Files in overrideDir, laid out
+// the same way, replace embedded files of the same name and can add kinds and
+// locales. Every kind needs a variant in the default locale.
+func LoadTemplates(overrideDir, defaultLocale string, brand Branding) (*Templates, error) {
+	embedded, err := fs.Sub(embeddedTemplates, "templates")
+	if err != nil {
+		return nil, err
+	}
+	fsys := embedded
+	if overrideDir != "" {
+		fsys = &overlayFS{override: os.DirFS(overrideDir), base: embedded}
+	}
+
+	t := &Templates{
+		brand:         brand,
+		defaultLocale: normalizeLocale(defaultLocale),
+		sets:          map[string]map[string]*templateSet{},
+	}
+
+	locales, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+	for _, locale := range locales {
+		if !locale.IsDir() {
+			continue
+		}
+		if err := t.loadLocale(fsys, locale.Name()); err != nil {
+			return nil, err
+		}
+	}
+
+	for kind, variants := range t.sets {
+		if variants[t.defaultLocale] == nil {
+			return nil, fmt.Errorf("email template %q has no variant in the default locale %q", kind, t.defaultLocale)
+		}
+		for locale := range variants {
+			if _, err := t.Render(kind, locale, SampleData()); err != nil {
+				return nil, fmt.Errorf("email template %s/%s: %w", locale, kind, err)
+			}
+		}
+	}
+	return t, nil
+}
+
+func (t *Templates) loadLocale(fsys fs.FS, locale string) error {
+	files, err := fs.ReadDir(fsys, locale)
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		if file.IsDir() || file.Name() == localeCommon || path.Ext(file.Name()) != ".tmpl" {
+			continue
+		}
+		kind := strings.TrimSuffix(file.Name(), ".tmpl")
+		common := path.Join(locale, localeCommon)
+		page := path.Join(locale, file.Name())
+
+		text, err := texttemplate.ParseFS(fsys, textLayout, common, page)
+		if err != nil {
+			return err
+		}
+		html, err := htmltemplate.ParseFS(fsys, htmlLayout, htmlPartials, common, page)
+		if err != nil {
+			return err
+		}
+
+		if t.sets[kind] == nil {
+			t.sets[kind] = map[string]*templateSet{}
+		}
+		t.sets[kind][normalizeLocale(locale)] = &templateSet{text: text, html: html}
+	}
+	return nil
+}
+
+// Render renders an email in the closest available locale: the exact locale,
+// then its base language, then the default locale
+func (t *Templates) Render(kind, locale string, data TemplateData) (*Content, error) {
+	variants, ok := t.sets[kind]
+	if !ok {
+		return nil, utils.ErrEmailTemplateNotFound
+	}
+
+	data.Locale = t.resolve(variants, locale)
+	data.Brand = t.brand
+	set := variants[data.Locale]
+
+	subject, err := executeText(set.text, "subject", data)
+	if err != nil {
+		return nil, err
+	}
+	text, err := executeText(set.text, "layout.text", data)
+	if err != nil {
+		return nil, err
+	}
+	var summary string
+	if set.text.Lookup("summary") != nil {
+		if summary, err = executeText(set.text, "summary", data); err != nil {
+			return nil, err
+		}
+	}
+	var html bytes.Buffer
+	if err := set.html.ExecuteTemplate(&html, "layout.html", data); err != nil {
+		return nil, err
+	}
+
+	return &Content{
+		Locale:   data.Locale,
+		Subject:  strings.Join(strings.Fields(subject), " "),
+		Summary:  strings.Join(strings.Fields(summary), " "),
+		TextBody: text,
+		HTMLBody: html.String(),
+	}, nil
+}
+
+// Kinds lists the email kinds with the locales each is available in
+func (t *Templates) Kinds() map[string][]string {
+	kinds := make(map[string][]string, len(t.sets))
+	for kind, variants := range t.sets {
+		locales := make([]string, 0, len(variants))
+		for locale := range variants {
+			locales = append(locales, locale)
+		}
+		sort.Strings(locales)
+		kinds[kind] = locales
+	}
+	return kinds
+}
+
+func (t *Templates) DefaultLocale() string {
+	return t.defaultLocale
+}
+
+func (t *Templates) resolve(variants map[string]*templateSet, locale string) string {
+	locale = normalizeLocale(locale)
+	if variants[locale] != nil {
+		return locale
+	}
+	if base, _, found := strings.Cut(locale, "-"); found && variants[base] != nil {
+		return base
+	}
+	return t.defaultLocale
+}
+
+// SampleData is rendered by template previews and the load-time check
+func SampleData() TemplateData {
+	return TemplateData{
+		Name:     "Jane Doe",
+		Email:    "jane@example.com",
+		Password: "Xk7#pQ2m!v",
+		Details: map[string]string{
+			"from":       "liaison",
+			"to":         "finance",
+			"role":       "finance",
+			"menu_name":  "Reports",
+			"ip":         "203.0.113.7",
+			"user_agent": "Mozilla/5.0 (Windows NT 10.0; Win64; x64) Firefox/128.0",
+			"time":       "2024-01-01 09:30 UTC",
+		},
+	}
+}
+
+func executeText(tmpl *texttemplate.Template, name string, data TemplateData) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&buf, name, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// normalizeLocale turns pt_BR and pt-BR into pt-br
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+}
+
+// overlayFS serves files from override when they exist there and from base
+// otherwise; directory listings are merged
+type overlayFS struct {
+	override fs.FS
+	base     fs.FS
+}
+
+func (o *overlayFS) Open(name string) (fs.File, error) {
+	file, err := o.override.Open(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return o.base.Open(name)
+	}
+	return file, err
+}
+
+func (o *overlayFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	overridden, overrideErr := fs.ReadDir(o.override, name)
+	base, baseErr := fs.ReadDir(o.base, name)
+	if overrideErr != nil && baseErr != nil {
+		return nil, baseErr
+	}
+
+	entries := map[string]fs.DirEntry{}
+	for _, entry := range base {
+		entries[entry.Name()] = entry
+	}
+	for _, entry := range overridden {
+		entries[entry.Name()] = entry
+	}
+
+	merged := make([]fs.DirEntry, 0, len(entries))
+	for _, entry := range entries {
+		merged = append(merged, entry)
+	}
+	sort.Slice(merged, func(i, j int) bool { return merged[i].Name() < merged[j].Name() })
+	return merged, nil
+}
@@ -0,0 +1,142 @@
+package mailer
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestOverlayFS(t *testing.T) {
+	overlay := &overlayFS{
+		override: fstest.MapFS{
+			"en/welcome.tmpl": {Data: []byte("override")},
+			"en/extra.tmpl":   {Data: []byte("extra")},
+			"pt/welcome.tmpl": {Data: []byte("nova")},
+		},
+		base: fstest.MapFS{
+			"en/welcome.tmpl": {Data: []byte("base")},
+			"en/reset.tmpl":   {Data: []byte("reset")},
+			"id/welcome.tmpl": {Data: []byte("selamat")},
+		},
+	}
+
+	tests := []struct {
+		file string
+		want string
+	}{
+		{file: "en/welcome.tmpl", want: "override"},
+		{file: "en/reset.tmpl", want: "reset"},
+		{file: "en/extra.tmpl", want: "extra"},
+		{file: "id/welcome.tmpl", want: "selamat"},
+		{file: "pt/welcome.tmpl", want: "nova"},
+	}
+	for _, tt := range tests {
+		data, err := fs.ReadFile(overlay, tt.file)
+		if err != nil {
+			t.Fatalf("read %s: %v", tt.file, err)
+		}
+		if string(data) != tt.want {
+			t.Errorf("%s: expected %q, got %q", tt.file, tt.want, data)
+		}
+	}
+
+	if _, err := fs.ReadFile(overlay, "en/missing.tmpl"); err == nil {
+		t.Error("expected a file in neither layer to be missing")
+	}
+
+	listings := map[string]string{
+		".":  "en id pt",
+		"en": "extra.tmpl reset.tmpl welcome.tmpl",
+		"id": "welcome.tmpl",
+		"pt": "welcome.tmpl",
+	}
+	for dir, want := range listings {
+		entries, err := fs.ReadDir(overlay, dir)
+		if err != nil {
+			t.Fatalf("list %s: %v", dir, err)
+		}
+		names := make([]string, 0, len(entries))
+		for _, entry := range entries {
+			names = append(names, entry.Name())
+		}
+		if got := strings.Join(names, " "); got != want {
+			t.Errorf("list %s: expected %q, got %q", dir, want, got)
+		}
+	}
+}
+
+func TestLoadTemplatesOverride(t *testing.T) {
+	dir := t.TempDir()
+	embedded, err := fs.ReadFile(embeddedTemplates, "templates/en/invitation.tmpl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	override := strings.Replace(string(embedded), "has been created{{end}}", "is ready{{end}}", 1)
+	if err := os.MkdirAll(filepath.Join(dir, "en"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "en", "invitation.tmpl"), []byte(override), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	templates, err := LoadTemplates(dir, "en", Branding{ProductName: "Acme"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		kind   string
+		locale string
+		want   string
+	}{
+		{kind: "invitation", locale: "en", want: "Your Acme account is ready"},
+		// Locales and kinds without an override keep the embedded templates
+		{kind: "password_reset", locale: "en", want: ""},
+		{kind: "invitation", locale: "id", want: ""},
+	}
+	for _, tt := range tests {
+		content, err := templates.Render(tt.kind, tt.locale, SampleData())
+		if err != nil {
+			t.Fatalf("render %s/%s: %v", tt.locale, tt.kind, err)
+		}
+		if tt.want != "" && content.Subject != tt.want {
+			t.Errorf("%s/%s: expected subject %q, got %q", tt.locale, tt.kind, tt.want, content.Subject)
+		}
+		if tt.want == "" && strings.Contains(content.Subject, "is ready") {
+			t.Errorf("%s/%s: the override leaked into %q", tt.locale, tt.kind, content.Subject)
+		}
+	}
+}
+
+func TestResolveLocale(t *testing.T) {
+	templates := &Templates{defaultLocale: "en"}
+
+	tests := []struct {
+		name      string
+		available []string
+		locale    string
+		want      string
+	}{
+		{name: "exact region", available: []string{"en", "pt", "pt-br"}, locale: "pt-BR", want: "pt-br"},
+		{name: "underscore separator", available: []string{"en", "pt-br"}, locale: "pt_BR", want: "pt-br"},
+		{name: "base language", available: []string{"en", "pt"}, locale: "pt-BR", want: "pt"},
+		{name: "default locale", available: []string{"en", "id"}, locale: "pt-BR", want: "en"},
+		{name: "empty locale", available: []string{"en", "id"}, locale: "", want: "en"},
+		{name: "other region is not a fallback", available: []string{"en", "pt-pt"}, locale: "pt-BR", want: "en"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			variants := map[string]*templateSet{}
+			for _, locale := range tt.available {
+				variants[locale] = &templateSet{}
+			}
+			if got := templates.resolve(variants, tt.locale); got != tt.want {
+				t.Fatalf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
@@ -21,16 +21,39 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"log"
+	"log/slog"
+	"net"
+	"os"
+	"os/signal"
 	"strings"
+	"syscall"
+	"time"
 
 	"backend/config"
 	"backend/database"
 	_ "backend/docs"
 	"backend/handlers"
+	"backend/health"
+	"backend/lifecycle"
+	"backend/logging"
+	"backend/mailer"
+	"backend/metrics"
+	"backend/middleware"
+	"backend/migrations"
+	"backend/ratelimit"
+	"backend/realtime"
 	"backend/repositories"
+	"backend/repositories/interfaces"
+	"backend/repositories/memory"
+	"backend/repositories/postgres"
 	"backend/routes"
+	"backend/scheduler"
 	"backend/services"
+	"backend/tracing"
 	"backend/utils"
 
 	"github.com/gofiber/fiber/v2"
@@ -39,56 +62,409 @@ import (
 func main() {
 	// Load configuration
 	config.LoadConfig()
+	if err := config.AppConfig.Validate(); err != nil {
+		log.Fatal("Invalid configuration: ", err)
+	}
+
+	// Structured logging; the standard log package is routed through it too
+	logging.Setup(config.AppConfig.LogLevel, config.AppConfig.LogFormat)
 
 	// Initialize validator
 	utils.InitValidator()
 
-	// Connect to MongoDB
-	database.ConnectMongoDB()
+	// The first argument selects a command; without one the server starts
+	if err := runCommand(os.Args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return
+		}
+		slog.Error("Command failed", "error", err)
+		os.Exit(1)
+	}
+}
+
+// serve starts the HTTP server and the background workers and blocks until
+// a termination signal
+func serve(args []string) error {
+	if err := parseFlags(newFlagSet("serve"), args); err != nil {
+		return err
+	}
+
+	// Subsystems register start/stop hooks; they stop in reverse order
+	lc := lifecycle.New()
+
+	// Tracing is registered first so buffered spans are flushed last
+	if config.AppConfig.TracingEnabled {
+		provider, err := tracing.Setup(context.Background(), config.AppConfig.TracingServiceName, health.Version, config.AppConfig.TracingSampleRatio)
+		if err != nil {
+			log.Fatal("Failed to set up tracing:", err)
+		}
+		lc.Append(lifecycle.Hook{Name: "tracing", OnStop: tracing.Shutdown(provider)})
+	}
 
 	// Configure Swagger based on environment
 	configureSwagger()
 
-	// Initialize repositories
-	userRepo := repositories.NewUserRepository()
-	tokenRepo := repositories.NewTokenRepository()
-	permissionRepo := repositories.NewPermissionRepository()
-	menuRepo := repositories.NewMenuRepository(permissionRepo)
+	// Readiness checks
+	checker := health.NewChecker()
+
+	// Initialize repositories; each one records its operation latency
+	repos := metrics.InstrumentRepositories(openStorage(lc, checker))
+	userRepo := repos.Users
+	tokenRepo := repos.Tokens
+	permissionRepo := repos.Permissions
+	menuRepo := repos.Menus
+	bulkJobRepo := repos.BulkJobs
+	auditRepo := repos.Audit
 
 	// Initialize services
-	emailService := services.NewEmailService()
-	userService := services.NewUserService(userRepo)
-	authService := services.NewAuthService(userRepo, tokenRepo, emailService)
-	adminService := services.NewAdminService(userRepo)
-	menuService := services.NewMenuService(menuRepo, permissionRepo, userRepo)
+	mailTransport, mailCapture := openMailTransport()
+	emailService := services.NewEmailService(mailTransport, loadMailTemplates())
+	auditService := services.NewAuditService(auditRepo, userRepo)
+	webhookService := services.NewWebhookService(repos.Webhooks, auditService)
+	realtimeService := services.NewRealtimeService(openBroker(), userRepo)
+	userService := services.NewUserService(userRepo, webhookService, realtimeService)
+	outboxService := services.NewOutboxService(repos.Outbox, emailService, auditService)
+	notificationService := services.NewNotificationService(repos.Notifications, repos.Devices, userRepo, emailService, outboxService)
+	authService := services.NewAuthService(userRepo, tokenRepo, emailService, outboxService, auditService, notificationService, realtimeService, repos.Transactor)
+	adminService := services.NewAdminService(userRepo, tokenRepo, auditService, notificationService, webhookService, realtimeService)
+	bulkAdminService := services.NewBulkAdminService(adminService, userRepo, bulkJobRepo)
+	userImportService := services.NewUserImportService(userRepo, emailService, outboxService, repos.Transactor)
+	reportService := services.NewReportService(userRepo, menuRepo, permissionRepo)
+	menuService := services.NewMenuService(menuRepo, permissionRepo, userRepo, auditService, notificationService, webhookService, realtimeService, repos.Transactor)
+	privacyService := services.NewPrivacyService(userRepo, tokenRepo, permissionRepo, menuRepo, repos.Outbox, repos.Notifications, repos.Devices, auditService, webhookService, realtimeService, repos.Transactor)
+	statsService := services.NewStatsService(userRepo, tokenRepo, menuRepo, auditRepo)
+
+	// Rate limiting; nil turns it off
+	var limiter *ratelimit.Limiter
+	if config.AppConfig.RateLimitEnabled {
+		limiter = ratelimit.New(openRateLimitStore(repos))
+	}
+
+	// Maintenance jobs; only the scheduler leader among the replicas runs them
+	jobScheduler := scheduler.New(repos.Jobs)
+	jobs := []scheduler.Job{{
+		Name:        "job-history-cleanup",
+		Description: "Delete job runs older than the retention period",
+		Schedule:    "@daily",
+		Run:         jobScheduler.PruneHistory(config.AppConfig.JobHistoryRetention),
+	}, {
+		Name:        "outbox-cleanup",
+		Description: "Delete emails sent or dead-lettered longer ago than the retention period",
+		Schedule:    "@daily",
+		Run:         outboxService.PruneFinished(config.AppConfig.OutboxRetention),
+	}, {
+		Name:        "notification-cleanup",
+		Description: "Delete notifications older than the retention period",
+		Schedule:    "@daily",
+		Run:         notificationService.Prune(config.AppConfig.NotificationRetention),
+	}, {
+		Name:        "webhook-delivery-cleanup",
+		Description: "Delete finished webhook deliveries older than the retention period",
+		Schedule:    "@daily",
+		Run:         webhookService.Prune(config.AppConfig.WebhookRetention),
+	}}
+	if config.AppConfig.RateLimitEnabled && config.AppConfig.RateLimitStore != config.RateLimitLocal {
+		jobs = append(jobs, scheduler.Job{
+			Name:        "rate-limit-cleanup",
+			Description: "Delete expired rate limit counters and buckets",
+			Schedule:    "@hourly",
+			Run: func(ctx context.Context) error {
+				_, err := repos.RateLimits.DeleteExpired(ctx, time.Now())
+				return err
+			},
+		})
+	}
+	if schedule := config.AppConfig.TokenCleanupSchedule; schedule != "off" {
+		jobs = append(jobs, scheduler.Job{
+			Name:        "token-cleanup",
+			Description: "Delete expired and revoked refresh tokens",
+			Schedule:    schedule,
+			Run:         authService.CleanupExpiredTokens,
+		})
+	}
+	for _, job := range jobs {
+		if err := jobScheduler.Register(job); err != nil {
+			log.Fatal("Failed to register job:", err)
+		}
+	}
 
 	// Initialize handlers
 	authHandler := handlers.NewAuthHandler(authService)
 	userHandler := handlers.NewUserHandler(userService)
 	adminHandler := handlers.NewAdminHandler(adminService)
+	bulkAdminHandler := handlers.NewBulkAdminHandler(bulkAdminService)
+	userImportHandler := handlers.NewUserImportHandler(userImportService)
+	reportHandler := handlers.NewReportHandler(reportService)
 	menuHandler := handlers.NewMenuHandler(menuService)
+	privacyHandler := handlers.NewPrivacyHandler(privacyService)
+	statsHandler := handlers.NewStatsHandler(statsService)
+	jobHandler := handlers.NewJobHandler(jobScheduler)
+	outboxHandler := handlers.NewOutboxHandler(outboxService)
+	notificationHandler := handlers.NewNotificationHandler(notificationService)
+	webhookHandler := handlers.NewWebhookHandler(webhookService)
+	realtimeHandler := handlers.NewRealtimeHandler(realtimeService)
+	emailTemplateHandler := handlers.NewEmailTemplateHandler(emailService)
+	var mailCaptureHandler *handlers.MailCaptureHandler
+	if mailCapture != nil {
+		mailCaptureHandler = handlers.NewMailCaptureHandler(mailCapture)
+	}
+
+	checker.Register("email", emailService.CheckConfiguration)
+	healthHandler := handlers.NewHealthHandler(checker)
+
+	// Background workers
+	lc.Append(lifecycle.Hook{Name: "bulk jobs", OnStop: bulkAdminService.Shutdown})
+	checker.Register("email outbox", outboxService.Check)
+	lc.Append(outboxService.Hook())
+	checker.Register("webhooks", webhookService.Check)
+	lc.Append(webhookService.Hook())
+	lc.Append(lifecycle.Hook{Name: "notifications", OnStop: notificationService.Shutdown})
+	if config.AppConfig.SchedulerEnabled {
+		checker.Register("scheduler", jobScheduler.Check)
+		lc.Append(jobScheduler.Hook())
+	}
 
 	// Create Fiber app
 	app := fiber.New(fiber.Config{
+		// Only trusted proxies may set X-Forwarded-Proto and X-Forwarded-Host
+		EnableTrustedProxyCheck: true,
+		TrustedProxies:          config.AppConfig.TrustedProxies,
 		ErrorHandler: func(c *fiber.Ctx, err error) error {
-			code := fiber.StatusInternalServerError
-			if e, ok := err.(*fiber.Error); ok {
-				code = e.Code
+			var fiberErr *fiber.Error
+			if errors.As(err, &fiberErr) {
+				return utils.ErrorResponse(c, fiberErr.Code, fiberErr.Message)
 			}
-			return utils.ErrorResponse(c, code, "Internal Server Error", err.Error())
+			return utils.HandleError(c, err, "Internal Server Error")
 		},
 	})
 
+	// Requests still running when the shutdown drain period ends are cancelled
+	requestsCtx, abortRequests := context.WithCancel(context.Background())
+	app.Use(middleware.BaseContext(requestsCtx))
+	app.Use(clientIPMiddleware())
+
 	// Setup routes
-	routes.SetupRoutes(app, authHandler, userHandler, adminHandler, menuHandler, userRepo)
+	routes.SetupRoutes(app, authHandler, userHandler, adminHandler, bulkAdminHandler, userImportHandler, reportHandler, menuHandler, privacyHandler, statsHandler, jobHandler, outboxHandler, notificationHandler, webhookHandler, realtimeHandler, emailTemplateHandler, mailCaptureHandler, healthHandler, userRepo, limiter)
 
 	// Log Swagger status
 	logSwaggerStatus()
 
-	// Start server
-	port := config.AppConfig.Port
-	log.Printf("Server starting on port %s", port)
-	log.Fatal(app.Listen(":" + port))
+	// The HTTP server is registered last so it is the first to stop
+	serverErr := make(chan error, 1)
+	lc.Append(lifecycle.Hook{
+		Name: "http server",
+		OnStart: func(ctx context.Context) error {
+			port := config.AppConfig.Port
+			ln, err := net.Listen("tcp", ":"+port)
+			if err != nil {
+				return err
+			}
+
+			slog.Info("Server starting", "port", port)
+			go func() {
+				serverErr <- app.Listener(ln)
+			}()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			defer abortRequests()
+			return app.ShutdownWithContext(ctx)
+		},
+	})
+
+	// Registered after the server so it stops before it: open event streams
+	// would otherwise hold up the server's shutdown
+	lc.Append(realtimeService.Hook())
+
+	// Registered after the server so it stops first: /readyz reports not ready
+	// while load balancers catch up, before the listener closes
+	lc.Append(lifecycle.Hook{
+		Name: "readiness",
+		OnStop: func(ctx context.Context) error {
+			checker.SetShuttingDown()
+
+			select {
+			case <-time.After(config.AppConfig.ShutdownReadinessDelay):
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		},
+	})
+
+	if err := lc.Start(context.Background()); err != nil {
+		slog.Error("Failed to start", "error", err)
+		os.Exit(1)
+	}
+
+	// Wait for a termination signal or for the server to fail
+	signals, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	select {
+	case <-signals.Done():
+		slog.Info("Shutdown signal received")
+	case err := <-serverErr:
+		slog.Error("Server stopped unexpectedly", "error", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), config.AppConfig.ShutdownTimeout)
+	defer cancel()
+
+	if err := lc.Stop(ctx); err != nil {
+		slog.Error("Shutdown finished with errors", "error", err)
+		return nil
+	}
+	slog.Info("Shutdown complete")
+	return nil
+}
+
+// openStorage connects the configured storage backend and registers its
+// shutdown hook and readiness checks
+func openStorage(lc *lifecycle.Lifecycle, checker *health.Checker) *repositories.Repositories {
+	switch config.AppConfig.StorageBackend {
+	case config.StorageMongoDB:
+		database.ConnectMongoDB()
+		lc.Append(lifecycle.Hook{Name: "mongodb", OnStop: database.Disconnect})
+		checker.Register("mongodb", database.Ping)
+		requireSchema(checker, migrations.ForMongo(database.DB))
+		return repositories.NewMongoRepositories(database.DB)
+	case config.StoragePostgres:
+		database.ConnectPostgres()
+		lc.Append(lifecycle.Hook{Name: "postgresql", OnStop: database.DisconnectPostgres})
+		checker.Register("postgresql", database.PingPostgres)
+		requireSchema(checker, migrations.ForPostgres(database.Pool))
+		return postgres.NewRepositories(database.Pool)
+	case config.StorageMemory:
+		slog.Warn("Using the in-memory storage backend; all data is lost when the server stops")
+		repos := memory.NewRepositories()
+		if err := database.SeedInitialData(context.Background(), repos.Menus); err != nil {
+			log.Fatal("Failed to seed menus:", err)
+		}
+		return repos
+	default:
+		log.Fatalf("Unknown STORAGE_BACKEND %q", config.AppConfig.StorageBackend)
+		return nil
+	}
+}
+
+// openBroker builds the configured realtime broker. The shared brokers use
+// the storage backend's connection, so they must match it.
+func openBroker() realtime.Broker {
+	switch config.AppConfig.RealtimeBroker {
+	case config.BrokerLocal:
+		return realtime.NewLocal()
+	case config.BrokerMongoDB:
+		if config.AppConfig.StorageBackend != config.StorageMongoDB {
+			log.Fatal("REALTIME_BROKER=mongodb needs STORAGE_BACKEND=mongodb")
+		}
+		return realtime.NewMongo(database.DB)
+	case config.BrokerPostgres:
+		if config.AppConfig.StorageBackend != config.StoragePostgres {
+			log.Fatal("REALTIME_BROKER=postgres needs STORAGE_BACKEND=postgres")
+		}
+		return realtime.NewPostgres(database.Pool)
+	default:
+		log.Fatalf("Unknown REALTIME_BROKER %q", config.AppConfig.RealtimeBroker)
+		return nil
+	}
+}
+
+// clientIPMiddleware resolves client addresses from PROXY_HEADER. Anyone who
+// reaches the server directly could forge the header, so it is only read
+// from TRUSTED_PROXIES.
+func clientIPMiddleware() fiber.Handler {
+	trustedProxies, err := middleware.ParseTrustedProxies(config.AppConfig.TrustedProxies)
+	if err != nil {
+		log.Fatal("Invalid TRUSTED_PROXIES:", err)
+	}
+	if config.AppConfig.ProxyHeader != "" && len(trustedProxies) == 0 {
+		log.Fatal("PROXY_HEADER needs TRUSTED_PROXIES")
+	}
+	return middleware.ClientIPMiddleware(config.AppConfig.ProxyHeader, trustedProxies)
+}
+
+// openRateLimitStore returns where rate limits are counted. The shared
+// stores are the storage backend's, so they must match it.
+func openRateLimitStore(repos *repositories.Repositories) interfaces.RateLimitRepository {
+	switch config.AppConfig.RateLimitStore {
+	case config.RateLimitLocal:
+		return memory.NewRateLimitRepository()
+	case config.RateLimitMongoDB, config.RateLimitPostgres:
+		if config.AppConfig.RateLimitStore != config.AppConfig.StorageBackend {
+			log.Fatalf("RATE_LIMIT_STORE=%s needs STORAGE_BACKEND=%s", config.AppConfig.RateLimitStore, config.AppConfig.RateLimitStore)
+		}
+		return repos.RateLimits
+	default:
+		log.Fatalf("Unknown RATE_LIMIT_STORE %q", config.AppConfig.RateLimitStore)
+		return nil
+	}
+}
+
+// openMailTransport builds the configured mail transport. The capture
+// transport is also returned so its messages can be browsed.
+func openMailTransport() (mailer.Transport, *mailer.Capture) {
+	switch config.AppConfig.MailTransport {
+	case config.MailSendGrid:
+		return mailer.NewSendGrid(config.AppConfig.SendGridAPIKey), nil
+	case config.MailSMTP:
+		transport, err := mailer.NewSMTP(mailer.SMTPConfig{
+			Host:     config.AppConfig.SMTPHost,
+			Port:     config.AppConfig.SMTPPort,
+			Username: config.AppConfig.SMTPUsername,
+			Password: config.AppConfig.SMTPPassword,
+			TLS:      config.AppConfig.SMTPTLS,
+		})
+		if err != nil {
+			log.Fatal("Failed to configure SMTP:", err)
+		}
+		return transport, nil
+	case config.MailCapture:
+		slog.Warn("Using the capture mail transport; emails are kept instead of sent")
+		capture, err := mailer.NewCapture(config.AppConfig.MailCaptureDir)
+		if err != nil {
+			log.Fatal("Failed to create the mail capture directory:", err)
+		}
+		return capture, capture
+	default:
+		log.Fatalf("Unknown MAIL_TRANSPORT %q", config.AppConfig.MailTransport)
+		return nil, nil
+	}
+}
+
+// loadMailTemplates parses the email templates, refusing to start when an
+// override does not parse or render
+func loadMailTemplates() *mailer.Templates {
+	templates, err := mailer.LoadTemplates(config.AppConfig.MailTemplateDir, config.AppConfig.MailDefaultLocale, mailer.Branding{
+		ProductName:  config.AppConfig.BrandProductName,
+		LogoURL:      config.AppConfig.BrandLogoURL,
+		SupportEmail: config.AppConfig.BrandSupportEmail,
+	})
+	if err != nil {
+		log.Fatal("Failed to load email templates:", err)
+	}
+	return templates
+}
+
+// requireSchema refuses to start unless every migration known to this build,
+// and no other, has been applied, and keeps checking for readiness. Once
+// running, a schema that is ahead is tolerated: replicas should keep serving
+// while a newer release migrates. One that falls behind, such as after a
+// rollback, means the indexes and tables this build relies on may be gone.
+func requireSchema(checker *health.Checker, migrator *migrations.Migrator) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := migrator.Check(ctx); err != nil {
+		log.Fatalf("Refusing to start: %v", err)
+	}
+
+	checker.Register("schema", func(ctx context.Context) error {
+		if err := migrator.Check(ctx); err != nil && !errors.Is(err, migrations.ErrSchemaAhead) {
+			return err
+		}
+		return nil
+	})
 }
 
 // configureSwagger sets up Swagger documentation based on configuration
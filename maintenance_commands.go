@@ -0,0 +1,41 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"backend/database"
+)
+
+func runSeed(args []string) error {
+	if err := parseFlags(newFlagSet("seed"), args); err != nil {
+		return err
+	}
+
+	app, err := openCLI()
+	if err != nil {
+		return err
+	}
+	defer app.close()
+
+	return database.SeedInitialData(context.Background(), app.repos.Menus)
+}
+
+func runCleanupTokens(args []string) error {
+	if err := parseFlags(newFlagSet("cleanup-tokens"), args); err != nil {
+		return err
+	}
+
+	app, err := openCLI()
+	if err != nil {
+		return err
+	}
+	defer app.close()
+
+	if err := app.auth.CleanupExpiredTokens(context.Background()); err != nil {
+		return err
+	}
+
+	fmt.Println("Deleted expired refresh tokens")
+	return nil
+}
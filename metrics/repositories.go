@@ -0,0 +1,665 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"backend/models"
+	"backend/repositories"
+	"backend/repositories/interfaces"
+)
+
+// The wrappers below time every repository method. They wrap the interfaces,
+// so any backend is instrumented the same way.
+
+type userRepositoryMetrics struct {
+	next interfaces.UserRepository
+}
+
+// InstrumentUserRepository records the latency of every UserRepository call
+func InstrumentUserRepository(next interfaces.UserRepository) interfaces.UserRepository {
+	return &userRepositoryMetrics{next: next}
+}
+
+func (r *userRepositoryMetrics) Create(ctx context.Context, user *models.User) error {
+	defer observeDB("user", "Create", time.Now())
+	return r.next.Create(ctx, user)
+}
+
+func (r *userRepositoryMetrics) GetByID(ctx context.Context, id string) (*models.User, error) {
+	defer observeDB("user", "GetByID", time.Now())
+	return r.next.GetByID(ctx, id)
+}
+
+func (r *userRepositoryMetrics) GetByEmail(ctx context.Context, email string) (*models.User, error) {
+	defer observeDB("user", "GetByEmail", time.Now())
+	return r.next.GetByEmail(ctx, email)
+}
+
+func (r *userRepositoryMetrics) Update(ctx context.Context, user *models.User) error {
+	defer observeDB("user", "Update", time.Now())
+	return r.next.Update(ctx, user)
+}
+
+func (r *userRepositoryMetrics) Delete(ctx context.Context, id string) error {
+	defer observeDB("user", "Delete", time.Now())
+	return r.next.Delete(ctx, id)
+}
+
+func (r *userRepositoryMetrics) GetPendingUsers(ctx context.Context) ([]*models.User, error) {
+	defer observeDB("user", "GetPendingUsers", time.Now())
+	return r.next.GetPendingUsers(ctx)
+}
+
+func (r *userRepositoryMetrics) VerifyUser(ctx context.Context, userID, adminID string, notes string) error {
+	defer observeDB("user", "VerifyUser", time.Now())
+	return r.next.VerifyUser(ctx, userID, adminID, notes)
+}
+
+func (r *userRepositoryMetrics) UpdatePassword(ctx context.Context, userID, hashedPassword string) error {
+	defer observeDB("user", "UpdatePassword", time.Now())
+	return r.next.UpdatePassword(ctx, userID, hashedPassword)
+}
+
+func (r *userRepositoryMetrics) UpdatePasswordResetInfo(ctx context.Context, userID string) error {
+	defer observeDB("user", "UpdatePasswordResetInfo", time.Now())
+	return r.next.UpdatePasswordResetInfo(ctx, userID)
+}
+
+func (r *userRepositoryMetrics) CountUsersByRole(ctx context.Context, role string) (int64, error) {
+	defer observeDB("user", "CountUsersByRole", time.Now())
+	return r.next.CountUsersByRole(ctx, role)
+}
+
+func (r *userRepositoryMetrics) CountActiveUsersByRole(ctx context.Context, role string) (int64, error) {
+	defer observeDB("user", "CountActiveUsersByRole", time.Now())
+	return r.next.CountActiveUsersByRole(ctx, role)
+}
+
+func (r *userRepositoryMetrics) FindByFilter(ctx context.Context, filter *models.UserFilter, limit int64) ([]*models.User, error) {
+	defer observeDB("user", "FindByFilter", time.Now())
+	return r.next.FindByFilter(ctx, filter, limit)
+}
+
+func (r *userRepositoryMetrics) SuspendUser(ctx context.Context, userID, adminID string, reason string) error {
+	defer observeDB("user", "SuspendUser", time.Now())
+	return r.next.SuspendUser(ctx, userID, adminID, reason)
+}
+
+func (r *userRepositoryMetrics) ReinstateUser(ctx context.Context, userID string) error {
+	defer observeDB("user", "ReinstateUser", time.Now())
+	return r.next.ReinstateUser(ctx, userID)
+}
+
+func (r *userRepositoryMetrics) UpdateLastLogin(ctx context.Context, userID string) error {
+	defer observeDB("user", "UpdateLastLogin", time.Now())
+	return r.next.UpdateLastLogin(ctx, userID)
+}
+
+func (r *userRepositoryMetrics) StreamAll(ctx context.Context, fn func(user *models.User) error) error {
+	defer observeDB("user", "StreamAll", time.Now())
+	return r.next.StreamAll(ctx, fn)
+}
+
+func (r *userRepositoryMetrics) Anonymize(ctx context.Context, userID, name, email, hashedPassword string) error {
+	defer observeDB("user", "Anonymize", time.Now())
+	return r.next.Anonymize(ctx, userID, name, email, hashedPassword)
+}
+
+func (r *userRepositoryMetrics) CountByRoleAndStatus(ctx context.Context) ([]models.RoleStatusCount, error) {
+	defer observeDB("user", "CountByRoleAndStatus", time.Now())
+	return r.next.CountByRoleAndStatus(ctx)
+}
+
+func (r *userRepositoryMetrics) CountPendingByCreatedAt(ctx context.Context, boundaries []time.Time) ([]models.PeriodCount, error) {
+	defer observeDB("user", "CountPendingByCreatedAt", time.Now())
+	return r.next.CountPendingByCreatedAt(ctx, boundaries)
+}
+
+func (r *userRepositoryMetrics) CountRegistrationsByPeriod(ctx context.Context, query *models.StatsQuery) ([]models.PeriodCount, error) {
+	defer observeDB("user", "CountRegistrationsByPeriod", time.Now())
+	return r.next.CountRegistrationsByPeriod(ctx, query)
+}
+
+type tokenRepositoryMetrics struct {
+	next interfaces.TokenRepository
+}
+
+// InstrumentTokenRepository records the latency of every TokenRepository call
+func InstrumentTokenRepository(next interfaces.TokenRepository) interfaces.TokenRepository {
+	return &tokenRepositoryMetrics{next: next}
+}
+
+func (r *tokenRepositoryMetrics) Create(ctx context.Context, token *models.RefreshToken) error {
+	defer observeDB("token", "Create", time.Now())
+	return r.next.Create(ctx, token)
+}
+
+func (r *tokenRepositoryMetrics) GetByToken(ctx context.Context, token string) (*models.RefreshToken, error) {
+	defer observeDB("token", "GetByToken", time.Now())
+	return r.next.GetByToken(ctx, token)
+}
+
+func (r *tokenRepositoryMetrics) GetByUserID(ctx context.Context, userID string) ([]models.RefreshToken, error) {
+	defer observeDB("token", "GetByUserID", time.Now())
+	return r.next.GetByUserID(ctx, userID)
+}
+
+func (r *tokenRepositoryMetrics) RevokeToken(ctx context.Context, token string) error {
+	defer observeDB("token", "RevokeToken", time.Now())
+	return r.next.RevokeToken(ctx, token)
+}
+
+func (r *tokenRepositoryMetrics) RevokeAllUserTokens(ctx context.Context, userID string) error {
+	defer observeDB("token", "RevokeAllUserTokens", time.Now())
+	return r.next.RevokeAllUserTokens(ctx, userID)
+}
+
+func (r *tokenRepositoryMetrics) DeleteExpiredTokens(ctx context.Context) error {
+	defer observeDB("token", "DeleteExpiredTokens", time.Now())
+	return r.next.DeleteExpiredTokens(ctx)
+}
+
+func (r *tokenRepositoryMetrics) CountActive(ctx context.Context) (int64, error) {
+	defer observeDB("token", "CountActive", time.Now())
+	return r.next.CountActive(ctx)
+}
+
+type permissionRepositoryMetrics struct {
+	next interfaces.PermissionRepository
+}
+
+// InstrumentPermissionRepository records the latency of every PermissionRepository call
+func InstrumentPermissionRepository(next interfaces.PermissionRepository) interfaces.PermissionRepository {
+	return &permissionRepositoryMetrics{next: next}
+}
+
+func (r *permissionRepositoryMetrics) GrantPermission(ctx context.Context, permission *models.RoleMenuPermission) error {
+	defer observeDB("permission", "GrantPermission", time.Now())
+	return r.next.GrantPermission(ctx, permission)
+}
+
+func (r *permissionRepositoryMetrics) RevokePermission(ctx context.Context, role, menuID string) error {
+	defer observeDB("permission", "RevokePermission", time.Now())
+	return r.next.RevokePermission(ctx, role, menuID)
+}
+
+func (r *permissionRepositoryMetrics) GetPermissionsByRole(ctx context.Context, role string) ([]*models.RoleMenuPermission, error) {
+	defer observeDB("permission", "GetPermissionsByRole", time.Now())
+	return r.next.GetPermissionsByRole(ctx, role)
+}
+
+func (r *permissionRepositoryMetrics) GetRolesByMenu(ctx context.Context, menuID string) ([]*models.RoleMenuPermission, error) {
+	defer observeDB("permission", "GetRolesByMenu", time.Now())
+	return r.next.GetRolesByMenu(ctx, menuID)
+}
+
+func (r *permissionRepositoryMetrics) GetAllPermissions(ctx context.Context) ([]*models.RoleMenuPermission, error) {
+	defer observeDB("permission", "GetAllPermissions", time.Now())
+	return r.next.GetAllPermissions(ctx)
+}
+
+func (r *permissionRepositoryMetrics) CheckPermission(ctx context.Context, role, menuID string) (bool, error) {
+	defer observeDB("permission", "CheckPermission", time.Now())
+	return r.next.CheckPermission(ctx, role, menuID)
+}
+
+func (r *permissionRepositoryMetrics) RevokeAllPermissionsForMenu(ctx context.Context, menuID string) error {
+	defer observeDB("permission", "RevokeAllPermissionsForMenu", time.Now())
+	return r.next.RevokeAllPermissionsForMenu(ctx, menuID)
+}
+
+func (r *permissionRepositoryMetrics) RevokeAllPermissionsForRole(ctx context.Context, role string) error {
+	defer observeDB("permission", "RevokeAllPermissionsForRole", time.Now())
+	return r.next.RevokeAllPermissionsForRole(ctx, role)
+}
+
+func (r *permissionRepositoryMetrics) PseudonymizeGrantor(ctx context.Context, grantedByID, pseudonym string) error {
+	defer observeDB("permission", "PseudonymizeGrantor", time.Now())
+	return r.next.PseudonymizeGrantor(ctx, grantedByID, pseudonym)
+}
+
+type menuRepositoryMetrics struct {
+	next interfaces.MenuRepository
+}
+
+// InstrumentMenuRepository records the latency of every MenuRepository call
+func InstrumentMenuRepository(next interfaces.MenuRepository) interfaces.MenuRepository {
+	return &menuRepositoryMetrics{next: next}
+}
+
+func (r *menuRepositoryMetrics) Create(ctx context.Context, menu *models.Menu) error {
+	defer observeDB("menu", "Create", time.Now())
+	return r.next.Create(ctx, menu)
+}
+
+func (r *menuRepositoryMetrics) GetAll(ctx context.Context) ([]*models.Menu, error) {
+	defer observeDB("menu", "GetAll", time.Now())
+	return r.next.GetAll(ctx)
+}
+
+func (r *menuRepositoryMetrics) GetByID(ctx context.Context, id string) (*models.Menu, error) {
+	defer observeDB("menu", "GetByID", time.Now())
+	return r.next.GetByID(ctx, id)
+}
+
+func (r *menuRepositoryMetrics) GetActiveMenus(ctx context.Context) ([]*models.Menu, error) {
+	defer observeDB("menu", "GetActiveMenus", time.Now())
+	return r.next.GetActiveMenus(ctx)
+}
+
+func (r *menuRepositoryMetrics) Update(ctx context.Context, id string, menu *models.Menu) error {
+	defer observeDB("menu", "Update", time.Now())
+	return r.next.Update(ctx, id, menu)
+}
+
+func (r *menuRepositoryMetrics) Delete(ctx context.Context, id string) error {
+	defer observeDB("menu", "Delete", time.Now())
+	return r.next.Delete(ctx, id)
+}
+
+func (r *menuRepositoryMetrics) GetMenusOrderedByOrder(ctx context.Context) ([]*models.Menu, error) {
+	defer observeDB("menu", "GetMenusOrderedByOrder", time.Now())
+	return r.next.GetMenusOrderedByOrder(ctx)
+}
+
+func (r *menuRepositoryMetrics) GetMenusByRole(ctx context.Context, role string) ([]*models.Menu, error) {
+	defer observeDB("menu", "GetMenusByRole", time.Now())
+	return r.next.GetMenusByRole(ctx, role)
+}
+
+func (r *menuRepositoryMetrics) GetMenusWithoutGrants(ctx context.Context) ([]*models.Menu, error) {
+	defer observeDB("menu", "GetMenusWithoutGrants", time.Now())
+	return r.next.GetMenusWithoutGrants(ctx)
+}
+
+type bulkJobRepositoryMetrics struct {
+	next interfaces.BulkJobRepository
+}
+
+// InstrumentBulkJobRepository records the latency of every BulkJobRepository call
+func InstrumentBulkJobRepository(next interfaces.BulkJobRepository) interfaces.BulkJobRepository {
+	return &bulkJobRepositoryMetrics{next: next}
+}
+
+func (r *bulkJobRepositoryMetrics) Create(ctx context.Context, job *models.BulkJob) error {
+	defer observeDB("bulk_job", "Create", time.Now())
+	return r.next.Create(ctx, job)
+}
+
+func (r *bulkJobRepositoryMetrics) GetByID(ctx context.Context, id string) (*models.BulkJob, error) {
+	defer observeDB("bulk_job", "GetByID", time.Now())
+	return r.next.GetByID(ctx, id)
+}
+
+func (r *bulkJobRepositoryMetrics) GetRecent(ctx context.Context, limit int64) ([]*models.BulkJob, error) {
+	defer observeDB("bulk_job", "GetRecent", time.Now())
+	return r.next.GetRecent(ctx, limit)
+}
+
+func (r *bulkJobRepositoryMetrics) MarkRunning(ctx context.Context, id string) error {
+	defer observeDB("bulk_job", "MarkRunning", time.Now())
+	return r.next.MarkRunning(ctx, id)
+}
+
+func (r *bulkJobRepositoryMetrics) AppendResult(ctx context.Context, id string, result models.BulkItemResult) error {
+	defer observeDB("bulk_job", "AppendResult", time.Now())
+	return r.next.AppendResult(ctx, id, result)
+}
+
+func (r *bulkJobRepositoryMetrics) Complete(ctx context.Context, id, status, errMessage string) error {
+	defer observeDB("bulk_job", "Complete", time.Now())
+	return r.next.Complete(ctx, id, status, errMessage)
+}
+
+type auditRepositoryMetrics struct {
+	next interfaces.AuditRepository
+}
+
+// InstrumentAuditRepository records the latency of every AuditRepository call
+func InstrumentAuditRepository(next interfaces.AuditRepository) interfaces.AuditRepository {
+	return &auditRepositoryMetrics{next: next}
+}
+
+func (r *auditRepositoryMetrics) Create(ctx context.Context, event *models.AuditEvent) error {
+	defer observeDB("audit", "Create", time.Now())
+	return r.next.Create(ctx, event)
+}
+
+func (r *auditRepositoryMetrics) GetInvolvingUser(ctx context.Context, userID string) ([]*models.AuditEvent, error) {
+	defer observeDB("audit", "GetInvolvingUser", time.Now())
+	return r.next.GetInvolvingUser(ctx, userID)
+}
+
+func (r *auditRepositoryMetrics) GetPermissionHistory(ctx context.Context, role string) ([]*models.AuditEvent, error) {
+	defer observeDB("audit", "GetPermissionHistory", time.Now())
+	return r.next.GetPermissionHistory(ctx, role)
+}
+
+func (r *auditRepositoryMetrics) PseudonymizeActor(ctx context.Context, userID, pseudonym string) error {
+	defer observeDB("audit", "PseudonymizeActor", time.Now())
+	return r.next.PseudonymizeActor(ctx, userID, pseudonym)
+}
+
+func (r *auditRepositoryMetrics) CountByPeriod(ctx context.Context, action string, query *models.StatsQuery) ([]models.PeriodCount, error) {
+	defer observeDB("audit", "CountByPeriod", time.Now())
+	return r.next.CountByPeriod(ctx, action, query)
+}
+
+func (r *auditRepositoryMetrics) CountSince(ctx context.Context, action string, since time.Time) (int64, error) {
+	defer observeDB("audit", "CountSince", time.Now())
+	return r.next.CountSince(ctx, action, since)
+}
+
+type jobRepositoryMetrics struct {
+	next interfaces.JobRepository
+}
+
+// InstrumentJobRepository records the latency of every JobRepository call
+func InstrumentJobRepository(next interfaces.JobRepository) interfaces.JobRepository {
+	return &jobRepositoryMetrics{next: next}
+}
+
+func (r *jobRepositoryMetrics) AcquireLease(ctx context.Context, name, owner string, ttl time.Duration) (bool, error) {
+	defer observeDB("job", "AcquireLease", time.Now())
+	return r.next.AcquireLease(ctx, name, owner, ttl)
+}
+
+func (r *jobRepositoryMetrics) ReleaseLease(ctx context.Context, name, owner string) error {
+	defer observeDB("job", "ReleaseLease", time.Now())
+	return r.next.ReleaseLease(ctx, name, owner)
+}
+
+func (r *jobRepositoryMetrics) CreateRun(ctx context.Context, run *models.JobRun) error {
+	defer observeDB("job", "CreateRun", time.Now())
+	return r.next.CreateRun(ctx, run)
+}
+
+func (r *jobRepositoryMetrics) GetRuns(ctx context.Context, job string, limit int64) ([]*models.JobRun, error) {
+	defer observeDB("job", "GetRuns", time.Now())
+	return r.next.GetRuns(ctx, job, limit)
+}
+
+func (r *jobRepositoryMetrics) GetLatestRuns(ctx context.Context) ([]*models.JobRun, error) {
+	defer observeDB("job", "GetLatestRuns", time.Now())
+	return r.next.GetLatestRuns(ctx)
+}
+
+func (r *jobRepositoryMetrics) DeleteRunsBefore(ctx context.Context, before time.Time) (int64, error) {
+	defer observeDB("job", "DeleteRunsBefore", time.Now())
+	return r.next.DeleteRunsBefore(ctx, before)
+}
+
+type outboxRepositoryMetrics struct {
+	next interfaces.OutboxRepository
+}
+
+// InstrumentOutboxRepository records the latency of every OutboxRepository call
+func InstrumentOutboxRepository(next interfaces.OutboxRepository) interfaces.OutboxRepository {
+	return &outboxRepositoryMetrics{next: next}
+}
+
+func (r *outboxRepositoryMetrics) Create(ctx context.Context, message *models.OutboxMessage) error {
+	defer observeDB("outbox", "Create", time.Now())
+	return r.next.Create(ctx, message)
+}
+
+func (r *outboxRepositoryMetrics) GetByID(ctx context.Context, id string) (*models.OutboxMessage, error) {
+	defer observeDB("outbox", "GetByID", time.Now())
+	return r.next.GetByID(ctx, id)
+}
+
+func (r *outboxRepositoryMetrics) List(ctx context.Context, status string, limit int64) ([]*models.OutboxMessage, error) {
+	defer observeDB("outbox", "List", time.Now())
+	return r.next.List(ctx, status, limit)
+}
+
+func (r *outboxRepositoryMetrics) Summary(ctx context.Context) (*models.OutboxSummary, error) {
+	defer observeDB("outbox", "Summary", time.Now())
+	return r.next.Summary(ctx)
+}
+
+func (r *outboxRepositoryMetrics) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.OutboxMessage, error) {
+	defer observeDB("outbox", "ClaimDue", time.Now())
+	return r.next.ClaimDue(ctx, now, lease, limit)
+}
+
+func (r *outboxRepositoryMetrics) MarkSent(ctx context.Context, claim *models.OutboxMessage) error {
+	defer observeDB("outbox", "MarkSent", time.Now())
+	return r.next.MarkSent(ctx, claim)
+}
+
+func (r *outboxRepositoryMetrics) MarkFailed(ctx context.Context, claim *models.OutboxMessage, errMessage string, retryAt *time.Time) error {
+	defer observeDB("outbox", "MarkFailed", time.Now())
+	return r.next.MarkFailed(ctx, claim, errMessage, retryAt)
+}
+
+func (r *outboxRepositoryMetrics) Requeue(ctx context.Context, id string) error {
+	defer observeDB("outbox", "Requeue", time.Now())
+	return r.next.Requeue(ctx, id)
+}
+
+func (r *outboxRepositoryMetrics) DeleteFinishedBefore(ctx context.Context, before time.Time) (int64, error) {
+	defer observeDB("outbox", "DeleteFinishedBefore", time.Now())
+	return r.next.DeleteFinishedBefore(ctx, before)
+}
+
+func (r *outboxRepositoryMetrics) DeleteByRecipient(ctx context.Context, email string) error {
+	defer observeDB("outbox", "DeleteByRecipient", time.Now())
+	return r.next.DeleteByRecipient(ctx, email)
+}
+
+type notificationRepositoryMetrics struct {
+	next interfaces.NotificationRepository
+}
+
+// InstrumentNotificationRepository records the latency of every NotificationRepository call
+func InstrumentNotificationRepository(next interfaces.NotificationRepository) interfaces.NotificationRepository {
+	return &notificationRepositoryMetrics{next: next}
+}
+
+func (r *notificationRepositoryMetrics) Create(ctx context.Context, notification *models.Notification) error {
+	defer observeDB("notification", "Create", time.Now())
+	return r.next.Create(ctx, notification)
+}
+
+func (r *notificationRepositoryMetrics) List(ctx context.Context, userID string, unreadOnly bool, limit int64) ([]*models.Notification, error) {
+	defer observeDB("notification", "List", time.Now())
+	return r.next.List(ctx, userID, unreadOnly, limit)
+}
+
+func (r *notificationRepositoryMetrics) CountUnread(ctx context.Context, userID string) (int64, error) {
+	defer observeDB("notification", "CountUnread", time.Now())
+	return r.next.CountUnread(ctx, userID)
+}
+
+func (r *notificationRepositoryMetrics) MarkRead(ctx context.Context, userID, id string) error {
+	defer observeDB("notification", "MarkRead", time.Now())
+	return r.next.MarkRead(ctx, userID, id)
+}
+
+func (r *notificationRepositoryMetrics) MarkAllRead(ctx context.Context, userID string) (int64, error) {
+	defer observeDB("notification", "MarkAllRead", time.Now())
+	return r.next.MarkAllRead(ctx, userID)
+}
+
+func (r *notificationRepositoryMetrics) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	defer observeDB("notification", "DeleteBefore", time.Now())
+	return r.next.DeleteBefore(ctx, before)
+}
+
+func (r *notificationRepositoryMetrics) GetPreferences(ctx context.Context, userID string) ([]models.NotificationPreference, error) {
+	defer observeDB("notification", "GetPreferences", time.Now())
+	return r.next.GetPreferences(ctx, userID)
+}
+
+func (r *notificationRepositoryMetrics) SavePreferences(ctx context.Context, userID string, preferences []models.NotificationPreference) error {
+	defer observeDB("notification", "SavePreferences", time.Now())
+	return r.next.SavePreferences(ctx, userID, preferences)
+}
+
+func (r *notificationRepositoryMetrics) DeleteByUser(ctx context.Context, userID string) error {
+	defer observeDB("notification", "DeleteByUser", time.Now())
+	return r.next.DeleteByUser(ctx, userID)
+}
+
+type deviceRepositoryMetrics struct {
+	next interfaces.DeviceRepository
+}
+
+// InstrumentDeviceRepository records the latency of every DeviceRepository call
+func InstrumentDeviceRepository(next interfaces.DeviceRepository) interfaces.DeviceRepository {
+	return &deviceRepositoryMetrics{next: next}
+}
+
+func (r *deviceRepositoryMetrics) Record(ctx context.Context, device *models.Device) (bool, error) {
+	defer observeDB("device", "Record", time.Now())
+	return r.next.Record(ctx, device)
+}
+
+func (r *deviceRepositoryMetrics) ListByUser(ctx context.Context, userID string) ([]*models.Device, error) {
+	defer observeDB("device", "ListByUser", time.Now())
+	return r.next.ListByUser(ctx, userID)
+}
+
+func (r *deviceRepositoryMetrics) DeleteByUser(ctx context.Context, userID string) error {
+	defer observeDB("device", "DeleteByUser", time.Now())
+	return r.next.DeleteByUser(ctx, userID)
+}
+
+type webhookRepositoryMetrics struct {
+	next interfaces.WebhookRepository
+}
+
+// InstrumentWebhookRepository records the latency of every WebhookRepository call
+func InstrumentWebhookRepository(next interfaces.WebhookRepository) interfaces.WebhookRepository {
+	return &webhookRepositoryMetrics{next: next}
+}
+
+func (r *webhookRepositoryMetrics) Create(ctx context.Context, webhook *models.Webhook) error {
+	defer observeDB("webhook", "Create", time.Now())
+	return r.next.Create(ctx, webhook)
+}
+
+func (r *webhookRepositoryMetrics) GetByID(ctx context.Context, id string) (*models.Webhook, error) {
+	defer observeDB("webhook", "GetByID", time.Now())
+	return r.next.GetByID(ctx, id)
+}
+
+func (r *webhookRepositoryMetrics) List(ctx context.Context) ([]*models.Webhook, error) {
+	defer observeDB("webhook", "List", time.Now())
+	return r.next.List(ctx)
+}
+
+func (r *webhookRepositoryMetrics) ListSubscribed(ctx context.Context, eventType string) ([]*models.Webhook, error) {
+	defer observeDB("webhook", "ListSubscribed", time.Now())
+	return r.next.ListSubscribed(ctx, eventType)
+}
+
+func (r *webhookRepositoryMetrics) Update(ctx context.Context, webhook *models.Webhook) error {
+	defer observeDB("webhook", "Update", time.Now())
+	return r.next.Update(ctx, webhook)
+}
+
+func (r *webhookRepositoryMetrics) Delete(ctx context.Context, id string) error {
+	defer observeDB("webhook", "Delete", time.Now())
+	return r.next.Delete(ctx, id)
+}
+
+func (r *webhookRepositoryMetrics) RecordSuccess(ctx context.Context, id string) error {
+	defer observeDB("webhook", "RecordSuccess", time.Now())
+	return r.next.RecordSuccess(ctx, id)
+}
+
+func (r *webhookRepositoryMetrics) RecordFailure(ctx context.Context, id string, disableAfter int, reason string) (bool, error) {
+	defer observeDB("webhook", "RecordFailure", time.Now())
+	return r.next.RecordFailure(ctx, id, disableAfter, reason)
+}
+
+func (r *webhookRepositoryMetrics) CreateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	defer observeDB("webhook", "CreateDelivery", time.Now())
+	return r.next.CreateDelivery(ctx, delivery)
+}
+
+func (r *webhookRepositoryMetrics) GetDelivery(ctx context.Context, id string) (*models.WebhookDelivery, error) {
+	defer observeDB("webhook", "GetDelivery", time.Now())
+	return r.next.GetDelivery(ctx, id)
+}
+
+func (r *webhookRepositoryMetrics) ListDeliveries(ctx context.Context, webhookID string, limit int64) ([]*models.WebhookDelivery, error) {
+	defer observeDB("webhook", "ListDeliveries", time.Now())
+	return r.next.ListDeliveries(ctx, webhookID, limit)
+}
+
+func (r *webhookRepositoryMetrics) ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.WebhookDelivery, error) {
+	defer observeDB("webhook", "ClaimDueDeliveries", time.Now())
+	return r.next.ClaimDueDeliveries(ctx, now, lease, limit)
+}
+
+func (r *webhookRepositoryMetrics) MarkDelivered(ctx context.Context, id string, attempt *models.WebhookAttempt) error {
+	defer observeDB("webhook", "MarkDelivered", time.Now())
+	return r.next.MarkDelivered(ctx, id, attempt)
+}
+
+func (r *webhookRepositoryMetrics) MarkFailed(ctx context.Context, id string, attempt *models.WebhookAttempt, retryAt *time.Time) error {
+	defer observeDB("webhook", "MarkFailed", time.Now())
+	return r.next.MarkFailed(ctx, id, attempt, retryAt)
+}
+
+func (r *webhookRepositoryMetrics) DeleteDeliveriesBefore(ctx context.Context, before time.Time) (int64, error) {
+	defer observeDB("webhook", "DeleteDeliveriesBefore", time.Now())
+	return r.next.DeleteDeliveriesBefore(ctx, before)
+}
+
+type rateLimitRepositoryMetrics struct {
+	next interfaces.RateLimitRepository
+}
+
+// InstrumentRateLimitRepository records the latency of every RateLimitRepository call
+func InstrumentRateLimitRepository(next interfaces.RateLimitRepository) interfaces.RateLimitRepository {
+	return &rateLimitRepositoryMetrics{next: next}
+}
+
+func (r *rateLimitRepositoryMetrics) Increment(ctx context.Context, key string, expiresAt time.Time) (int64, error) {
+	defer observeDB("rate_limit", "Increment", time.Now())
+	return r.next.Increment(ctx, key, expiresAt)
+}
+
+func (r *rateLimitRepositoryMetrics) Decrement(ctx context.Context, key string) error {
+	defer observeDB("rate_limit", "Decrement", time.Now())
+	return r.next.Decrement(ctx, key)
+}
+
+func (r *rateLimitRepositoryMetrics) Count(ctx context.Context, key string) (int64, error) {
+	defer observeDB("rate_limit", "Count", time.Now())
+	return r.next.Count(ctx, key)
+}
+
+func (r *rateLimitRepositoryMetrics) TakeToken(ctx context.Context, key string, capacity, rate float64, now time.Time) (bool, float64, error) {
+	defer observeDB("rate_limit", "TakeToken", time.Now())
+	return r.next.TakeToken(ctx, key, capacity, rate, now)
+}
+
+func (r *rateLimitRepositoryMetrics) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	defer observeDB("rate_limit", "DeleteExpired", time.Now())
+	return r.next.DeleteExpired(ctx, before)
+}
+
+// InstrumentRepositories wraps every repository of a backend
+func InstrumentRepositories(repos *repositories.Repositories) *repositories.Repositories {
+	return &repositories.Repositories{
+		Users:         InstrumentUserRepository(repos.Users),
+		Tokens:        InstrumentTokenRepository(repos.Tokens),
+		Permissions:   InstrumentPermissionRepository(repos.Permissions),
+		Menus:         InstrumentMenuRepository(repos.Menus),
+		BulkJobs:      InstrumentBulkJobRepository(repos.BulkJobs),
+		Audit:         InstrumentAuditRepository(repos.Audit),
+		Jobs:          InstrumentJobRepository(repos.Jobs),
+		Outbox:        InstrumentOutboxRepository(repos.Outbox),
+		Notifications: InstrumentNotificationRepository(repos.Notifications),
+		Devices:       InstrumentDeviceRepository(repos.Devices),
+		Webhooks:      InstrumentWebhookRepository(repos.Webhooks),
+		RateLimits:    InstrumentRateLimitRepository(repos.RateLimits),
+		Transactor:    repos.Transactor,
+	}
+}
@@ -1,9 +1,7 @@
 package middleware
 
 import (
-	"context"
-	"time"
-
+	"backend/metrics"
 	"backend/repositories/interfaces"
 	"backend/utils"
 
@@ -15,28 +13,46 @@ func AdminMiddleware(userRepo interfaces.UserRepository) fiber.Handler {
 		// Get user ID from auth middleware
 		userID, ok := c.Locals("userID").(string)
 		if !ok {
+			metrics.Deny("admin", "missing_user")
 			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "User ID not found in context")
 		}
 
 		// Get user from database to check role
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
+		ctx := c.UserContext()
 
 		user, err := userRepo.GetByID(ctx, userID)
 		if err != nil {
+			if utils.IsContextError(err) {
+				return err
+			}
+			metrics.Deny("admin", "user_not_found")
+			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "User not found")
+		}
+
+		// Erasure keeps the role, so tokens issued before it must stop working here
+		if user.ErasedAt != nil {
+			metrics.Deny("admin", "erased")
 			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "User not found")
 		}
 
 		// Check if user has admin role
 		if user.Role != "admin" {
-			return utils.ErrorResponse(c, fiber.StatusForbidden, "Admin access required")
+			metrics.Deny("admin", "not_admin")
+			return utils.HandleError(c, utils.ErrUnauthorizedAdmin, "")
 		}
 
 		// Check if admin is verified
 		if !user.IsVerified {
+			metrics.Deny("admin", "not_verified")
 			return utils.ErrorResponse(c, fiber.StatusForbidden, "Admin account not verified")
 		}
 
+		// Check if admin is suspended
+		if user.IsSuspended {
+			metrics.Deny("admin", "suspended")
+			return utils.HandleError(c, utils.ErrUserSuspended, "")
+		}
+
 		return c.Next()
 	}
 }
@@ -3,6 +3,7 @@ package middleware
 import (
 	"strings"
 
+	"backend/metrics"
 	"backend/utils"
 
 	"github.com/gofiber/fiber/v2"
@@ -13,29 +14,34 @@ func AuthMiddleware() fiber.Handler {
 		// Get Authorization header
 		authHeader := c.Get("Authorization")
 		if authHeader == "" {
+			metrics.Deny("auth", "missing_header")
 			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Authorization header required")
 		}
 
 		// Check Bearer format
 		if !strings.HasPrefix(authHeader, "Bearer ") {
+			metrics.Deny("auth", "invalid_format")
 			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Invalid authorization format")
 		}
 
 		// Extract token
 		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
 		if tokenString == "" {
+			metrics.Deny("auth", "missing_token")
 			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Token required")
 		}
 
 		// Validate token
 		claims, err := utils.ValidateAccessToken(tokenString)
 		if err != nil {
+			metrics.Deny("auth", "invalid_token")
 			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Invalid token", err.Error())
 		}
 
 		// Store user info in context
 		c.Locals("userID", claims.UserID)
 		c.Locals("userEmail", claims.Email)
+		c.SetUserContext(utils.WithUserID(c.UserContext(), claims.UserID))
 
 		return c.Next()
 	}
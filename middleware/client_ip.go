@@ -0,0 +1,85 @@
+package middleware
+
+import (
+	"bytes"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const clientIPLocal = "clientIP"
+
+// ClientIPMiddleware resolves the address of the client behind the proxies
+// and stores it for the other middleware. header is the header the proxies
+// append the address they received the request from to, such as
+// X-Forwarded-For, and is only read when the peer is a trusted proxy. Its
+// values are read from the right, skipping trusted proxies, and the first
+// other address is the client's: everything to its left came from the
+// client and can be forged. A header the proxy sets to a single value, such
+// as X-Real-IP, works the same way.
+func ClientIPMiddleware(header string, trustedProxies []netip.Prefix) fiber.Handler {
+	trusted := func(addr netip.Addr) bool {
+		for _, prefix := range trustedProxies {
+			if prefix.Contains(addr) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return func(c *fiber.Ctx) error {
+		client, _ := netip.AddrFromSlice(c.Context().RemoteIP())
+		client = client.Unmap()
+
+		if header != "" && trusted(client) {
+			hops := bytes.Split(bytes.Join(c.Request().Header.PeekAll(header), []byte(",")), []byte(","))
+			for i := len(hops) - 1; i >= 0; i-- {
+				hop, err := netip.ParseAddr(strings.TrimSpace(string(hops[i])))
+				if err != nil {
+					// A proxy we trust would not have written this
+					break
+				}
+				client = hop.Unmap()
+				if !trusted(client) {
+					break
+				}
+			}
+		}
+
+		c.Locals(clientIPLocal, client.String())
+		return c.Next()
+	}
+}
+
+// ParseTrustedProxies parses proxy addresses and CIDR ranges
+func ParseTrustedProxies(values []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(values))
+	for _, value := range values {
+		if strings.Contains(value, "/") {
+			prefix, err := netip.ParsePrefix(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid proxy range %q: %w", value, err)
+			}
+			prefixes = append(prefixes, prefix.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy address %q: %w", value, err)
+		}
+		addr = addr.Unmap()
+		prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+	return prefixes, nil
+}
+
+// clientIP returns the address ClientIPMiddleware resolved, or the peer's
+// when it did not run
+func clientIP(c *fiber.Ctx) string {
+	if ip, ok := c.Locals(clientIPLocal).(string); ok {
+		return ip
+	}
+	return c.IP()
+}
@@ -0,0 +1,78 @@
+package middleware_test
+
+import (
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"backend/middleware"
+	"backend/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestClientIPMiddleware(t *testing.T) {
+	// app.Test requests come from 0.0.0.0
+	peer := netip.MustParsePrefix("0.0.0.0/32")
+	proxies := []netip.Prefix{peer, netip.MustParsePrefix("10.0.0.0/8")}
+
+	tests := []struct {
+		name    string
+		header  string
+		trusted []netip.Prefix
+		values  []string
+		want    string
+	}{
+		{name: "no proxy header configured", trusted: proxies, values: []string{"203.0.113.1"}, want: "0.0.0.0"},
+		{name: "peer is not a trusted proxy", header: "X-Forwarded-For", trusted: []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}, values: []string{"203.0.113.1"}, want: "0.0.0.0"},
+		{name: "address appended by the proxy", header: "X-Forwarded-For", trusted: proxies, values: []string{"203.0.113.1"}, want: "203.0.113.1"},
+		{name: "forged addresses on the left are ignored", header: "X-Forwarded-For", trusted: proxies, values: []string{"198.51.100.9, 192.0.2.4, 203.0.113.1"}, want: "203.0.113.1"},
+		{name: "trusted hops are skipped", header: "X-Forwarded-For", trusted: proxies, values: []string{"198.51.100.9, 203.0.113.1, 10.1.2.3"}, want: "203.0.113.1"},
+		{name: "one header line per proxy", header: "X-Forwarded-For", trusted: proxies, values: []string{"198.51.100.9", "203.0.113.1, 10.1.2.3"}, want: "203.0.113.1"},
+		{name: "garbage left by the client", header: "X-Forwarded-For", trusted: proxies, values: []string{"not-an-address, 10.1.2.3"}, want: "10.1.2.3"},
+		{name: "single value header", header: "X-Real-IP", trusted: proxies, values: []string{"203.0.113.1"}, want: "203.0.113.1"},
+		{name: "missing header", header: "X-Forwarded-For", trusted: proxies, want: "0.0.0.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Use(middleware.ClientIPMiddleware(tt.header, tt.trusted))
+			app.Use(middleware.RequestIDMiddleware())
+			app.Get("/", func(c *fiber.Ctx) error {
+				return c.SendString(utils.ClientFromContext(c.UserContext()).IP)
+			})
+
+			request := httptest.NewRequest(fiber.MethodGet, "/", nil)
+			for _, value := range tt.values {
+				request.Header.Add(tt.header, value)
+			}
+			response, err := app.Test(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			body := make([]byte, 64)
+			n, _ := response.Body.Read(body)
+			if got := string(body[:n]); got != tt.want {
+				t.Fatalf("expected client %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParseTrustedProxies(t *testing.T) {
+	prefixes, err := middleware.ParseTrustedProxies([]string{"10.0.0.1", "192.168.0.0/16", "::ffff:172.16.0.1", "fd00::/8"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"10.0.0.1/32", "192.168.0.0/16", "172.16.0.1/32", "fd00::/8"}
+	for i, prefix := range prefixes {
+		if prefix.String() != want[i] {
+			t.Fatalf("expected %s, got %s", want[i], prefix)
+		}
+	}
+
+	if _, err := middleware.ParseTrustedProxies([]string{"proxy.internal"}); err == nil {
+		t.Fatal("expected an error for a host name")
+	}
+}
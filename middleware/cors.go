@@ -9,7 +9,8 @@ func CorsMiddleware() fiber.Handler {
 	return cors.New(cors.Config{
 		AllowOrigins:     "*",
 		AllowMethods:     "GET,POST,HEAD,PUT,DELETE,PATCH,OPTIONS",
-		AllowHeaders:     "Origin,Content-Type,Accept,Authorization",
+		AllowHeaders:     "Origin,Content-Type,Accept,Authorization," + RequestIDHeader,
+		ExposeHeaders:    RequestIDHeader,
 		AllowCredentials: false,
 	})
 }
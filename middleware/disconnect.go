@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"net"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// disconnectPollInterval is how often a running request checks whether its
+// client has closed the connection
+const disconnectPollInterval = 200 * time.Millisecond
+
+// watchDisconnect calls onClose once the client closes conn, checking every
+// disconnectPollInterval until stop is called. The check peeks at the socket
+// without consuming data, so a pipelined request is left for the server.
+// Connections without a socket, such as those of app.Test, are not watched.
+func watchDisconnect(conn net.Conn, onClose func()) (stop func()) {
+	// TLS connections are watched through the TCP connection underneath
+	if tlsConn, ok := conn.(interface{ NetConn() net.Conn }); ok {
+		conn = tlsConn.NetConn()
+	}
+	sysConn, ok := conn.(syscall.Conn)
+	if !ok {
+		return func() {}
+	}
+	raw, err := sysConn.SyscallConn()
+	if err != nil {
+		return func() {}
+	}
+
+	var (
+		mu      sync.Mutex
+		stopped bool
+		timer   *time.Timer
+	)
+	mu.Lock()
+	defer mu.Unlock()
+	timer = time.AfterFunc(disconnectPollInterval, func() {
+		if peerClosed(raw) {
+			onClose()
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if !stopped {
+			timer.Reset(disconnectPollInterval)
+		}
+	})
+
+	return func() {
+		mu.Lock()
+		defer mu.Unlock()
+		stopped = true
+		timer.Stop()
+	}
+}
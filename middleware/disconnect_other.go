@@ -0,0 +1,11 @@
+//go:build !unix
+
+package middleware
+
+import "syscall"
+
+// peerClosed cannot peek at sockets on this platform, so client disconnects
+// are not detected and requests run until their deadline
+func peerClosed(syscall.RawConn) bool {
+	return false
+}
@@ -0,0 +1,24 @@
+//go:build unix
+
+package middleware
+
+import "syscall"
+
+// peerClosed reports whether the peer has closed the connection: a
+// non-blocking peek reads end of file, or fails with anything but "no data yet"
+func peerClosed(raw syscall.RawConn) bool {
+	closed := false
+	err := raw.Control(func(fd uintptr) {
+		var buf [1]byte
+		n, _, err := syscall.Recvfrom(int(fd), buf[:], syscall.MSG_PEEK|syscall.MSG_DONTWAIT)
+		switch {
+		case err == nil:
+			closed = n == 0
+		case err == syscall.EAGAIN || err == syscall.EWOULDBLOCK || err == syscall.EINTR:
+		default:
+			closed = true
+		}
+	})
+	// The server has closed the connection itself
+	return closed || err != nil
+}
@@ -1,12 +1,74 @@
 package middleware
 
 import (
+	"log/slog"
+	"regexp"
+	"time"
+
+	"backend/utils"
+
 	"github.com/gofiber/fiber/v2"
-	"github.com/gofiber/fiber/v2/middleware/logger"
 )
 
+// RequestIDHeader carries the correlation ID between clients, proxies and this service
+const RequestIDHeader = "X-Request-ID"
+
+// validRequestID limits accepted incoming IDs to safe, reasonably short tokens
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._\-]{1,128}$`)
+
+// RequestIDMiddleware accepts the caller's X-Request-ID or generates one,
+// echoes it on the response and stores it, together with the route, in the
+// request context so every log line of the request can be correlated. The
+// caller's address and User-Agent are stored as well.
+func RequestIDMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		requestID := c.Get(RequestIDHeader)
+		if !validRequestID.MatchString(requestID) {
+			requestID = utils.GenerateUUID()
+		}
+
+		c.Set(RequestIDHeader, requestID)
+
+		ctx := utils.WithRequestID(c.UserContext(), requestID)
+		ctx = utils.WithRoute(ctx, c.Method()+" "+c.Path())
+		ctx = utils.WithClient(ctx, utils.Client{IP: clientIP(c), UserAgent: c.Get(fiber.HeaderUserAgent)})
+		c.SetUserContext(ctx)
+
+		return c.Next()
+	}
+}
+
+// LoggerMiddleware writes one structured access log line per request
 func LoggerMiddleware() fiber.Handler {
-	return logger.New(logger.Config{
-		Format: "[${time}] ${status} - ${method} ${path} - ${latency}\n",
-	})
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+
+		err := c.Next()
+		if err != nil {
+			// Let the error handler write the response so the logged status is final
+			if handlerErr := c.App().ErrorHandler(c, err); handlerErr != nil {
+				_ = c.SendStatus(fiber.StatusInternalServerError)
+			}
+		}
+
+		status := c.Response().StatusCode()
+		level := slog.LevelInfo
+		switch {
+		case status >= fiber.StatusInternalServerError:
+			level = slog.LevelError
+		case status >= fiber.StatusBadRequest:
+			level = slog.LevelWarn
+		}
+
+		slog.LogAttrs(c.UserContext(), level, "request completed",
+			slog.String("method", c.Method()),
+			slog.String("path", c.Path()),
+			slog.String("pattern", c.Route().Path),
+			slog.Int("status", status),
+			slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+			slog.String("ip", clientIP(c)),
+		)
+
+		return nil
+	}
 }
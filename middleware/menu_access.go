@@ -1,9 +1,7 @@
 package middleware
 
 import (
-	"context"
-	"time"
-
+	"backend/metrics"
 	"backend/repositories/interfaces"
 	"backend/utils"
 
@@ -15,15 +13,24 @@ func MenuAccessMiddleware(userRepo interfaces.UserRepository, permRepo interface
 		// Get user ID from auth middleware
 		userID, ok := c.Locals("userID").(string)
 		if !ok {
+			metrics.Deny("menu_access", "missing_user")
 			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "User ID not found in context")
 		}
 
 		// Get user from database to check role
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
+		ctx := c.UserContext()
 
 		user, err := userRepo.GetByID(ctx, userID)
 		if err != nil {
+			if utils.IsContextError(err) {
+				return err
+			}
+			metrics.Deny("menu_access", "user_not_found")
+			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "User not found")
+		}
+
+		if user.ErasedAt != nil {
+			metrics.Deny("menu_access", "erased")
 			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "User not found")
 		}
 
@@ -35,11 +42,12 @@ func MenuAccessMiddleware(userRepo interfaces.UserRepository, permRepo interface
 		// Check if user's role has permission to access this menu
 		hasPermission, err := permRepo.CheckPermission(ctx, user.Role, menuID)
 		if err != nil {
-			return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to check permissions")
+			return utils.HandleError(c, err, "Failed to check permissions")
 		}
 
 		if !hasPermission {
-			return utils.ErrorResponse(c, fiber.StatusForbidden, "Menu access denied for your role")
+			metrics.Deny("menu_access", "menu_denied")
+			return utils.HandleError(c, utils.ErrMenuAccessDenied, "")
 		}
 
 		return c.Next()
@@ -52,15 +60,24 @@ func RoleMiddleware(userRepo interfaces.UserRepository, allowedRoles ...string)
 		// Get user ID from auth middleware
 		userID, ok := c.Locals("userID").(string)
 		if !ok {
+			metrics.Deny("role", "missing_user")
 			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "User ID not found in context")
 		}
 
 		// Get user from database to check role
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
+		ctx := c.UserContext()
 
 		user, err := userRepo.GetByID(ctx, userID)
 		if err != nil {
+			if utils.IsContextError(err) {
+				return err
+			}
+			metrics.Deny("role", "user_not_found")
+			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "User not found")
+		}
+
+		if user.ErasedAt != nil {
+			metrics.Deny("role", "erased")
 			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "User not found")
 		}
 
@@ -71,6 +88,7 @@ func RoleMiddleware(userRepo interfaces.UserRepository, allowedRoles ...string)
 			}
 		}
 
+		metrics.Deny("role", "role_denied")
 		return utils.ErrorResponse(c, fiber.StatusForbidden, "Insufficient role permissions")
 	}
 }
@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"strconv"
+	"strings"
+	"time"
+
+	"backend/metrics"
+	"backend/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// unmatchedRoute labels requests that did not match any route, keeping
+// arbitrary paths out of the metric label set
+const unmatchedRoute = "unmatched"
+
+// MetricsMiddleware counts requests and records their latency by route
+// template. It must run outside LoggerMiddleware so the status is final.
+func MetricsMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+
+		err := c.Next()
+
+		route := c.Route().Path
+		if route == "/" && c.Path() != "/" {
+			route = unmatchedRoute
+		}
+		status := strconv.Itoa(c.Response().StatusCode())
+
+		metrics.HTTPRequests.WithLabelValues(c.Method(), route, status).Inc()
+		metrics.HTTPRequestDuration.WithLabelValues(c.Method(), route, status).Observe(time.Since(start).Seconds())
+
+		return err
+	}
+}
+
+// MetricsTokenMiddleware requires "Authorization: Bearer <token>" when token
+// is set. An empty token leaves the endpoint open, e.g. behind a private network.
+func MetricsTokenMiddleware(token string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if token == "" {
+			return c.Next()
+		}
+
+		provided := strings.TrimPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			metrics.Deny("metrics", "invalid_token")
+			return utils.ErrorResponse(c, fiber.StatusUnauthorized, "Invalid metrics token")
+		}
+
+		return c.Next()
+	}
+}
@@ -0,0 +1,18 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+// QueryTokenMiddleware lets a route take the access token from the
+// access_token query parameter, for clients such as EventSource that cannot
+// set headers. It goes before AuthMiddleware and only on routes that need
+// it, as tokens in URLs are easier to leak.
+func QueryTokenMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if token := c.Query("access_token"); token != "" && c.Get(fiber.HeaderAuthorization) == "" {
+			c.Request().Header.Set(fiber.HeaderAuthorization, "Bearer "+token)
+		}
+		return c.Next()
+	}
+}
@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"encoding/json"
+	"log/slog"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"backend/metrics"
+	"backend/ratelimit"
+	"backend/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RateLimit counts requests against the policy and refuses those over its
+// limit with 429. Responses carry the RateLimit-Limit, RateLimit-Remaining,
+// RateLimit-Reset and RateLimit-Policy headers, and refusals Retry-After.
+// Policies keyed by user go after AuthMiddleware. A nil limiter, when rate
+// limiting is disabled, lets every request through.
+func RateLimit(limiter *ratelimit.Limiter, policy ratelimit.Policy) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if limiter == nil {
+			return c.Next()
+		}
+
+		decision, err := limiter.Allow(c.UserContext(), policy, rateLimitKey(c, policy.KeyBy))
+		if err != nil {
+			if utils.IsContextError(err) {
+				return err
+			}
+			// An unavailable store should not take the API down with it
+			slog.ErrorContext(c.UserContext(), "Rate limit check failed; allowing request", "policy", policy.Name, "error", err)
+			return c.Next()
+		}
+
+		c.Set("RateLimit-Limit", strconv.Itoa(decision.Limit))
+		c.Set("RateLimit-Remaining", strconv.Itoa(decision.Remaining))
+		c.Set("RateLimit-Reset", seconds(decision.Reset))
+		c.Set("RateLimit-Policy", policy.Header())
+
+		if !decision.Allowed {
+			metrics.RateLimited.WithLabelValues(policy.Name).Inc()
+			c.Set(fiber.HeaderRetryAfter, seconds(max(decision.RetryAfter, time.Second)))
+			return utils.HandleError(c, utils.ErrRateLimited, "")
+		}
+		return c.Next()
+	}
+}
+
+// rateLimitKey identifies the client a request is counted against. Requests
+// without a user or email are counted by address.
+func rateLimitKey(c *fiber.Ctx, keyBy ratelimit.KeyBy) string {
+	switch keyBy {
+	case ratelimit.KeyByUser:
+		if userID, ok := c.Locals("userID").(string); ok && userID != "" {
+			return userID
+		}
+	case ratelimit.KeyByEmail, ratelimit.KeyByEmailAndIP:
+		var body struct {
+			Email string `json:"email"`
+		}
+		if json.Unmarshal(c.Body(), &body) == nil && body.Email != "" {
+			email := strings.ToLower(strings.TrimSpace(body.Email))
+			if keyBy == ratelimit.KeyByEmailAndIP {
+				return email + "|" + clientIP(c)
+			}
+			return email
+		}
+	}
+	return clientIP(c)
+}
+
+// seconds rounds up to whole seconds, as the headers carry
+func seconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(math.Max(d.Seconds(), 0))))
+}
@@ -0,0 +1,110 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+
+	"backend/middleware"
+	"backend/ratelimit"
+	"backend/repositories/memory"
+	"backend/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func newRateLimitedApp(policy ratelimit.Policy) *fiber.App {
+	app := fiber.New()
+	// app.Test requests come from 0.0.0.0
+	app.Use(middleware.ClientIPMiddleware("X-Forwarded-For", []netip.Prefix{netip.MustParsePrefix("0.0.0.0/32")}))
+	limiter := ratelimit.New(memory.NewRateLimitRepository())
+	app.Post("/", middleware.RateLimit(limiter, policy), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+	return app
+}
+
+func TestRateLimitHeadersAndRefusal(t *testing.T) {
+	// Two tokens, refilled at one every 30 seconds
+	app := newRateLimitedApp(ratelimit.Policy{
+		Name: "test", Algorithm: ratelimit.TokenBucket, Limit: 2, Window: time.Minute, KeyBy: ratelimit.KeyByIP,
+	})
+
+	tests := []struct {
+		status     int
+		remaining  string
+		reset      string
+		retryAfter string
+	}{
+		{status: fiber.StatusNoContent, remaining: "1", reset: "30"},
+		{status: fiber.StatusNoContent, remaining: "0", reset: "60"},
+		{status: fiber.StatusTooManyRequests, remaining: "0", reset: "60", retryAfter: "30"},
+	}
+
+	for i, tt := range tests {
+		response, err := app.Test(httptest.NewRequest(fiber.MethodPost, "/", nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if response.StatusCode != tt.status {
+			t.Fatalf("request %d: expected status %d, got %d", i, tt.status, response.StatusCode)
+		}
+		headers := map[string]string{
+			"RateLimit-Limit":     "2",
+			"RateLimit-Remaining": tt.remaining,
+			"RateLimit-Reset":     tt.reset,
+			"RateLimit-Policy":    "2;w=60",
+			"Retry-After":         tt.retryAfter,
+		}
+		for name, want := range headers {
+			if got := response.Header.Get(name); got != want {
+				t.Fatalf("request %d: expected %s %q, got %q", i, name, want, got)
+			}
+		}
+
+		if tt.status == fiber.StatusTooManyRequests {
+			var body utils.Response
+			if err := json.NewDecoder(response.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+			if body.Success || body.Code != "rate_limited" {
+				t.Fatalf("expected a rate_limited error, got %+v", body)
+			}
+		}
+	}
+}
+
+func TestRateLimitByEmailAndAddress(t *testing.T) {
+	app := newRateLimitedApp(ratelimit.Policy{
+		Name: "login", Algorithm: ratelimit.SlidingWindow, Limit: 1, Window: time.Minute, KeyBy: ratelimit.KeyByEmailAndIP,
+	})
+
+	login := func(email, address string) int {
+		t.Helper()
+		request := httptest.NewRequest(fiber.MethodPost, "/", strings.NewReader(`{"email":"`+email+`"}`))
+		request.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		request.Header.Set("X-Forwarded-For", address)
+		response, err := app.Test(request)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return response.StatusCode
+	}
+
+	if status := login("alice@example.com", "203.0.113.1"); status != fiber.StatusNoContent {
+		t.Fatalf("expected the first login allowed, got %d", status)
+	}
+	if status := login("Alice@Example.com", "203.0.113.1"); status != fiber.StatusTooManyRequests {
+		t.Fatalf("expected the same account and address to be limited, got %d", status)
+	}
+	if status := login("alice@example.com", "198.51.100.7"); status != fiber.StatusNoContent {
+		t.Fatalf("expected another address not to be locked out of the account, got %d", status)
+	}
+	if status := login("bob@example.com", "203.0.113.1"); status != fiber.StatusNoContent {
+		t.Fatalf("expected another account from the same address to be counted apart, got %d", status)
+	}
+}
@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"backend/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// baseContextKey holds the context that ends when the request should be
+	// abandoned: on shutdown or when the client goes away
+	baseContextKey = "baseContext"
+
+	// timeoutOwnerKey records which Timeout middleware set the active deadline
+	timeoutOwnerKey = "timeoutOwner"
+)
+
+// errClientDisconnected is the cause of a request abandoned by its client
+var errClientDisconnected = errors.New("client closed the connection")
+
+// BaseContext ties every request to ctx and to its connection. Cancelling ctx
+// cancels the context of all in-flight requests; the server does this once
+// the shutdown drain period is over. A request is also cancelled when its
+// client closes the connection, so abandoned requests stop their database work.
+func BaseContext(ctx context.Context) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		base, cancel := context.WithCancelCause(context.Background())
+		defer cancel(nil)
+
+		stopShutdown := context.AfterFunc(ctx, func() { cancel(utils.ErrShuttingDown) })
+		defer stopShutdown()
+		stopWatching := watchDisconnect(c.Context().Conn(), func() { cancel(errClientDisconnected) })
+		defer stopWatching()
+
+		c.Locals(baseContextKey, base)
+		return c.Next()
+	}
+}
+
+// Timeout gives the request a context with the given deadline and stores it
+// as the Fiber user context, where handlers pick it up via c.UserContext().
+//
+// It can be applied globally and again on individual routes: the innermost
+// Timeout replaces the deadline of the outer one while keeping request-scoped
+// values. The context is also cancelled when the BaseContext is.
+//
+// If the context has ended by the time the handler chain fails, the response
+// is replaced with 504 (deadline exceeded), 503 (shutdown) or 499 (client
+// disconnected) so callers can tell these apart from internal errors.
+func Timeout(timeout time.Duration) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		parent := context.WithoutCancel(c.UserContext())
+		ctx, cancel := context.WithTimeout(parent, timeout)
+		defer cancel()
+
+		base, _ := c.Locals(baseContextKey).(context.Context)
+		if base != nil {
+			stop := context.AfterFunc(base, cancel)
+			defer stop()
+		}
+
+		c.SetUserContext(ctx)
+		c.Locals(timeoutOwnerKey, ctx)
+
+		err := c.Next()
+
+		// A nested Timeout owns the response mapping for its own deadline
+		if c.Locals(timeoutOwnerKey) != ctx {
+			return err
+		}
+
+		ctxErr := ctx.Err()
+		if ctxErr == nil {
+			return err
+		}
+		if err == nil && c.Response().StatusCode() < fiber.StatusInternalServerError {
+			return nil
+		}
+
+		if errors.Is(ctxErr, context.DeadlineExceeded) {
+			return utils.ErrorResponse(c, fiber.StatusGatewayTimeout, "Request timed out", ctxErr.Error())
+		}
+		if base != nil && context.Cause(base) == utils.ErrShuttingDown {
+			return utils.HandleError(c, utils.ErrShuttingDown, "")
+		}
+		return utils.ErrorResponse(c, utils.StatusClientClosedRequest, "Request cancelled", errClientDisconnected.Error())
+	}
+}
@@ -0,0 +1,107 @@
+package middleware_test
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"backend/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// waitForCancel fails the request once its context ends
+func waitForCancel(c *fiber.Ctx) error {
+	<-c.UserContext().Done()
+	return c.UserContext().Err()
+}
+
+func TestTimeoutResponses(t *testing.T) {
+	shutdown, abortRequests := context.WithCancel(context.Background())
+	abortRequests()
+
+	tests := []struct {
+		name       string
+		base       context.Context
+		timeout    time.Duration
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "deadline exceeded", base: context.Background(), timeout: 20 * time.Millisecond, wantStatus: fiber.StatusGatewayTimeout},
+		{name: "aborted by shutdown", base: shutdown, timeout: time.Minute, wantStatus: fiber.StatusServiceUnavailable, wantCode: "shutting_down"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Use(middleware.BaseContext(tt.base))
+			app.Use(middleware.Timeout(tt.timeout))
+			app.Get("/", waitForCancel)
+
+			response, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/", nil), 5000)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if response.StatusCode != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, response.StatusCode)
+			}
+			if tt.wantCode == "" {
+				return
+			}
+
+			var body struct {
+				Code string `json:"code"`
+			}
+			if err := json.NewDecoder(response.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+			if body.Code != tt.wantCode {
+				t.Fatalf("expected code %s, got %s", tt.wantCode, body.Code)
+			}
+		})
+	}
+}
+
+func TestTimeoutCancelsOnClientDisconnect(t *testing.T) {
+	cancelled := make(chan error, 1)
+
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	app.Use(middleware.BaseContext(context.Background()))
+	app.Use(middleware.Timeout(time.Minute))
+	app.Get("/", func(c *fiber.Ctx) error {
+		err := waitForCancel(c)
+		cancelled <- err
+		return err
+	})
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go app.Listener(listener)
+	defer app.Shutdown()
+
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := conn.Write([]byte("GET / HTTP/1.1\r\nHost: localhost\r\n\r\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	// Give the server time to start the handler before hanging up
+	time.Sleep(100 * time.Millisecond)
+	conn.Close()
+
+	select {
+	case err := <-cancelled:
+		if err != context.Canceled {
+			t.Fatalf("expected the request to be cancelled, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("request was not cancelled after the client disconnected")
+	}
+}
@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"backend/tracing"
+	"backend/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracingMiddleware starts a server span per request, continuing the trace
+// from the caller's traceparent header when present. The span is named after
+// the route template once routing is done. It must run outside
+// LoggerMiddleware so the recorded status is final.
+func TracingMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		carrier := propagation.HeaderCarrier{}
+		c.Request().Header.VisitAll(func(key, value []byte) {
+			carrier.Set(string(key), string(value))
+		})
+		ctx := tracing.Propagator.Extract(c.UserContext(), carrier)
+
+		ctx, span := tracing.StartWithKind(ctx, c.Method(), trace.SpanKindServer,
+			attribute.String("http.request.method", c.Method()),
+			attribute.String("url.path", c.Path()),
+			attribute.String("url.scheme", c.Protocol()),
+			attribute.String("client.address", clientIP(c)),
+			attribute.String("user_agent.original", c.Get(fiber.HeaderUserAgent)),
+			attribute.String("request_id", utils.RequestIDFromContext(ctx)),
+		)
+		defer span.End()
+		c.SetUserContext(ctx)
+
+		err := c.Next()
+
+		route := c.Route().Path
+		if route == "/" && c.Path() != "/" {
+			route = unmatchedRoute
+		} else {
+			span.SetName(c.Method() + " " + route)
+		}
+		status := c.Response().StatusCode()
+		span.SetAttributes(
+			attribute.String("http.route", route),
+			attribute.Int("http.response.status_code", status),
+		)
+		if status >= fiber.StatusInternalServerError {
+			span.SetStatus(codes.Error, "")
+		}
+		if userID := utils.UserIDFromContext(c.UserContext()); userID != "" {
+			span.SetAttributes(attribute.String("enduser.id", userID))
+		}
+
+		return err
+	}
+}
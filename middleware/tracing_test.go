@@ -0,0 +1,108 @@
+package middleware_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"backend/config"
+	"backend/middleware"
+	"backend/tracing"
+	"backend/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func findSpan(t *testing.T, spans tracetest.SpanStubs, name string) tracetest.SpanStub {
+	t.Helper()
+
+	for _, span := range spans {
+		if span.Name == name {
+			return span
+		}
+	}
+	t.Fatalf("no span named %q among %d spans", name, len(spans))
+	return tracetest.SpanStub{}
+}
+
+func attributeValue(span tracetest.SpanStub, key attribute.Key) attribute.Value {
+	for _, attr := range span.Attributes {
+		if attr.Key == key {
+			return attr.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func TestTracingMiddleware(t *testing.T) {
+	config.AppConfig = &config.Config{BcryptRounds: 4}
+	exporter := tracing.UseInMemory()
+
+	app := fiber.New()
+	app.Use(middleware.TracingMiddleware())
+	app.Post("/users/:id/password", func(c *fiber.Ctx) error {
+		if _, err := utils.HashPasswordContext(c.UserContext(), "secret"); err != nil {
+			return err
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+	app.Get("/fail", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusInternalServerError)
+	})
+
+	t.Run("continues the caller's trace", func(t *testing.T) {
+		exporter.Reset()
+
+		const (
+			traceID  = "4bf92f3577b34da6a3ce929d0e0e4736"
+			parentID = "00f067aa0ba902b7"
+		)
+		request := httptest.NewRequest(fiber.MethodPost, "/users/42/password", nil)
+		request.Header.Set("traceparent", "00-"+traceID+"-"+parentID+"-01")
+		if _, err := app.Test(request); err != nil {
+			t.Fatal(err)
+		}
+
+		spans := exporter.GetSpans()
+		server := findSpan(t, spans, "POST /users/:id/password")
+		if server.SpanKind != trace.SpanKindServer {
+			t.Fatalf("expected a server span, got %s", server.SpanKind)
+		}
+		if got := server.SpanContext.TraceID().String(); got != traceID {
+			t.Fatalf("expected the caller's trace %s, got %s", traceID, got)
+		}
+		if got := server.Parent.SpanID().String(); got != parentID || !server.Parent.IsRemote() {
+			t.Fatalf("expected the caller's span %s as remote parent, got %s", parentID, got)
+		}
+		if got := attributeValue(server, "http.route").AsString(); got != "/users/:id/password" {
+			t.Fatalf("expected the route template, got %q", got)
+		}
+		if got := attributeValue(server, "http.response.status_code").AsInt64(); got != fiber.StatusNoContent {
+			t.Fatalf("expected status 204, got %d", got)
+		}
+
+		hash := findSpan(t, spans, "bcrypt.hash")
+		if hash.Parent.SpanID() != server.SpanContext.SpanID() || hash.SpanContext.TraceID() != server.SpanContext.TraceID() {
+			t.Fatal("expected the bcrypt span to be a child of the server span")
+		}
+	})
+
+	t.Run("starts a trace without a traceparent", func(t *testing.T) {
+		exporter.Reset()
+
+		if _, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/fail", nil)); err != nil {
+			t.Fatal(err)
+		}
+
+		server := findSpan(t, exporter.GetSpans(), "GET /fail")
+		if server.Parent.IsValid() {
+			t.Fatalf("expected a root span, got parent %s", server.Parent.SpanID())
+		}
+		if server.Status.Code != codes.Error {
+			t.Fatalf("expected a 500 to mark the span failed, got %s", server.Status.Code)
+		}
+	})
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"backend/config"
+	"backend/database"
+	"backend/migrations"
+)
+
+var errMigrateUsage = errors.New("usage: migrate status | up | down | to VERSION")
+
+// runMigrate implements the migrate command against the configured storage backend:
+//
+//	migrate status       list migrations and when they were applied
+//	migrate up           apply every pending migration
+//	migrate down         revert the most recently applied migration
+//	migrate to VERSION   apply or revert migrations until VERSION; 0 reverts all
+func runMigrate(args []string) error {
+	if len(args) == 0 {
+		return errMigrateUsage
+	}
+
+	migrator, closeStorage, err := openMigrator()
+	if err != nil {
+		return err
+	}
+	defer closeStorage()
+
+	ctx := context.Background()
+	switch {
+	case args[0] == "status" && len(args) == 1:
+		return printMigrationStatus(ctx, migrator)
+	case args[0] == "up" && len(args) == 1:
+		err = migrator.Up(ctx)
+	case args[0] == "down" && len(args) == 1:
+		err = migrator.Down(ctx)
+	case args[0] == "to" && len(args) == 2:
+		version, convErr := strconv.Atoi(args[1])
+		if convErr != nil {
+			return errMigrateUsage
+		}
+		err = migrator.To(ctx, version)
+	default:
+		return errMigrateUsage
+	}
+	if err != nil {
+		return err
+	}
+
+	return printMigrationStatus(ctx, migrator)
+}
+
+// openMigrator connects the configured storage backend and returns its migrator
+func openMigrator() (*migrations.Migrator, func(), error) {
+	disconnect := func(stop func(context.Context) error) func() {
+		return func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			_ = stop(ctx)
+		}
+	}
+
+	switch config.AppConfig.StorageBackend {
+	case config.StorageMongoDB:
+		database.ConnectMongoDB()
+		return migrations.ForMongo(database.DB), disconnect(database.Disconnect), nil
+	case config.StoragePostgres:
+		database.ConnectPostgres()
+		return migrations.ForPostgres(database.Pool), disconnect(database.DisconnectPostgres), nil
+	default:
+		return nil, nil, fmt.Errorf("the %q storage backend has no schema to migrate", config.AppConfig.StorageBackend)
+	}
+}
+
+func printMigrationStatus(ctx context.Context, migrator *migrations.Migrator) error {
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tDESCRIPTION\tAPPLIED")
+	for _, status := range statuses {
+		applied := "pending"
+		if status.AppliedAt != nil {
+			applied = status.AppliedAt.UTC().Format(time.RFC3339)
+		}
+		if status.Unknown {
+			applied += " (unknown to this build)"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\n", status.Version, status.Description, applied)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,256 @@
+// Package migrations applies ordered, versioned schema changes and records
+// them in the database, so every replica agrees on the schema version. Only
+// one process migrates at a time; the others fail with ErrLocked.
+package migrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sort"
+	"time"
+)
+
+var (
+	ErrLocked         = errors.New("another process is running migrations")
+	ErrSchemaBehind   = errors.New("database schema is behind this build; run `migrate up`")
+	ErrSchemaAhead    = errors.New("database schema is newer than this build")
+	ErrUnknownVersion = errors.New("unknown migration version")
+)
+
+// lockTTL bounds how long a crashed migrator can hold the lock. Migrations
+// must finish within it.
+const lockTTL = 10 * time.Minute
+
+// Migration is one versioned schema change. Down reverts Up.
+type Migration struct {
+	Version     int
+	Description string
+	Up          func(ctx context.Context) error
+	Down        func(ctx context.Context) error
+}
+
+// Record is an applied migration as stored in schema_migrations
+type Record struct {
+	Version     int
+	Description string
+	AppliedAt   time.Time
+}
+
+// Store persists applied migrations and the lock that serializes migrators
+type Store interface {
+	// Applied returns the applied migrations ordered by version
+	Applied(ctx context.Context) ([]Record, error)
+	Insert(ctx context.Context, record Record) error
+	Delete(ctx context.Context, version int) error
+	// Lock takes the migration lock for owner until ttl elapses, failing
+	// with ErrLocked while another owner holds it
+	Lock(ctx context.Context, owner string, ttl time.Duration) error
+	Unlock(ctx context.Context, owner string) error
+}
+
+// Status describes a migration known to this build or recorded in the database
+type Status struct {
+	Version     int
+	Description string
+	AppliedAt   *time.Time
+	// Unknown is set for applied migrations this build does not know
+	Unknown bool
+}
+
+// Migrator runs a backend's migrations against its store
+type Migrator struct {
+	store      Store
+	migrations []Migration
+	owner      string
+}
+
+// New returns a migrator for migrations, which must have unique positive versions
+func New(store Store, migrations []Migration) *Migrator {
+	sorted := append([]Migration{}, migrations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Version < sorted[j].Version })
+	for i, migration := range sorted {
+		if migration.Version <= 0 || (i > 0 && sorted[i-1].Version == migration.Version) {
+			panic(fmt.Sprintf("migrations: invalid or duplicate version %d", migration.Version))
+		}
+	}
+
+	hostname, _ := os.Hostname()
+	return &Migrator{
+		store:      store,
+		migrations: sorted,
+		owner:      fmt.Sprintf("%s-%d", hostname, os.Getpid()),
+	}
+}
+
+// Latest returns the highest version known to this build
+func (m *Migrator) Latest() int {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+func (m *Migrator) find(version int) *Migration {
+	for i := range m.migrations {
+		if m.migrations[i].Version == version {
+			return &m.migrations[i]
+		}
+	}
+	return nil
+}
+
+// applied returns the applied migrations keyed by version
+func (m *Migrator) applied(ctx context.Context) (map[int]Record, error) {
+	records, err := m.store.Applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	applied := make(map[int]Record, len(records))
+	for _, record := range records {
+		applied[record.Version] = record
+	}
+	return applied, nil
+}
+
+// Status lists every known migration followed by applied ones this build does not know
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := []Status{}
+	for _, migration := range m.migrations {
+		status := Status{Version: migration.Version, Description: migration.Description}
+		if record, ok := applied[migration.Version]; ok {
+			appliedAt := record.AppliedAt
+			status.AppliedAt = &appliedAt
+			delete(applied, migration.Version)
+		}
+		statuses = append(statuses, status)
+	}
+
+	var unknown []Status
+	for _, record := range applied {
+		appliedAt := record.AppliedAt
+		unknown = append(unknown, Status{Version: record.Version, Description: record.Description, AppliedAt: &appliedAt, Unknown: true})
+	}
+	sort.Slice(unknown, func(i, j int) bool { return unknown[i].Version < unknown[j].Version })
+
+	return append(statuses, unknown...), nil
+}
+
+// Check reports whether the database schema matches this build exactly. It
+// is used to refuse startup and as a readiness check.
+func (m *Migrator) Check(ctx context.Context) error {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	pending := 0
+	for _, status := range statuses {
+		if status.Unknown {
+			return fmt.Errorf("%w: migration %d (%s) is applied but this build only knows up to %d",
+				ErrSchemaAhead, status.Version, status.Description, m.Latest())
+		}
+		if status.AppliedAt == nil {
+			pending++
+		}
+	}
+	if pending > 0 {
+		return fmt.Errorf("%w (%d pending)", ErrSchemaBehind, pending)
+	}
+	return nil
+}
+
+// Up applies every pending migration
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.To(ctx, m.Latest())
+}
+
+// Down reverts the most recently applied migration
+func (m *Migrator) Down(ctx context.Context) error {
+	records, err := m.store.Applied(ctx)
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		return nil
+	}
+
+	target := 0
+	if len(records) > 1 {
+		target = records[len(records)-2].Version
+	}
+	return m.To(ctx, target)
+}
+
+// To applies or reverts migrations until exactly those up to version are
+// applied. Version 0 reverts everything.
+func (m *Migrator) To(ctx context.Context, version int) error {
+	if version != 0 && m.find(version) == nil {
+		return fmt.Errorf("%w: %d", ErrUnknownVersion, version)
+	}
+
+	if err := m.store.Lock(ctx, m.owner, lockTTL); err != nil {
+		return err
+	}
+	defer func() {
+		// Release even if ctx was cancelled mid-migration
+		unlockCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := m.store.Unlock(unlockCtx, m.owner); err != nil {
+			slog.Warn("Failed to release migration lock", "error", err)
+		}
+	}()
+
+	// Read the state only once the lock is held, as another replica may have just migrated
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return err
+	}
+	for appliedVersion, record := range applied {
+		if m.find(appliedVersion) == nil {
+			return fmt.Errorf("%w: migration %d (%s) is applied but unknown to this build",
+				ErrSchemaAhead, appliedVersion, record.Description)
+		}
+	}
+
+	// Revert newest first, then apply oldest first
+	for i := len(m.migrations) - 1; i >= 0; i-- {
+		migration := m.migrations[i]
+		if _, ok := applied[migration.Version]; !ok || migration.Version <= version {
+			continue
+		}
+
+		slog.Info("Reverting migration", "version", migration.Version, "description", migration.Description)
+		if err := migration.Down(ctx); err != nil {
+			return fmt.Errorf("migration %d down: %w", migration.Version, err)
+		}
+		if err := m.store.Delete(ctx, migration.Version); err != nil {
+			return err
+		}
+	}
+
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; ok || migration.Version > version {
+			continue
+		}
+
+		slog.Info("Applying migration", "version", migration.Version, "description", migration.Description)
+		if err := migration.Up(ctx); err != nil {
+			return fmt.Errorf("migration %d up: %w", migration.Version, err)
+		}
+		record := Record{Version: migration.Version, Description: migration.Description, AppliedAt: time.Now()}
+		if err := m.store.Insert(ctx, record); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
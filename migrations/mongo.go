@@ -0,0 +1,216 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"backend/repositories"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// migrationLockID is the locks document that serializes migrators
+const migrationLockID = "schema_migrations"
+
+// ForMongo returns the migrator for the MongoDB backend
+func ForMongo(db *mongo.Database) *Migrator {
+	menuRepo := repositories.NewMenuRepository(db, repositories.NewPermissionRepository(db), repositories.NewTransactor(db.Client()))
+
+	return New(NewMongoStore(db), []Migration{
+		mongoIndexes(1, "create indexes", db, initialMongoIndexes),
+		seedMenus(2, menuRepo),
+		mongoIndexes(3, "create job run indexes", db, jobRunMongoIndexes),
+		mongoIndexes(4, "create email outbox indexes", db, outboxMongoIndexes),
+		mongoIndexes(5, "create notification indexes", db, notificationMongoIndexes),
+		mongoIndexes(6, "create webhook indexes", db, webhookMongoIndexes),
+		mongoIndexes(7, "create realtime event indexes", db, realtimeMongoIndexes),
+		mongoIndexes(8, "create rate limit indexes", db, rateLimitMongoIndexes),
+	})
+}
+
+type mongoStore struct {
+	migrations *mongo.Collection
+	locks      *mongo.Collection
+}
+
+// NewMongoStore records migrations in schema_migrations and locks in locks
+func NewMongoStore(db *mongo.Database) Store {
+	return &mongoStore{
+		migrations: db.Collection("schema_migrations"),
+		locks:      db.Collection("locks"),
+	}
+}
+
+type mongoRecord struct {
+	Version     int       `bson:"_id"`
+	Description string    `bson:"description"`
+	AppliedAt   time.Time `bson:"applied_at"`
+}
+
+func (s *mongoStore) Applied(ctx context.Context) ([]Record, error) {
+	cursor, err := s.migrations.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	records := []Record{}
+	for cursor.Next(ctx) {
+		var record mongoRecord
+		if err := cursor.Decode(&record); err != nil {
+			return nil, err
+		}
+		records = append(records, Record(record))
+	}
+	return records, cursor.Err()
+}
+
+func (s *mongoStore) Insert(ctx context.Context, record Record) error {
+	_, err := s.migrations.InsertOne(ctx, mongoRecord(record))
+	return err
+}
+
+func (s *mongoStore) Delete(ctx context.Context, version int) error {
+	_, err := s.migrations.DeleteOne(ctx, bson.M{"_id": version})
+	return err
+}
+
+// Lock upserts the lock document unless another owner holds an unexpired
+// lease; the upsert then collides with the existing _id
+func (s *mongoStore) Lock(ctx context.Context, owner string, ttl time.Duration) error {
+	now := time.Now()
+	filter := bson.M{
+		"_id": migrationLockID,
+		"$or": bson.A{
+			bson.M{"owner": owner},
+			bson.M{"expires_at": bson.M{"$lt": now}},
+		},
+	}
+	update := bson.M{"$set": bson.M{"owner": owner, "expires_at": now.Add(ttl)}}
+
+	_, err := s.locks.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrLocked
+	}
+	return err
+}
+
+func (s *mongoStore) Unlock(ctx context.Context, owner string) error {
+	_, err := s.locks.DeleteOne(ctx, bson.M{"_id": migrationLockID, "owner": owner})
+	return err
+}
+
+// mongoIndex is an index the repositories rely on
+type mongoIndex struct {
+	collection string
+	keys       bson.D
+	unique     bool
+	// ttl makes a TTL index that removes documents expireAfterSeconds after the indexed time
+	ttl                bool
+	expireAfterSeconds int32
+}
+
+// name matches the name MongoDB generates when none is given
+func (i mongoIndex) name() string {
+	parts := make([]string, 0, len(i.keys))
+	for _, key := range i.keys {
+		parts = append(parts, fmt.Sprintf("%s_%v", key.Key, key.Value))
+	}
+	return strings.Join(parts, "_")
+}
+
+var initialMongoIndexes = []mongoIndex{
+	{collection: "users", keys: bson.D{{Key: "email", Value: 1}}, unique: true},
+	{collection: "refresh_tokens", keys: bson.D{{Key: "token", Value: 1}}},
+	{collection: "refresh_tokens", keys: bson.D{{Key: "expires_at", Value: 1}}, ttl: true},
+	{collection: "menus", keys: bson.D{{Key: "name", Value: 1}}, unique: true},
+	{collection: "menus", keys: bson.D{{Key: "path", Value: 1}}, unique: true},
+	{collection: "menus", keys: bson.D{{Key: "order", Value: 1}}},
+	{collection: "role_menu_permissions", keys: bson.D{{Key: "role", Value: 1}, {Key: "menu_id", Value: 1}}, unique: true},
+	{collection: "role_menu_permissions", keys: bson.D{{Key: "role", Value: 1}}},
+	{collection: "role_menu_permissions", keys: bson.D{{Key: "menu_id", Value: 1}}},
+	// Finished bulk job reports expire after a week
+	{collection: "bulk_jobs", keys: bson.D{{Key: "created_at", Value: 1}}, ttl: true, expireAfterSeconds: 7 * 24 * 60 * 60},
+	{collection: "audit_events", keys: bson.D{{Key: "actor_id", Value: 1}}},
+	{collection: "audit_events", keys: bson.D{{Key: "target_id", Value: 1}}},
+	// Key order matters here: statistics filter on action, then range over created_at
+	{collection: "audit_events", keys: bson.D{{Key: "action", Value: 1}, {Key: "created_at", Value: 1}}},
+}
+
+var jobRunMongoIndexes = []mongoIndex{
+	{collection: "job_runs", keys: bson.D{{Key: "job", Value: 1}, {Key: "started_at", Value: -1}}},
+	{collection: "job_runs", keys: bson.D{{Key: "started_at", Value: 1}}},
+}
+
+var outboxMongoIndexes = []mongoIndex{
+	// The worker claims due pending messages; cleanup removes old sent ones
+	{collection: "email_outbox", keys: bson.D{{Key: "status", Value: 1}, {Key: "next_attempt_at", Value: 1}}},
+	{collection: "email_outbox", keys: bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: -1}}},
+	{collection: "email_outbox", keys: bson.D{{Key: "created_at", Value: -1}}},
+	{collection: "email_outbox", keys: bson.D{{Key: "recipient", Value: 1}}},
+}
+
+var notificationMongoIndexes = []mongoIndex{
+	// The inbox lists a user's notifications newest first; cleanup ranges over created_at
+	{collection: "notifications", keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}}},
+	{collection: "notifications", keys: bson.D{{Key: "created_at", Value: 1}}},
+	{collection: "notification_preferences", keys: bson.D{{Key: "user_id", Value: 1}, {Key: "type", Value: 1}}, unique: true},
+	{collection: "user_devices", keys: bson.D{{Key: "user_id", Value: 1}, {Key: "fingerprint", Value: 1}}, unique: true},
+}
+
+var webhookMongoIndexes = []mongoIndex{
+	// The worker claims due pending deliveries; the log lists a webhook's
+	// deliveries newest first; cleanup ranges over created_at
+	{collection: "webhook_deliveries", keys: bson.D{{Key: "status", Value: 1}, {Key: "next_attempt_at", Value: 1}}},
+	{collection: "webhook_deliveries", keys: bson.D{{Key: "webhook_id", Value: 1}, {Key: "created_at", Value: -1}}},
+	{collection: "webhook_deliveries", keys: bson.D{{Key: "created_at", Value: 1}}},
+}
+
+var realtimeMongoIndexes = []mongoIndex{
+	// Events are only read from the change stream as they are inserted, so
+	// they expire after an hour
+	{collection: "realtime_events", keys: bson.D{{Key: "created_at", Value: 1}}, ttl: true, expireAfterSeconds: 60 * 60},
+}
+
+var rateLimitMongoIndexes = []mongoIndex{
+	// Counters and buckets are removed once they no longer affect a limit
+	{collection: "rate_limits", keys: bson.D{{Key: "expires_at", Value: 1}}, ttl: true},
+}
+
+// mongoIndexes creates indexes on up and drops them on down. Version 1 holds
+// the indexes that used to be created on every boot.
+func mongoIndexes(version int, description string, db *mongo.Database, indexes []mongoIndex) Migration {
+	return Migration{
+		Version:     version,
+		Description: description,
+		Up: func(ctx context.Context) error {
+			for _, index := range indexes {
+				opts := options.Index()
+				if index.unique {
+					opts.SetUnique(true)
+				}
+				if index.ttl {
+					opts.SetExpireAfterSeconds(index.expireAfterSeconds)
+				}
+
+				model := mongo.IndexModel{Keys: index.keys, Options: opts}
+				if _, err := db.Collection(index.collection).Indexes().CreateOne(ctx, model); err != nil {
+					return fmt.Errorf("%s.%s: %w", index.collection, index.name(), err)
+				}
+			}
+			return nil
+		},
+		Down: func(ctx context.Context) error {
+			for _, index := range indexes {
+				if _, err := db.Collection(index.collection).Indexes().DropOne(ctx, index.name()); err != nil {
+					return fmt.Errorf("%s.%s: %w", index.collection, index.name(), err)
+				}
+			}
+			return nil
+		},
+	}
+}
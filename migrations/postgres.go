@@ -0,0 +1,383 @@
+package migrations
+
+import (
+	"context"
+	"time"
+
+	"backend/repositories/postgres"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ForPostgres returns the migrator for the PostgreSQL backend
+func ForPostgres(pool *pgxpool.Pool) *Migrator {
+	return New(NewPostgresStore(pool), []Migration{
+		postgresSQL(1, "create tables", pool, initialPostgresSchema, dropInitialPostgresSchema),
+		seedMenus(2, postgres.NewMenuRepository(pool)),
+		postgresSQL(3, "create job runs table", pool, jobRunsPostgresSchema, `DROP TABLE IF EXISTS job_runs;`),
+		postgresSQL(4, "create email outbox table", pool, outboxPostgresSchema, `DROP TABLE IF EXISTS email_outbox;`),
+		postgresSQL(5, "add user locale", pool,
+			`ALTER TABLE users ADD COLUMN IF NOT EXISTS locale TEXT NOT NULL DEFAULT '';`,
+			`ALTER TABLE users DROP COLUMN IF EXISTS locale;`),
+		postgresSQL(6, "create notification tables", pool, notificationsPostgresSchema, dropNotificationsPostgresSchema),
+		postgresSQL(7, "create webhook tables", pool, webhooksPostgresSchema, dropWebhooksPostgresSchema),
+		postgresSQL(8, "create rate limit table", pool, rateLimitsPostgresSchema, `DROP TABLE IF EXISTS rate_limits;`),
+	})
+}
+
+// postgresSQL runs up or down in a single transaction
+func postgresSQL(version int, description string, pool *pgxpool.Pool, up, down string) Migration {
+	exec := func(sql string) func(ctx context.Context) error {
+		return func(ctx context.Context) error {
+			tx, err := pool.Begin(ctx)
+			if err != nil {
+				return err
+			}
+			defer tx.Rollback(ctx)
+
+			if _, err := tx.Exec(ctx, sql); err != nil {
+				return err
+			}
+			return tx.Commit(ctx)
+		}
+	}
+
+	return Migration{Version: version, Description: description, Up: exec(up), Down: exec(down)}
+}
+
+type postgresStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresStore records migrations in schema_migrations and locks in locks,
+// creating both tables on first use
+func NewPostgresStore(pool *pgxpool.Pool) Store {
+	return &postgresStore{pool: pool}
+}
+
+const postgresBookkeeping = `
+CREATE TABLE IF NOT EXISTS schema_migrations (
+	version     INTEGER PRIMARY KEY,
+	description TEXT NOT NULL,
+	applied_at  TIMESTAMPTZ NOT NULL
+);
+CREATE TABLE IF NOT EXISTS locks (
+	name       TEXT PRIMARY KEY,
+	owner      TEXT NOT NULL,
+	expires_at TIMESTAMPTZ NOT NULL
+);
+`
+
+func (s *postgresStore) prepare(ctx context.Context) error {
+	_, err := s.pool.Exec(ctx, postgresBookkeeping)
+	return err
+}
+
+func (s *postgresStore) Applied(ctx context.Context) ([]Record, error) {
+	if err := s.prepare(ctx); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.pool.Query(ctx, `SELECT version, description, applied_at FROM schema_migrations ORDER BY version`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	records := []Record{}
+	for rows.Next() {
+		var record Record
+		if err := rows.Scan(&record.Version, &record.Description, &record.AppliedAt); err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, rows.Err()
+}
+
+func (s *postgresStore) Insert(ctx context.Context, record Record) error {
+	_, err := s.pool.Exec(ctx, `INSERT INTO schema_migrations (version, description, applied_at) VALUES ($1, $2, $3)`,
+		record.Version, record.Description, record.AppliedAt)
+	return err
+}
+
+func (s *postgresStore) Delete(ctx context.Context, version int) error {
+	_, err := s.pool.Exec(ctx, `DELETE FROM schema_migrations WHERE version = $1`, version)
+	return err
+}
+
+// Lock inserts the lock row, or takes it over when it is ours or expired
+func (s *postgresStore) Lock(ctx context.Context, owner string, ttl time.Duration) error {
+	if err := s.prepare(ctx); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	tag, err := s.pool.Exec(ctx, `
+		INSERT INTO locks (name, owner, expires_at) VALUES ($1, $2, $3)
+		ON CONFLICT (name) DO UPDATE SET owner = EXCLUDED.owner, expires_at = EXCLUDED.expires_at
+		WHERE locks.owner = EXCLUDED.owner OR locks.expires_at < $4`,
+		migrationLockID, owner, now.Add(ttl), now,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrLocked
+	}
+	return nil
+}
+
+func (s *postgresStore) Unlock(ctx context.Context, owner string) error {
+	_, err := s.pool.Exec(ctx, `DELETE FROM locks WHERE name = $1 AND owner = $2`, migrationLockID, owner)
+	return err
+}
+
+// initialPostgresSchema mirrors the MongoDB collections and indexes. IDs are
+// ObjectID hex strings so models and API responses are the same on both
+// backends. Permissions reference menus, so deleting a menu removes its
+// grants in the same statement.
+const initialPostgresSchema = `
+CREATE TABLE IF NOT EXISTS users (
+	id                   CHAR(24) PRIMARY KEY,
+	name                 TEXT NOT NULL,
+	email                TEXT NOT NULL,
+	password             TEXT NOT NULL,
+	role                 TEXT NOT NULL,
+	is_verified          BOOLEAN NOT NULL DEFAULT FALSE,
+	verified_at          TIMESTAMPTZ,
+	verified_by          CHAR(24),
+	verification_notes   TEXT NOT NULL DEFAULT '',
+	last_password_reset  TIMESTAMPTZ,
+	password_reset_count INTEGER NOT NULL DEFAULT 0,
+	is_suspended         BOOLEAN NOT NULL DEFAULT FALSE,
+	suspended_at         TIMESTAMPTZ,
+	suspended_by         CHAR(24),
+	suspension_reason    TEXT NOT NULL DEFAULT '',
+	last_login_at        TIMESTAMPTZ,
+	erased_at            TIMESTAMPTZ,
+	created_at           TIMESTAMPTZ NOT NULL,
+	updated_at           TIMESTAMPTZ NOT NULL,
+	CONSTRAINT users_email_key UNIQUE (email)
+);
+CREATE INDEX IF NOT EXISTS users_created_at_idx ON users (created_at);
+
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+	id         CHAR(24) PRIMARY KEY,
+	user_id    CHAR(24) NOT NULL,
+	token      TEXT NOT NULL,
+	expires_at TIMESTAMPTZ NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL,
+	is_revoked BOOLEAN NOT NULL DEFAULT FALSE
+);
+CREATE INDEX IF NOT EXISTS refresh_tokens_token_idx ON refresh_tokens (token);
+CREATE INDEX IF NOT EXISTS refresh_tokens_user_id_idx ON refresh_tokens (user_id);
+CREATE INDEX IF NOT EXISTS refresh_tokens_expires_at_idx ON refresh_tokens (expires_at);
+
+CREATE TABLE IF NOT EXISTS menus (
+	id          CHAR(24) PRIMARY KEY,
+	name        TEXT NOT NULL,
+	description TEXT NOT NULL DEFAULT '',
+	icon        TEXT NOT NULL DEFAULT '',
+	path        TEXT NOT NULL,
+	sort_order  INTEGER NOT NULL DEFAULT 0,
+	is_active   BOOLEAN NOT NULL DEFAULT TRUE,
+	created_at  TIMESTAMPTZ NOT NULL,
+	updated_at  TIMESTAMPTZ NOT NULL,
+	CONSTRAINT menus_name_key UNIQUE (name),
+	CONSTRAINT menus_path_key UNIQUE (path)
+);
+CREATE INDEX IF NOT EXISTS menus_sort_order_idx ON menus (sort_order);
+
+CREATE TABLE IF NOT EXISTS role_menu_permissions (
+	id              CHAR(24) PRIMARY KEY,
+	role            TEXT NOT NULL,
+	menu_id         CHAR(24) NOT NULL REFERENCES menus (id) ON DELETE CASCADE,
+	granted_by_id   CHAR(24) NOT NULL,
+	granted_by_name TEXT NOT NULL DEFAULT '',
+	created_at      TIMESTAMPTZ NOT NULL,
+	CONSTRAINT role_menu_permissions_role_menu_key UNIQUE (role, menu_id)
+);
+CREATE INDEX IF NOT EXISTS role_menu_permissions_menu_id_idx ON role_menu_permissions (menu_id);
+
+CREATE TABLE IF NOT EXISTS bulk_jobs (
+	id            CHAR(24) PRIMARY KEY,
+	operation     TEXT NOT NULL,
+	status        TEXT NOT NULL,
+	total         INTEGER NOT NULL DEFAULT 0,
+	processed     INTEGER NOT NULL DEFAULT 0,
+	succeeded     INTEGER NOT NULL DEFAULT 0,
+	failed        INTEGER NOT NULL DEFAULT 0,
+	results       JSONB NOT NULL DEFAULT '[]',
+	error         TEXT NOT NULL DEFAULT '',
+	created_by_id CHAR(24) NOT NULL,
+	created_at    TIMESTAMPTZ NOT NULL,
+	started_at    TIMESTAMPTZ,
+	completed_at  TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS bulk_jobs_created_at_idx ON bulk_jobs (created_at);
+
+CREATE TABLE IF NOT EXISTS audit_events (
+	id          CHAR(24) PRIMARY KEY,
+	action      TEXT NOT NULL,
+	actor_id    CHAR(24),
+	actor_name  TEXT NOT NULL DEFAULT '',
+	target_type TEXT NOT NULL,
+	target_id   TEXT NOT NULL,
+	details     JSONB,
+	created_at  TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS audit_events_actor_id_idx ON audit_events (actor_id);
+CREATE INDEX IF NOT EXISTS audit_events_target_id_idx ON audit_events (target_id);
+CREATE INDEX IF NOT EXISTS audit_events_action_created_at_idx ON audit_events (action, created_at);
+`
+
+const dropInitialPostgresSchema = `
+DROP TABLE IF EXISTS audit_events;
+DROP TABLE IF EXISTS bulk_jobs;
+DROP TABLE IF EXISTS role_menu_permissions;
+DROP TABLE IF EXISTS menus;
+DROP TABLE IF EXISTS refresh_tokens;
+DROP TABLE IF EXISTS users;
+`
+
+// jobRunsPostgresSchema holds the scheduler's job history. Job leases live in
+// the locks table the migrator creates.
+const jobRunsPostgresSchema = `
+CREATE TABLE IF NOT EXISTS job_runs (
+	id           CHAR(24) PRIMARY KEY,
+	job          TEXT NOT NULL,
+	trigger      TEXT NOT NULL,
+	triggered_by CHAR(24),
+	instance     TEXT NOT NULL,
+	started_at   TIMESTAMPTZ NOT NULL,
+	finished_at  TIMESTAMPTZ NOT NULL,
+	duration_ms  BIGINT NOT NULL,
+	error        TEXT NOT NULL DEFAULT ''
+);
+CREATE INDEX IF NOT EXISTS job_runs_job_started_at_idx ON job_runs (job, started_at DESC);
+CREATE INDEX IF NOT EXISTS job_runs_started_at_idx ON job_runs (started_at);
+`
+
+const outboxPostgresSchema = `
+CREATE TABLE IF NOT EXISTS email_outbox (
+	id              CHAR(24) PRIMARY KEY,
+	kind            TEXT NOT NULL,
+	recipient       TEXT NOT NULL,
+	recipient_name  TEXT NOT NULL DEFAULT '',
+	subject         TEXT NOT NULL,
+	text_body       TEXT NOT NULL DEFAULT '',
+	html_body       TEXT NOT NULL DEFAULT '',
+	status          TEXT NOT NULL,
+	attempts        INTEGER NOT NULL DEFAULT 0,
+	last_error      TEXT NOT NULL DEFAULT '',
+	next_attempt_at TIMESTAMPTZ NOT NULL,
+	last_attempt_at TIMESTAMPTZ,
+	sent_at         TIMESTAMPTZ,
+	created_at      TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS email_outbox_status_next_attempt_at_idx ON email_outbox (status, next_attempt_at);
+CREATE INDEX IF NOT EXISTS email_outbox_status_created_at_idx ON email_outbox (status, created_at DESC);
+CREATE INDEX IF NOT EXISTS email_outbox_created_at_idx ON email_outbox (created_at DESC);
+CREATE INDEX IF NOT EXISTS email_outbox_recipient_idx ON email_outbox (recipient);
+`
+
+const notificationsPostgresSchema = `
+CREATE TABLE IF NOT EXISTS notifications (
+	id         CHAR(24) PRIMARY KEY,
+	user_id    CHAR(24) NOT NULL,
+	type       TEXT NOT NULL,
+	title      TEXT NOT NULL,
+	body       TEXT NOT NULL DEFAULT '',
+	data       JSONB,
+	read_at    TIMESTAMPTZ,
+	created_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS notifications_user_id_created_at_idx ON notifications (user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS notifications_created_at_idx ON notifications (created_at);
+
+CREATE TABLE IF NOT EXISTS notification_preferences (
+	user_id CHAR(24) NOT NULL,
+	type    TEXT NOT NULL,
+	email   BOOLEAN NOT NULL,
+	in_app  BOOLEAN NOT NULL,
+	PRIMARY KEY (user_id, type)
+);
+
+CREATE TABLE IF NOT EXISTS user_devices (
+	id            CHAR(24) PRIMARY KEY,
+	user_id       CHAR(24) NOT NULL,
+	fingerprint   TEXT NOT NULL,
+	user_agent    TEXT NOT NULL DEFAULT '',
+	ip            TEXT NOT NULL DEFAULT '',
+	first_seen_at TIMESTAMPTZ NOT NULL,
+	last_seen_at  TIMESTAMPTZ NOT NULL,
+	CONSTRAINT user_devices_user_id_fingerprint_key UNIQUE (user_id, fingerprint)
+);
+`
+
+const dropNotificationsPostgresSchema = `
+DROP TABLE IF EXISTS user_devices;
+DROP TABLE IF EXISTS notification_preferences;
+DROP TABLE IF EXISTS notifications;
+`
+
+// webhooksPostgresSchema stores payloads as TEXT rather than JSONB, which
+// would reorder keys, so a redelivery is the same bytes as the original
+const webhooksPostgresSchema = `
+CREATE TABLE IF NOT EXISTS webhooks (
+	id                   CHAR(24) PRIMARY KEY,
+	url                  TEXT NOT NULL,
+	description          TEXT NOT NULL DEFAULT '',
+	events               TEXT[] NOT NULL,
+	secret               TEXT NOT NULL,
+	enabled              BOOLEAN NOT NULL,
+	consecutive_failures INTEGER NOT NULL DEFAULT 0,
+	disabled_reason      TEXT NOT NULL DEFAULT '',
+	disabled_at          TIMESTAMPTZ,
+	created_by           CHAR(24) NOT NULL,
+	created_at           TIMESTAMPTZ NOT NULL,
+	updated_at           TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+	id              CHAR(24) PRIMARY KEY,
+	webhook_id      CHAR(24) NOT NULL REFERENCES webhooks (id) ON DELETE CASCADE,
+	event_id        TEXT NOT NULL,
+	event_type      TEXT NOT NULL,
+	payload         TEXT NOT NULL,
+	redelivery_of   CHAR(24),
+	status          TEXT NOT NULL,
+	attempts        INTEGER NOT NULL DEFAULT 0,
+	response_status INTEGER NOT NULL DEFAULT 0,
+	response_body   TEXT NOT NULL DEFAULT '',
+	duration_ms     BIGINT NOT NULL DEFAULT 0,
+	last_error      TEXT NOT NULL DEFAULT '',
+	next_attempt_at TIMESTAMPTZ NOT NULL,
+	last_attempt_at TIMESTAMPTZ,
+	delivered_at    TIMESTAMPTZ,
+	created_at      TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS webhook_deliveries_status_next_attempt_at_idx ON webhook_deliveries (status, next_attempt_at);
+CREATE INDEX IF NOT EXISTS webhook_deliveries_webhook_id_created_at_idx ON webhook_deliveries (webhook_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS webhook_deliveries_created_at_idx ON webhook_deliveries (created_at);
+`
+
+const dropWebhooksPostgresSchema = `
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhooks;
+`
+
+// rateLimitsPostgresSchema holds both sliding window counters, which use
+// count, and token buckets, which use tokens and updated_at
+const rateLimitsPostgresSchema = `
+CREATE TABLE IF NOT EXISTS rate_limits (
+	key        TEXT PRIMARY KEY,
+	count      BIGINT NOT NULL DEFAULT 0,
+	tokens     DOUBLE PRECISION NOT NULL DEFAULT 0,
+	allowed    BOOLEAN NOT NULL DEFAULT FALSE,
+	updated_at TIMESTAMPTZ,
+	expires_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS rate_limits_expires_at_idx ON rate_limits (expires_at);
+`
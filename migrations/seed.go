@@ -0,0 +1,40 @@
+package migrations
+
+import (
+	"context"
+
+	"backend/database"
+	"backend/repositories/interfaces"
+)
+
+// seedMenus creates the default menus on an empty store. Down removes them,
+// and with them any grants that reference them.
+func seedMenus(version int, menuRepo interfaces.MenuRepository) Migration {
+	return Migration{
+		Version:     version,
+		Description: "seed default menus",
+		Up: func(ctx context.Context) error {
+			return database.SeedInitialData(ctx, menuRepo)
+		},
+		Down: func(ctx context.Context) error {
+			defaults := map[string]bool{}
+			for _, menu := range database.DefaultMenus() {
+				defaults[menu.Path] = true
+			}
+
+			menus, err := menuRepo.GetAll(ctx)
+			if err != nil {
+				return err
+			}
+			for _, menu := range menus {
+				if !defaults[menu.Path] {
+					continue
+				}
+				if err := menuRepo.Delete(ctx, menu.ID.Hex()); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
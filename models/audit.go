@@ -0,0 +1,78 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Audit actions
+const (
+	AuditUserCreated         = "user.created"
+	AuditUserVerified        = "user.verified"
+	AuditUserRoleChanged     = "user.role_changed"
+	AuditUserSuspended       = "user.suspended"
+	AuditUserReinstated      = "user.reinstated"
+	AuditUserSessionsRevoked = "user.sessions_revoked"
+	AuditUserLoggedIn        = "user.logged_in"
+	AuditUserPasswordReset   = "user.password_reset"
+	AuditUserDataExported    = "user.data_exported"
+	AuditUserErased          = "user.erased"
+	AuditPermissionGranted   = "permission.granted"
+	AuditPermissionRevoked   = "permission.revoked"
+	AuditEmailResent         = "email.resent"
+	AuditWebhookCreated      = "webhook.created"
+	AuditWebhookUpdated      = "webhook.updated"
+	AuditWebhookDeleted      = "webhook.deleted"
+	AuditWebhookDisabled     = "webhook.disabled"
+	AuditWebhookRedelivered  = "webhook.redelivered"
+)
+
+// Audit target types
+const (
+	AuditTargetUser    = "user"
+	AuditTargetRole    = "role"
+	AuditTargetEmail   = "email"
+	AuditTargetWebhook = "webhook"
+)
+
+// AuditEvent records who did what to which user, role, email or webhook.
+// Events reference people by ID; ActorName is a display copy that erasure
+// pseudonymizes.
+type AuditEvent struct {
+	ID         primitive.ObjectID  `json:"id" bson:"_id,omitempty"`
+	Action     string              `json:"action" bson:"action"`
+	ActorID    *primitive.ObjectID `json:"actor_id,omitempty" bson:"actor_id,omitempty"`
+	ActorName  string              `json:"actor_name,omitempty" bson:"actor_name,omitempty"`
+	TargetType string              `json:"target_type" bson:"target_type"`
+	TargetID   string              `json:"target_id" bson:"target_id"`
+	Details    map[string]string   `json:"details,omitempty" bson:"details,omitempty"`
+	CreatedAt  time.Time           `json:"created_at" bson:"created_at"`
+}
+
+// Data subject request models
+
+type SessionExport struct {
+	ID        string    `json:"id" example:"507f1f77bcf86cd799439011"`
+	CreatedAt time.Time `json:"created_at" example:"2024-01-01T00:00:00Z"`
+	ExpiresAt time.Time `json:"expires_at" example:"2024-01-08T00:00:00Z"`
+	IsRevoked bool      `json:"is_revoked" example:"false"`
+}
+
+// DataSubjectExport is the machine-readable archive of everything held about a user
+type DataSubjectExport struct {
+	GeneratedAt        time.Time                    `json:"generated_at" example:"2024-01-01T00:00:00Z"`
+	User               User                         `json:"user"`
+	Sessions           []SessionExport              `json:"sessions"`
+	Devices            []*Device                    `json:"devices"`
+	Notifications      []*Notification              `json:"notifications"`
+	AuditEvents        []*AuditEvent                `json:"audit_events"`
+	CurrentPermissions []RoleMenuPermissionResponse `json:"current_permissions"`
+	PermissionHistory  []*AuditEvent                `json:"permission_history"`
+}
+
+type ErasureResponse struct {
+	UserID    string    `json:"user_id" example:"507f1f77bcf86cd799439011"`
+	Pseudonym string    `json:"pseudonym" example:"Erased User 3f2a9c1b"`
+	ErasedAt  time.Time `json:"erased_at" example:"2024-01-01T00:00:00Z"`
+}
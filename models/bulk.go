@@ -0,0 +1,129 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Bulk operation names
+const (
+	BulkOperationVerify         = "verify"
+	BulkOperationChangeRole     = "change_role"
+	BulkOperationSuspend        = "suspend"
+	BulkOperationRevokeSessions = "revoke_sessions"
+)
+
+// Bulk job statuses
+const (
+	BulkJobStatusPending   = "pending"
+	BulkJobStatusRunning   = "running"
+	BulkJobStatusCompleted = "completed"
+	BulkJobStatusFailed    = "failed"
+)
+
+// BulkJob tracks an asynchronous bulk operation and its per-item results
+type BulkJob struct {
+	ID          primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Operation   string             `json:"operation" bson:"operation"`
+	Status      string             `json:"status" bson:"status"`
+	Total       int                `json:"total" bson:"total"`
+	Processed   int                `json:"processed" bson:"processed"`
+	Succeeded   int                `json:"succeeded" bson:"succeeded"`
+	Failed      int                `json:"failed" bson:"failed"`
+	Results     []BulkItemResult   `json:"results" bson:"results"`
+	Error       string             `json:"error,omitempty" bson:"error,omitempty"`
+	CreatedByID primitive.ObjectID `json:"created_by_id" bson:"created_by_id"`
+	CreatedAt   time.Time          `json:"created_at" bson:"created_at"`
+	StartedAt   *time.Time         `json:"started_at,omitempty" bson:"started_at,omitempty"`
+	CompletedAt *time.Time         `json:"completed_at,omitempty" bson:"completed_at,omitempty"`
+}
+
+// BulkItemResult is the outcome of a bulk operation for a single user
+type BulkItemResult struct {
+	UserID  string `json:"user_id" bson:"user_id" example:"507f1f77bcf86cd799439011"`
+	Success bool   `json:"success" bson:"success" example:"true"`
+	Error   string `json:"error,omitempty" bson:"error,omitempty" example:""`
+}
+
+// Request/Response models for API
+
+// BulkUserSelector targets users either by explicit IDs or by a filter query
+type BulkUserSelector struct {
+	IDs    []string    `json:"ids" validate:"omitempty,dive,required" example:"507f1f77bcf86cd799439011"`
+	Filter *UserFilter `json:"filter" validate:"omitempty"`
+	Async  bool        `json:"async" example:"false"`
+}
+
+type BulkVerifyRequest struct {
+	BulkUserSelector
+	Notes string `json:"notes" validate:"omitempty,max=500" example:"Verified during onboarding"`
+}
+
+type BulkRoleUpdateRequest struct {
+	BulkUserSelector
+	Role string `json:"role" validate:"required,oneof=admin liaison voice finance" example:"liaison"`
+}
+
+type BulkSuspendRequest struct {
+	BulkUserSelector
+	Reason string `json:"reason" validate:"omitempty,max=500" example:"Contract ended"`
+}
+
+type BulkRevokeSessionsRequest struct {
+	BulkUserSelector
+}
+
+type BulkOperationReport struct {
+	Operation string           `json:"operation" example:"verify"`
+	Total     int              `json:"total" example:"20"`
+	Succeeded int              `json:"succeeded" example:"19"`
+	Failed    int              `json:"failed" example:"1"`
+	Results   []BulkItemResult `json:"results"`
+}
+
+type BulkJobResponse struct {
+	ID          string           `json:"id" example:"507f1f77bcf86cd799439011"`
+	Operation   string           `json:"operation" example:"verify"`
+	Status      string           `json:"status" example:"running"`
+	Total       int              `json:"total" example:"200"`
+	Processed   int              `json:"processed" example:"120"`
+	Succeeded   int              `json:"succeeded" example:"118"`
+	Failed      int              `json:"failed" example:"2"`
+	Progress    float64          `json:"progress" example:"60"`
+	Results     []BulkItemResult `json:"results,omitempty"`
+	Error       string           `json:"error,omitempty" example:""`
+	CreatedAt   time.Time        `json:"created_at" example:"2024-01-01T00:00:00Z"`
+	StartedAt   *time.Time       `json:"started_at,omitempty" example:"2024-01-01T00:00:00Z"`
+	CompletedAt *time.Time       `json:"completed_at,omitempty" example:"2024-01-01T00:00:05Z"`
+}
+
+// Helper methods
+
+func (j *BulkJob) ToResponse(includeResults bool) BulkJobResponse {
+	progress := 100.0
+	if j.Total > 0 {
+		progress = float64(j.Processed) / float64(j.Total) * 100
+	}
+
+	response := BulkJobResponse{
+		ID:          j.ID.Hex(),
+		Operation:   j.Operation,
+		Status:      j.Status,
+		Total:       j.Total,
+		Processed:   j.Processed,
+		Succeeded:   j.Succeeded,
+		Failed:      j.Failed,
+		Progress:    progress,
+		Error:       j.Error,
+		CreatedAt:   j.CreatedAt,
+		StartedAt:   j.StartedAt,
+		CompletedAt: j.CompletedAt,
+	}
+
+	if includeResults {
+		response.Results = j.Results
+	}
+
+	return response
+}
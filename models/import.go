@@ -0,0 +1,57 @@
+package models
+
+// Import row statuses
+const (
+	ImportRowValid     = "valid"
+	ImportRowInvalid   = "invalid"
+	ImportRowDuplicate = "duplicate"
+	ImportRowCreated   = "created"
+	ImportRowFailed    = "failed"
+)
+
+// Credential delivery modes for imported users
+const (
+	ImportDeliveryInvite = "invite"
+	ImportDeliveryReset  = "reset"
+	ImportDeliveryReturn = "return"
+)
+
+// UserImportRow is a single parsed row from an uploaded CSV/XLSX file. The
+// locale column is optional.
+type UserImportRow struct {
+	Name   string `json:"name" validate:"required,min=2,max=50"`
+	Email  string `json:"email" validate:"required,email"`
+	Role   string `json:"role" validate:"required,oneof=admin liaison voice finance"`
+	Locale string `json:"locale" validate:"omitempty,bcp47_language_tag"`
+}
+
+// UserImportOptions controls how an import is processed
+type UserImportOptions struct {
+	DryRun   bool   `json:"dry_run" example:"true"`
+	Delivery string `json:"delivery" validate:"omitempty,oneof=invite reset return" example:"invite"`
+	Notes    string `json:"notes" validate:"omitempty,max=500" example:"Partner onboarding batch"`
+}
+
+type UserImportRowResult struct {
+	Row         int      `json:"row" example:"2"`
+	Name        string   `json:"name" example:"John Doe"`
+	Email       string   `json:"email" example:"john@example.com"`
+	Role        string   `json:"role" example:"liaison"`
+	Status      string   `json:"status" example:"valid"`
+	Errors      []string `json:"errors,omitempty"`
+	UserID      string   `json:"user_id,omitempty" example:"507f1f77bcf86cd799439011"`
+	Password    string   `json:"password,omitempty" example:""`
+	EmailQueued bool     `json:"email_queued,omitempty" example:"true"`
+}
+
+type UserImportReport struct {
+	DryRun     bool                  `json:"dry_run" example:"true"`
+	Delivery   string                `json:"delivery,omitempty" example:"invite"`
+	Total      int                   `json:"total" example:"25"`
+	Valid      int                   `json:"valid" example:"23"`
+	Invalid    int                   `json:"invalid" example:"1"`
+	Duplicates int                   `json:"duplicates" example:"1"`
+	Created    int                   `json:"created" example:"0"`
+	Failed     int                   `json:"failed" example:"0"`
+	Rows       []UserImportRowResult `json:"rows"`
+}
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// What started a job run
+const (
+	JobTriggerSchedule = "schedule"
+	JobTriggerManual   = "manual"
+)
+
+// JobRun records one execution of a scheduled maintenance job
+type JobRun struct {
+	ID          primitive.ObjectID  `json:"id" bson:"_id,omitempty"`
+	Job         string              `json:"job" bson:"job"`
+	Trigger     string              `json:"trigger" bson:"trigger"`
+	TriggeredBy *primitive.ObjectID `json:"triggered_by,omitempty" bson:"triggered_by,omitempty"`
+	Instance    string              `json:"instance" bson:"instance"`
+	StartedAt   time.Time           `json:"started_at" bson:"started_at"`
+	FinishedAt  time.Time           `json:"finished_at" bson:"finished_at"`
+	DurationMs  int64               `json:"duration_ms" bson:"duration_ms"`
+	Error       string              `json:"error,omitempty" bson:"error,omitempty"`
+}
+
+type JobRunResponse struct {
+	ID            string    `json:"id" example:"507f1f77bcf86cd799439011"`
+	Job           string    `json:"job" example:"token-cleanup"`
+	Trigger       string    `json:"trigger" example:"schedule"`
+	TriggeredByID string    `json:"triggered_by_id,omitempty" example:"507f1f77bcf86cd799439012"`
+	Instance      string    `json:"instance" example:"backend-7d9f-1"`
+	StartedAt     time.Time `json:"started_at" example:"2024-01-01T00:00:00Z"`
+	FinishedAt    time.Time `json:"finished_at" example:"2024-01-01T00:00:01Z"`
+	DurationMs    int64     `json:"duration_ms" example:"842"`
+	Error         string    `json:"error,omitempty" example:""`
+}
+
+// JobResponse describes a registered job and its most recent run on any replica
+type JobResponse struct {
+	Name        string          `json:"name" example:"token-cleanup"`
+	Description string          `json:"description" example:"Delete expired and revoked refresh tokens"`
+	Schedule    string          `json:"schedule" example:"@hourly"`
+	NextRunAt   time.Time       `json:"next_run_at" example:"2024-01-01T01:00:00Z"`
+	LastRun     *JobRunResponse `json:"last_run,omitempty"`
+}
+
+func (r *JobRun) ToResponse() JobRunResponse {
+	response := JobRunResponse{
+		ID:         r.ID.Hex(),
+		Job:        r.Job,
+		Trigger:    r.Trigger,
+		Instance:   r.Instance,
+		StartedAt:  r.StartedAt,
+		FinishedAt: r.FinishedAt,
+		DurationMs: r.DurationMs,
+		Error:      r.Error,
+	}
+	if r.TriggeredBy != nil {
+		response.TriggeredByID = r.TriggeredBy.Hex()
+	}
+	return response
+}
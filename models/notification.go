@@ -0,0 +1,105 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Notification event types
+const (
+	NotificationUserVerified      = "user.verified"
+	NotificationUserRoleChanged   = "user.role_changed"
+	NotificationPermissionGranted = "permission.granted"
+	NotificationPermissionRevoked = "permission.revoked"
+	NotificationNewDevice         = "security.new_device"
+)
+
+// NotificationDefaults are the channels each event type is delivered to until
+// the user chooses otherwise. Changes to a role's menus are frequent and low
+// stakes, so they stay in the inbox.
+var NotificationDefaults = map[string]NotificationChannels{
+	NotificationUserVerified:      {Email: true, InApp: true},
+	NotificationUserRoleChanged:   {Email: true, InApp: true},
+	NotificationPermissionGranted: {InApp: true},
+	NotificationPermissionRevoked: {InApp: true},
+	NotificationNewDevice:         {Email: true, InApp: true},
+}
+
+// NotificationChannels says where notifications of one event type are delivered
+type NotificationChannels struct {
+	Email bool `json:"email" bson:"email" example:"true"`
+	InApp bool `json:"in_app" bson:"in_app" example:"true"`
+}
+
+// Notification is an entry in a user's in-app inbox. Data holds the details
+// of the event, such as the new role or the menu that was granted.
+type Notification struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	UserID    primitive.ObjectID `json:"user_id" bson:"user_id"`
+	Type      string             `json:"type" bson:"type"`
+	Title     string             `json:"title" bson:"title"`
+	Body      string             `json:"body" bson:"body"`
+	Data      map[string]string  `json:"data,omitempty" bson:"data,omitempty"`
+	ReadAt    *time.Time         `json:"read_at,omitempty" bson:"read_at,omitempty"`
+	CreatedAt time.Time          `json:"created_at" bson:"created_at"`
+}
+
+type NotificationResponse struct {
+	ID        string            `json:"id" example:"507f1f77bcf86cd799439011"`
+	Type      string            `json:"type" example:"user.role_changed"`
+	Title     string            `json:"title" example:"Your role has changed"`
+	Body      string            `json:"body" example:"You now have the finance role."`
+	Data      map[string]string `json:"data,omitempty"`
+	Read      bool              `json:"read" example:"false"`
+	ReadAt    *time.Time        `json:"read_at,omitempty" example:"2024-01-01T00:05:00Z"`
+	CreatedAt time.Time         `json:"created_at" example:"2024-01-01T00:00:00Z"`
+}
+
+func (n *Notification) ToResponse() NotificationResponse {
+	return NotificationResponse{
+		ID:        n.ID.Hex(),
+		Type:      n.Type,
+		Title:     n.Title,
+		Body:      n.Body,
+		Data:      n.Data,
+		Read:      n.ReadAt != nil,
+		ReadAt:    n.ReadAt,
+		CreatedAt: n.CreatedAt,
+	}
+}
+
+type UnreadCountResponse struct {
+	Unread int64 `json:"unread" example:"3"`
+}
+
+type MarkAllReadResponse struct {
+	Marked int64 `json:"marked" example:"3"`
+}
+
+// NotificationPreference is a user's choice of channels for one event type.
+// Event types without a stored preference use NotificationDefaults.
+type NotificationPreference struct {
+	Type                 string `json:"type" bson:"type" validate:"required" example:"permission.granted"`
+	NotificationChannels `bson:",inline"`
+}
+
+type NotificationPreferencesRequest struct {
+	Preferences []NotificationPreference `json:"preferences" validate:"required,min=1,dive"`
+}
+
+type NotificationPreferencesResponse struct {
+	Preferences []NotificationPreference `json:"preferences"`
+}
+
+// Device is a browser or app a user has logged in from, told apart by its
+// User-Agent. A login from an unknown device raises a security notification.
+type Device struct {
+	ID          primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	UserID      primitive.ObjectID `json:"user_id" bson:"user_id"`
+	Fingerprint string             `json:"-" bson:"fingerprint"`
+	UserAgent   string             `json:"user_agent" bson:"user_agent"`
+	IP          string             `json:"ip" bson:"ip"`
+	FirstSeenAt time.Time          `json:"first_seen_at" bson:"first_seen_at"`
+	LastSeenAt  time.Time          `json:"last_seen_at" bson:"last_seen_at"`
+}
@@ -0,0 +1,108 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Outbox message statuses
+const (
+	OutboxStatusPending = "pending"
+	OutboxStatusSent    = "sent"
+	// OutboxStatusDead marks a message that failed every delivery attempt
+	OutboxStatusDead = "dead"
+)
+
+// Outbox message kinds
+const (
+	EmailKindPasswordReset = "password_reset"
+	EmailKindInvitation    = "invitation"
+)
+
+// OutboxMessage is an email queued in the same unit of work as the change
+// it announces and delivered by the outbox worker. Bodies may carry
+// temporary passwords, so they are cleared once the message is sent and are
+// never serialized to JSON.
+type OutboxMessage struct {
+	ID            primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Kind          string             `json:"kind" bson:"kind"`
+	Recipient     string             `json:"recipient" bson:"recipient"`
+	RecipientName string             `json:"recipient_name" bson:"recipient_name"`
+	Subject       string             `json:"subject" bson:"subject"`
+	TextBody      string             `json:"-" bson:"text_body"`
+	HTMLBody      string             `json:"-" bson:"html_body"`
+	Status        string             `json:"status" bson:"status"`
+	Attempts      int                `json:"attempts" bson:"attempts"`
+	LastError     string             `json:"last_error,omitempty" bson:"last_error,omitempty"`
+	NextAttemptAt time.Time          `json:"next_attempt_at" bson:"next_attempt_at"`
+	LastAttemptAt *time.Time         `json:"last_attempt_at,omitempty" bson:"last_attempt_at,omitempty"`
+	SentAt        *time.Time         `json:"sent_at,omitempty" bson:"sent_at,omitempty"`
+	CreatedAt     time.Time          `json:"created_at" bson:"created_at"`
+}
+
+type OutboxMessageResponse struct {
+	ID            string     `json:"id" example:"507f1f77bcf86cd799439011"`
+	Kind          string     `json:"kind" example:"password_reset"`
+	Recipient     string     `json:"recipient" example:"john@example.com"`
+	Subject       string     `json:"subject" example:"Your password has been reset"`
+	Status        string     `json:"status" example:"pending"`
+	Attempts      int        `json:"attempts" example:"2"`
+	LastError     string     `json:"last_error,omitempty" example:"email service returned status 503"`
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty" example:"2024-01-01T00:01:00Z"`
+	LastAttemptAt *time.Time `json:"last_attempt_at,omitempty" example:"2024-01-01T00:00:30Z"`
+	SentAt        *time.Time `json:"sent_at,omitempty" example:"2024-01-01T00:01:02Z"`
+	CreatedAt     time.Time  `json:"created_at" example:"2024-01-01T00:00:00Z"`
+}
+
+// OutboxSummary counts messages by status. OldestPendingAt shows how long
+// the queue has been backed up.
+type OutboxSummary struct {
+	Pending         int64      `json:"pending" example:"3"`
+	Sent            int64      `json:"sent" example:"1250"`
+	Dead            int64      `json:"dead" example:"1"`
+	OldestPendingAt *time.Time `json:"oldest_pending_at,omitempty" example:"2024-01-01T00:00:00Z"`
+}
+
+func (m *OutboxMessage) ToResponse() OutboxMessageResponse {
+	response := OutboxMessageResponse{
+		ID:            m.ID.Hex(),
+		Kind:          m.Kind,
+		Recipient:     m.Recipient,
+		Subject:       m.Subject,
+		Status:        m.Status,
+		Attempts:      m.Attempts,
+		LastError:     m.LastError,
+		LastAttemptAt: m.LastAttemptAt,
+		SentAt:        m.SentAt,
+		CreatedAt:     m.CreatedAt,
+	}
+	if m.Status == OutboxStatusPending {
+		nextAttemptAt := m.NextAttemptAt
+		response.NextAttemptAt = &nextAttemptAt
+	}
+	return response
+}
+
+// EmailTemplatesResponse lists the email templates. Recipients whose locale
+// has no variant get the closest one: their base language, then the default.
+type EmailTemplatesResponse struct {
+	DefaultLocale string                  `json:"default_locale" example:"en"`
+	Templates     []EmailTemplateResponse `json:"templates"`
+}
+
+type EmailTemplateResponse struct {
+	Kind    string   `json:"kind" example:"password_reset"`
+	Locales []string `json:"locales" example:"en,id"`
+}
+
+// EmailPreviewResponse is a template rendered with sample data
+type EmailPreviewResponse struct {
+	Kind    string `json:"kind" example:"password_reset"`
+	Locale  string `json:"locale" example:"en"`
+	Subject string `json:"subject" example:"Your Support password has been reset"`
+	// Summary is the in-app notification body of notification templates
+	Summary  string `json:"summary,omitempty" example:"Your role changed from liaison to finance."`
+	TextBody string `json:"text_body"`
+	HTMLBody string `json:"html_body"`
+}
@@ -0,0 +1,44 @@
+package models
+
+// AccessPolicy is the portable form of the menus and the role grants. Grants
+// refer to menus by path, so a policy exported from one environment can be
+// imported into another.
+type AccessPolicy struct {
+	Menus  []PolicyMenu  `json:"menus" validate:"unique=Path,unique=Name,dive"`
+	Grants []PolicyGrant `json:"grants" validate:"dive"`
+}
+
+type PolicyMenu struct {
+	Name        string `json:"name" validate:"required,min=2,max=50" example:"Dashboard"`
+	Description string `json:"description,omitempty" validate:"omitempty,max=200" example:"Main dashboard view"`
+	Icon        string `json:"icon,omitempty" validate:"omitempty,max=50" example:"dashboard"`
+	Path        string `json:"path" validate:"required,max=100" example:"/dashboard"`
+	Order       int    `json:"order" validate:"min=0" example:"1"`
+	IsActive    bool   `json:"is_active" example:"true"`
+}
+
+type PolicyGrant struct {
+	Role     string `json:"role" validate:"required,oneof=admin liaison voice finance" example:"liaison"`
+	MenuPath string `json:"menu_path" validate:"required,max=100" example:"/reports"`
+}
+
+// PolicyImportResult counts the changes made by importing a policy
+type PolicyImportResult struct {
+	MenusCreated  int `json:"menus_created"`
+	MenusUpdated  int `json:"menus_updated"`
+	MenusDeleted  int `json:"menus_deleted"`
+	GrantsAdded   int `json:"grants_added"`
+	GrantsRevoked int `json:"grants_revoked"`
+}
+
+// ToPolicyMenu converts a menu to its portable form
+func (m *Menu) ToPolicyMenu() PolicyMenu {
+	return PolicyMenu{
+		Name:        m.Name,
+		Description: m.Description,
+		Icon:        m.Icon,
+		Path:        m.Path,
+		Order:       m.Order,
+		IsActive:    m.IsActive,
+	}
+}
@@ -0,0 +1,59 @@
+package models
+
+import "time"
+
+// Stats bucket sizes
+const (
+	StatsBucketDay   = "day"
+	StatsBucketWeek  = "week"
+	StatsBucketMonth = "month"
+)
+
+// StatsQuery selects the time range and bucket size for time series statistics
+type StatsQuery struct {
+	From   time.Time
+	To     time.Time
+	Bucket string
+}
+
+type RoleStatusCount struct {
+	Role      string `json:"role" bson:"_id" example:"liaison"`
+	Total     int64  `json:"total" bson:"total" example:"42"`
+	Verified  int64  `json:"verified" bson:"verified" example:"38"`
+	Pending   int64  `json:"pending" bson:"pending" example:"4"`
+	Suspended int64  `json:"suspended" bson:"suspended" example:"1"`
+}
+
+// AgeBucketCount counts pending verifications whose age falls in [MinDays, MaxDays)
+type AgeBucketCount struct {
+	Label   string `json:"label" example:"1-3d"`
+	MinDays int    `json:"min_days" example:"1"`
+	MaxDays int    `json:"max_days,omitempty" example:"3"`
+	Count   int64  `json:"count" example:"2"`
+}
+
+// PeriodCount is one point of a time series; Period is the UTC start of the bucket
+type PeriodCount struct {
+	Period time.Time `json:"period" bson:"_id" example:"2024-01-01T00:00:00Z"`
+	Count  int64     `json:"count" bson:"count" example:"7"`
+}
+
+type UngrantedMenu struct {
+	ID   string `json:"id" example:"507f1f77bcf86cd799439011"`
+	Name string `json:"name" example:"reports"`
+	Path string `json:"path" example:"/reports"`
+}
+
+type AdminStatsResponse struct {
+	GeneratedAt           time.Time         `json:"generated_at" example:"2024-01-31T12:00:00Z"`
+	From                  time.Time         `json:"from" example:"2024-01-01T00:00:00Z"`
+	To                    time.Time         `json:"to" example:"2024-01-31T00:00:00Z"`
+	Bucket                string            `json:"bucket" example:"day"`
+	UsersByRole           []RoleStatusCount `json:"users_by_role"`
+	PendingByAge          []AgeBucketCount  `json:"pending_by_age"`
+	Registrations         []PeriodCount     `json:"registrations"`
+	Logins                []PeriodCount     `json:"logins"`
+	ActiveSessions        int64             `json:"active_sessions" example:"57"`
+	PasswordResetsLast24h int64             `json:"password_resets_last_24h" example:"3"`
+	MenusWithoutGrants    []UngrantedMenu   `json:"menus_without_grants"`
+}
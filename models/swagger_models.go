@@ -10,10 +10,25 @@ type SwaggerResponse struct {
 
 // SwaggerErrorResponse represents an error response for Swagger documentation
 type SwaggerErrorResponse struct {
-	Success bool   `json:"success" example:"false"`
-	Message string `json:"message" example:"Error occurred"`
-	Data    string `json:"data" example:"null"`
-	Error   string `json:"error" example:"Detailed error message"`
+	Success   bool   `json:"success" example:"false"`
+	Message   string `json:"message" example:"User not found"`
+	Code      string `json:"code" example:"user_not_found"`
+	Data      string `json:"data" example:"null"`
+	Error     string `json:"error,omitempty" example:""`
+	RequestID string `json:"request_id,omitempty" example:""`
+}
+
+// SwaggerProblem represents an RFC 7807 problem document, returned instead of
+// the error response to clients that accept application/problem+json
+type SwaggerProblem struct {
+	Type      string                   `json:"type" example:"about:blank"`
+	Title     string                   `json:"title" example:"Not Found"`
+	Status    int                      `json:"status" example:"404"`
+	Detail    string                   `json:"detail" example:"User not found"`
+	Instance  string                   `json:"instance" example:"/api/v1/admin/users/64b7f0c2e1a4d2a3b4c5d6e7"`
+	Code      string                   `json:"code" example:"user_not_found"`
+	Errors    []SwaggerValidationError `json:"errors,omitempty"`
+	RequestID string                   `json:"request_id,omitempty" example:""`
 }
 
 // SwaggerLoginResponse represents login response for Swagger documentation
@@ -48,15 +63,19 @@ type SwaggerHealthResponse struct {
 // SwaggerValidationError represents validation error details
 type SwaggerValidationError struct {
 	Field   string `json:"field" example:"email"`
-	Message string `json:"message" example:"Email is required"`
+	Rule    string `json:"rule" example:"required"`
+	Param   string `json:"param,omitempty" example:""`
+	Message string `json:"message" example:"email is required"`
 }
 
 // SwaggerValidationErrorResponse represents validation error response
 type SwaggerValidationErrorResponse struct {
 	Success bool                     `json:"success" example:"false"`
 	Message string                   `json:"message" example:"Validation failed"`
+	Code    string                   `json:"code" example:"validation_failed"`
 	Data    string                   `json:"data" example:"null"`
-	Error   []SwaggerValidationError `json:"error"`
+	Error   string                   `json:"error" example:"email is required"`
+	Details []SwaggerValidationError `json:"details"`
 }
 
 // SwaggerRegisterPendingResponse represents registration pending response for Swagger documentation
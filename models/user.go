@@ -12,12 +12,19 @@ type User struct {
 	Email              string              `json:"email" bson:"email" validate:"required,email"`
 	Password           string              `json:"-" bson:"password" validate:"required,min=6"`
 	Role               string              `json:"role" bson:"role" validate:"required,oneof=admin liaison voice finance"`
+	Locale             string              `json:"locale,omitempty" bson:"locale,omitempty" validate:"omitempty,bcp47_language_tag"`
 	IsVerified         bool                `json:"is_verified" bson:"is_verified"`
 	VerifiedAt         *time.Time          `json:"verified_at,omitempty" bson:"verified_at,omitempty"`
 	VerifiedBy         *primitive.ObjectID `json:"verified_by,omitempty" bson:"verified_by,omitempty"`
 	VerificationNotes  string              `json:"verification_notes,omitempty" bson:"verification_notes,omitempty"`
 	LastPasswordReset  *time.Time          `json:"last_password_reset,omitempty" bson:"last_password_reset,omitempty"`
 	PasswordResetCount int                 `json:"password_reset_count" bson:"password_reset_count"`
+	IsSuspended        bool                `json:"is_suspended" bson:"is_suspended"`
+	SuspendedAt        *time.Time          `json:"suspended_at,omitempty" bson:"suspended_at,omitempty"`
+	SuspendedBy        *primitive.ObjectID `json:"suspended_by,omitempty" bson:"suspended_by,omitempty"`
+	SuspensionReason   string              `json:"suspension_reason,omitempty" bson:"suspension_reason,omitempty"`
+	LastLoginAt        *time.Time          `json:"last_login_at,omitempty" bson:"last_login_at,omitempty"`
+	ErasedAt           *time.Time          `json:"erased_at,omitempty" bson:"erased_at,omitempty"`
 	CreatedAt          time.Time           `json:"created_at" bson:"created_at"`
 	UpdatedAt          time.Time           `json:"updated_at" bson:"updated_at"`
 }
@@ -27,6 +34,7 @@ type UserCreateRequest struct {
 	Email    string `json:"email" validate:"required,email" example:"john@example.com"`
 	Password string `json:"password" validate:"required,min=6" example:"password123"`
 	Role     string `json:"role" validate:"required,oneof=admin liaison voice finance" example:"user"`
+	Locale   string `json:"locale" validate:"omitempty,bcp47_language_tag" example:"en"`
 }
 
 type UserLoginRequest struct {
@@ -39,19 +47,37 @@ type UserResponse struct {
 	Name              string     `json:"name" example:"John Doe"`
 	Email             string     `json:"email" example:"john@example.com"`
 	Role              string     `json:"role" example:"user"`
+	Locale            string     `json:"locale,omitempty" example:"en"`
 	IsVerified        bool       `json:"is_verified" example:"true"`
 	VerifiedAt        *time.Time `json:"verified_at,omitempty" example:"2024-01-01T00:00:00Z"`
 	VerificationNotes string     `json:"verification_notes,omitempty" example:"Verified by admin"`
+	IsSuspended       bool       `json:"is_suspended" example:"false"`
+	SuspendedAt       *time.Time `json:"suspended_at,omitempty" example:"2024-01-01T00:00:00Z"`
+	SuspensionReason  string     `json:"suspension_reason,omitempty" example:"Left the organization"`
+	LastLoginAt       *time.Time `json:"last_login_at,omitempty" example:"2024-01-01T00:00:00Z"`
 	CreatedAt         time.Time  `json:"created_at" example:"2024-01-01T00:00:00Z"`
 	UpdatedAt         time.Time  `json:"updated_at" example:"2024-01-01T00:00:00Z"`
 }
 
 type UserUpdateRequest struct {
-	Name  string `json:"name" validate:"omitempty,min=2,max=50" example:"Jane Doe"`
-	Email string `json:"email" validate:"omitempty,email" example:"jane@example.com"`
+	Name   string `json:"name" validate:"omitempty,min=2,max=50" example:"Jane Doe"`
+	Email  string `json:"email" validate:"omitempty,email" example:"jane@example.com"`
+	Locale string `json:"locale" validate:"omitempty,bcp47_language_tag" example:"id"`
 }
 
 // Admin role management models
+// AdminCreateRequest creates an administrator who is verified immediately
+type AdminCreateRequest struct {
+	Name     string `json:"name" validate:"required,min=2,max=50" example:"Jane Admin"`
+	Email    string `json:"email" validate:"required,email" example:"jane@example.com"`
+	Password string `json:"password" validate:"required,min=6" example:"password123"`
+}
+
+// AdminPasswordResetRequest sets a user's password on their behalf
+type AdminPasswordResetRequest struct {
+	Password string `json:"password" validate:"required,min=6" example:"newpassword456"`
+}
+
 type AdminUserRoleUpdateRequest struct {
 	Role string `json:"role" validate:"required,oneof=admin liaison voice finance" example:"liaison"`
 }
@@ -66,6 +92,20 @@ type VerificationRequest struct {
 	Notes string `json:"notes" validate:"omitempty,max=500" example:"Identity verified through company records"`
 }
 
+// Admin suspension models
+type SuspensionRequest struct {
+	Reason string `json:"reason" validate:"omitempty,max=500" example:"Left the organization"`
+}
+
+// UserFilter selects users for admin queries and bulk operations
+type UserFilter struct {
+	Role          string     `json:"role" validate:"omitempty,oneof=admin liaison voice finance" example:"liaison"`
+	IsVerified    *bool      `json:"is_verified" example:"false"`
+	IsSuspended   *bool      `json:"is_suspended" example:"false"`
+	CreatedAfter  *time.Time `json:"created_after" example:"2024-01-01T00:00:00Z"`
+	CreatedBefore *time.Time `json:"created_before" example:"2024-12-31T23:59:59Z"`
+}
+
 type PendingUserResponse struct {
 	ID        string    `json:"id" example:"507f1f77bcf86cd799439011"`
 	Name      string    `json:"name" example:"John Doe"`
@@ -103,9 +143,14 @@ func (u *User) ToResponse() UserResponse {
 		Name:              u.Name,
 		Email:             u.Email,
 		Role:              u.Role,
+		Locale:            u.Locale,
 		IsVerified:        u.IsVerified,
 		VerifiedAt:        u.VerifiedAt,
 		VerificationNotes: u.VerificationNotes,
+		IsSuspended:       u.IsSuspended,
+		SuspendedAt:       u.SuspendedAt,
+		SuspensionReason:  u.SuspensionReason,
+		LastLoginAt:       u.LastLoginAt,
 		CreatedAt:         u.CreatedAt,
 		UpdatedAt:         u.UpdatedAt,
 	}
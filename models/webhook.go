@@ -0,0 +1,219 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Webhook event types. Payloads carry IDs rather than personal data;
+// receivers look the user up through the admin API when they need more.
+const (
+	WebhookUserVerified      = "user.verified"
+	WebhookUserRoleChanged   = "user.role_changed"
+	WebhookUserDeleted       = "user.deleted"
+	WebhookPermissionGranted = "permission.granted"
+	WebhookPermissionRevoked = "permission.revoked"
+	// WebhookAllEvents subscribes a webhook to every event type, including
+	// ones added later
+	WebhookAllEvents = "*"
+)
+
+// WebhookEvents lists the event types a webhook can subscribe to
+var WebhookEvents = []string{
+	WebhookUserVerified,
+	WebhookUserRoleChanged,
+	WebhookUserDeleted,
+	WebhookPermissionGranted,
+	WebhookPermissionRevoked,
+}
+
+// Webhook delivery statuses
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliveryDelivered = "delivered"
+	// WebhookDeliveryFailed marks a delivery that failed every attempt, or
+	// whose webhook was disabled before it was delivered
+	WebhookDeliveryFailed = "failed"
+)
+
+// Webhook is a subscription of an HTTP endpoint to event types. The secret
+// signs every delivery and is only shown when the webhook is created.
+// ConsecutiveFailures counts failed attempts since the last success; the
+// webhook is disabled when it reaches WEBHOOK_DISABLE_AFTER_FAILURES.
+type Webhook struct {
+	ID                  primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	URL                 string             `json:"url" bson:"url"`
+	Description         string             `json:"description" bson:"description"`
+	Events              []string           `json:"events" bson:"events"`
+	Secret              string             `json:"-" bson:"secret"`
+	Enabled             bool               `json:"enabled" bson:"enabled"`
+	ConsecutiveFailures int                `json:"consecutive_failures" bson:"consecutive_failures"`
+	DisabledReason      string             `json:"disabled_reason,omitempty" bson:"disabled_reason,omitempty"`
+	DisabledAt          *time.Time         `json:"disabled_at,omitempty" bson:"disabled_at,omitempty"`
+	CreatedBy           primitive.ObjectID `json:"created_by" bson:"created_by"`
+	CreatedAt           time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt           time.Time          `json:"updated_at" bson:"updated_at"`
+}
+
+// Subscribes reports whether the webhook receives events of the given type
+func (w *Webhook) Subscribes(eventType string) bool {
+	for _, event := range w.Events {
+		if event == eventType || event == WebhookAllEvents {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookCreateRequest subscribes a URL to event types. A secret is
+// generated when none is given.
+type WebhookCreateRequest struct {
+	URL         string   `json:"url" validate:"required,http_url,max=2048" example:"https://hooks.example.com/identity"`
+	Description string   `json:"description" validate:"max=200" example:"Provision accounts in the billing system"`
+	Events      []string `json:"events" validate:"required,min=1,dive,required" example:"user.verified,user.role_changed"`
+	Secret      string   `json:"secret" validate:"omitempty,min=16,max=256" example:""`
+}
+
+// WebhookUpdateRequest changes the given fields and leaves the others as
+// they are. Enabling a webhook clears its failure count.
+type WebhookUpdateRequest struct {
+	URL         *string  `json:"url" validate:"omitempty,http_url,max=2048" example:"https://hooks.example.com/identity"`
+	Description *string  `json:"description" validate:"omitempty,max=200" example:"Provision accounts in the billing system"`
+	Events      []string `json:"events" validate:"omitempty,min=1,dive,required" example:"user.verified,user.role_changed"`
+	Secret      *string  `json:"secret" validate:"omitempty,min=16,max=256" example:"a-new-shared-secret-value"`
+	Enabled     *bool    `json:"enabled" example:"true"`
+}
+
+type WebhookResponse struct {
+	ID                  string     `json:"id" example:"507f1f77bcf86cd799439011"`
+	URL                 string     `json:"url" example:"https://hooks.example.com/identity"`
+	Description         string     `json:"description" example:"Provision accounts in the billing system"`
+	Events              []string   `json:"events" example:"user.verified,user.role_changed"`
+	Enabled             bool       `json:"enabled" example:"true"`
+	ConsecutiveFailures int        `json:"consecutive_failures" example:"0"`
+	DisabledReason      string     `json:"disabled_reason,omitempty" example:"20 consecutive failed deliveries"`
+	DisabledAt          *time.Time `json:"disabled_at,omitempty" example:"2024-01-01T00:00:00Z"`
+	CreatedBy           string     `json:"created_by" example:"507f1f77bcf86cd799439012"`
+	CreatedAt           time.Time  `json:"created_at" example:"2024-01-01T00:00:00Z"`
+	UpdatedAt           time.Time  `json:"updated_at" example:"2024-01-01T00:00:00Z"`
+}
+
+// WebhookCreatedResponse is the only response that includes the secret
+type WebhookCreatedResponse struct {
+	WebhookResponse
+	Secret string `json:"secret" example:"whsec_3q2+7w..."`
+}
+
+func (w *Webhook) ToResponse() WebhookResponse {
+	return WebhookResponse{
+		ID:                  w.ID.Hex(),
+		URL:                 w.URL,
+		Description:         w.Description,
+		Events:              w.Events,
+		Enabled:             w.Enabled,
+		ConsecutiveFailures: w.ConsecutiveFailures,
+		DisabledReason:      w.DisabledReason,
+		DisabledAt:          w.DisabledAt,
+		CreatedBy:           w.CreatedBy.Hex(),
+		CreatedAt:           w.CreatedAt,
+		UpdatedAt:           w.UpdatedAt,
+	}
+}
+
+// WebhookPayload is the JSON body of every delivery. ID identifies the
+// event: every webhook it is delivered to, and every redelivery, carries the
+// same ID, so receivers can process an event once.
+type WebhookPayload struct {
+	ID        string            `json:"id" example:"65a1f0c2e4b0a1b2c3d4e5f6"`
+	Type      string            `json:"type" example:"user.role_changed"`
+	CreatedAt time.Time         `json:"created_at" example:"2024-01-01T00:00:00Z"`
+	Data      map[string]string `json:"data"`
+}
+
+// WebhookDelivery is one event queued for one webhook, and the log of its
+// delivery. The payload is stored as sent so a redelivery is byte for byte
+// the same; only the timestamp and signature headers change per attempt.
+// RedeliveryOf is the delivery an admin redelivered this one from.
+type WebhookDelivery struct {
+	ID             primitive.ObjectID  `json:"id" bson:"_id,omitempty"`
+	WebhookID      primitive.ObjectID  `json:"webhook_id" bson:"webhook_id"`
+	EventID        string              `json:"event_id" bson:"event_id"`
+	EventType      string              `json:"event_type" bson:"event_type"`
+	Payload        string              `json:"payload" bson:"payload"`
+	RedeliveryOf   *primitive.ObjectID `json:"redelivery_of,omitempty" bson:"redelivery_of,omitempty"`
+	Status         string              `json:"status" bson:"status"`
+	Attempts       int                 `json:"attempts" bson:"attempts"`
+	ResponseStatus int                 `json:"response_status,omitempty" bson:"response_status,omitempty"`
+	ResponseBody   string              `json:"response_body,omitempty" bson:"response_body,omitempty"`
+	DurationMs     int64               `json:"duration_ms" bson:"duration_ms"`
+	LastError      string              `json:"last_error,omitempty" bson:"last_error,omitempty"`
+	NextAttemptAt  time.Time           `json:"next_attempt_at" bson:"next_attempt_at"`
+	LastAttemptAt  *time.Time          `json:"last_attempt_at,omitempty" bson:"last_attempt_at,omitempty"`
+	DeliveredAt    *time.Time          `json:"delivered_at,omitempty" bson:"delivered_at,omitempty"`
+	CreatedAt      time.Time           `json:"created_at" bson:"created_at"`
+}
+
+// WebhookAttempt is the outcome of one delivery attempt. ResponseStatus is
+// zero when no response was received.
+type WebhookAttempt struct {
+	ResponseStatus int
+	ResponseBody   string
+	Duration       time.Duration
+	Error          string
+}
+
+type WebhookDeliveryResponse struct {
+	ID             string          `json:"id" example:"507f1f77bcf86cd799439013"`
+	WebhookID      string          `json:"webhook_id" example:"507f1f77bcf86cd799439011"`
+	EventID        string          `json:"event_id" example:"65a1f0c2e4b0a1b2c3d4e5f6"`
+	EventType      string          `json:"event_type" example:"user.role_changed"`
+	Payload        *WebhookPayload `json:"payload"`
+	RedeliveryOf   string          `json:"redelivery_of,omitempty" example:"507f1f77bcf86cd799439014"`
+	Status         string          `json:"status" example:"delivered"`
+	Attempts       int             `json:"attempts" example:"1"`
+	ResponseStatus int             `json:"response_status,omitempty" example:"200"`
+	ResponseBody   string          `json:"response_body,omitempty" example:"ok"`
+	DurationMs     int64           `json:"duration_ms" example:"84"`
+	LastError      string          `json:"last_error,omitempty" example:"endpoint returned status 503"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at,omitempty" example:"2024-01-01T00:01:00Z"`
+	LastAttemptAt  *time.Time      `json:"last_attempt_at,omitempty" example:"2024-01-01T00:00:30Z"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty" example:"2024-01-01T00:00:30Z"`
+	CreatedAt      time.Time       `json:"created_at" example:"2024-01-01T00:00:00Z"`
+}
+
+func (d *WebhookDelivery) ToResponse() WebhookDeliveryResponse {
+	response := WebhookDeliveryResponse{
+		ID:             d.ID.Hex(),
+		WebhookID:      d.WebhookID.Hex(),
+		EventID:        d.EventID,
+		EventType:      d.EventType,
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		ResponseStatus: d.ResponseStatus,
+		ResponseBody:   d.ResponseBody,
+		DurationMs:     d.DurationMs,
+		LastError:      d.LastError,
+		LastAttemptAt:  d.LastAttemptAt,
+		DeliveredAt:    d.DeliveredAt,
+		CreatedAt:      d.CreatedAt,
+	}
+	// Payloads are always encoded from a WebhookPayload
+	var payload WebhookPayload
+	if json.Unmarshal([]byte(d.Payload), &payload) == nil {
+		response.Payload = &payload
+	}
+	if d.RedeliveryOf != nil {
+		response.RedeliveryOf = d.RedeliveryOf.Hex()
+	}
+	if d.Status == WebhookDeliveryPending {
+		nextAttemptAt := d.NextAttemptAt
+		response.NextAttemptAt = &nextAttemptAt
+	}
+	return response
+}
+
+type WebhookEventsResponse struct {
+	Events []string `json:"events" example:"user.verified,user.role_changed,user.deleted,permission.granted,permission.revoked"`
+}
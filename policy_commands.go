@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"backend/models"
+)
+
+func runExportPolicy(args []string) error {
+	fs := newFlagSet("export-policy")
+	output := fs.String("output", "-", "file to write, or - for standard output")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	app, err := openCLI()
+	if err != nil {
+		return err
+	}
+	defer app.close()
+
+	policy, err := app.menus.ExportPolicy(context.Background())
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if *output != "-" {
+		file, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		w = file
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(policy)
+}
+
+func runImportPolicy(args []string) error {
+	fs := newFlagSet("import-policy")
+	input := fs.String("input", "", "file to read, or - for standard input")
+	actor := fs.String("actor", "", "email of the administrator granting the permissions")
+	prune := fs.Bool("prune", false, "delete menus and revoke grants that are not in the policy")
+	if err := parseFlags(fs, args, "input", "actor"); err != nil {
+		return err
+	}
+
+	var r io.Reader = os.Stdin
+	if *input != "-" {
+		file, err := os.Open(*input)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		r = file
+	}
+
+	// Unknown fields are most likely typos, which would otherwise be ignored
+	var policy models.AccessPolicy
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&policy); err != nil {
+		return fmt.Errorf("import-policy: invalid policy: %w", err)
+	}
+
+	app, err := openCLI()
+	if err != nil {
+		return err
+	}
+	defer app.close()
+
+	ctx := context.Background()
+	actorID, err := app.actorID(ctx, *actor)
+	if err != nil {
+		return err
+	}
+
+	result, err := app.menus.ImportPolicy(ctx, &policy, actorID, *prune)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Menus: %d created, %d updated, %d deleted\n", result.MenusCreated, result.MenusUpdated, result.MenusDeleted)
+	fmt.Printf("Grants: %d added, %d revoked\n", result.GrantsAdded, result.GrantsRevoked)
+	return nil
+}
@@ -0,0 +1,162 @@
+// Package ratelimit decides whether a request is within its policy's limit.
+// Counters and buckets live in a RateLimitRepository, in process memory or
+// in the storage backend so that every replica enforces the same limits.
+package ratelimit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"time"
+
+	"backend/repositories/interfaces"
+)
+
+// Algorithm is how a policy counts requests
+type Algorithm string
+
+const (
+	// SlidingWindow allows Limit requests in any Window, estimating the
+	// requests in the window from the counts of the current and previous
+	// fixed windows. Only allowed requests are counted, so refused retries
+	// do not extend how long a client stays limited.
+	SlidingWindow Algorithm = "sliding_window"
+	// TokenBucket allows bursts of up to Limit requests and refills at
+	// Limit requests per Window
+	TokenBucket Algorithm = "token_bucket"
+)
+
+// KeyBy is what a policy limits requests by
+type KeyBy string
+
+const (
+	KeyByIP    KeyBy = "ip"
+	KeyByUser  KeyBy = "user"
+	KeyByEmail KeyBy = "email"
+	// KeyByEmailAndIP limits each address's requests for an email, so
+	// requests from elsewhere cannot use up an account's limit
+	KeyByEmailAndIP KeyBy = "email_ip"
+)
+
+// Policy limits a group of routes
+type Policy struct {
+	// Name identifies the policy in stored keys, logs and metrics; routes
+	// sharing a name share their limits
+	Name      string
+	Algorithm Algorithm
+	Limit     int
+	Window    time.Duration
+	KeyBy     KeyBy
+}
+
+// Header is the policy in the RateLimit-Policy header format, such as
+// "10;w=60"
+func (p Policy) Header() string {
+	return fmt.Sprintf("%d;w=%d", p.Limit, int(p.Window.Seconds()))
+}
+
+// Decision is the outcome of a request against a policy
+type Decision struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset is how long until the limit is fully available again
+	Reset time.Duration
+	// RetryAfter is how long a refused client should wait
+	RetryAfter time.Duration
+}
+
+type Limiter struct {
+	store interfaces.RateLimitRepository
+	now   func() time.Time
+}
+
+func New(store interfaces.RateLimitRepository) *Limiter {
+	return &Limiter{store: store, now: time.Now}
+}
+
+// Allow counts a request by the client identified by key against the policy
+func (l *Limiter) Allow(ctx context.Context, policy Policy, key string) (*Decision, error) {
+	// Keys may be email addresses; only their hashes are stored
+	sum := sha256.Sum256([]byte(key))
+	key = policy.Name + ":" + string(policy.KeyBy) + ":" + hex.EncodeToString(sum[:16])
+
+	switch policy.Algorithm {
+	case SlidingWindow:
+		return l.slidingWindow(ctx, policy, key, l.now())
+	case TokenBucket:
+		return l.tokenBucket(ctx, policy, key, l.now())
+	default:
+		return nil, fmt.Errorf("unknown rate limit algorithm %q", policy.Algorithm)
+	}
+}
+
+func (l *Limiter) slidingWindow(ctx context.Context, policy Policy, key string, now time.Time) (*Decision, error) {
+	start := now.Truncate(policy.Window)
+	currentKey := fmt.Sprintf("%s:%d", key, start.Unix())
+	// A window's counter is read while the next one is current
+	current, err := l.store.Increment(ctx, currentKey, start.Add(2*policy.Window))
+	if err != nil {
+		return nil, err
+	}
+	previous, err := l.store.Count(ctx, fmt.Sprintf("%s:%d", key, start.Add(-policy.Window).Unix()))
+	if err != nil {
+		return nil, err
+	}
+
+	// The previous window counts for the part of it still inside the
+	// sliding window
+	elapsed := now.Sub(start)
+	weight := 1 - float64(elapsed)/float64(policy.Window)
+	estimate := float64(previous)*weight + float64(current)
+	limit := float64(policy.Limit)
+
+	decision := &Decision{
+		Allowed:   estimate <= limit,
+		Limit:     policy.Limit,
+		Remaining: int(math.Max(0, math.Floor(limit-estimate))),
+		Reset:     policy.Window - elapsed,
+	}
+	if !decision.Allowed {
+		// Counting and then undoing keeps concurrent requests from all
+		// passing a check made before any of them was counted
+		if err := l.store.Decrement(ctx, currentKey); err != nil {
+			return nil, err
+		}
+		current--
+
+		// The next request is allowed once the estimate after counting it
+		// stays within the limit: later in this window if the previous
+		// window's share falls far enough, otherwise in the next one
+		if spare := limit - float64(current) - 1; spare >= 0 {
+			decision.RetryAfter = time.Duration((1-spare/float64(previous))*float64(policy.Window)) - elapsed
+		} else {
+			decision.RetryAfter = policy.Window - elapsed + time.Duration((1-(limit-1)/float64(current))*float64(policy.Window))
+		}
+		decision.Reset = decision.RetryAfter
+	}
+	return decision, nil
+}
+
+func (l *Limiter) tokenBucket(ctx context.Context, policy Policy, key string, now time.Time) (*Decision, error) {
+	capacity := float64(policy.Limit)
+	rate := capacity / policy.Window.Seconds()
+
+	allowed, tokens, err := l.store.TakeToken(ctx, key, capacity, rate, now)
+	if err != nil {
+		return nil, err
+	}
+
+	decision := &Decision{
+		Allowed:   allowed,
+		Limit:     policy.Limit,
+		Remaining: int(math.Floor(tokens)),
+		Reset:     time.Duration((capacity - tokens) / rate * float64(time.Second)),
+	}
+	if !allowed {
+		decision.RetryAfter = time.Duration((1 - tokens) / rate * float64(time.Second))
+	}
+	return decision, nil
+}
@@ -0,0 +1,178 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"backend/repositories/memory"
+)
+
+type step struct {
+	at        time.Duration
+	allowed   bool
+	remaining int
+	// retryAfter is checked on refusals, to the second
+	retryAfter time.Duration
+}
+
+// runSteps sends a request at each step's offset from start and checks the decision
+func runSteps(t *testing.T, policy Policy, start time.Time, steps []step) {
+	t.Helper()
+
+	now := start
+	limiter := New(memory.NewRateLimitRepository())
+	limiter.now = func() time.Time { return now }
+
+	for i, s := range steps {
+		now = start.Add(s.at)
+		decision, err := limiter.Allow(context.Background(), policy, "client")
+		if err != nil {
+			t.Fatalf("step %d: %v", i, err)
+		}
+		if decision.Allowed != s.allowed || decision.Remaining != s.remaining {
+			t.Fatalf("step %d at %s: expected allowed=%v remaining=%d, got allowed=%v remaining=%d",
+				i, s.at, s.allowed, s.remaining, decision.Allowed, decision.Remaining)
+		}
+		if decision.Limit != policy.Limit {
+			t.Fatalf("step %d: expected limit %d, got %d", i, policy.Limit, decision.Limit)
+		}
+		if !s.allowed && decision.RetryAfter.Round(time.Second) != s.retryAfter {
+			t.Fatalf("step %d: expected retry after %s, got %s", i, s.retryAfter, decision.RetryAfter)
+		}
+	}
+}
+
+func TestTokenBucket(t *testing.T) {
+	// Three tokens, refilled at one a second
+	policy := Policy{Name: "test", Algorithm: TokenBucket, Limit: 3, Window: 3 * time.Second, KeyBy: KeyByIP}
+	start := time.Now()
+
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "burst up to the limit",
+			steps: []step{
+				{at: 0, allowed: true, remaining: 2},
+				{at: 0, allowed: true, remaining: 1},
+				{at: 0, allowed: true, remaining: 0},
+				{at: 0, allowed: false, remaining: 0, retryAfter: time.Second},
+			},
+		},
+		{
+			name: "refills steadily",
+			steps: []step{
+				{at: 0, allowed: true, remaining: 2},
+				{at: 0, allowed: true, remaining: 1},
+				{at: 0, allowed: true, remaining: 0},
+				{at: 500 * time.Millisecond, allowed: false, remaining: 0, retryAfter: time.Second},
+				{at: time.Second, allowed: true, remaining: 0},
+				{at: 3 * time.Second, allowed: true, remaining: 1},
+			},
+		},
+		{
+			name: "refills up to the limit",
+			steps: []step{
+				{at: 0, allowed: true, remaining: 2},
+				{at: time.Hour, allowed: true, remaining: 2},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runSteps(t, policy, start, tt.steps)
+		})
+	}
+}
+
+func TestSlidingWindow(t *testing.T) {
+	policy := Policy{Name: "test", Algorithm: SlidingWindow, Limit: 3, Window: time.Minute, KeyBy: KeyByIP}
+	// Counters expire by the wall clock, so the fake clock starts at the
+	// beginning of the next window rather than in the past
+	start := time.Now().Truncate(time.Minute).Add(time.Minute)
+
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "limit within a window",
+			steps: []step{
+				{at: 0, allowed: true, remaining: 2},
+				{at: 10 * time.Second, allowed: true, remaining: 1},
+				{at: 20 * time.Second, allowed: true, remaining: 0},
+				// The window is full, and the next one starts with all three
+				// counted; one fits once a third of it has passed
+				{at: 30 * time.Second, allowed: false, remaining: 0, retryAfter: 50 * time.Second},
+			},
+		},
+		{
+			name: "previous window counts for its share",
+			steps: []step{
+				{at: 0, allowed: true, remaining: 2},
+				{at: 0, allowed: true, remaining: 1},
+				{at: 0, allowed: true, remaining: 0},
+				// At a quarter into the next window the previous one counts for
+				// 2.25, leaving room for none; after a third it counts for 2
+				{at: 75 * time.Second, allowed: false, remaining: 0, retryAfter: 5 * time.Second},
+				{at: 81 * time.Second, allowed: true, remaining: 0},
+			},
+		},
+		{
+			name: "refused requests are not counted",
+			steps: []step{
+				{at: 0, allowed: true, remaining: 2},
+				{at: 0, allowed: true, remaining: 1},
+				{at: 0, allowed: true, remaining: 0},
+				{at: 0, allowed: false, remaining: 0, retryAfter: 80 * time.Second},
+				{at: time.Second, allowed: false, remaining: 0, retryAfter: 79 * time.Second},
+				{at: 2 * time.Second, allowed: false, remaining: 0, retryAfter: 78 * time.Second},
+				{at: 59 * time.Second, allowed: false, remaining: 0, retryAfter: 21 * time.Second},
+				{at: 81 * time.Second, allowed: true, remaining: 0},
+			},
+		},
+		{
+			name: "old windows are forgotten",
+			steps: []step{
+				{at: 0, allowed: true, remaining: 2},
+				{at: 0, allowed: true, remaining: 1},
+				{at: 0, allowed: true, remaining: 0},
+				{at: 2 * time.Minute, allowed: true, remaining: 2},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runSteps(t, policy, start, tt.steps)
+		})
+	}
+}
+
+func TestPoliciesAndClientsAreIndependent(t *testing.T) {
+	limiter := New(memory.NewRateLimitRepository())
+	first := Policy{Name: "first", Algorithm: TokenBucket, Limit: 1, Window: time.Minute, KeyBy: KeyByIP}
+	second := Policy{Name: "second", Algorithm: TokenBucket, Limit: 1, Window: time.Minute, KeyBy: KeyByIP}
+
+	allow := func(policy Policy, key string) bool {
+		t.Helper()
+		decision, err := limiter.Allow(context.Background(), policy, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return decision.Allowed
+	}
+
+	if !allow(first, "a") || allow(first, "a") {
+		t.Fatal("expected the first request allowed and the second refused")
+	}
+	if !allow(first, "b") {
+		t.Fatal("expected another client to have its own limit")
+	}
+	if !allow(second, "a") {
+		t.Fatal("expected another policy to have its own limit")
+	}
+}
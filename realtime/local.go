@@ -0,0 +1,47 @@
+package realtime
+
+import (
+	"context"
+	"sync"
+)
+
+// Local delivers events within this process. It suits a single replica; with
+// several, clients on other replicas miss the events.
+type Local struct {
+	mu          sync.RWMutex
+	subscribers map[int]func(event *Event)
+	next        int
+}
+
+func NewLocal() *Local {
+	return &Local{subscribers: make(map[int]func(event *Event))}
+}
+
+func (l *Local) Name() string {
+	return "local"
+}
+
+func (l *Local) Publish(ctx context.Context, event *Event) error {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	for _, handle := range l.subscribers {
+		handle(event)
+	}
+	return nil
+}
+
+func (l *Local) Subscribe(ctx context.Context, handle func(event *Event)) error {
+	l.mu.Lock()
+	id := l.next
+	l.next++
+	l.subscribers[id] = handle
+	l.mu.Unlock()
+
+	<-ctx.Done()
+
+	l.mu.Lock()
+	delete(l.subscribers, id)
+	l.mu.Unlock()
+	return nil
+}
@@ -0,0 +1,71 @@
+package realtime
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Mongo publishes events by inserting them into the realtime_events
+// collection and receives them from a change stream on it. Change streams
+// need a replica set or sharded cluster. A TTL index removes the events.
+type Mongo struct {
+	collection *mongo.Collection
+	// resumeToken is where the last subscription stopped, so a new one
+	// picks up the events published in between
+	resumeToken bson.Raw
+}
+
+// storedEvent is an event as stored in the collection
+type storedEvent struct {
+	Event     `bson:",inline"`
+	CreatedAt time.Time `bson:"created_at"`
+}
+
+func NewMongo(db *mongo.Database) *Mongo {
+	return &Mongo{collection: db.Collection("realtime_events")}
+}
+
+func (m *Mongo) Name() string {
+	return "mongodb"
+}
+
+func (m *Mongo) Publish(ctx context.Context, event *Event) error {
+	_, err := m.collection.InsertOne(ctx, &storedEvent{Event: *event, CreatedAt: time.Now()})
+	return err
+}
+
+func (m *Mongo) Subscribe(ctx context.Context, handle func(event *Event)) error {
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: bson.M{"operationType": "insert"}}}}
+	opts := options.ChangeStream()
+	if m.resumeToken != nil {
+		opts.SetResumeAfter(m.resumeToken)
+	}
+
+	stream, err := m.collection.Watch(ctx, pipeline, opts)
+	if err != nil {
+		// The token may have fallen out of the oplog; start from now next time
+		m.resumeToken = nil
+		return err
+	}
+	defer stream.Close(context.Background())
+
+	for stream.Next(ctx) {
+		var change struct {
+			FullDocument storedEvent `bson:"fullDocument"`
+		}
+		m.resumeToken = stream.ResumeToken()
+		if err := stream.Decode(&change); err != nil {
+			continue
+		}
+		handle(&change.FullDocument.Event)
+	}
+
+	if ctx.Err() != nil {
+		return nil
+	}
+	return stream.Err()
+}
@@ -0,0 +1,66 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// postgresChannel is the NOTIFY channel events are published on
+const postgresChannel = "realtime_events"
+
+// Postgres publishes events with NOTIFY and receives them on a connection
+// that LISTENs. Notification payloads are limited to 8000 bytes, which
+// events stay well below.
+type Postgres struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgres(pool *pgxpool.Pool) *Postgres {
+	return &Postgres{pool: pool}
+}
+
+func (p *Postgres) Name() string {
+	return "postgres"
+}
+
+func (p *Postgres) Publish(ctx context.Context, event *Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	_, err = p.pool.Exec(ctx, `SELECT pg_notify($1, $2)`, postgresChannel, string(payload))
+	return err
+}
+
+func (p *Postgres) Subscribe(ctx context.Context, handle func(event *Event)) error {
+	conn, err := p.pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+	// Stop listening before the connection goes back to the pool
+	defer conn.Exec(context.Background(), `UNLISTEN *`)
+
+	if _, err := conn.Exec(ctx, `LISTEN `+postgresChannel); err != nil {
+		return err
+	}
+
+	for {
+		notification, err := conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		var event Event
+		if err := json.Unmarshal([]byte(notification.Payload), &event); err != nil {
+			continue
+		}
+		handle(&event)
+	}
+}
@@ -0,0 +1,43 @@
+// Package realtime carries events for connected clients between replicas. A
+// client's stream is held by one replica, but the change it must hear about
+// can happen on any other, so every replica publishes events through a
+// broker and receives all of them back: in-process for a single replica,
+// MongoDB change streams, or PostgreSQL LISTEN/NOTIFY.
+package realtime
+
+import "context"
+
+// Event types sent to clients
+const (
+	// EventReady is the first event on every stream; clients reload their
+	// menus on it, since events sent while they were disconnected are lost
+	EventReady = "ready"
+	// EventMenusChanged tells clients to reload their menus
+	EventMenusChanged = "menus.changed"
+	// EventRoleChanged tells a user their role changed
+	EventRoleChanged = "role.changed"
+	// EventLogout tells a client to discard its tokens; the stream is closed
+	// after it
+	EventLogout = "logout"
+)
+
+// Event is addressed to one user, to the members of some roles, or, with
+// neither, to every connected user. Only Type and Data reach clients.
+type Event struct {
+	Type   string            `json:"type" bson:"type"`
+	UserID string            `json:"user_id,omitempty" bson:"user_id,omitempty"`
+	Roles  []string          `json:"roles,omitempty" bson:"roles,omitempty"`
+	Data   map[string]string `json:"data,omitempty" bson:"data,omitempty"`
+}
+
+// Broker fans events out to every replica
+type Broker interface {
+	// Name identifies the broker in logs
+	Name() string
+	Publish(ctx context.Context, event *Event) error
+	// Subscribe passes the events published through every replica's broker,
+	// this one's included, to handle until ctx is cancelled or the
+	// subscription fails. Events published while no subscription is open are
+	// not replayed.
+	Subscribe(ctx context.Context, handle func(event *Event)) error
+}
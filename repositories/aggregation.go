@@ -0,0 +1,55 @@
+package repositories
+
+import (
+	"context"
+
+	"backend/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// countByPeriod counts documents matching filter whose dateField falls in
+// [query.From, query.To), grouped into UTC buckets of query.Bucket size.
+// Empty buckets are omitted.
+func countByPeriod(ctx context.Context, collection *mongo.Collection, filter bson.M, dateField string, query *models.StatsQuery) ([]models.PeriodCount, error) {
+	match := bson.M{dateField: bson.M{"$gte": query.From, "$lt": query.To}}
+	for key, value := range filter {
+		match[key] = value
+	}
+
+	truncate := bson.M{
+		"date":     "$" + dateField,
+		"unit":     query.Bucket,
+		"timezone": "UTC",
+	}
+	if query.Bucket == models.StatsBucketWeek {
+		truncate["startOfWeek"] = "monday"
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   bson.M{"$dateTrunc": truncate},
+			"count": bson.M{"$sum": 1},
+		}}},
+		{{Key: "$sort", Value: bson.M{"_id": 1}}},
+	}
+
+	cursor, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	counts := []models.PeriodCount{}
+	for cursor.Next(ctx) {
+		var count models.PeriodCount
+		if err := cursor.Decode(&count); err != nil {
+			return nil, err
+		}
+		counts = append(counts, count)
+	}
+
+	return counts, cursor.Err()
+}
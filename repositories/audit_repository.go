@@ -0,0 +1,113 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"backend/models"
+	"backend/repositories/interfaces"
+	"backend/utils"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type auditRepository struct {
+	collection *mongo.Collection
+}
+
+func NewAuditRepository(db *mongo.Database) interfaces.AuditRepository {
+	return &auditRepository{
+		collection: db.Collection("audit_events"),
+	}
+}
+
+func (r *auditRepository) Create(ctx context.Context, event *models.AuditEvent) error {
+	event.ID = primitive.NewObjectID()
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+
+	_, err := r.collection.InsertOne(ctx, event)
+	return err
+}
+
+// GetInvolvingUser returns events where the user is either the actor or the target
+func (r *auditRepository) GetInvolvingUser(ctx context.Context, userID string) ([]*models.AuditEvent, error) {
+	objectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, utils.ErrInvalidID
+	}
+
+	filter := bson.M{
+		"$or": []bson.M{
+			{"actor_id": objectID},
+			{"target_type": models.AuditTargetUser, "target_id": userID},
+		},
+	}
+
+	return r.find(ctx, filter)
+}
+
+// GetPermissionHistory returns grant and revoke events for a role
+func (r *auditRepository) GetPermissionHistory(ctx context.Context, role string) ([]*models.AuditEvent, error) {
+	filter := bson.M{
+		"target_type": models.AuditTargetRole,
+		"target_id":   role,
+		"action": bson.M{"$in": []string{
+			models.AuditPermissionGranted,
+			models.AuditPermissionRevoked,
+		}},
+	}
+
+	return r.find(ctx, filter)
+}
+
+// PseudonymizeActor replaces the stored display name of every event the user performed
+func (r *auditRepository) PseudonymizeActor(ctx context.Context, userID, pseudonym string) error {
+	objectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return utils.ErrInvalidID
+	}
+
+	update := bson.M{"$set": bson.M{"actor_name": pseudonym}}
+	_, err = r.collection.UpdateMany(ctx, bson.M{"actor_id": objectID}, update)
+	return err
+}
+
+func (r *auditRepository) find(ctx context.Context, filter bson.M) ([]*models.AuditEvent, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var events []*models.AuditEvent
+	for cursor.Next(ctx) {
+		var event models.AuditEvent
+		if err := cursor.Decode(&event); err != nil {
+			return nil, err
+		}
+		events = append(events, &event)
+	}
+
+	return events, cursor.Err()
+}
+
+// CountByPeriod counts events of one action per time bucket
+func (r *auditRepository) CountByPeriod(ctx context.Context, action string, query *models.StatsQuery) ([]models.PeriodCount, error) {
+	return countByPeriod(ctx, r.collection, bson.M{"action": action}, "created_at", query)
+}
+
+// CountSince counts events of one action recorded at or after since
+func (r *auditRepository) CountSince(ctx context.Context, action string, since time.Time) (int64, error) {
+	filter := bson.M{
+		"action":     action,
+		"created_at": bson.M{"$gte": since},
+	}
+	return r.collection.CountDocuments(ctx, filter)
+}
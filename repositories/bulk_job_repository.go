@@ -0,0 +1,166 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"backend/models"
+	"backend/repositories/interfaces"
+	"backend/utils"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type bulkJobRepository struct {
+	collection *mongo.Collection
+}
+
+func NewBulkJobRepository(db *mongo.Database) interfaces.BulkJobRepository {
+	return &bulkJobRepository{
+		collection: db.Collection("bulk_jobs"),
+	}
+}
+
+func (r *bulkJobRepository) Create(ctx context.Context, job *models.BulkJob) error {
+	job.ID = primitive.NewObjectID()
+	job.CreatedAt = time.Now()
+	if job.Status == "" {
+		job.Status = models.BulkJobStatusPending
+	}
+	if job.Results == nil {
+		job.Results = []models.BulkItemResult{}
+	}
+
+	_, err := r.collection.InsertOne(ctx, job)
+	return err
+}
+
+func (r *bulkJobRepository) GetByID(ctx context.Context, id string) (*models.BulkJob, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, utils.ErrInvalidID
+	}
+
+	var job models.BulkJob
+	err = r.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&job)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, utils.ErrBulkJobNotFound
+		}
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+func (r *bulkJobRepository) GetRecent(ctx context.Context, limit int64) ([]*models.BulkJob, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetLimit(limit).
+		SetProjection(bson.M{"results": 0})
+
+	cursor, err := r.collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var jobs []*models.BulkJob
+	for cursor.Next(ctx) {
+		var job models.BulkJob
+		if err := cursor.Decode(&job); err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, &job)
+	}
+
+	return jobs, cursor.Err()
+}
+
+func (r *bulkJobRepository) MarkRunning(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return utils.ErrInvalidID
+	}
+
+	now := time.Now()
+	update := bson.M{
+		"$set": bson.M{
+			"status":     models.BulkJobStatusRunning,
+			"started_at": &now,
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return utils.ErrBulkJobNotFound
+	}
+
+	return nil
+}
+
+// AppendResult records one item outcome and advances the progress counters
+func (r *bulkJobRepository) AppendResult(ctx context.Context, id string, item models.BulkItemResult) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return utils.ErrInvalidID
+	}
+
+	counter := "failed"
+	if item.Success {
+		counter = "succeeded"
+	}
+
+	update := bson.M{
+		"$push": bson.M{"results": item},
+		"$inc": bson.M{
+			"processed": 1,
+			counter:     1,
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return utils.ErrBulkJobNotFound
+	}
+
+	return nil
+}
+
+func (r *bulkJobRepository) Complete(ctx context.Context, id, status, errMessage string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return utils.ErrInvalidID
+	}
+
+	now := time.Now()
+	set := bson.M{
+		"status":       status,
+		"completed_at": &now,
+	}
+	if errMessage != "" {
+		set["error"] = errMessage
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": objectID}, bson.M{"$set": set})
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return utils.ErrBulkJobNotFound
+	}
+
+	return nil
+}
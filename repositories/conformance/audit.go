@@ -0,0 +1,133 @@
+package conformance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"backend/models"
+	"backend/utils"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func testAudit(t *testing.T, newRepos Factory) {
+	ctx := context.Background()
+
+	t.Run("InvolvingUser", func(t *testing.T) {
+		repos := newRepos(t)
+		alice := primitive.NewObjectID()
+		admin := primitive.NewObjectID()
+		base := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+
+		events := []*models.AuditEvent{
+			{Action: models.AuditUserVerified, ActorID: &admin, ActorName: "Admin", TargetType: models.AuditTargetUser, TargetID: alice.Hex(), CreatedAt: base.Add(2 * time.Hour)},
+			{Action: models.AuditUserLoggedIn, ActorID: &alice, ActorName: "Alice", TargetType: models.AuditTargetUser, TargetID: alice.Hex(), CreatedAt: base},
+			{Action: models.AuditPermissionGranted, ActorID: &alice, ActorName: "Alice", TargetType: models.AuditTargetRole, TargetID: "liaison", CreatedAt: base.Add(time.Hour), Details: map[string]string{"menu": "Reports"}},
+			{Action: models.AuditUserLoggedIn, ActorID: &admin, ActorName: "Admin", TargetType: models.AuditTargetUser, TargetID: admin.Hex(), CreatedAt: base},
+		}
+		for _, event := range events {
+			mustSucceed(t, repos.Audit.Create(ctx, event))
+			if event.ID.IsZero() {
+				t.Fatal("expected Create to assign an ID")
+			}
+		}
+
+		involving, err := repos.Audit.GetInvolvingUser(ctx, alice.Hex())
+		mustSucceed(t, err)
+		if len(involving) != 3 {
+			t.Fatalf("expected 3 events involving alice, got %d", len(involving))
+		}
+		for i, want := range []*models.AuditEvent{events[1], events[2], events[0]} {
+			if involving[i].ID != want.ID {
+				t.Fatalf("expected events oldest first, got %s at %d", involving[i].Action, i)
+			}
+		}
+		expectTime(t, "CreatedAt", involving[0].CreatedAt, base)
+		if involving[1].Details["menu"] != "Reports" || involving[0].Details != nil {
+			t.Fatalf("expected details to round-trip, got %v and %v", involving[1].Details, involving[0].Details)
+		}
+
+		_, err = repos.Audit.GetInvolvingUser(ctx, "not-an-id")
+		expectError(t, err, utils.ErrInvalidID)
+	})
+
+	t.Run("PermissionHistory", func(t *testing.T) {
+		repos := newRepos(t)
+		base := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+		for i, action := range []string{models.AuditPermissionGranted, models.AuditPermissionRevoked, models.AuditUserLoggedIn} {
+			event := &models.AuditEvent{Action: action, TargetType: models.AuditTargetRole, TargetID: "liaison", CreatedAt: base.Add(time.Duration(i) * time.Minute)}
+			mustSucceed(t, repos.Audit.Create(ctx, event))
+		}
+		other := &models.AuditEvent{Action: models.AuditPermissionGranted, TargetType: models.AuditTargetRole, TargetID: "voice", CreatedAt: base}
+		mustSucceed(t, repos.Audit.Create(ctx, other))
+
+		history, err := repos.Audit.GetPermissionHistory(ctx, "liaison")
+		mustSucceed(t, err)
+		if len(history) != 2 || history[0].Action != models.AuditPermissionGranted || history[1].Action != models.AuditPermissionRevoked {
+			t.Fatalf("expected grant then revoke for liaison, got %d events", len(history))
+		}
+	})
+
+	t.Run("PseudonymizeActor", func(t *testing.T) {
+		repos := newRepos(t)
+		alice := primitive.NewObjectID()
+		admin := primitive.NewObjectID()
+		mustSucceed(t, repos.Audit.Create(ctx, &models.AuditEvent{Action: models.AuditUserLoggedIn, ActorID: &alice, ActorName: "Alice", TargetType: models.AuditTargetUser, TargetID: alice.Hex()}))
+		mustSucceed(t, repos.Audit.Create(ctx, &models.AuditEvent{Action: models.AuditUserVerified, ActorID: &admin, ActorName: "Admin", TargetType: models.AuditTargetUser, TargetID: alice.Hex()}))
+
+		expectError(t, repos.Audit.PseudonymizeActor(ctx, "not-an-id", "x"), utils.ErrInvalidID)
+		mustSucceed(t, repos.Audit.PseudonymizeActor(ctx, alice.Hex(), "Erased User"))
+
+		events, err := repos.Audit.GetInvolvingUser(ctx, alice.Hex())
+		mustSucceed(t, err)
+		for _, event := range events {
+			want := "Admin"
+			if *event.ActorID == alice {
+				want = "Erased User"
+			}
+			if event.ActorName != want {
+				t.Fatalf("expected actor name %q, got %q", want, event.ActorName)
+			}
+		}
+	})
+
+	t.Run("Counts", func(t *testing.T) {
+		repos := newRepos(t)
+		// 2024-01-01 is a Monday
+		times := []time.Time{
+			time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+			time.Date(2024, 1, 1, 15, 0, 0, 0, time.UTC),
+			time.Date(2024, 1, 3, 8, 0, 0, 0, time.UTC),
+			time.Date(2024, 1, 9, 8, 0, 0, 0, time.UTC),
+			time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+		}
+		for _, createdAt := range times {
+			mustSucceed(t, repos.Audit.Create(ctx, &models.AuditEvent{Action: models.AuditUserLoggedIn, TargetType: models.AuditTargetUser, TargetID: "x", CreatedAt: createdAt}))
+		}
+		mustSucceed(t, repos.Audit.Create(ctx, &models.AuditEvent{Action: models.AuditUserVerified, TargetType: models.AuditTargetUser, TargetID: "x", CreatedAt: times[0]}))
+
+		day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+		query := func(bucket string) *models.StatsQuery {
+			return &models.StatsQuery{From: day(1), To: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), Bucket: bucket}
+		}
+
+		counts, err := repos.Audit.CountByPeriod(ctx, models.AuditUserLoggedIn, query(models.StatsBucketDay))
+		mustSucceed(t, err)
+		expectCounts(t, counts, []models.PeriodCount{{Period: day(1), Count: 2}, {Period: day(3), Count: 1}, {Period: day(9), Count: 1}})
+
+		counts, err = repos.Audit.CountByPeriod(ctx, models.AuditUserLoggedIn, query(models.StatsBucketWeek))
+		mustSucceed(t, err)
+		expectCounts(t, counts, []models.PeriodCount{{Period: day(1), Count: 3}, {Period: day(8), Count: 1}})
+
+		counts, err = repos.Audit.CountByPeriod(ctx, models.AuditUserLoggedIn, query(models.StatsBucketMonth))
+		mustSucceed(t, err)
+		expectCounts(t, counts, []models.PeriodCount{{Period: day(1), Count: 4}})
+
+		since, err := repos.Audit.CountSince(ctx, models.AuditUserLoggedIn, times[2])
+		mustSucceed(t, err)
+		if since != 3 {
+			t.Fatalf("expected 3 logins since %v, got %d", times[2], since)
+		}
+	})
+}
@@ -0,0 +1,92 @@
+package conformance
+
+import (
+	"context"
+	"testing"
+
+	"backend/models"
+	"backend/utils"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func testBulkJobs(t *testing.T, newRepos Factory) {
+	ctx := context.Background()
+
+	t.Run("Lifecycle", func(t *testing.T) {
+		repos := newRepos(t)
+		job := &models.BulkJob{Operation: models.BulkOperationVerify, Total: 2, CreatedByID: primitive.NewObjectID()}
+		mustSucceed(t, repos.BulkJobs.Create(ctx, job))
+
+		if job.ID.IsZero() || job.Status != models.BulkJobStatusPending || job.Results == nil {
+			t.Fatalf("expected Create to assign an ID, pending status and empty results: %+v", job)
+		}
+
+		mustSucceed(t, repos.BulkJobs.MarkRunning(ctx, job.ID.Hex()))
+		mustSucceed(t, repos.BulkJobs.AppendResult(ctx, job.ID.Hex(), models.BulkItemResult{UserID: "a", Success: true}))
+		mustSucceed(t, repos.BulkJobs.AppendResult(ctx, job.ID.Hex(), models.BulkItemResult{UserID: "b", Error: "user not found"}))
+		mustSucceed(t, repos.BulkJobs.Complete(ctx, job.ID.Hex(), models.BulkJobStatusCompleted, ""))
+
+		stored, err := repos.BulkJobs.GetByID(ctx, job.ID.Hex())
+		mustSucceed(t, err)
+		if stored.Status != models.BulkJobStatusCompleted || stored.StartedAt == nil || stored.CompletedAt == nil {
+			t.Fatalf("expected a completed job with start and end times: %+v", stored)
+		}
+		if stored.Processed != 2 || stored.Succeeded != 1 || stored.Failed != 1 || stored.Error != "" {
+			t.Fatalf("unexpected counters: %+v", stored)
+		}
+		if len(stored.Results) != 2 || stored.Results[0].UserID != "a" || stored.Results[1] != (models.BulkItemResult{UserID: "b", Error: "user not found"}) {
+			t.Fatalf("expected results in append order, got %+v", stored.Results)
+		}
+	})
+
+	t.Run("FailureMessage", func(t *testing.T) {
+		repos := newRepos(t)
+		job := &models.BulkJob{Operation: models.BulkOperationSuspend, CreatedByID: primitive.NewObjectID()}
+		mustSucceed(t, repos.BulkJobs.Create(ctx, job))
+		mustSucceed(t, repos.BulkJobs.Complete(ctx, job.ID.Hex(), models.BulkJobStatusFailed, "database unavailable"))
+
+		stored, err := repos.BulkJobs.GetByID(ctx, job.ID.Hex())
+		mustSucceed(t, err)
+		if stored.Status != models.BulkJobStatusFailed || stored.Error != "database unavailable" {
+			t.Fatalf("expected the failure to be recorded: %+v", stored)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		repos := newRepos(t)
+
+		_, err := repos.BulkJobs.GetByID(ctx, "not-an-id")
+		expectError(t, err, utils.ErrInvalidID)
+		_, err = repos.BulkJobs.GetByID(ctx, unknownID)
+		expectError(t, err, utils.ErrBulkJobNotFound)
+		expectError(t, repos.BulkJobs.MarkRunning(ctx, unknownID), utils.ErrBulkJobNotFound)
+		expectError(t, repos.BulkJobs.AppendResult(ctx, "not-an-id", models.BulkItemResult{}), utils.ErrInvalidID)
+		expectError(t, repos.BulkJobs.Complete(ctx, unknownID, models.BulkJobStatusCompleted, ""), utils.ErrBulkJobNotFound)
+	})
+
+	t.Run("GetRecent", func(t *testing.T) {
+		repos := newRepos(t)
+		var ids []primitive.ObjectID
+		for _, operation := range []string{models.BulkOperationVerify, models.BulkOperationSuspend, models.BulkOperationChangeRole} {
+			job := &models.BulkJob{Operation: operation, CreatedByID: primitive.NewObjectID()}
+			mustSucceed(t, repos.BulkJobs.Create(ctx, job))
+			mustSucceed(t, repos.BulkJobs.AppendResult(ctx, job.ID.Hex(), models.BulkItemResult{UserID: "a", Success: true}))
+			ids = append(ids, job.ID)
+		}
+
+		recent, err := repos.BulkJobs.GetRecent(ctx, 2)
+		mustSucceed(t, err)
+		if len(recent) != 2 || recent[0].ID != ids[2] || recent[1].ID != ids[1] {
+			t.Fatalf("expected the 2 newest jobs, newest first")
+		}
+		for _, job := range recent {
+			if len(job.Results) != 0 {
+				t.Fatalf("expected GetRecent to omit results, got %d", len(job.Results))
+			}
+			if job.Processed != 1 {
+				t.Fatalf("expected counters to be returned, got %+v", job)
+			}
+		}
+	})
+}
@@ -0,0 +1,110 @@
+// Package conformance is a behaviour suite shared by every repository
+// implementation. Each backend calls Run from its own tests with a factory
+// that returns repositories over an empty store; the suite checks the error
+// semantics, unique constraints, cascades, ordering and aggregations that
+// services rely on, so backends stay interchangeable.
+package conformance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"backend/models"
+	"backend/repositories"
+)
+
+// Factory returns repositories backed by an empty store. It is called once
+// per subtest and may register cleanup with t.
+type Factory func(t *testing.T) *repositories.Repositories
+
+// Run executes the whole suite against the repositories built by newRepos
+func Run(t *testing.T, newRepos Factory) {
+	t.Run("Users", func(t *testing.T) { testUsers(t, newRepos) })
+	t.Run("Tokens", func(t *testing.T) { testTokens(t, newRepos) })
+	t.Run("Menus", func(t *testing.T) { testMenus(t, newRepos) })
+	t.Run("Permissions", func(t *testing.T) { testPermissions(t, newRepos) })
+	t.Run("BulkJobs", func(t *testing.T) { testBulkJobs(t, newRepos) })
+	t.Run("Audit", func(t *testing.T) { testAudit(t, newRepos) })
+	t.Run("Jobs", func(t *testing.T) { testJobs(t, newRepos) })
+	t.Run("Outbox", func(t *testing.T) { testOutbox(t, newRepos) })
+	t.Run("Notifications", func(t *testing.T) { testNotifications(t, newRepos) })
+	t.Run("Devices", func(t *testing.T) { testDevices(t, newRepos) })
+	t.Run("Webhooks", func(t *testing.T) { testWebhooks(t, newRepos) })
+	t.Run("RateLimits", func(t *testing.T) { testRateLimits(t, newRepos) })
+	t.Run("Transactions", func(t *testing.T) { testTransactions(t, newRepos) })
+}
+
+// timePrecision allows for backends that store timestamps with millisecond
+// or microsecond precision
+const timePrecision = time.Millisecond
+
+// unknownID is a well-formed ObjectID hex that no store contains
+const unknownID = "000000000000000000000000"
+
+func mustSucceed(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func expectError(t *testing.T, got, want error) {
+	t.Helper()
+	if got != want {
+		t.Fatalf("expected error %v, got %v", want, got)
+	}
+}
+
+func expectTime(t *testing.T, field string, got, want time.Time) {
+	t.Helper()
+	if diff := got.Sub(want); diff > timePrecision || diff < -timePrecision {
+		t.Fatalf("%s: expected %v, got %v", field, want, got)
+	}
+}
+
+func expectCounts(t *testing.T, got []models.PeriodCount, want []models.PeriodCount) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("expected %d periods %v, got %d %v", len(want), want, len(got), got)
+	}
+	for i := range want {
+		if !got[i].Period.Equal(want[i].Period) || got[i].Count != want[i].Count {
+			t.Fatalf("period %d: expected %v, got %v", i, want[i], got[i])
+		}
+	}
+}
+
+func newUser(t *testing.T, repos *repositories.Repositories, email, role string) *models.User {
+	t.Helper()
+	user := &models.User{Name: "Test User", Email: email, Password: "hashed", Role: role}
+	mustSucceed(t, repos.Users.Create(context.Background(), user))
+	return user
+}
+
+func newMenu(t *testing.T, repos *repositories.Repositories, name, path string, order int, active bool) *models.Menu {
+	t.Helper()
+	menu := &models.Menu{Name: name, Path: path, Order: order, IsActive: active}
+	mustSucceed(t, repos.Menus.Create(context.Background(), menu))
+	return menu
+}
+
+func menuNames(menus []*models.Menu) []string {
+	names := []string{}
+	for _, menu := range menus {
+		names = append(names, menu.Name)
+	}
+	return names
+}
+
+func expectNames(t *testing.T, got, want []string) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package conformance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"backend/models"
+	"backend/repositories"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func testJobs(t *testing.T, newRepos Factory) {
+	ctx := context.Background()
+
+	t.Run("Leases", func(t *testing.T) {
+		repos := newRepos(t)
+
+		acquired, err := repos.Jobs.AcquireLease(ctx, "scheduler", "a", time.Minute)
+		mustSucceed(t, err)
+		if !acquired {
+			t.Fatalf("expected a free lease to be acquired")
+		}
+
+		acquired, err = repos.Jobs.AcquireLease(ctx, "scheduler", "b", time.Minute)
+		mustSucceed(t, err)
+		if acquired {
+			t.Fatalf("expected a held lease to be refused to another owner")
+		}
+
+		acquired, err = repos.Jobs.AcquireLease(ctx, "scheduler", "a", time.Minute)
+		mustSucceed(t, err)
+		if !acquired {
+			t.Fatalf("expected the holder to extend its lease")
+		}
+
+		// Releasing a lease someone else holds does nothing
+		mustSucceed(t, repos.Jobs.ReleaseLease(ctx, "scheduler", "b"))
+		acquired, err = repos.Jobs.AcquireLease(ctx, "scheduler", "b", time.Minute)
+		mustSucceed(t, err)
+		if acquired {
+			t.Fatalf("expected a release by another owner to be ignored")
+		}
+
+		mustSucceed(t, repos.Jobs.ReleaseLease(ctx, "scheduler", "a"))
+		acquired, err = repos.Jobs.AcquireLease(ctx, "scheduler", "b", time.Minute)
+		mustSucceed(t, err)
+		if !acquired {
+			t.Fatalf("expected a released lease to be acquired")
+		}
+
+		// Leases are independent of each other
+		acquired, err = repos.Jobs.AcquireLease(ctx, "job:cleanup", "a", time.Minute)
+		mustSucceed(t, err)
+		if !acquired {
+			t.Fatalf("expected a different lease to be acquired")
+		}
+	})
+
+	t.Run("ExpiredLease", func(t *testing.T) {
+		repos := newRepos(t)
+
+		acquired, err := repos.Jobs.AcquireLease(ctx, "scheduler", "a", -time.Second)
+		mustSucceed(t, err)
+		if !acquired {
+			t.Fatalf("expected a free lease to be acquired")
+		}
+
+		acquired, err = repos.Jobs.AcquireLease(ctx, "scheduler", "b", time.Minute)
+		mustSucceed(t, err)
+		if !acquired {
+			t.Fatalf("expected an expired lease to be taken over")
+		}
+	})
+
+	t.Run("Runs", func(t *testing.T) {
+		repos := newRepos(t)
+		start := time.Now().Add(-time.Hour).Truncate(time.Millisecond)
+		adminID := primitive.NewObjectID()
+
+		first := newJobRun(t, repos, "cleanup", start, "")
+		second := newJobRun(t, repos, "cleanup", start.Add(time.Minute), "database unavailable")
+		other := newJobRun(t, repos, "report", start.Add(2*time.Minute), "")
+		manual := &models.JobRun{
+			Job:         "cleanup",
+			Trigger:     models.JobTriggerManual,
+			TriggeredBy: &adminID,
+			Instance:    "host-2",
+			StartedAt:   start.Add(3 * time.Minute),
+			FinishedAt:  start.Add(3*time.Minute + time.Second),
+			DurationMs:  1000,
+		}
+		mustSucceed(t, repos.Jobs.CreateRun(ctx, manual))
+		if first.ID.IsZero() || manual.ID.IsZero() {
+			t.Fatalf("expected CreateRun to assign an ID")
+		}
+
+		runs, err := repos.Jobs.GetRuns(ctx, "cleanup", 10)
+		mustSucceed(t, err)
+		if len(runs) != 3 || runs[0].ID != manual.ID || runs[1].ID != second.ID || runs[2].ID != first.ID {
+			t.Fatalf("expected the cleanup runs newest first, got %+v", runs)
+		}
+		if runs[0].Trigger != models.JobTriggerManual || runs[0].TriggeredBy == nil || *runs[0].TriggeredBy != adminID ||
+			runs[0].Instance != "host-2" || runs[0].DurationMs != 1000 {
+			t.Fatalf("unexpected manual run: %+v", runs[0])
+		}
+		expectTime(t, "StartedAt", runs[0].StartedAt, manual.StartedAt)
+		expectTime(t, "FinishedAt", runs[0].FinishedAt, manual.FinishedAt)
+		if runs[1].Error != "database unavailable" || runs[2].TriggeredBy != nil {
+			t.Fatalf("unexpected scheduled runs: %+v %+v", runs[1], runs[2])
+		}
+
+		runs, err = repos.Jobs.GetRuns(ctx, "cleanup", 1)
+		mustSucceed(t, err)
+		if len(runs) != 1 || runs[0].ID != manual.ID {
+			t.Fatalf("expected only the newest run, got %+v", runs)
+		}
+
+		runs, err = repos.Jobs.GetRuns(ctx, "unknown", 10)
+		mustSucceed(t, err)
+		if len(runs) != 0 {
+			t.Fatalf("expected no runs for an unknown job, got %d", len(runs))
+		}
+
+		latest, err := repos.Jobs.GetLatestRuns(ctx)
+		mustSucceed(t, err)
+		if len(latest) != 2 || latest[0].ID != manual.ID || latest[1].ID != other.ID {
+			t.Fatalf("expected the latest run of each job ordered by job, got %+v", latest)
+		}
+	})
+
+	t.Run("DeleteRunsBefore", func(t *testing.T) {
+		repos := newRepos(t)
+		now := time.Now()
+
+		newJobRun(t, repos, "cleanup", now.Add(-48*time.Hour), "")
+		newJobRun(t, repos, "report", now.Add(-25*time.Hour), "")
+		recent := newJobRun(t, repos, "cleanup", now.Add(-time.Hour), "")
+
+		deleted, err := repos.Jobs.DeleteRunsBefore(ctx, now.Add(-24*time.Hour))
+		mustSucceed(t, err)
+		if deleted != 2 {
+			t.Fatalf("expected 2 runs to be deleted, got %d", deleted)
+		}
+
+		latest, err := repos.Jobs.GetLatestRuns(ctx)
+		mustSucceed(t, err)
+		if len(latest) != 1 || latest[0].ID != recent.ID {
+			t.Fatalf("expected only the recent run to remain, got %+v", latest)
+		}
+	})
+}
+
+func newJobRun(t *testing.T, repos *repositories.Repositories, job string, startedAt time.Time, runErr string) *models.JobRun {
+	t.Helper()
+	run := &models.JobRun{
+		Job:        job,
+		Trigger:    models.JobTriggerSchedule,
+		Instance:   "host-1",
+		StartedAt:  startedAt,
+		FinishedAt: startedAt.Add(time.Second),
+		DurationMs: 1000,
+		Error:      runErr,
+	}
+	mustSucceed(t, repos.Jobs.CreateRun(context.Background(), run))
+	return run
+}
@@ -0,0 +1,248 @@
+package conformance
+
+import (
+	"context"
+	"testing"
+
+	"backend/models"
+	"backend/repositories"
+	"backend/utils"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func testMenus(t *testing.T, newRepos Factory) {
+	ctx := context.Background()
+
+	t.Run("CreateAndGet", func(t *testing.T) {
+		repos := newRepos(t)
+		menu := newMenu(t, repos, "Dashboard", "/dashboard", 1, true)
+
+		if menu.ID.IsZero() || menu.CreatedAt.IsZero() {
+			t.Fatalf("expected Create to assign an ID and timestamps: %+v", menu)
+		}
+
+		stored, err := repos.Menus.GetByID(ctx, menu.ID.Hex())
+		mustSucceed(t, err)
+		if stored.Name != "Dashboard" || stored.Path != "/dashboard" || stored.Order != 1 || !stored.IsActive {
+			t.Fatalf("stored menu does not match: %+v", stored)
+		}
+
+		_, err = repos.Menus.GetByID(ctx, "not-an-id")
+		expectError(t, err, utils.ErrInvalidID)
+		_, err = repos.Menus.GetByID(ctx, unknownID)
+		expectError(t, err, utils.ErrMenuNotFound)
+	})
+
+	t.Run("UniqueNameAndPath", func(t *testing.T) {
+		repos := newRepos(t)
+		newMenu(t, repos, "Dashboard", "/dashboard", 1, true)
+		reports := newMenu(t, repos, "Reports", "/reports", 2, true)
+
+		sameName := &models.Menu{Name: "Dashboard", Path: "/other", IsActive: true}
+		expectError(t, repos.Menus.Create(ctx, sameName), utils.ErrMenuAlreadyExists)
+		samePath := &models.Menu{Name: "Other", Path: "/dashboard", IsActive: true}
+		expectError(t, repos.Menus.Create(ctx, samePath), utils.ErrMenuAlreadyExists)
+
+		update := *reports
+		update.Path = "/dashboard"
+		expectError(t, repos.Menus.Update(ctx, reports.ID.Hex(), &update), utils.ErrMenuAlreadyExists)
+
+		// Keeping its own name and path is not a conflict
+		update = *reports
+		update.Description = "Generated reports"
+		mustSucceed(t, repos.Menus.Update(ctx, reports.ID.Hex(), &update))
+
+		stored, err := repos.Menus.GetByID(ctx, reports.ID.Hex())
+		mustSucceed(t, err)
+		if stored.Path != "/reports" || stored.Description != "Generated reports" {
+			t.Fatalf("unexpected menu after updates: %+v", stored)
+		}
+	})
+
+	t.Run("UpdateAndDeleteErrors", func(t *testing.T) {
+		repos := newRepos(t)
+		menu := &models.Menu{Name: "Dashboard", Path: "/dashboard"}
+
+		expectError(t, repos.Menus.Update(ctx, "not-an-id", menu), utils.ErrInvalidID)
+		expectError(t, repos.Menus.Update(ctx, unknownID, menu), utils.ErrMenuNotFound)
+		expectError(t, repos.Menus.Delete(ctx, "not-an-id"), utils.ErrInvalidID)
+		expectError(t, repos.Menus.Delete(ctx, unknownID), utils.ErrMenuNotFound)
+	})
+
+	t.Run("Ordering", func(t *testing.T) {
+		repos := newRepos(t)
+		newMenu(t, repos, "Settings", "/settings", 3, true)
+		newMenu(t, repos, "Hidden", "/hidden", 2, false)
+		newMenu(t, repos, "Dashboard", "/dashboard", 1, true)
+
+		all, err := repos.Menus.GetAll(ctx)
+		mustSucceed(t, err)
+		if len(all) != 3 {
+			t.Fatalf("expected 3 menus, got %d", len(all))
+		}
+
+		ordered, err := repos.Menus.GetMenusOrderedByOrder(ctx)
+		mustSucceed(t, err)
+		expectNames(t, menuNames(ordered), []string{"Dashboard", "Hidden", "Settings"})
+
+		active, err := repos.Menus.GetActiveMenus(ctx)
+		mustSucceed(t, err)
+		expectNames(t, menuNames(active), []string{"Dashboard", "Settings"})
+	})
+
+	t.Run("ByRole", func(t *testing.T) {
+		repos := newRepos(t)
+		settings := newMenu(t, repos, "Settings", "/settings", 3, true)
+		hidden := newMenu(t, repos, "Hidden", "/hidden", 2, false)
+		dashboard := newMenu(t, repos, "Dashboard", "/dashboard", 1, true)
+		newMenu(t, repos, "Reports", "/reports", 4, true)
+		for _, menu := range []*models.Menu{settings, hidden, dashboard} {
+			grant(t, repos, "liaison", menu)
+		}
+
+		admin, err := repos.Menus.GetMenusByRole(ctx, "admin")
+		mustSucceed(t, err)
+		expectNames(t, menuNames(admin), []string{"Dashboard", "Settings", "Reports"})
+
+		liaison, err := repos.Menus.GetMenusByRole(ctx, "liaison")
+		mustSucceed(t, err)
+		expectNames(t, menuNames(liaison), []string{"Dashboard", "Settings"})
+
+		voice, err := repos.Menus.GetMenusByRole(ctx, "voice")
+		mustSucceed(t, err)
+		if len(voice) != 0 {
+			t.Fatalf("expected no menus for a role without grants, got %v", menuNames(voice))
+		}
+
+		ungranted, err := repos.Menus.GetMenusWithoutGrants(ctx)
+		mustSucceed(t, err)
+		expectNames(t, menuNames(ungranted), []string{"Reports"})
+	})
+
+	t.Run("DeleteCascadesToPermissions", func(t *testing.T) {
+		repos := newRepos(t)
+		dashboard := newMenu(t, repos, "Dashboard", "/dashboard", 1, true)
+		reports := newMenu(t, repos, "Reports", "/reports", 2, true)
+		grant(t, repos, "liaison", dashboard)
+		grant(t, repos, "voice", dashboard)
+		grant(t, repos, "liaison", reports)
+
+		mustSucceed(t, repos.Menus.Delete(ctx, dashboard.ID.Hex()))
+
+		_, err := repos.Menus.GetByID(ctx, dashboard.ID.Hex())
+		expectError(t, err, utils.ErrMenuNotFound)
+
+		roles, err := repos.Permissions.GetRolesByMenu(ctx, dashboard.ID.Hex())
+		mustSucceed(t, err)
+		if len(roles) != 0 {
+			t.Fatalf("expected grants for the deleted menu to be removed, got %d", len(roles))
+		}
+
+		all, err := repos.Permissions.GetAllPermissions(ctx)
+		mustSucceed(t, err)
+		if len(all) != 1 || all[0].MenuID != reports.ID {
+			t.Fatalf("expected only the reports grant to remain, got %d grants", len(all))
+		}
+	})
+}
+
+func grant(t *testing.T, repos *repositories.Repositories, role string, menu *models.Menu) *models.RoleMenuPermission {
+	t.Helper()
+	permission := &models.RoleMenuPermission{
+		Role:          role,
+		MenuID:        menu.ID,
+		GrantedByID:   primitive.NewObjectID(),
+		GrantedByName: "Admin",
+	}
+	mustSucceed(t, repos.Permissions.GrantPermission(context.Background(), permission))
+	return permission
+}
+
+func testPermissions(t *testing.T, newRepos Factory) {
+	ctx := context.Background()
+
+	t.Run("GrantAndCheck", func(t *testing.T) {
+		repos := newRepos(t)
+		menu := newMenu(t, repos, "Dashboard", "/dashboard", 1, true)
+		permission := grant(t, repos, "liaison", menu)
+
+		if permission.ID.IsZero() || permission.CreatedAt.IsZero() {
+			t.Fatalf("expected GrantPermission to assign an ID and timestamp: %+v", permission)
+		}
+
+		allowed, err := repos.Permissions.CheckPermission(ctx, "liaison", menu.ID.Hex())
+		mustSucceed(t, err)
+		if !allowed {
+			t.Fatal("expected liaison to have access")
+		}
+		allowed, err = repos.Permissions.CheckPermission(ctx, "voice", menu.ID.Hex())
+		mustSucceed(t, err)
+		if allowed {
+			t.Fatal("expected voice not to have access")
+		}
+		_, err = repos.Permissions.CheckPermission(ctx, "voice", "not-an-id")
+		expectError(t, err, utils.ErrInvalidID)
+	})
+
+	t.Run("UniqueRoleAndMenu", func(t *testing.T) {
+		repos := newRepos(t)
+		menu := newMenu(t, repos, "Dashboard", "/dashboard", 1, true)
+		grant(t, repos, "liaison", menu)
+
+		duplicate := &models.RoleMenuPermission{Role: "liaison", MenuID: menu.ID, GrantedByID: primitive.NewObjectID()}
+		expectError(t, repos.Permissions.GrantPermission(ctx, duplicate), utils.ErrPermissionAlreadyExists)
+
+		// The same menu can still be granted to another role
+		grant(t, repos, "voice", menu)
+	})
+
+	t.Run("Revoke", func(t *testing.T) {
+		repos := newRepos(t)
+		dashboard := newMenu(t, repos, "Dashboard", "/dashboard", 1, true)
+		reports := newMenu(t, repos, "Reports", "/reports", 2, true)
+		grant(t, repos, "liaison", dashboard)
+		grant(t, repos, "liaison", reports)
+		grant(t, repos, "voice", dashboard)
+		grant(t, repos, "finance", reports)
+
+		expectError(t, repos.Permissions.RevokePermission(ctx, "liaison", "not-an-id"), utils.ErrInvalidID)
+		expectError(t, repos.Permissions.RevokePermission(ctx, "finance", dashboard.ID.Hex()), utils.ErrPermissionNotFound)
+		mustSucceed(t, repos.Permissions.RevokePermission(ctx, "liaison", dashboard.ID.Hex()))
+
+		liaison, err := repos.Permissions.GetPermissionsByRole(ctx, "liaison")
+		mustSucceed(t, err)
+		if len(liaison) != 1 || liaison[0].MenuID != reports.ID {
+			t.Fatalf("expected liaison to keep only reports, got %d grants", len(liaison))
+		}
+
+		mustSucceed(t, repos.Permissions.RevokeAllPermissionsForRole(ctx, "liaison"))
+		mustSucceed(t, repos.Permissions.RevokeAllPermissionsForMenu(ctx, dashboard.ID.Hex()))
+		expectError(t, repos.Permissions.RevokeAllPermissionsForMenu(ctx, "not-an-id"), utils.ErrInvalidID)
+
+		all, err := repos.Permissions.GetAllPermissions(ctx)
+		mustSucceed(t, err)
+		if len(all) != 1 || all[0].Role != "finance" {
+			t.Fatalf("expected only the finance grant to remain, got %d grants", len(all))
+		}
+	})
+
+	t.Run("PseudonymizeGrantor", func(t *testing.T) {
+		repos := newRepos(t)
+		menu := newMenu(t, repos, "Dashboard", "/dashboard", 1, true)
+		permission := grant(t, repos, "liaison", menu)
+		grant(t, repos, "voice", menu)
+
+		expectError(t, repos.Permissions.PseudonymizeGrantor(ctx, "not-an-id", "x"), utils.ErrInvalidID)
+		mustSucceed(t, repos.Permissions.PseudonymizeGrantor(ctx, permission.GrantedByID.Hex(), "Former Admin"))
+
+		all, err := repos.Permissions.GetAllPermissions(ctx)
+		mustSucceed(t, err)
+		for _, stored := range all {
+			renamed := stored.GrantedByID == permission.GrantedByID
+			if renamed != (stored.GrantedByName == "Former Admin") {
+				t.Fatalf("unexpected grantor name %q for role %s", stored.GrantedByName, stored.Role)
+			}
+		}
+	})
+}
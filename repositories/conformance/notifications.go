@@ -0,0 +1,241 @@
+package conformance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"backend/models"
+	"backend/repositories"
+	"backend/utils"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func testNotifications(t *testing.T, newRepos Factory) {
+	ctx := context.Background()
+
+	t.Run("Inbox", func(t *testing.T) {
+		repos := newRepos(t)
+		user := primitive.NewObjectID()
+		other := primitive.NewObjectID()
+		first := newNotification(t, repos, user, models.NotificationUserVerified)
+		second := newNotification(t, repos, user, models.NotificationUserRoleChanged)
+		newNotification(t, repos, other, models.NotificationUserVerified)
+
+		notifications, err := repos.Notifications.List(ctx, user.Hex(), false, 10)
+		mustSucceed(t, err)
+		if len(notifications) != 2 || notifications[0].ID != second.ID || notifications[1].ID != first.ID {
+			t.Fatalf("expected the user's notifications newest first, got %+v", notifications)
+		}
+		if notifications[0].Title != "Title" || notifications[0].Data["role"] != "finance" || notifications[0].ReadAt != nil {
+			t.Fatalf("unexpected stored notification: %+v", notifications[0])
+		}
+		expectTime(t, "CreatedAt", notifications[0].CreatedAt, second.CreatedAt)
+
+		limited, err := repos.Notifications.List(ctx, user.Hex(), false, 1)
+		mustSucceed(t, err)
+		if len(limited) != 1 || limited[0].ID != second.ID {
+			t.Fatalf("expected only the newest notification, got %+v", limited)
+		}
+
+		unread, err := repos.Notifications.CountUnread(ctx, user.Hex())
+		mustSucceed(t, err)
+		if unread != 2 {
+			t.Fatalf("expected 2 unread notifications, got %d", unread)
+		}
+
+		mustSucceed(t, repos.Notifications.MarkRead(ctx, user.Hex(), first.ID.Hex()))
+		notifications, err = repos.Notifications.List(ctx, user.Hex(), false, 10)
+		mustSucceed(t, err)
+		readAt := notifications[1].ReadAt
+		if readAt == nil {
+			t.Fatalf("expected the notification to be read: %+v", notifications[1])
+		}
+
+		// Marking it again keeps the original time
+		time.Sleep(5 * time.Millisecond)
+		mustSucceed(t, repos.Notifications.MarkRead(ctx, user.Hex(), first.ID.Hex()))
+		notifications, err = repos.Notifications.List(ctx, user.Hex(), false, 10)
+		mustSucceed(t, err)
+		expectTime(t, "ReadAt", *notifications[1].ReadAt, *readAt)
+
+		unreadOnly, err := repos.Notifications.List(ctx, user.Hex(), true, 10)
+		mustSucceed(t, err)
+		if len(unreadOnly) != 1 || unreadOnly[0].ID != second.ID {
+			t.Fatalf("expected only the unread notification, got %+v", unreadOnly)
+		}
+
+		marked, err := repos.Notifications.MarkAllRead(ctx, user.Hex())
+		mustSucceed(t, err)
+		if marked != 1 {
+			t.Fatalf("expected 1 notification marked read, got %d", marked)
+		}
+		unread, err = repos.Notifications.CountUnread(ctx, user.Hex())
+		mustSucceed(t, err)
+		if unread != 0 {
+			t.Fatalf("expected no unread notifications, got %d", unread)
+		}
+		unread, err = repos.Notifications.CountUnread(ctx, other.Hex())
+		mustSucceed(t, err)
+		if unread != 1 {
+			t.Fatalf("expected other users' notifications to stay unread, got %d", unread)
+		}
+	})
+
+	t.Run("Preferences", func(t *testing.T) {
+		repos := newRepos(t)
+		user := primitive.NewObjectID()
+
+		preferences, err := repos.Notifications.GetPreferences(ctx, user.Hex())
+		mustSucceed(t, err)
+		if len(preferences) != 0 {
+			t.Fatalf("expected no stored preferences, got %+v", preferences)
+		}
+
+		mustSucceed(t, repos.Notifications.SavePreferences(ctx, user.Hex(), []models.NotificationPreference{
+			{Type: models.NotificationUserVerified, NotificationChannels: models.NotificationChannels{Email: true}},
+			{Type: models.NotificationPermissionGranted, NotificationChannels: models.NotificationChannels{InApp: true}},
+		}))
+		mustSucceed(t, repos.Notifications.SavePreferences(ctx, user.Hex(), []models.NotificationPreference{
+			{Type: models.NotificationUserVerified, NotificationChannels: models.NotificationChannels{InApp: true}},
+		}))
+
+		preferences, err = repos.Notifications.GetPreferences(ctx, user.Hex())
+		mustSucceed(t, err)
+		if len(preferences) != 2 ||
+			preferences[0].Type != models.NotificationPermissionGranted || !preferences[0].InApp || preferences[0].Email ||
+			preferences[1].Type != models.NotificationUserVerified || !preferences[1].InApp || preferences[1].Email {
+			t.Fatalf("expected saved preferences to replace stored ones by type, got %+v", preferences)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		repos := newRepos(t)
+		user := primitive.NewObjectID()
+		other := primitive.NewObjectID()
+		newNotification(t, repos, user, models.NotificationUserVerified)
+		newNotification(t, repos, other, models.NotificationUserVerified)
+		mustSucceed(t, repos.Notifications.SavePreferences(ctx, user.Hex(), []models.NotificationPreference{
+			{Type: models.NotificationUserVerified},
+		}))
+
+		deleted, err := repos.Notifications.DeleteBefore(ctx, time.Now().Add(-time.Hour))
+		mustSucceed(t, err)
+		if deleted != 0 {
+			t.Fatalf("expected recent notifications to be kept, got %d deleted", deleted)
+		}
+
+		mustSucceed(t, repos.Notifications.DeleteByUser(ctx, user.Hex()))
+		notifications, err := repos.Notifications.List(ctx, user.Hex(), false, 10)
+		mustSucceed(t, err)
+		preferences, err := repos.Notifications.GetPreferences(ctx, user.Hex())
+		mustSucceed(t, err)
+		if len(notifications) != 0 || len(preferences) != 0 {
+			t.Fatalf("expected the user's notifications and preferences to be deleted, got %+v %+v", notifications, preferences)
+		}
+
+		deleted, err = repos.Notifications.DeleteBefore(ctx, time.Now().Add(time.Hour))
+		mustSucceed(t, err)
+		if deleted != 1 {
+			t.Fatalf("expected the other user's notification to be deleted, got %d", deleted)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		repos := newRepos(t)
+		user := primitive.NewObjectID()
+		notification := newNotification(t, repos, user, models.NotificationUserVerified)
+
+		_, err := repos.Notifications.List(ctx, "not-an-id", false, 10)
+		expectError(t, err, utils.ErrInvalidID)
+		expectError(t, repos.Notifications.MarkRead(ctx, user.Hex(), "not-an-id"), utils.ErrInvalidID)
+		expectError(t, repos.Notifications.MarkRead(ctx, user.Hex(), unknownID), utils.ErrNotificationNotFound)
+		// Another user's notification cannot be marked read
+		expectError(t, repos.Notifications.MarkRead(ctx, unknownID, notification.ID.Hex()), utils.ErrNotificationNotFound)
+	})
+}
+
+func testDevices(t *testing.T, newRepos Factory) {
+	ctx := context.Background()
+
+	t.Run("Record", func(t *testing.T) {
+		repos := newRepos(t)
+		user := primitive.NewObjectID()
+		seen := time.Now().Add(-time.Hour)
+
+		isNew, err := repos.Devices.Record(ctx, &models.Device{
+			UserID: user, Fingerprint: "laptop", UserAgent: "Firefox", IP: "203.0.113.1", LastSeenAt: seen,
+		})
+		mustSucceed(t, err)
+		if !isNew {
+			t.Fatalf("expected the first login from a device to be new")
+		}
+
+		isNew, err = repos.Devices.Record(ctx, &models.Device{
+			UserID: user, Fingerprint: "laptop", UserAgent: "Firefox", IP: "203.0.113.2", LastSeenAt: seen.Add(time.Minute),
+		})
+		mustSucceed(t, err)
+		if isNew {
+			t.Fatalf("expected a known device not to be new")
+		}
+
+		isNew, err = repos.Devices.Record(ctx, &models.Device{
+			UserID: user, Fingerprint: "phone", UserAgent: "Safari", IP: "198.51.100.1", LastSeenAt: seen.Add(2 * time.Minute),
+		})
+		mustSucceed(t, err)
+		if !isNew {
+			t.Fatalf("expected a second device to be new")
+		}
+
+		// The same device is new for another user
+		isNew, err = repos.Devices.Record(ctx, &models.Device{
+			UserID: primitive.NewObjectID(), Fingerprint: "laptop", LastSeenAt: seen,
+		})
+		mustSucceed(t, err)
+		if !isNew {
+			t.Fatalf("expected devices to be tracked per user")
+		}
+
+		devices, err := repos.Devices.ListByUser(ctx, user.Hex())
+		mustSucceed(t, err)
+		if len(devices) != 2 || devices[0].Fingerprint != "phone" || devices[1].Fingerprint != "laptop" {
+			t.Fatalf("expected the user's devices most recently seen first, got %+v", devices)
+		}
+		if devices[1].IP != "203.0.113.2" {
+			t.Fatalf("expected a known device to get its latest address, got %+v", devices[1])
+		}
+		expectTime(t, "FirstSeenAt", devices[1].FirstSeenAt, seen)
+		expectTime(t, "LastSeenAt", devices[1].LastSeenAt, seen.Add(time.Minute))
+
+		mustSucceed(t, repos.Devices.DeleteByUser(ctx, user.Hex()))
+		devices, err = repos.Devices.ListByUser(ctx, user.Hex())
+		mustSucceed(t, err)
+		if len(devices) != 0 {
+			t.Fatalf("expected the user's devices to be deleted, got %+v", devices)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		repos := newRepos(t)
+
+		_, err := repos.Devices.ListByUser(ctx, "not-an-id")
+		expectError(t, err, utils.ErrInvalidID)
+		expectError(t, repos.Devices.DeleteByUser(ctx, "not-an-id"), utils.ErrInvalidID)
+	})
+}
+
+func newNotification(t *testing.T, repos *repositories.Repositories, userID primitive.ObjectID, eventType string) *models.Notification {
+	t.Helper()
+	notification := &models.Notification{
+		UserID: userID,
+		Type:   eventType,
+		Title:  "Title",
+		Body:   "Body",
+		Data:   map[string]string{"role": "finance"},
+	}
+	mustSucceed(t, repos.Notifications.Create(context.Background(), notification))
+	// Keep creation times distinct so newest-first ordering is deterministic
+	time.Sleep(2 * time.Millisecond)
+	return notification
+}
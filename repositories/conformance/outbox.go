@@ -0,0 +1,260 @@
+package conformance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"backend/models"
+	"backend/repositories"
+	"backend/utils"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func testOutbox(t *testing.T, newRepos Factory) {
+	ctx := context.Background()
+
+	t.Run("Lifecycle", func(t *testing.T) {
+		repos := newRepos(t)
+		message := newOutboxMessage(t, repos, "john@example.com")
+
+		if message.ID.IsZero() || message.Status != models.OutboxStatusPending || message.NextAttemptAt.IsZero() {
+			t.Fatalf("expected Create to assign an ID, pending status and a due time: %+v", message)
+		}
+
+		stored, err := repos.Outbox.GetByID(ctx, message.ID.Hex())
+		mustSucceed(t, err)
+		if stored.Recipient != "john@example.com" || stored.RecipientName != "John Doe" || stored.Subject != "Welcome" ||
+			stored.TextBody != "text" || stored.HTMLBody != "<p>html</p>" || stored.Attempts != 0 {
+			t.Fatalf("unexpected stored message: %+v", stored)
+		}
+		expectTime(t, "CreatedAt", stored.CreatedAt, message.CreatedAt)
+
+		claim := claimOutboxMessage(t, repos)
+		mustSucceed(t, repos.Outbox.MarkSent(ctx, claim))
+		stored, err = repos.Outbox.GetByID(ctx, message.ID.Hex())
+		mustSucceed(t, err)
+		if stored.Status != models.OutboxStatusSent || stored.SentAt == nil || stored.TextBody != "" || stored.HTMLBody != "" {
+			t.Fatalf("expected a sent message without bodies: %+v", stored)
+		}
+	})
+
+	t.Run("Claim", func(t *testing.T) {
+		repos := newRepos(t)
+		first := newOutboxMessage(t, repos, "a@example.com")
+		second := newOutboxMessage(t, repos, "b@example.com")
+		newOutboxMessage(t, repos, "c@example.com")
+		now := time.Now().Add(time.Second)
+
+		claimed, err := repos.Outbox.ClaimDue(ctx, now, time.Minute, 2)
+		mustSucceed(t, err)
+		if len(claimed) != 2 || claimed[0].ID != first.ID || claimed[1].ID != second.ID {
+			t.Fatalf("expected the 2 oldest messages to be claimed, got %+v", claimed)
+		}
+		for _, message := range claimed {
+			if message.Attempts != 1 || message.LastAttemptAt == nil || message.TextBody != "text" {
+				t.Fatalf("expected a claimed message with its bodies and one attempt: %+v", message)
+			}
+			expectTime(t, "NextAttemptAt", message.NextAttemptAt, now.Add(time.Minute))
+		}
+		firstClaim, secondClaim := claimed[0], claimed[1]
+
+		claimed, err = repos.Outbox.ClaimDue(ctx, now, time.Minute, 10)
+		mustSucceed(t, err)
+		if len(claimed) != 1 {
+			t.Fatalf("expected claimed messages to be skipped until their lease passes, got %d", len(claimed))
+		}
+
+		// A failed attempt is retried at the given time, and the last one
+		// dead-letters the message
+		retryAt := now.Add(time.Hour)
+		mustSucceed(t, repos.Outbox.MarkFailed(ctx, firstClaim, "status 503", &retryAt))
+		mustSucceed(t, repos.Outbox.MarkFailed(ctx, secondClaim, "rejected", nil))
+		claimed, err = repos.Outbox.ClaimDue(ctx, retryAt.Add(-time.Second), time.Minute, 10)
+		mustSucceed(t, err)
+		for _, message := range claimed {
+			if message.ID == first.ID {
+				t.Fatalf("expected the failed message to wait for its retry time")
+			}
+		}
+		claimed, err = repos.Outbox.ClaimDue(ctx, retryAt, time.Minute, 10)
+		mustSucceed(t, err)
+		if len(claimed) != 1 || claimed[0].ID != first.ID || claimed[0].Attempts != 2 || claimed[0].LastError != "status 503" {
+			t.Fatalf("expected the failed message to be claimed again: %+v", claimed)
+		}
+
+		// Dead messages are never claimed until requeued
+		stored, err := repos.Outbox.GetByID(ctx, second.ID.Hex())
+		mustSucceed(t, err)
+		if stored.Status != models.OutboxStatusDead || stored.LastError != "rejected" {
+			t.Fatalf("expected a dead message: %+v", stored)
+		}
+		claimed, err = repos.Outbox.ClaimDue(ctx, now.Add(24*time.Hour), time.Minute, 10)
+		mustSucceed(t, err)
+		for _, message := range claimed {
+			if message.ID == second.ID {
+				t.Fatalf("expected a dead message not to be claimed")
+			}
+		}
+
+		mustSucceed(t, repos.Outbox.Requeue(ctx, second.ID.Hex()))
+		stored, err = repos.Outbox.GetByID(ctx, second.ID.Hex())
+		mustSucceed(t, err)
+		if stored.Status != models.OutboxStatusPending || stored.Attempts != 0 || stored.LastError != "" {
+			t.Fatalf("expected a requeued message to start over: %+v", stored)
+		}
+		claimed, err = repos.Outbox.ClaimDue(ctx, time.Now().Add(time.Second), time.Minute, 10)
+		mustSucceed(t, err)
+		if len(claimed) != 1 || claimed[0].ID != second.ID {
+			t.Fatalf("expected the requeued message to be due, got %+v", claimed)
+		}
+	})
+
+	t.Run("StaleClaim", func(t *testing.T) {
+		repos := newRepos(t)
+		message := newOutboxMessage(t, repos, "a@example.com")
+		now := time.Now().Add(time.Second)
+
+		claimed, err := repos.Outbox.ClaimDue(ctx, now, time.Minute, 1)
+		mustSucceed(t, err)
+		stale := claimed[0]
+
+		// The lease passed while the first worker was still sending
+		claimed, err = repos.Outbox.ClaimDue(ctx, now.Add(2*time.Minute), time.Minute, 1)
+		mustSucceed(t, err)
+		if len(claimed) != 1 || claimed[0].Attempts != 2 {
+			t.Fatalf("expected the message to be claimed again, got %+v", claimed)
+		}
+		current := claimed[0]
+
+		retryAt := now.Add(time.Hour)
+		mustSucceed(t, repos.Outbox.MarkFailed(ctx, current, "status 503", &retryAt))
+		expectError(t, repos.Outbox.MarkSent(ctx, stale), utils.ErrOutboxClaimLost)
+		expectError(t, repos.Outbox.MarkFailed(ctx, stale, "timeout", nil), utils.ErrOutboxClaimLost)
+
+		stored, err := repos.Outbox.GetByID(ctx, message.ID.Hex())
+		mustSucceed(t, err)
+		if stored.Status != models.OutboxStatusPending || stored.LastError != "status 503" || stored.TextBody != "text" {
+			t.Fatalf("expected the newer claim's outcome to be kept: %+v", stored)
+		}
+		expectTime(t, "NextAttemptAt", stored.NextAttemptAt, retryAt)
+
+		// A requeued message starts over, so an earlier claim with the same
+		// attempt count does not hold either
+		mustSucceed(t, repos.Outbox.Requeue(ctx, message.ID.Hex()))
+		claimed, err = repos.Outbox.ClaimDue(ctx, time.Now().Add(time.Second), time.Minute, 1)
+		mustSucceed(t, err)
+		if len(claimed) != 1 || claimed[0].Attempts != stale.Attempts {
+			t.Fatalf("expected the requeued message to be claimed with one attempt, got %+v", claimed)
+		}
+		expectError(t, repos.Outbox.MarkSent(ctx, stale), utils.ErrOutboxClaimLost)
+		mustSucceed(t, repos.Outbox.MarkSent(ctx, claimed[0]))
+	})
+
+	t.Run("ListAndSummary", func(t *testing.T) {
+		repos := newRepos(t)
+		sent := newOutboxMessage(t, repos, "a@example.com")
+		dead := newOutboxMessage(t, repos, "b@example.com")
+		mustSucceed(t, repos.Outbox.MarkSent(ctx, claimOutboxMessage(t, repos)))
+		mustSucceed(t, repos.Outbox.MarkFailed(ctx, claimOutboxMessage(t, repos), "rejected", nil))
+		pending := newOutboxMessage(t, repos, "c@example.com")
+
+		all, err := repos.Outbox.List(ctx, "", 10)
+		mustSucceed(t, err)
+		if len(all) != 3 || all[0].ID != pending.ID || all[2].ID != sent.ID {
+			t.Fatalf("expected every message newest first, got %+v", all)
+		}
+		for _, message := range all {
+			if message.TextBody != "" || message.HTMLBody != "" {
+				t.Fatalf("expected List to omit bodies: %+v", message)
+			}
+		}
+
+		limited, err := repos.Outbox.List(ctx, "", 1)
+		mustSucceed(t, err)
+		if len(limited) != 1 || limited[0].ID != pending.ID {
+			t.Fatalf("expected only the newest message, got %+v", limited)
+		}
+
+		deadOnly, err := repos.Outbox.List(ctx, models.OutboxStatusDead, 10)
+		mustSucceed(t, err)
+		if len(deadOnly) != 1 || deadOnly[0].ID != dead.ID || deadOnly[0].LastError != "rejected" {
+			t.Fatalf("expected only the dead message, got %+v", deadOnly)
+		}
+
+		summary, err := repos.Outbox.Summary(ctx)
+		mustSucceed(t, err)
+		if summary.Pending != 1 || summary.Sent != 1 || summary.Dead != 1 || summary.OldestPendingAt == nil {
+			t.Fatalf("unexpected summary: %+v", summary)
+		}
+		expectTime(t, "OldestPendingAt", *summary.OldestPendingAt, pending.CreatedAt)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		repos := newRepos(t)
+		newOutboxMessage(t, repos, "a@example.com")
+		newOutboxMessage(t, repos, "b@example.com")
+		mustSucceed(t, repos.Outbox.MarkSent(ctx, claimOutboxMessage(t, repos)))
+		mustSucceed(t, repos.Outbox.MarkFailed(ctx, claimOutboxMessage(t, repos), "rejected", nil))
+		pending := newOutboxMessage(t, repos, "a@example.com")
+		other := newOutboxMessage(t, repos, "b@example.com")
+
+		deleted, err := repos.Outbox.DeleteFinishedBefore(ctx, time.Now().Add(-time.Hour))
+		mustSucceed(t, err)
+		if deleted != 0 {
+			t.Fatalf("expected recently finished messages to be kept, got %d deleted", deleted)
+		}
+		deleted, err = repos.Outbox.DeleteFinishedBefore(ctx, time.Now().Add(time.Hour))
+		mustSucceed(t, err)
+		if deleted != 2 {
+			t.Fatalf("expected the sent and the dead message to be deleted, got %d", deleted)
+		}
+
+		mustSucceed(t, repos.Outbox.DeleteByRecipient(ctx, "a@example.com"))
+		_, err = repos.Outbox.GetByID(ctx, pending.ID.Hex())
+		expectError(t, err, utils.ErrOutboxMessageNotFound)
+		_, err = repos.Outbox.GetByID(ctx, other.ID.Hex())
+		mustSucceed(t, err)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		repos := newRepos(t)
+
+		_, err := repos.Outbox.GetByID(ctx, "not-an-id")
+		expectError(t, err, utils.ErrInvalidID)
+		_, err = repos.Outbox.GetByID(ctx, unknownID)
+		expectError(t, err, utils.ErrOutboxMessageNotFound)
+		unknown := &models.OutboxMessage{ID: primitive.NewObjectID()}
+		expectError(t, repos.Outbox.MarkSent(ctx, unknown), utils.ErrOutboxMessageNotFound)
+		expectError(t, repos.Outbox.MarkFailed(ctx, unknown, "", nil), utils.ErrOutboxMessageNotFound)
+		expectError(t, repos.Outbox.Requeue(ctx, unknownID), utils.ErrOutboxMessageNotFound)
+	})
+}
+
+// claimOutboxMessage claims the oldest due message
+func claimOutboxMessage(t *testing.T, repos *repositories.Repositories) *models.OutboxMessage {
+	t.Helper()
+	claimed, err := repos.Outbox.ClaimDue(context.Background(), time.Now().Add(time.Second), time.Minute, 1)
+	mustSucceed(t, err)
+	if len(claimed) != 1 {
+		t.Fatal("expected a message to claim")
+	}
+	return claimed[0]
+}
+
+func newOutboxMessage(t *testing.T, repos *repositories.Repositories, recipient string) *models.OutboxMessage {
+	t.Helper()
+	message := &models.OutboxMessage{
+		Kind:          models.EmailKindInvitation,
+		Recipient:     recipient,
+		RecipientName: "John Doe",
+		Subject:       "Welcome",
+		TextBody:      "text",
+		HTMLBody:      "<p>html</p>",
+	}
+	mustSucceed(t, repos.Outbox.Create(context.Background(), message))
+	// Keep creation times distinct so newest-first ordering is deterministic
+	time.Sleep(2 * time.Millisecond)
+	return message
+}
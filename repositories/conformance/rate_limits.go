@@ -0,0 +1,166 @@
+package conformance
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func testRateLimits(t *testing.T, newRepos Factory) {
+	ctx := context.Background()
+
+	t.Run("Counters", func(t *testing.T) {
+		repos := newRepos(t)
+		expiresAt := time.Now().Add(time.Minute)
+
+		count, err := repos.RateLimits.Count(ctx, "login:a")
+		mustSucceed(t, err)
+		if count != 0 {
+			t.Fatalf("expected a missing counter to count 0, got %d", count)
+		}
+
+		for want := int64(1); want <= 3; want++ {
+			count, err := repos.RateLimits.Increment(ctx, "login:a", expiresAt)
+			mustSucceed(t, err)
+			if count != want {
+				t.Fatalf("expected count %d, got %d", want, count)
+			}
+		}
+
+		count, err = repos.RateLimits.Count(ctx, "login:a")
+		mustSucceed(t, err)
+		if count != 3 {
+			t.Fatalf("expected count 3, got %d", count)
+		}
+
+		// A decrement undoes an increment and never goes below zero
+		mustSucceed(t, repos.RateLimits.Decrement(ctx, "login:a"))
+		count, err = repos.RateLimits.Count(ctx, "login:a")
+		mustSucceed(t, err)
+		if count != 2 {
+			t.Fatalf("expected count 2 after a decrement, got %d", count)
+		}
+		mustSucceed(t, repos.RateLimits.Decrement(ctx, "login:missing"))
+		count, err = repos.RateLimits.Count(ctx, "login:missing")
+		mustSucceed(t, err)
+		if count != 0 {
+			t.Fatalf("expected a missing counter to stay at 0, got %d", count)
+		}
+
+		// Counters are independent of each other
+		count, err = repos.RateLimits.Increment(ctx, "login:b", expiresAt)
+		mustSucceed(t, err)
+		if count != 1 {
+			t.Fatalf("expected a new counter to start at 1, got %d", count)
+		}
+
+		// Expired counters count 0 until they are deleted
+		_, err = repos.RateLimits.Increment(ctx, "login:old", time.Now().Add(-time.Second))
+		mustSucceed(t, err)
+		count, err = repos.RateLimits.Count(ctx, "login:old")
+		mustSucceed(t, err)
+		if count != 0 {
+			t.Fatalf("expected an expired counter to count 0, got %d", count)
+		}
+	})
+
+	t.Run("ConcurrentIncrements", func(t *testing.T) {
+		repos := newRepos(t)
+		expiresAt := time.Now().Add(time.Minute)
+
+		var wg sync.WaitGroup
+		errs := make(chan error, 10)
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := repos.RateLimits.Increment(ctx, "register:a", expiresAt)
+				errs <- err
+			}()
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			mustSucceed(t, err)
+		}
+
+		count, err := repos.RateLimits.Count(ctx, "register:a")
+		mustSucceed(t, err)
+		if count != 10 {
+			t.Fatalf("expected no increment to be lost, got %d", count)
+		}
+	})
+
+	t.Run("TokenBuckets", func(t *testing.T) {
+		repos := newRepos(t)
+		now := time.Now().Truncate(time.Millisecond)
+
+		// A new bucket starts full: three tokens, refilled at one a second
+		for want := 2.0; want >= 0; want-- {
+			allowed, tokens, err := repos.RateLimits.TakeToken(ctx, "admin:a", 3, 1, now)
+			mustSucceed(t, err)
+			if !allowed || tokens != want {
+				t.Fatalf("expected a token with %v left, got %v with %v left", want, allowed, tokens)
+			}
+		}
+
+		allowed, tokens, err := repos.RateLimits.TakeToken(ctx, "admin:a", 3, 1, now)
+		mustSucceed(t, err)
+		if allowed || tokens != 0 {
+			t.Fatalf("expected an empty bucket to refuse, got %v with %v left", allowed, tokens)
+		}
+
+		// Half a second refills half a token, which is not enough
+		allowed, tokens, err = repos.RateLimits.TakeToken(ctx, "admin:a", 3, 1, now.Add(500*time.Millisecond))
+		mustSucceed(t, err)
+		if allowed || tokens != 0.5 {
+			t.Fatalf("expected a partial token to refuse, got %v with %v left", allowed, tokens)
+		}
+
+		allowed, tokens, err = repos.RateLimits.TakeToken(ctx, "admin:a", 3, 1, now.Add(1500*time.Millisecond))
+		mustSucceed(t, err)
+		if !allowed || tokens != 0.5 {
+			t.Fatalf("expected a refilled token, got %v with %v left", allowed, tokens)
+		}
+
+		// Refills stop at capacity
+		allowed, tokens, err = repos.RateLimits.TakeToken(ctx, "admin:a", 3, 1, now.Add(time.Hour))
+		mustSucceed(t, err)
+		if !allowed || tokens != 2 {
+			t.Fatalf("expected a full bucket, got %v with %v left", allowed, tokens)
+		}
+
+		// A clock behind the last use refills nothing
+		allowed, tokens, err = repos.RateLimits.TakeToken(ctx, "admin:a", 3, 1, now)
+		mustSucceed(t, err)
+		if !allowed || tokens != 1 {
+			t.Fatalf("expected no refill from an earlier time, got %v with %v left", allowed, tokens)
+		}
+	})
+
+	t.Run("DeleteExpired", func(t *testing.T) {
+		repos := newRepos(t)
+		now := time.Now()
+
+		_, err := repos.RateLimits.Increment(ctx, "old", now.Add(-time.Minute))
+		mustSucceed(t, err)
+		_, err = repos.RateLimits.Increment(ctx, "current", now.Add(time.Minute))
+		mustSucceed(t, err)
+		// Expires a second after the earlier use, when its one token is back
+		_, _, err = repos.RateLimits.TakeToken(ctx, "bucket", 1, 1, now.Add(-time.Minute))
+		mustSucceed(t, err)
+
+		deleted, err := repos.RateLimits.DeleteExpired(ctx, now)
+		mustSucceed(t, err)
+		if deleted != 2 {
+			t.Fatalf("expected 2 expired entries deleted, got %d", deleted)
+		}
+
+		count, err := repos.RateLimits.Increment(ctx, "current", now.Add(time.Minute))
+		mustSucceed(t, err)
+		if count != 2 {
+			t.Fatalf("expected the current counter to be kept, got %d", count)
+		}
+	})
+}
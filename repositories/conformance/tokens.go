@@ -0,0 +1,107 @@
+package conformance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"backend/models"
+	"backend/repositories/interfaces"
+	"backend/utils"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func testTokens(t *testing.T, newRepos Factory) {
+	ctx := context.Background()
+
+	// Tokens are created already revoked to check that Create resets the flag
+	create := func(t *testing.T, tokens interfaces.TokenRepository, userID primitive.ObjectID, value string, expiresIn time.Duration) *models.RefreshToken {
+		t.Helper()
+		token := &models.RefreshToken{UserID: userID, Token: value, ExpiresAt: time.Now().Add(expiresIn), IsRevoked: true}
+		mustSucceed(t, tokens.Create(ctx, token))
+		return token
+	}
+
+	t.Run("CreateAndGet", func(t *testing.T) {
+		repos := newRepos(t)
+		userID := primitive.NewObjectID()
+		token := create(t, repos.Tokens, userID, "token-a", time.Hour)
+
+		if token.ID.IsZero() || token.IsRevoked {
+			t.Fatalf("expected Create to assign an ID and reset IsRevoked: %+v", token)
+		}
+
+		stored, err := repos.Tokens.GetByToken(ctx, "token-a")
+		mustSucceed(t, err)
+		if stored.ID != token.ID || stored.UserID != userID || stored.IsRevoked {
+			t.Fatalf("stored token does not match: %+v", stored)
+		}
+		expectTime(t, "ExpiresAt", stored.ExpiresAt, token.ExpiresAt)
+
+		_, err = repos.Tokens.GetByToken(ctx, "missing")
+		expectError(t, err, utils.ErrTokenNotFound)
+	})
+
+	t.Run("Revoke", func(t *testing.T) {
+		repos := newRepos(t)
+		userID := primitive.NewObjectID()
+		create(t, repos.Tokens, userID, "token-a", time.Hour)
+		create(t, repos.Tokens, userID, "token-b", time.Hour)
+		create(t, repos.Tokens, primitive.NewObjectID(), "token-c", time.Hour)
+
+		mustSucceed(t, repos.Tokens.RevokeToken(ctx, "token-a"))
+		expectError(t, repos.Tokens.RevokeToken(ctx, "missing"), utils.ErrTokenNotFound)
+		// Only the first of two rotations of the same token may revoke it
+		expectError(t, repos.Tokens.RevokeToken(ctx, "token-a"), utils.ErrTokenRevoked)
+
+		stored, err := repos.Tokens.GetByToken(ctx, "token-a")
+		mustSucceed(t, err)
+		if !stored.IsRevoked {
+			t.Fatal("expected token-a to be revoked")
+		}
+
+		active, err := repos.Tokens.GetByUserID(ctx, userID.Hex())
+		mustSucceed(t, err)
+		if len(active) != 1 || active[0].Token != "token-b" {
+			t.Fatalf("expected only token-b to be active, got %v", active)
+		}
+
+		mustSucceed(t, repos.Tokens.RevokeAllUserTokens(ctx, userID.Hex()))
+		active, err = repos.Tokens.GetByUserID(ctx, userID.Hex())
+		mustSucceed(t, err)
+		if len(active) != 0 {
+			t.Fatalf("expected no active tokens, got %d", len(active))
+		}
+
+		count, err := repos.Tokens.CountActive(ctx)
+		mustSucceed(t, err)
+		if count != 1 {
+			t.Fatalf("expected 1 active token, got %d", count)
+		}
+	})
+
+	t.Run("DeleteExpired", func(t *testing.T) {
+		repos := newRepos(t)
+		userID := primitive.NewObjectID()
+		create(t, repos.Tokens, userID, "expired", -time.Hour)
+		create(t, repos.Tokens, userID, "revoked", time.Hour)
+		create(t, repos.Tokens, userID, "active", time.Hour)
+		mustSucceed(t, repos.Tokens.RevokeToken(ctx, "revoked"))
+
+		active, err := repos.Tokens.GetByUserID(ctx, userID.Hex())
+		mustSucceed(t, err)
+		if len(active) != 1 {
+			t.Fatalf("expected expired and revoked tokens to be excluded, got %d", len(active))
+		}
+
+		mustSucceed(t, repos.Tokens.DeleteExpiredTokens(ctx))
+
+		for _, value := range []string{"expired", "revoked"} {
+			_, err := repos.Tokens.GetByToken(ctx, value)
+			expectError(t, err, utils.ErrTokenNotFound)
+		}
+		_, err = repos.Tokens.GetByToken(ctx, "active")
+		mustSucceed(t, err)
+	})
+}
@@ -0,0 +1,94 @@
+package conformance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"backend/models"
+	"backend/utils"
+)
+
+// rollbackReporter is implemented by transactors that cannot undo a failed
+// unit of work, such as the in-memory one
+type rollbackReporter interface {
+	RollsBack() bool
+}
+
+// testTransactions checks the Transactor contract services rely on. MongoDB
+// only runs transactions on a replica set, so the suite needs one there.
+func testTransactions(t *testing.T, newRepos Factory) {
+	ctx := context.Background()
+
+	t.Run("Commit", func(t *testing.T) {
+		repos := newRepos(t)
+
+		err := repos.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			user := &models.User{Name: "Test User", Email: "tx@example.com", Password: "hashed", Role: "voice"}
+			if err := repos.Users.Create(ctx, user); err != nil {
+				return err
+			}
+			// Reads inside the unit of work see its own writes
+			_, err := repos.Users.GetByID(ctx, user.ID.Hex())
+			return err
+		})
+		mustSucceed(t, err)
+
+		_, err = repos.Users.GetByEmail(ctx, "tx@example.com")
+		mustSucceed(t, err)
+	})
+
+	t.Run("ErrorReturnedUnchanged", func(t *testing.T) {
+		repos := newRepos(t)
+
+		err := repos.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			return utils.ErrMenuNotFound
+		})
+		expectError(t, err, utils.ErrMenuNotFound)
+	})
+
+	t.Run("Rollback", func(t *testing.T) {
+		repos := newRepos(t)
+		if reporter, ok := repos.Transactor.(rollbackReporter); ok && !reporter.RollsBack() {
+			t.Skip("the backend does not roll back failed units of work")
+		}
+		menu := newMenu(t, repos, "Dashboard", "/dashboard", 1, true)
+
+		err := repos.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			user := &models.User{Name: "Test User", Email: "tx@example.com", Password: "hashed", Role: "voice"}
+			if err := repos.Users.Create(ctx, user); err != nil {
+				return err
+			}
+			if err := repos.Tokens.Create(ctx, &models.RefreshToken{UserID: user.ID, Token: "tx-token", ExpiresAt: time.Now().Add(time.Hour)}); err != nil {
+				return err
+			}
+			if err := repos.Menus.Delete(ctx, menu.ID.Hex()); err != nil {
+				return err
+			}
+			return utils.ErrMenuNotFound
+		})
+		expectError(t, err, utils.ErrMenuNotFound)
+
+		_, err = repos.Users.GetByEmail(ctx, "tx@example.com")
+		expectError(t, err, utils.ErrUserNotFound)
+		_, err = repos.Tokens.GetByToken(ctx, "tx-token")
+		expectError(t, err, utils.ErrTokenNotFound)
+		_, err = repos.Menus.GetByID(ctx, menu.ID.Hex())
+		mustSucceed(t, err)
+	})
+
+	t.Run("NestedJoinsOuter", func(t *testing.T) {
+		repos := newRepos(t)
+		menu := newMenu(t, repos, "Dashboard", "/dashboard", 1, true)
+
+		err := repos.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			return repos.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+				return repos.Menus.Delete(ctx, menu.ID.Hex())
+			})
+		})
+		mustSucceed(t, err)
+
+		_, err = repos.Menus.GetByID(ctx, menu.ID.Hex())
+		expectError(t, err, utils.ErrMenuNotFound)
+	})
+}
@@ -0,0 +1,339 @@
+package conformance
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"backend/models"
+	"backend/utils"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func testUsers(t *testing.T, newRepos Factory) {
+	ctx := context.Background()
+
+	t.Run("CreateAssignsIDAndTimestamps", func(t *testing.T) {
+		repos := newRepos(t)
+		before := time.Now()
+		user := newUser(t, repos, "alice@example.com", "liaison")
+
+		if user.ID.IsZero() {
+			t.Fatal("expected Create to assign an ID")
+		}
+		if user.CreatedAt.Before(before.Add(-timePrecision)) {
+			t.Fatalf("expected CreatedAt to be set, got %v", user.CreatedAt)
+		}
+
+		stored, err := repos.Users.GetByID(ctx, user.ID.Hex())
+		mustSucceed(t, err)
+		if stored.Email != user.Email || stored.Role != user.Role || stored.IsVerified {
+			t.Fatalf("stored user does not match: %+v", stored)
+		}
+		expectTime(t, "CreatedAt", stored.CreatedAt, user.CreatedAt)
+
+		byEmail, err := repos.Users.GetByEmail(ctx, user.Email)
+		mustSucceed(t, err)
+		if byEmail.ID != user.ID {
+			t.Fatalf("GetByEmail returned %s, want %s", byEmail.ID.Hex(), user.ID.Hex())
+		}
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		repos := newRepos(t)
+
+		_, err := repos.Users.GetByID(ctx, unknownID)
+		expectError(t, err, utils.ErrUserNotFound)
+		_, err = repos.Users.GetByID(ctx, "not-an-id")
+		expectError(t, err, utils.ErrUserNotFound)
+		_, err = repos.Users.GetByEmail(ctx, "nobody@example.com")
+		expectError(t, err, utils.ErrUserNotFound)
+		expectError(t, repos.Users.Delete(ctx, unknownID), utils.ErrUserNotFound)
+		expectError(t, repos.Users.ReinstateUser(ctx, unknownID), utils.ErrUserNotFound)
+		expectError(t, repos.Users.UpdateLastLogin(ctx, unknownID), utils.ErrUserNotFound)
+		expectError(t, repos.Users.SuspendUser(ctx, "not-an-id", unknownID, ""), utils.ErrUserNotFound)
+	})
+
+	t.Run("UniqueEmail", func(t *testing.T) {
+		repos := newRepos(t)
+		newUser(t, repos, "alice@example.com", "liaison")
+		bob := newUser(t, repos, "bob@example.com", "voice")
+
+		duplicate := &models.User{Name: "Alice Again", Email: "alice@example.com", Password: "hashed", Role: "voice"}
+		expectError(t, repos.Users.Create(ctx, duplicate), utils.ErrUserAlreadyExists)
+
+		bob.Email = "alice@example.com"
+		expectError(t, repos.Users.Update(ctx, bob), utils.ErrUserAlreadyExists)
+
+		stored, err := repos.Users.GetByID(ctx, bob.ID.Hex())
+		mustSucceed(t, err)
+		if stored.Email != "bob@example.com" {
+			t.Fatalf("rejected update changed the email to %q", stored.Email)
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		repos := newRepos(t)
+		user := newUser(t, repos, "alice@example.com", "liaison")
+
+		user.Name = "Alice Renamed"
+		user.Role = "finance"
+		user.Locale = "id"
+		mustSucceed(t, repos.Users.Update(ctx, user))
+
+		stored, err := repos.Users.GetByID(ctx, user.ID.Hex())
+		mustSucceed(t, err)
+		if stored.Name != "Alice Renamed" || stored.Role != "finance" || stored.Locale != "id" {
+			t.Fatalf("update not applied: %+v", stored)
+		}
+
+		missing := *user
+		missing.ID = primitive.NilObjectID
+		missing.Email = "missing@example.com"
+		expectError(t, repos.Users.Update(ctx, &missing), utils.ErrUserNotFound)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		repos := newRepos(t)
+		user := newUser(t, repos, "alice@example.com", "liaison")
+
+		mustSucceed(t, repos.Users.Delete(ctx, user.ID.Hex()))
+		_, err := repos.Users.GetByID(ctx, user.ID.Hex())
+		expectError(t, err, utils.ErrUserNotFound)
+		expectError(t, repos.Users.Delete(ctx, user.ID.Hex()), utils.ErrUserNotFound)
+
+		// The email is free again once the user is gone
+		newUser(t, repos, "alice@example.com", "liaison")
+	})
+
+	t.Run("Verification", func(t *testing.T) {
+		repos := newRepos(t)
+		admin := newUser(t, repos, "admin@example.com", "admin")
+		alice := newUser(t, repos, "alice@example.com", "liaison")
+		newUser(t, repos, "bob@example.com", "voice")
+
+		pending, err := repos.Users.GetPendingUsers(ctx)
+		mustSucceed(t, err)
+		if len(pending) != 3 {
+			t.Fatalf("expected 3 pending users, got %d", len(pending))
+		}
+
+		if err := repos.Users.VerifyUser(ctx, alice.ID.Hex(), "not-an-id", ""); err == nil {
+			t.Fatal("expected an error for a malformed admin ID")
+		}
+		mustSucceed(t, repos.Users.VerifyUser(ctx, alice.ID.Hex(), admin.ID.Hex(), "checked"))
+
+		stored, err := repos.Users.GetByID(ctx, alice.ID.Hex())
+		mustSucceed(t, err)
+		if !stored.IsVerified || stored.VerifiedAt == nil || stored.VerifiedBy == nil || *stored.VerifiedBy != admin.ID {
+			t.Fatalf("verification not recorded: %+v", stored)
+		}
+		if stored.VerificationNotes != "checked" {
+			t.Fatalf("expected notes %q, got %q", "checked", stored.VerificationNotes)
+		}
+
+		pending, err = repos.Users.GetPendingUsers(ctx)
+		mustSucceed(t, err)
+		if len(pending) != 2 {
+			t.Fatalf("expected 2 pending users, got %d", len(pending))
+		}
+	})
+
+	t.Run("SuspendAndReinstate", func(t *testing.T) {
+		repos := newRepos(t)
+		admin := newUser(t, repos, "admin@example.com", "admin")
+		user := newUser(t, repos, "alice@example.com", "liaison")
+
+		expectError(t, repos.Users.SuspendUser(ctx, user.ID.Hex(), "not-an-id", ""), utils.ErrInvalidID)
+		mustSucceed(t, repos.Users.SuspendUser(ctx, user.ID.Hex(), admin.ID.Hex(), "left"))
+
+		stored, err := repos.Users.GetByID(ctx, user.ID.Hex())
+		mustSucceed(t, err)
+		if !stored.IsSuspended || stored.SuspendedAt == nil || stored.SuspendedBy == nil || stored.SuspensionReason != "left" {
+			t.Fatalf("suspension not recorded: %+v", stored)
+		}
+
+		active, err := repos.Users.CountActiveUsersByRole(ctx, "liaison")
+		mustSucceed(t, err)
+		total, err := repos.Users.CountUsersByRole(ctx, "liaison")
+		mustSucceed(t, err)
+		if active != 0 || total != 1 {
+			t.Fatalf("expected 0 active of 1 liaison, got %d of %d", active, total)
+		}
+
+		mustSucceed(t, repos.Users.ReinstateUser(ctx, user.ID.Hex()))
+		stored, err = repos.Users.GetByID(ctx, user.ID.Hex())
+		mustSucceed(t, err)
+		if stored.IsSuspended || stored.SuspendedAt != nil || stored.SuspendedBy != nil || stored.SuspensionReason != "" {
+			t.Fatalf("suspension not cleared: %+v", stored)
+		}
+	})
+
+	t.Run("ErasedAdminIsNotActive", func(t *testing.T) {
+		repos := newRepos(t)
+		first := newUser(t, repos, "first@example.com", "admin")
+		newUser(t, repos, "second@example.com", "admin")
+
+		mustSucceed(t, repos.Users.Anonymize(ctx, first.ID.Hex(), "Erased user", "erased@example.invalid", "unusable"))
+
+		active, err := repos.Users.CountActiveUsersByRole(ctx, "admin")
+		mustSucceed(t, err)
+		if active != 1 {
+			t.Fatalf("expected 1 active admin after erasure, got %d", active)
+		}
+	})
+
+	t.Run("PasswordAndLogin", func(t *testing.T) {
+		repos := newRepos(t)
+		user := newUser(t, repos, "alice@example.com", "liaison")
+
+		mustSucceed(t, repos.Users.UpdatePassword(ctx, user.ID.Hex(), "rehashed"))
+		mustSucceed(t, repos.Users.UpdatePasswordResetInfo(ctx, user.ID.Hex()))
+		mustSucceed(t, repos.Users.UpdatePasswordResetInfo(ctx, user.ID.Hex()))
+		mustSucceed(t, repos.Users.UpdateLastLogin(ctx, user.ID.Hex()))
+
+		stored, err := repos.Users.GetByID(ctx, user.ID.Hex())
+		mustSucceed(t, err)
+		if stored.Password != "rehashed" {
+			t.Fatalf("password not updated")
+		}
+		if stored.PasswordResetCount != 2 || stored.LastPasswordReset == nil {
+			t.Fatalf("expected 2 resets, got %d (last %v)", stored.PasswordResetCount, stored.LastPasswordReset)
+		}
+		if stored.LastLoginAt == nil {
+			t.Fatal("expected LastLoginAt to be set")
+		}
+
+		expectError(t, repos.Users.UpdatePassword(ctx, unknownID, "rehashed"), utils.ErrUserNotFound)
+	})
+
+	t.Run("FindByFilter", func(t *testing.T) {
+		repos := newRepos(t)
+		first := newUser(t, repos, "c@example.com", "liaison")
+		newUser(t, repos, "b@example.com", "voice")
+		third := newUser(t, repos, "a@example.com", "liaison")
+
+		users, err := repos.Users.FindByFilter(ctx, &models.UserFilter{Role: "liaison"}, 0)
+		mustSucceed(t, err)
+		if len(users) != 2 || users[0].ID != first.ID || users[1].ID != third.ID {
+			t.Fatalf("expected liaisons oldest first, got %d users", len(users))
+		}
+
+		users, err = repos.Users.FindByFilter(ctx, nil, 2)
+		mustSucceed(t, err)
+		if len(users) != 2 || users[0].ID != first.ID {
+			t.Fatalf("expected the 2 oldest users, got %d", len(users))
+		}
+
+		verified := true
+		users, err = repos.Users.FindByFilter(ctx, &models.UserFilter{IsVerified: &verified}, 0)
+		mustSucceed(t, err)
+		if len(users) != 0 {
+			t.Fatalf("expected no verified users, got %d", len(users))
+		}
+
+		future := time.Now().Add(time.Hour)
+		users, err = repos.Users.FindByFilter(ctx, &models.UserFilter{CreatedAfter: &future}, 0)
+		mustSucceed(t, err)
+		if len(users) != 0 {
+			t.Fatalf("expected no users created after %v, got %d", future, len(users))
+		}
+	})
+
+	t.Run("StreamAll", func(t *testing.T) {
+		repos := newRepos(t)
+		for _, email := range []string{"c@example.com", "a@example.com", "b@example.com"} {
+			newUser(t, repos, email, "liaison")
+		}
+
+		var emails []string
+		mustSucceed(t, repos.Users.StreamAll(ctx, func(user *models.User) error {
+			emails = append(emails, user.Email)
+			return nil
+		}))
+		expectNames(t, emails, []string{"a@example.com", "b@example.com", "c@example.com"})
+
+		stop := errors.New("stop")
+		calls := 0
+		err := repos.Users.StreamAll(ctx, func(user *models.User) error {
+			calls++
+			return stop
+		})
+		if err != stop || calls != 1 {
+			t.Fatalf("expected iteration to stop with the callback error, got %v after %d calls", err, calls)
+		}
+	})
+
+	t.Run("Anonymize", func(t *testing.T) {
+		repos := newRepos(t)
+		user := newUser(t, repos, "alice@example.com", "liaison")
+		mustSucceed(t, repos.Users.UpdateLastLogin(ctx, user.ID.Hex()))
+
+		mustSucceed(t, repos.Users.Anonymize(ctx, user.ID.Hex(), "Erased User", "erased@invalid", "scrambled"))
+
+		stored, err := repos.Users.GetByID(ctx, user.ID.Hex())
+		mustSucceed(t, err)
+		if stored.Name != "Erased User" || stored.Email != "erased@invalid" || stored.Password != "scrambled" {
+			t.Fatalf("personal data not replaced: %+v", stored)
+		}
+		if stored.ErasedAt == nil || stored.LastLoginAt != nil {
+			t.Fatalf("expected ErasedAt set and LastLoginAt cleared: %+v", stored)
+		}
+		expectError(t, repos.Users.Anonymize(ctx, unknownID, "x", "x@invalid", "x"), utils.ErrUserNotFound)
+	})
+
+	t.Run("Statistics", func(t *testing.T) {
+		repos := newRepos(t)
+		admin := newUser(t, repos, "admin@example.com", "admin")
+		newUser(t, repos, "a@example.com", "liaison")
+		verified := newUser(t, repos, "b@example.com", "liaison")
+		suspended := newUser(t, repos, "c@example.com", "voice")
+		erased := newUser(t, repos, "d@example.com", "voice")
+		mustSucceed(t, repos.Users.VerifyUser(ctx, admin.ID.Hex(), admin.ID.Hex(), ""))
+		mustSucceed(t, repos.Users.VerifyUser(ctx, verified.ID.Hex(), admin.ID.Hex(), ""))
+		mustSucceed(t, repos.Users.SuspendUser(ctx, suspended.ID.Hex(), admin.ID.Hex(), ""))
+		mustSucceed(t, repos.Users.Anonymize(ctx, erased.ID.Hex(), "Erased", "erased@invalid", "x"))
+
+		counts, err := repos.Users.CountByRoleAndStatus(ctx)
+		mustSucceed(t, err)
+		want := []models.RoleStatusCount{
+			{Role: "admin", Total: 1, Verified: 1},
+			{Role: "liaison", Total: 2, Verified: 1, Pending: 1},
+			{Role: "voice", Total: 1, Pending: 1, Suspended: 1},
+		}
+		if len(counts) != len(want) {
+			t.Fatalf("expected %v, got %v", want, counts)
+		}
+		for i := range want {
+			if counts[i] != want[i] {
+				t.Fatalf("expected %v, got %v", want, counts)
+			}
+		}
+
+		// Pending, non-suspended users: a@example.com and the erased d@example.com
+		now := time.Now().UTC()
+		lower := now.Add(-time.Hour).Truncate(time.Second)
+		pending, err := repos.Users.CountPendingByCreatedAt(ctx, []time.Time{lower.Add(-time.Hour), lower, now.Add(time.Hour)})
+		mustSucceed(t, err)
+		expectCounts(t, pending, []models.PeriodCount{{Period: lower, Count: 2}})
+
+		registrations, err := repos.Users.CountRegistrationsByPeriod(ctx, &models.StatsQuery{
+			From:   now.Add(-48 * time.Hour),
+			To:     now.Add(48 * time.Hour),
+			Bucket: models.StatsBucketDay,
+		})
+		mustSucceed(t, err)
+		var total int64
+		for _, count := range registrations {
+			if !count.Period.Equal(count.Period.UTC().Truncate(24 * time.Hour)) {
+				t.Fatalf("expected day buckets to start at UTC midnight, got %v", count.Period)
+			}
+			total += count.Count
+		}
+		if total != 5 {
+			t.Fatalf("expected 5 registrations, got %d", total)
+		}
+	})
+}
@@ -0,0 +1,324 @@
+package conformance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"backend/models"
+	"backend/repositories"
+	"backend/utils"
+)
+
+func testWebhooks(t *testing.T, newRepos Factory) {
+	ctx := context.Background()
+
+	t.Run("CRUD", func(t *testing.T) {
+		repos := newRepos(t)
+		first := newWebhook(t, repos, models.WebhookUserDeleted)
+		second := newWebhook(t, repos, models.WebhookAllEvents)
+
+		if first.ID.IsZero() || first.CreatedAt.IsZero() {
+			t.Fatalf("expected Create to assign an ID and timestamps: %+v", first)
+		}
+
+		stored, err := repos.Webhooks.GetByID(ctx, first.ID.Hex())
+		mustSucceed(t, err)
+		if stored.URL != "https://example.com/hooks" || stored.Secret != "whsec_test" || !stored.Enabled ||
+			len(stored.Events) != 1 || stored.Events[0] != models.WebhookUserDeleted {
+			t.Fatalf("unexpected stored webhook: %+v", stored)
+		}
+		expectTime(t, "CreatedAt", stored.CreatedAt, first.CreatedAt)
+
+		all, err := repos.Webhooks.List(ctx)
+		mustSucceed(t, err)
+		if len(all) != 2 || all[0].ID != second.ID || all[1].ID != first.ID {
+			t.Fatalf("expected every webhook newest first, got %+v", all)
+		}
+
+		disabledAt := time.Now()
+		stored.URL = "https://example.org/hooks"
+		stored.Events = []string{models.WebhookUserDeleted, models.WebhookUserVerified}
+		stored.Enabled = false
+		stored.DisabledReason = "paused"
+		stored.DisabledAt = &disabledAt
+		mustSucceed(t, repos.Webhooks.Update(ctx, stored))
+		updated, err := repos.Webhooks.GetByID(ctx, first.ID.Hex())
+		mustSucceed(t, err)
+		if updated.URL != "https://example.org/hooks" || len(updated.Events) != 2 || updated.Enabled ||
+			updated.DisabledReason != "paused" || updated.DisabledAt == nil {
+			t.Fatalf("expected the update to be saved: %+v", updated)
+		}
+		expectTime(t, "DisabledAt", *updated.DisabledAt, disabledAt)
+		expectTime(t, "CreatedAt", updated.CreatedAt, first.CreatedAt)
+
+		delivery := newWebhookDelivery(t, repos, first)
+		mustSucceed(t, repos.Webhooks.Delete(ctx, first.ID.Hex()))
+		_, err = repos.Webhooks.GetByID(ctx, first.ID.Hex())
+		expectError(t, err, utils.ErrWebhookNotFound)
+		_, err = repos.Webhooks.GetDelivery(ctx, delivery.ID.Hex())
+		expectError(t, err, utils.ErrWebhookDeliveryNotFound)
+	})
+
+	t.Run("ListSubscribed", func(t *testing.T) {
+		repos := newRepos(t)
+		deleted := newWebhook(t, repos, models.WebhookUserDeleted, models.WebhookUserVerified)
+		all := newWebhook(t, repos, models.WebhookAllEvents)
+		newWebhook(t, repos, models.WebhookPermissionGranted)
+		disabled := newWebhook(t, repos, models.WebhookUserDeleted)
+		disabled.Enabled = false
+		mustSucceed(t, repos.Webhooks.Update(ctx, disabled))
+
+		subscribed, err := repos.Webhooks.ListSubscribed(ctx, models.WebhookUserDeleted)
+		mustSucceed(t, err)
+		if len(subscribed) != 2 || subscribed[0].ID != all.ID || subscribed[1].ID != deleted.ID {
+			t.Fatalf("expected the enabled webhooks subscribed directly or through *, got %+v", subscribed)
+		}
+		if subscribed[0].Secret != "whsec_test" {
+			t.Fatalf("expected subscribed webhooks to carry their secret: %+v", subscribed[0])
+		}
+
+		subscribed, err = repos.Webhooks.ListSubscribed(ctx, models.WebhookPermissionRevoked)
+		mustSucceed(t, err)
+		if len(subscribed) != 1 || subscribed[0].ID != all.ID {
+			t.Fatalf("expected only the * webhook, got %+v", subscribed)
+		}
+	})
+
+	t.Run("FailureTracking", func(t *testing.T) {
+		repos := newRepos(t)
+		webhook := newWebhook(t, repos, models.WebhookAllEvents)
+		id := webhook.ID.Hex()
+
+		disabled, err := repos.Webhooks.RecordFailure(ctx, id, 3, "too many failures")
+		mustSucceed(t, err)
+		if disabled {
+			t.Fatalf("expected the first failure not to disable the webhook")
+		}
+		mustSucceed(t, repos.Webhooks.RecordSuccess(ctx, id))
+		stored, err := repos.Webhooks.GetByID(ctx, id)
+		mustSucceed(t, err)
+		if stored.ConsecutiveFailures != 0 {
+			t.Fatalf("expected a success to clear the failure count: %+v", stored)
+		}
+
+		for i := 1; i <= 3; i++ {
+			disabled, err = repos.Webhooks.RecordFailure(ctx, id, 3, "too many failures")
+			mustSucceed(t, err)
+			if disabled != (i == 3) {
+				t.Fatalf("failure %d: expected disabled=%v, got %v", i, i == 3, disabled)
+			}
+		}
+		stored, err = repos.Webhooks.GetByID(ctx, id)
+		mustSucceed(t, err)
+		if stored.Enabled || stored.ConsecutiveFailures != 3 || stored.DisabledReason != "too many failures" || stored.DisabledAt == nil {
+			t.Fatalf("expected the webhook to be disabled: %+v", stored)
+		}
+
+		// Only the failure that crossed the threshold reports disabling it
+		disabled, err = repos.Webhooks.RecordFailure(ctx, id, 3, "too many failures")
+		mustSucceed(t, err)
+		if disabled {
+			t.Fatalf("expected an already disabled webhook not to be reported again")
+		}
+	})
+
+	t.Run("Deliveries", func(t *testing.T) {
+		repos := newRepos(t)
+		webhook := newWebhook(t, repos, models.WebhookAllEvents)
+		other := newWebhook(t, repos, models.WebhookAllEvents)
+		first := newWebhookDelivery(t, repos, webhook)
+		second := newWebhookDelivery(t, repos, webhook)
+		newWebhookDelivery(t, repos, other)
+
+		if first.ID.IsZero() || first.Status != models.WebhookDeliveryPending || first.NextAttemptAt.IsZero() {
+			t.Fatalf("expected CreateDelivery to assign an ID, pending status and a due time: %+v", first)
+		}
+
+		stored, err := repos.Webhooks.GetDelivery(ctx, first.ID.Hex())
+		mustSucceed(t, err)
+		if stored.WebhookID != webhook.ID || stored.EventID != "evt_1" || stored.EventType != models.WebhookUserDeleted ||
+			stored.Payload != `{"id":"evt_1"}` || stored.Attempts != 0 || stored.RedeliveryOf != nil {
+			t.Fatalf("unexpected stored delivery: %+v", stored)
+		}
+
+		deliveries, err := repos.Webhooks.ListDeliveries(ctx, webhook.ID.Hex(), 10)
+		mustSucceed(t, err)
+		if len(deliveries) != 2 || deliveries[0].ID != second.ID || deliveries[1].ID != first.ID {
+			t.Fatalf("expected the webhook's deliveries newest first, got %+v", deliveries)
+		}
+		deliveries, err = repos.Webhooks.ListDeliveries(ctx, webhook.ID.Hex(), 1)
+		mustSucceed(t, err)
+		if len(deliveries) != 1 || deliveries[0].ID != second.ID {
+			t.Fatalf("expected only the newest delivery, got %+v", deliveries)
+		}
+
+		redelivery := &models.WebhookDelivery{
+			WebhookID:    webhook.ID,
+			EventID:      first.EventID,
+			EventType:    first.EventType,
+			Payload:      first.Payload,
+			RedeliveryOf: &first.ID,
+		}
+		mustSucceed(t, repos.Webhooks.CreateDelivery(ctx, redelivery))
+		stored, err = repos.Webhooks.GetDelivery(ctx, redelivery.ID.Hex())
+		mustSucceed(t, err)
+		if stored.RedeliveryOf == nil || *stored.RedeliveryOf != first.ID || stored.Payload != first.Payload {
+			t.Fatalf("expected the redelivery to reference the original: %+v", stored)
+		}
+	})
+
+	t.Run("Claim", func(t *testing.T) {
+		repos := newRepos(t)
+		webhook := newWebhook(t, repos, models.WebhookAllEvents)
+		first := newWebhookDelivery(t, repos, webhook)
+		second := newWebhookDelivery(t, repos, webhook)
+		newWebhookDelivery(t, repos, webhook)
+		now := time.Now().Add(time.Second)
+
+		claimed, err := repos.Webhooks.ClaimDueDeliveries(ctx, now, time.Minute, 2)
+		mustSucceed(t, err)
+		if len(claimed) != 2 {
+			t.Fatalf("expected 2 claimed deliveries, got %d", len(claimed))
+		}
+		for _, delivery := range claimed {
+			if delivery.Attempts != 1 || delivery.LastAttemptAt == nil || delivery.Payload == "" {
+				t.Fatalf("expected a claimed delivery with its payload and one attempt: %+v", delivery)
+			}
+			expectTime(t, "NextAttemptAt", delivery.NextAttemptAt, now.Add(time.Minute))
+		}
+
+		claimed, err = repos.Webhooks.ClaimDueDeliveries(ctx, now, time.Minute, 10)
+		mustSucceed(t, err)
+		if len(claimed) != 1 {
+			t.Fatalf("expected claimed deliveries to be skipped until their lease passes, got %d", len(claimed))
+		}
+
+		// A failed attempt keeps its response and is retried at the given time
+		retryAt := now.Add(time.Hour)
+		mustSucceed(t, repos.Webhooks.MarkFailed(ctx, first.ID.Hex(), &models.WebhookAttempt{
+			ResponseStatus: 503,
+			ResponseBody:   "unavailable",
+			Duration:       120 * time.Millisecond,
+			Error:          "status 503",
+		}, &retryAt))
+		stored, err := repos.Webhooks.GetDelivery(ctx, first.ID.Hex())
+		mustSucceed(t, err)
+		if stored.Status != models.WebhookDeliveryPending || stored.ResponseStatus != 503 || stored.ResponseBody != "unavailable" ||
+			stored.DurationMs != 120 || stored.LastError != "status 503" {
+			t.Fatalf("expected a pending delivery with the failed attempt recorded: %+v", stored)
+		}
+		expectTime(t, "NextAttemptAt", stored.NextAttemptAt, retryAt)
+
+		claimed, err = repos.Webhooks.ClaimDueDeliveries(ctx, retryAt.Add(-time.Second), time.Minute, 10)
+		mustSucceed(t, err)
+		for _, delivery := range claimed {
+			if delivery.ID == first.ID {
+				t.Fatalf("expected the failed delivery to wait for its retry time")
+			}
+		}
+		claimed, err = repos.Webhooks.ClaimDueDeliveries(ctx, retryAt, time.Minute, 10)
+		mustSucceed(t, err)
+		if len(claimed) != 1 || claimed[0].ID != first.ID || claimed[0].Attempts != 2 {
+			t.Fatalf("expected the failed delivery to be claimed again: %+v", claimed)
+		}
+
+		mustSucceed(t, repos.Webhooks.MarkDelivered(ctx, first.ID.Hex(), &models.WebhookAttempt{
+			ResponseStatus: 204,
+			Duration:       40 * time.Millisecond,
+		}))
+		stored, err = repos.Webhooks.GetDelivery(ctx, first.ID.Hex())
+		mustSucceed(t, err)
+		if stored.Status != models.WebhookDeliveryDelivered || stored.DeliveredAt == nil || stored.ResponseStatus != 204 ||
+			stored.ResponseBody != "" || stored.DurationMs != 40 || stored.LastError != "" {
+			t.Fatalf("expected a delivered delivery with the latest response: %+v", stored)
+		}
+
+		// Failed deliveries are never claimed again
+		mustSucceed(t, repos.Webhooks.MarkFailed(ctx, second.ID.Hex(), &models.WebhookAttempt{Error: "connection refused"}, nil))
+		stored, err = repos.Webhooks.GetDelivery(ctx, second.ID.Hex())
+		mustSucceed(t, err)
+		if stored.Status != models.WebhookDeliveryFailed || stored.LastError != "connection refused" {
+			t.Fatalf("expected a failed delivery: %+v", stored)
+		}
+		claimed, err = repos.Webhooks.ClaimDueDeliveries(ctx, now.Add(24*time.Hour), time.Minute, 10)
+		mustSucceed(t, err)
+		for _, delivery := range claimed {
+			if delivery.ID == first.ID || delivery.ID == second.ID {
+				t.Fatalf("expected finished deliveries not to be claimed: %+v", delivery)
+			}
+		}
+	})
+
+	t.Run("Prune", func(t *testing.T) {
+		repos := newRepos(t)
+		webhook := newWebhook(t, repos, models.WebhookAllEvents)
+		delivered := newWebhookDelivery(t, repos, webhook)
+		failed := newWebhookDelivery(t, repos, webhook)
+		pending := newWebhookDelivery(t, repos, webhook)
+		mustSucceed(t, repos.Webhooks.MarkDelivered(ctx, delivered.ID.Hex(), &models.WebhookAttempt{ResponseStatus: 200}))
+		mustSucceed(t, repos.Webhooks.MarkFailed(ctx, failed.ID.Hex(), &models.WebhookAttempt{Error: "timeout"}, nil))
+
+		deleted, err := repos.Webhooks.DeleteDeliveriesBefore(ctx, time.Now().Add(-time.Hour))
+		mustSucceed(t, err)
+		if deleted != 0 {
+			t.Fatalf("expected recent deliveries to be kept, got %d deleted", deleted)
+		}
+		deleted, err = repos.Webhooks.DeleteDeliveriesBefore(ctx, time.Now().Add(time.Hour))
+		mustSucceed(t, err)
+		if deleted != 2 {
+			t.Fatalf("expected the finished deliveries to be deleted, got %d", deleted)
+		}
+		_, err = repos.Webhooks.GetDelivery(ctx, pending.ID.Hex())
+		mustSucceed(t, err)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		repos := newRepos(t)
+
+		_, err := repos.Webhooks.GetByID(ctx, "not-an-id")
+		expectError(t, err, utils.ErrInvalidID)
+		_, err = repos.Webhooks.GetByID(ctx, unknownID)
+		expectError(t, err, utils.ErrWebhookNotFound)
+		expectError(t, repos.Webhooks.Delete(ctx, unknownID), utils.ErrWebhookNotFound)
+		expectError(t, repos.Webhooks.RecordSuccess(ctx, unknownID), utils.ErrWebhookNotFound)
+		_, err = repos.Webhooks.RecordFailure(ctx, unknownID, 3, "")
+		expectError(t, err, utils.ErrWebhookNotFound)
+
+		_, err = repos.Webhooks.GetDelivery(ctx, "not-an-id")
+		expectError(t, err, utils.ErrInvalidID)
+		_, err = repos.Webhooks.GetDelivery(ctx, unknownID)
+		expectError(t, err, utils.ErrWebhookDeliveryNotFound)
+		_, err = repos.Webhooks.ListDeliveries(ctx, "not-an-id", 10)
+		expectError(t, err, utils.ErrInvalidID)
+		expectError(t, repos.Webhooks.MarkDelivered(ctx, unknownID, &models.WebhookAttempt{}), utils.ErrWebhookDeliveryNotFound)
+		expectError(t, repos.Webhooks.MarkFailed(ctx, "not-an-id", &models.WebhookAttempt{}, nil), utils.ErrInvalidID)
+	})
+}
+
+func newWebhook(t *testing.T, repos *repositories.Repositories, events ...string) *models.Webhook {
+	t.Helper()
+	webhook := &models.Webhook{
+		URL:     "https://example.com/hooks",
+		Events:  events,
+		Secret:  "whsec_test",
+		Enabled: true,
+	}
+	mustSucceed(t, repos.Webhooks.Create(context.Background(), webhook))
+	// Keep creation times distinct so newest-first ordering is deterministic
+	time.Sleep(2 * time.Millisecond)
+	return webhook
+}
+
+func newWebhookDelivery(t *testing.T, repos *repositories.Repositories, webhook *models.Webhook) *models.WebhookDelivery {
+	t.Helper()
+	delivery := &models.WebhookDelivery{
+		WebhookID: webhook.ID,
+		EventID:   "evt_1",
+		EventType: models.WebhookUserDeleted,
+		Payload:   `{"id":"evt_1"}`,
+	}
+	mustSucceed(t, repos.Webhooks.CreateDelivery(context.Background(), delivery))
+	time.Sleep(2 * time.Millisecond)
+	return delivery
+}
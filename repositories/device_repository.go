@@ -0,0 +1,94 @@
+package repositories
+
+import (
+	"context"
+
+	"backend/models"
+	"backend/repositories/interfaces"
+	"backend/utils"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type deviceRepository struct {
+	collection *mongo.Collection
+}
+
+func NewDeviceRepository(db *mongo.Database) interfaces.DeviceRepository {
+	return &deviceRepository{
+		collection: db.Collection("user_devices"),
+	}
+}
+
+// Record upserts on the unique user and fingerprint index. When two logins
+// from a new device race, the losing upsert fails on the index and is retried
+// as a plain update of the device the other one created.
+func (r *deviceRepository) Record(ctx context.Context, device *models.Device) (bool, error) {
+	id := primitive.NewObjectID()
+	filter := bson.M{"user_id": device.UserID, "fingerprint": device.Fingerprint}
+	update := bson.M{
+		"$set": bson.M{
+			"user_agent":   device.UserAgent,
+			"ip":           device.IP,
+			"last_seen_at": device.LastSeenAt,
+		},
+		"$setOnInsert": bson.M{
+			"_id":           id,
+			"first_seen_at": device.LastSeenAt,
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		delete(update, "$setOnInsert")
+		_, err = r.collection.UpdateOne(ctx, filter, update)
+		return false, err
+	}
+	if err != nil {
+		return false, err
+	}
+	if result.UpsertedCount == 0 {
+		return false, nil
+	}
+
+	device.ID = id
+	device.FirstSeenAt = device.LastSeenAt
+	return true, nil
+}
+
+func (r *deviceRepository) ListByUser(ctx context.Context, userID string) ([]*models.Device, error) {
+	userObjectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, utils.ErrInvalidID
+	}
+
+	cursor, err := r.collection.Find(ctx, bson.M{"user_id": userObjectID}, options.Find().SetSort(bson.D{{Key: "last_seen_at", Value: -1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var devices []*models.Device
+	for cursor.Next(ctx) {
+		var device models.Device
+		if err := cursor.Decode(&device); err != nil {
+			return nil, err
+		}
+		devices = append(devices, &device)
+	}
+
+	return devices, cursor.Err()
+}
+
+func (r *deviceRepository) DeleteByUser(ctx context.Context, userID string) error {
+	userObjectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return utils.ErrInvalidID
+	}
+
+	_, err = r.collection.DeleteMany(ctx, bson.M{"user_id": userObjectID})
+	return err
+}
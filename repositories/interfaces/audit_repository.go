@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"backend/models"
+)
+
+type AuditRepository interface {
+	Create(ctx context.Context, event *models.AuditEvent) error
+	GetInvolvingUser(ctx context.Context, userID string) ([]*models.AuditEvent, error)
+	GetPermissionHistory(ctx context.Context, role string) ([]*models.AuditEvent, error)
+	PseudonymizeActor(ctx context.Context, userID, pseudonym string) error
+	CountByPeriod(ctx context.Context, action string, query *models.StatsQuery) ([]models.PeriodCount, error)
+	CountSince(ctx context.Context, action string, since time.Time) (int64, error)
+}
@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"context"
+
+	"backend/models"
+)
+
+type BulkJobRepository interface {
+	Create(ctx context.Context, job *models.BulkJob) error
+	GetByID(ctx context.Context, id string) (*models.BulkJob, error)
+	GetRecent(ctx context.Context, limit int64) ([]*models.BulkJob, error)
+	MarkRunning(ctx context.Context, id string) error
+	AppendResult(ctx context.Context, id string, result models.BulkItemResult) error
+	Complete(ctx context.Context, id, status, errMessage string) error
+}
@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+
+	"backend/models"
+)
+
+type DeviceRepository interface {
+	// Record stores a login from the device, or refreshes its address and last
+	// seen time when the user has used it before. It reports whether the
+	// device is new.
+	Record(ctx context.Context, device *models.Device) (bool, error)
+	// ListByUser returns the user's devices, most recently seen first
+	ListByUser(ctx context.Context, userID string) ([]*models.Device, error)
+	DeleteByUser(ctx context.Context, userID string) error
+}
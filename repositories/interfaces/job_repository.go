@@ -0,0 +1,24 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"backend/models"
+)
+
+type JobRepository interface {
+	// AcquireLease takes the named lease for owner until ttl elapses, or
+	// extends it when owner already holds it. It reports false while another
+	// owner holds an unexpired lease.
+	AcquireLease(ctx context.Context, name, owner string, ttl time.Duration) (bool, error)
+	// ReleaseLease gives the lease up if owner still holds it
+	ReleaseLease(ctx context.Context, name, owner string) error
+
+	CreateRun(ctx context.Context, run *models.JobRun) error
+	// GetRuns returns the runs of a job, newest first
+	GetRuns(ctx context.Context, job string, limit int64) ([]*models.JobRun, error)
+	// GetLatestRuns returns the most recent run of every job that has run
+	GetLatestRuns(ctx context.Context) ([]*models.JobRun, error)
+	DeleteRunsBefore(ctx context.Context, before time.Time) (int64, error)
+}
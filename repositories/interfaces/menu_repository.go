@@ -20,4 +20,7 @@ type MenuRepository interface {
 
 	// Menu by role access
 	GetMenusByRole(ctx context.Context, role string) ([]*models.Menu, error)
+
+	// Menus no role has been granted
+	GetMenusWithoutGrants(ctx context.Context) ([]*models.Menu, error)
 }
@@ -0,0 +1,30 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"backend/models"
+)
+
+type NotificationRepository interface {
+	Create(ctx context.Context, notification *models.Notification) error
+	// List returns the user's notifications, newest first
+	List(ctx context.Context, userID string, unreadOnly bool, limit int64) ([]*models.Notification, error)
+	CountUnread(ctx context.Context, userID string) (int64, error)
+	// MarkRead marks one of the user's notifications read. Marking a read
+	// notification again keeps the original time.
+	MarkRead(ctx context.Context, userID, id string) error
+	MarkAllRead(ctx context.Context, userID string) (int64, error)
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+
+	// GetPreferences returns the preferences the user has stored; event types
+	// without one are absent
+	GetPreferences(ctx context.Context, userID string) ([]models.NotificationPreference, error)
+	// SavePreferences stores the given preferences, replacing stored ones of
+	// the same event types
+	SavePreferences(ctx context.Context, userID string, preferences []models.NotificationPreference) error
+
+	// DeleteByUser removes the user's notifications and preferences
+	DeleteByUser(ctx context.Context, userID string) error
+}
@@ -0,0 +1,38 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"backend/models"
+)
+
+type OutboxRepository interface {
+	// Create queues a pending message that is due immediately
+	Create(ctx context.Context, message *models.OutboxMessage) error
+	GetByID(ctx context.Context, id string) (*models.OutboxMessage, error)
+	// List returns messages with the given status, or all when status is
+	// empty, newest first
+	List(ctx context.Context, status string, limit int64) ([]*models.OutboxMessage, error)
+	Summary(ctx context.Context) (*models.OutboxSummary, error)
+
+	// ClaimDue takes up to limit pending messages that are due at now. Each
+	// claimed message counts an attempt and is not due again until lease has
+	// passed, so concurrent workers never claim the same message.
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.OutboxMessage, error)
+	// MarkSent records the delivery of a claimed message and clears its
+	// bodies. The claim is identified by its attempt count and time; if the
+	// message was claimed again or requeued since, ErrOutboxClaimLost is
+	// returned and the newer claim's outcome is kept.
+	MarkSent(ctx context.Context, claim *models.OutboxMessage) error
+	// MarkFailed records a failed attempt of a claimed message, like MarkSent.
+	// The message is retried at retryAt, or dead-lettered when retryAt is nil.
+	MarkFailed(ctx context.Context, claim *models.OutboxMessage, errMessage string, retryAt *time.Time) error
+	// Requeue makes a message due immediately with a fresh attempt count
+	Requeue(ctx context.Context, id string) error
+
+	// DeleteFinishedBefore deletes messages sent, or dead-lettered after
+	// their last attempt, before the given time
+	DeleteFinishedBefore(ctx context.Context, before time.Time) (int64, error)
+	DeleteByRecipient(ctx context.Context, email string) error
+}
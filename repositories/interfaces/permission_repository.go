@@ -18,4 +18,7 @@ type PermissionRepository interface {
 	// Bulk operations
 	RevokeAllPermissionsForMenu(ctx context.Context, menuID string) error
 	RevokeAllPermissionsForRole(ctx context.Context, role string) error
+
+	// Privacy
+	PseudonymizeGrantor(ctx context.Context, grantedByID, pseudonym string) error
 }
@@ -0,0 +1,25 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+)
+
+type RateLimitRepository interface {
+	// Increment adds one to the counter, creating it to expire at expiresAt
+	// when it does not exist, and returns the new count
+	Increment(ctx context.Context, key string, expiresAt time.Time) (int64, error)
+	// Decrement takes one from a counter above zero, undoing an Increment.
+	// A missing counter is left missing.
+	Decrement(ctx context.Context, key string) error
+	// Count returns the counter's value, or 0 when it does not exist or has
+	// expired
+	Count(ctx context.Context, key string) (int64, error)
+	// TakeToken refills the bucket at rate tokens per second, up to capacity,
+	// for the time since it was last used, then takes a token if a whole one
+	// is left. New buckets start full. It reports whether a token was taken
+	// and how many are left. The bucket expires capacity/rate seconds after
+	// it was last used, by when it is full again.
+	TakeToken(ctx context.Context, key string, capacity, rate float64, now time.Time) (bool, float64, error)
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+}
@@ -12,4 +12,5 @@ type TokenRepository interface {
 	RevokeToken(ctx context.Context, token string) error
 	RevokeAllUserTokens(ctx context.Context, userID string) error
 	DeleteExpiredTokens(ctx context.Context) error
+	CountActive(ctx context.Context) (int64, error)
 }
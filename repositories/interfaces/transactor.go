@@ -0,0 +1,11 @@
+package interfaces
+
+import "context"
+
+// Transactor runs a unit of work atomically. Repository calls made with the
+// context passed to fn join the transaction, and a unit of work started
+// inside another one joins the outer transaction. Errors returned by fn are
+// returned unchanged after rolling back.
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -3,6 +3,7 @@ package interfaces
 import (
 	"backend/models"
 	"context"
+	"time"
 )
 
 type UserRepository interface {
@@ -16,4 +17,14 @@ type UserRepository interface {
 	UpdatePassword(ctx context.Context, userID, hashedPassword string) error
 	UpdatePasswordResetInfo(ctx context.Context, userID string) error
 	CountUsersByRole(ctx context.Context, role string) (int64, error)
+	CountActiveUsersByRole(ctx context.Context, role string) (int64, error)
+	FindByFilter(ctx context.Context, filter *models.UserFilter, limit int64) ([]*models.User, error)
+	SuspendUser(ctx context.Context, userID, adminID string, reason string) error
+	ReinstateUser(ctx context.Context, userID string) error
+	UpdateLastLogin(ctx context.Context, userID string) error
+	StreamAll(ctx context.Context, fn func(user *models.User) error) error
+	Anonymize(ctx context.Context, userID, name, email, hashedPassword string) error
+	CountByRoleAndStatus(ctx context.Context) ([]models.RoleStatusCount, error)
+	CountPendingByCreatedAt(ctx context.Context, boundaries []time.Time) ([]models.PeriodCount, error)
+	CountRegistrationsByPeriod(ctx context.Context, query *models.StatsQuery) ([]models.PeriodCount, error)
 }
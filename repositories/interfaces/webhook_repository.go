@@ -0,0 +1,47 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"backend/models"
+)
+
+type WebhookRepository interface {
+	Create(ctx context.Context, webhook *models.Webhook) error
+	GetByID(ctx context.Context, id string) (*models.Webhook, error)
+	// List returns every webhook, newest first
+	List(ctx context.Context) ([]*models.Webhook, error)
+	// ListSubscribed returns the enabled webhooks subscribed to an event type,
+	// directly or through models.WebhookAllEvents
+	ListSubscribed(ctx context.Context, eventType string) ([]*models.Webhook, error)
+	// Update saves the webhook's settings, enabled state and failure count
+	Update(ctx context.Context, webhook *models.Webhook) error
+	// Delete removes the webhook and its deliveries
+	Delete(ctx context.Context, id string) error
+
+	// RecordSuccess clears the webhook's consecutive failure count
+	RecordSuccess(ctx context.Context, id string) error
+	// RecordFailure counts a failed attempt. When the count reaches
+	// disableAfter the webhook is disabled with reason; disabled reports
+	// whether this call disabled it.
+	RecordFailure(ctx context.Context, id string, disableAfter int, reason string) (disabled bool, err error)
+
+	// CreateDelivery queues a pending delivery that is due immediately
+	CreateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error
+	GetDelivery(ctx context.Context, id string) (*models.WebhookDelivery, error)
+	// ListDeliveries returns a webhook's deliveries, newest first
+	ListDeliveries(ctx context.Context, webhookID string, limit int64) ([]*models.WebhookDelivery, error)
+	// ClaimDueDeliveries takes up to limit pending deliveries that are due at
+	// now. Each claimed delivery counts an attempt and is not due again until
+	// lease has passed, so concurrent workers never claim the same delivery.
+	ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.WebhookDelivery, error)
+	// MarkDelivered records the successful attempt
+	MarkDelivered(ctx context.Context, id string, attempt *models.WebhookAttempt) error
+	// MarkFailed records a failed attempt. The delivery is retried at retryAt,
+	// or marked failed when retryAt is nil.
+	MarkFailed(ctx context.Context, id string, attempt *models.WebhookAttempt, retryAt *time.Time) error
+	// DeleteDeliveriesBefore removes finished deliveries created before the
+	// given time; pending ones are kept
+	DeleteDeliveriesBefore(ctx context.Context, before time.Time) (int64, error)
+}
@@ -0,0 +1,115 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"backend/models"
+	"backend/repositories/interfaces"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// jobRepository keeps leases in the locks collection shared with the
+// migrator and run history in job_runs
+type jobRepository struct {
+	locks *mongo.Collection
+	runs  *mongo.Collection
+}
+
+func NewJobRepository(db *mongo.Database) interfaces.JobRepository {
+	return &jobRepository{
+		locks: db.Collection("locks"),
+		runs:  db.Collection("job_runs"),
+	}
+}
+
+// AcquireLease upserts the lease document unless another owner holds an
+// unexpired lease; the upsert then collides with the existing _id
+func (r *jobRepository) AcquireLease(ctx context.Context, name, owner string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	filter := bson.M{
+		"_id": name,
+		"$or": bson.A{
+			bson.M{"owner": owner},
+			bson.M{"expires_at": bson.M{"$lt": now}},
+		},
+	}
+	update := bson.M{"$set": bson.M{"owner": owner, "expires_at": now.Add(ttl)}}
+
+	_, err := r.locks.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (r *jobRepository) ReleaseLease(ctx context.Context, name, owner string) error {
+	_, err := r.locks.DeleteOne(ctx, bson.M{"_id": name, "owner": owner})
+	return err
+}
+
+func (r *jobRepository) CreateRun(ctx context.Context, run *models.JobRun) error {
+	run.ID = primitive.NewObjectID()
+
+	_, err := r.runs.InsertOne(ctx, run)
+	return err
+}
+
+func (r *jobRepository) GetRuns(ctx context.Context, job string, limit int64) ([]*models.JobRun, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "started_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetLimit(limit)
+
+	cursor, err := r.runs.Find(ctx, bson.M{"job": job}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeJobRuns(ctx, cursor)
+}
+
+func (r *jobRepository) GetLatestRuns(ctx context.Context) ([]*models.JobRun, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$sort", Value: bson.D{{Key: "job", Value: 1}, {Key: "started_at", Value: -1}, {Key: "_id", Value: -1}}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$job"},
+			{Key: "run", Value: bson.D{{Key: "$first", Value: "$$ROOT"}}},
+		}}},
+		{{Key: "$replaceRoot", Value: bson.D{{Key: "newRoot", Value: "$run"}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "job", Value: 1}}}},
+	}
+
+	cursor, err := r.runs.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeJobRuns(ctx, cursor)
+}
+
+func decodeJobRuns(ctx context.Context, cursor *mongo.Cursor) ([]*models.JobRun, error) {
+	defer cursor.Close(ctx)
+
+	var runs []*models.JobRun
+	for cursor.Next(ctx) {
+		var run models.JobRun
+		if err := cursor.Decode(&run); err != nil {
+			return nil, err
+		}
+		runs = append(runs, &run)
+	}
+
+	return runs, cursor.Err()
+}
+
+func (r *jobRepository) DeleteRunsBefore(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.runs.DeleteMany(ctx, bson.M{"started_at": bson.M{"$lt": before}})
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
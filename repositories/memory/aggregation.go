@@ -0,0 +1,44 @@
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"backend/models"
+)
+
+// countByPeriod counts the dates in [query.From, query.To), grouped into UTC
+// buckets of query.Bucket size. Empty buckets are omitted, matching the
+// MongoDB $dateTrunc aggregation.
+func countByPeriod(dates []time.Time, query *models.StatsQuery) []models.PeriodCount {
+	byPeriod := map[time.Time]int64{}
+	for _, date := range dates {
+		if date.Before(query.From) || !date.Before(query.To) {
+			continue
+		}
+		byPeriod[truncate(date, query.Bucket)]++
+	}
+
+	counts := []models.PeriodCount{}
+	for period, count := range byPeriod {
+		counts = append(counts, models.PeriodCount{Period: period, Count: count})
+	}
+	sort.Slice(counts, func(i, j int) bool { return counts[i].Period.Before(counts[j].Period) })
+	return counts
+}
+
+// truncate returns the start of the UTC day, Monday-based week or month containing t
+func truncate(t time.Time, bucket string) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+
+	switch bucket {
+	case models.StatsBucketWeek:
+		offset := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -offset)
+	case models.StatsBucketMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return day
+	}
+}
@@ -0,0 +1,121 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"backend/models"
+	"backend/repositories/interfaces"
+	"backend/utils"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type auditRepository struct {
+	mu     sync.RWMutex
+	events []*models.AuditEvent
+}
+
+func NewAuditRepository() interfaces.AuditRepository {
+	return &auditRepository{}
+}
+
+func cloneAuditEvent(event *models.AuditEvent) *models.AuditEvent {
+	clone := *event
+	if event.Details != nil {
+		clone.Details = make(map[string]string, len(event.Details))
+		for key, value := range event.Details {
+			clone.Details[key] = value
+		}
+	}
+	return &clone
+}
+
+func (r *auditRepository) Create(ctx context.Context, event *models.AuditEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	event.ID = primitive.NewObjectID()
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+
+	r.events = append(r.events, cloneAuditEvent(event))
+	return nil
+}
+
+// GetInvolvingUser returns events where the user is either the actor or the target
+func (r *auditRepository) GetInvolvingUser(ctx context.Context, userID string) ([]*models.AuditEvent, error) {
+	objectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, utils.ErrInvalidID
+	}
+
+	return r.find(func(event *models.AuditEvent) bool {
+		isActor := event.ActorID != nil && *event.ActorID == objectID
+		isTarget := event.TargetType == models.AuditTargetUser && event.TargetID == userID
+		return isActor || isTarget
+	}), nil
+}
+
+// GetPermissionHistory returns grant and revoke events for a role
+func (r *auditRepository) GetPermissionHistory(ctx context.Context, role string) ([]*models.AuditEvent, error) {
+	return r.find(func(event *models.AuditEvent) bool {
+		return event.TargetType == models.AuditTargetRole &&
+			event.TargetID == role &&
+			(event.Action == models.AuditPermissionGranted || event.Action == models.AuditPermissionRevoked)
+	}), nil
+}
+
+// PseudonymizeActor replaces the stored display name of every event the user performed
+func (r *auditRepository) PseudonymizeActor(ctx context.Context, userID, pseudonym string) error {
+	objectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return utils.ErrInvalidID
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, event := range r.events {
+		if event.ActorID != nil && *event.ActorID == objectID {
+			event.ActorName = pseudonym
+		}
+	}
+	return nil
+}
+
+// find returns copies of the matching events, oldest first
+func (r *auditRepository) find(match func(event *models.AuditEvent) bool) []*models.AuditEvent {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var events []*models.AuditEvent
+	for _, event := range r.events {
+		if match(event) {
+			events = append(events, cloneAuditEvent(event))
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool { return events[i].CreatedAt.Before(events[j].CreatedAt) })
+	return events
+}
+
+// CountByPeriod counts events of one action per time bucket
+func (r *auditRepository) CountByPeriod(ctx context.Context, action string, query *models.StatsQuery) ([]models.PeriodCount, error) {
+	var dates []time.Time
+	for _, event := range r.find(func(event *models.AuditEvent) bool { return event.Action == action }) {
+		dates = append(dates, event.CreatedAt)
+	}
+	return countByPeriod(dates, query), nil
+}
+
+// CountSince counts events of one action recorded at or after since
+func (r *auditRepository) CountSince(ctx context.Context, action string, since time.Time) (int64, error) {
+	events := r.find(func(event *models.AuditEvent) bool {
+		return event.Action == action && !event.CreatedAt.Before(since)
+	})
+	return int64(len(events)), nil
+}
@@ -0,0 +1,135 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"backend/models"
+	"backend/repositories/interfaces"
+	"backend/utils"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// bulkJobRepository keeps jobs for the life of the process; the week-long
+// expiry of the MongoDB TTL index is not emulated
+type bulkJobRepository struct {
+	mu   sync.RWMutex
+	jobs []*models.BulkJob
+}
+
+func NewBulkJobRepository() interfaces.BulkJobRepository {
+	return &bulkJobRepository{}
+}
+
+func cloneBulkJob(job *models.BulkJob) *models.BulkJob {
+	clone := *job
+	clone.Results = append([]models.BulkItemResult{}, job.Results...)
+	return &clone
+}
+
+// update applies fn to the job with the given ID
+func (r *bulkJobRepository) update(id string, fn func(job *models.BulkJob)) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return utils.ErrInvalidID
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, job := range r.jobs {
+		if job.ID == objectID {
+			fn(job)
+			return nil
+		}
+	}
+	return utils.ErrBulkJobNotFound
+}
+
+func (r *bulkJobRepository) Create(ctx context.Context, job *models.BulkJob) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	job.ID = primitive.NewObjectID()
+	job.CreatedAt = time.Now()
+	if job.Status == "" {
+		job.Status = models.BulkJobStatusPending
+	}
+	if job.Results == nil {
+		job.Results = []models.BulkItemResult{}
+	}
+
+	r.jobs = append(r.jobs, cloneBulkJob(job))
+	return nil
+}
+
+func (r *bulkJobRepository) GetByID(ctx context.Context, id string) (*models.BulkJob, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, utils.ErrInvalidID
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, job := range r.jobs {
+		if job.ID == objectID {
+			return cloneBulkJob(job), nil
+		}
+	}
+	return nil, utils.ErrBulkJobNotFound
+}
+
+// GetRecent returns the newest jobs without their per-item results
+func (r *bulkJobRepository) GetRecent(ctx context.Context, limit int64) ([]*models.BulkJob, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var jobs []*models.BulkJob
+	for _, job := range r.jobs {
+		clone := *job
+		clone.Results = nil
+		jobs = append(jobs, &clone)
+	}
+
+	sort.SliceStable(jobs, func(i, j int) bool { return jobs[i].CreatedAt.After(jobs[j].CreatedAt) })
+	if limit > 0 && int64(len(jobs)) > limit {
+		jobs = jobs[:limit]
+	}
+	return jobs, nil
+}
+
+func (r *bulkJobRepository) MarkRunning(ctx context.Context, id string) error {
+	now := time.Now()
+	return r.update(id, func(job *models.BulkJob) {
+		job.Status = models.BulkJobStatusRunning
+		job.StartedAt = &now
+	})
+}
+
+// AppendResult records one item outcome and advances the progress counters
+func (r *bulkJobRepository) AppendResult(ctx context.Context, id string, item models.BulkItemResult) error {
+	return r.update(id, func(job *models.BulkJob) {
+		job.Results = append(job.Results, item)
+		job.Processed++
+		if item.Success {
+			job.Succeeded++
+		} else {
+			job.Failed++
+		}
+	})
+}
+
+func (r *bulkJobRepository) Complete(ctx context.Context, id, status, errMessage string) error {
+	now := time.Now()
+	return r.update(id, func(job *models.BulkJob) {
+		job.Status = status
+		job.CompletedAt = &now
+		if errMessage != "" {
+			job.Error = errMessage
+		}
+	})
+}
@@ -0,0 +1,84 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"backend/models"
+	"backend/repositories/interfaces"
+	"backend/utils"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type deviceRepository struct {
+	mu      sync.RWMutex
+	devices []*models.Device
+}
+
+func NewDeviceRepository() interfaces.DeviceRepository {
+	return &deviceRepository{}
+}
+
+func cloneDevice(device *models.Device) *models.Device {
+	clone := *device
+	return &clone
+}
+
+func (r *deviceRepository) Record(ctx context.Context, device *models.Device) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, stored := range r.devices {
+		if stored.UserID == device.UserID && stored.Fingerprint == device.Fingerprint {
+			stored.UserAgent = device.UserAgent
+			stored.IP = device.IP
+			stored.LastSeenAt = device.LastSeenAt
+			return false, nil
+		}
+	}
+
+	device.ID = primitive.NewObjectID()
+	device.FirstSeenAt = device.LastSeenAt
+	r.devices = append(r.devices, cloneDevice(device))
+	return true, nil
+}
+
+func (r *deviceRepository) ListByUser(ctx context.Context, userID string) ([]*models.Device, error) {
+	userObjectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, utils.ErrInvalidID
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var devices []*models.Device
+	for _, device := range r.devices {
+		if device.UserID == userObjectID {
+			devices = append(devices, cloneDevice(device))
+		}
+	}
+	sort.SliceStable(devices, func(i, j int) bool { return devices[i].LastSeenAt.After(devices[j].LastSeenAt) })
+	return devices, nil
+}
+
+func (r *deviceRepository) DeleteByUser(ctx context.Context, userID string) error {
+	userObjectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return utils.ErrInvalidID
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := r.devices[:0]
+	for _, device := range r.devices {
+		if device.UserID != userObjectID {
+			kept = append(kept, device)
+		}
+	}
+	r.devices = kept
+	return nil
+}
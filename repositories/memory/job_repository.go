@@ -0,0 +1,139 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"backend/models"
+	"backend/repositories/interfaces"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type lease struct {
+	owner     string
+	expiresAt time.Time
+}
+
+type jobRepository struct {
+	mu     sync.RWMutex
+	now    func() time.Time
+	leases map[string]lease
+	runs   []*models.JobRun
+}
+
+func NewJobRepository() interfaces.JobRepository {
+	return NewJobRepositoryWithClock(time.Now)
+}
+
+// NewJobRepositoryWithClock expires leases by the given clock, for tests that
+// move time forward
+func NewJobRepositoryWithClock(now func() time.Time) interfaces.JobRepository {
+	return &jobRepository{now: now, leases: make(map[string]lease)}
+}
+
+func cloneJobRun(run *models.JobRun) *models.JobRun {
+	clone := *run
+	return &clone
+}
+
+func (r *jobRepository) AcquireLease(ctx context.Context, name, owner string, ttl time.Duration) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	if current, ok := r.leases[name]; ok && current.owner != owner && !current.expiresAt.Before(now) {
+		return false, nil
+	}
+
+	r.leases[name] = lease{owner: owner, expiresAt: now.Add(ttl)}
+	return true, nil
+}
+
+func (r *jobRepository) ReleaseLease(ctx context.Context, name, owner string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if current, ok := r.leases[name]; ok && current.owner == owner {
+		delete(r.leases, name)
+	}
+	return nil
+}
+
+func (r *jobRepository) CreateRun(ctx context.Context, run *models.JobRun) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	run.ID = primitive.NewObjectID()
+	r.runs = append(r.runs, cloneJobRun(run))
+	return nil
+}
+
+// newestFirst sorts runs by start time, then by ID, descending
+func newestFirst(runs []*models.JobRun) {
+	sort.SliceStable(runs, func(i, j int) bool {
+		if !runs[i].StartedAt.Equal(runs[j].StartedAt) {
+			return runs[i].StartedAt.After(runs[j].StartedAt)
+		}
+		return runs[i].ID.Hex() > runs[j].ID.Hex()
+	})
+}
+
+func (r *jobRepository) GetRuns(ctx context.Context, job string, limit int64) ([]*models.JobRun, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var runs []*models.JobRun
+	for _, run := range r.runs {
+		if run.Job == job {
+			runs = append(runs, cloneJobRun(run))
+		}
+	}
+
+	newestFirst(runs)
+	if limit > 0 && int64(len(runs)) > limit {
+		runs = runs[:limit]
+	}
+	return runs, nil
+}
+
+func (r *jobRepository) GetLatestRuns(ctx context.Context) ([]*models.JobRun, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	all := make([]*models.JobRun, 0, len(r.runs))
+	for _, run := range r.runs {
+		all = append(all, cloneJobRun(run))
+	}
+	newestFirst(all)
+
+	var latest []*models.JobRun
+	seen := make(map[string]bool)
+	for _, run := range all {
+		if !seen[run.Job] {
+			seen[run.Job] = true
+			latest = append(latest, run)
+		}
+	}
+
+	sort.Slice(latest, func(i, j int) bool { return latest[i].Job < latest[j].Job })
+	return latest, nil
+}
+
+func (r *jobRepository) DeleteRunsBefore(ctx context.Context, before time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := r.runs[:0]
+	for _, run := range r.runs {
+		if !run.StartedAt.Before(before) {
+			kept = append(kept, run)
+		}
+	}
+
+	deleted := int64(len(r.runs) - len(kept))
+	r.runs = kept
+	return deleted, nil
+}
@@ -0,0 +1,29 @@
+// Package memory implements the repository interfaces in process memory.
+// It keeps the error semantics of the MongoDB repositories, including the
+// unique constraints on user emails, menu names and paths and role/menu
+// grants, so services behave the same on either backend. Data is lost when
+// the process exits; use it for local development and tests.
+package memory
+
+import "backend/repositories"
+
+// NewRepositories builds an empty in-memory data store
+func NewRepositories() *repositories.Repositories {
+	permissions := NewPermissionRepository()
+
+	return &repositories.Repositories{
+		Users:         NewUserRepository(),
+		Tokens:        NewTokenRepository(),
+		Permissions:   permissions,
+		Menus:         NewMenuRepository(permissions),
+		BulkJobs:      NewBulkJobRepository(),
+		Audit:         NewAuditRepository(),
+		Jobs:          NewJobRepository(),
+		Outbox:        NewOutboxRepository(),
+		Notifications: NewNotificationRepository(),
+		Devices:       NewDeviceRepository(),
+		Webhooks:      NewWebhookRepository(),
+		RateLimits:    NewRateLimitRepository(),
+		Transactor:    NewTransactor(),
+	}
+}
@@ -0,0 +1,15 @@
+package memory_test
+
+import (
+	"testing"
+
+	"backend/repositories"
+	"backend/repositories/conformance"
+	"backend/repositories/memory"
+)
+
+func TestConformance(t *testing.T) {
+	conformance.Run(t, func(t *testing.T) *repositories.Repositories {
+		return memory.NewRepositories()
+	})
+}
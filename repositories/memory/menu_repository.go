@@ -0,0 +1,186 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"backend/models"
+	"backend/repositories/interfaces"
+	"backend/utils"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type menuRepository struct {
+	mu       sync.RWMutex
+	menus    []*models.Menu
+	permRepo interfaces.PermissionRepository
+}
+
+func NewMenuRepository(permRepo interfaces.PermissionRepository) interfaces.MenuRepository {
+	return &menuRepository{
+		permRepo: permRepo,
+	}
+}
+
+// conflicts mirrors the unique name and path indexes; callers must hold the lock
+func (r *menuRepository) conflicts(menu *models.Menu, except primitive.ObjectID) bool {
+	for _, existing := range r.menus {
+		if existing.ID != except && (existing.Name == menu.Name || existing.Path == menu.Path) {
+			return true
+		}
+	}
+	return false
+}
+
+// filter returns copies of the menus matching match, in insertion order
+func (r *menuRepository) filter(match func(menu *models.Menu) bool) []*models.Menu {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var menus []*models.Menu
+	for _, menu := range r.menus {
+		if match(menu) {
+			clone := *menu
+			menus = append(menus, &clone)
+		}
+	}
+	return menus
+}
+
+func sortByOrder(menus []*models.Menu) []*models.Menu {
+	sort.SliceStable(menus, func(i, j int) bool { return menus[i].Order < menus[j].Order })
+	return menus
+}
+
+func (r *menuRepository) Create(ctx context.Context, menu *models.Menu) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	menu.ID = primitive.NewObjectID()
+	menu.CreatedAt = time.Now()
+	menu.UpdatedAt = time.Now()
+
+	if r.conflicts(menu, menu.ID) {
+		return utils.ErrMenuAlreadyExists
+	}
+
+	clone := *menu
+	r.menus = append(r.menus, &clone)
+	return nil
+}
+
+func (r *menuRepository) GetAll(ctx context.Context) ([]*models.Menu, error) {
+	return r.filter(func(*models.Menu) bool { return true }), nil
+}
+
+func (r *menuRepository) GetByID(ctx context.Context, id string) (*models.Menu, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, utils.ErrInvalidID
+	}
+
+	menus := r.filter(func(menu *models.Menu) bool { return menu.ID == objectID })
+	if len(menus) == 0 {
+		return nil, utils.ErrMenuNotFound
+	}
+	return menus[0], nil
+}
+
+func (r *menuRepository) GetActiveMenus(ctx context.Context) ([]*models.Menu, error) {
+	return sortByOrder(r.filter(func(menu *models.Menu) bool { return menu.IsActive })), nil
+}
+
+func (r *menuRepository) Update(ctx context.Context, id string, menu *models.Menu) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return utils.ErrInvalidID
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	menu.UpdatedAt = time.Now()
+
+	for i, existing := range r.menus {
+		if existing.ID != objectID {
+			continue
+		}
+		if r.conflicts(menu, objectID) {
+			return utils.ErrMenuAlreadyExists
+		}
+
+		clone := *menu
+		clone.ID = objectID
+		r.menus[i] = &clone
+		return nil
+	}
+	return utils.ErrMenuNotFound
+}
+
+func (r *menuRepository) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return utils.ErrInvalidID
+	}
+
+	// First, revoke all permissions for this menu
+	if err := r.permRepo.RevokeAllPermissionsForMenu(ctx, id); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, existing := range r.menus {
+		if existing.ID == objectID {
+			r.menus = append(r.menus[:i], r.menus[i+1:]...)
+			return nil
+		}
+	}
+	return utils.ErrMenuNotFound
+}
+
+func (r *menuRepository) GetMenusOrderedByOrder(ctx context.Context) ([]*models.Menu, error) {
+	return sortByOrder(r.filter(func(*models.Menu) bool { return true })), nil
+}
+
+func (r *menuRepository) GetMenusByRole(ctx context.Context, role string) ([]*models.Menu, error) {
+	// Admin has access to all active menus
+	if role == "admin" {
+		return r.GetActiveMenus(ctx)
+	}
+
+	permissions, err := r.permRepo.GetPermissionsByRole(ctx, role)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(permissions) == 0 {
+		return []*models.Menu{}, nil
+	}
+
+	granted := map[primitive.ObjectID]bool{}
+	for _, perm := range permissions {
+		granted[perm.MenuID] = true
+	}
+
+	return sortByOrder(r.filter(func(menu *models.Menu) bool { return menu.IsActive && granted[menu.ID] })), nil
+}
+
+// GetMenusWithoutGrants returns menus that no role has an explicit permission for
+func (r *menuRepository) GetMenusWithoutGrants(ctx context.Context) ([]*models.Menu, error) {
+	permissions, err := r.permRepo.GetAllPermissions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	granted := map[primitive.ObjectID]bool{}
+	for _, perm := range permissions {
+		granted[perm.MenuID] = true
+	}
+
+	return sortByOrder(r.filter(func(menu *models.Menu) bool { return !granted[menu.ID] })), nil
+}
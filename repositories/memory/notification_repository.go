@@ -0,0 +1,220 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"backend/models"
+	"backend/repositories/interfaces"
+	"backend/utils"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type notificationRepository struct {
+	mu            sync.RWMutex
+	notifications []*models.Notification
+	// user ID -> event type -> channels
+	preferences map[primitive.ObjectID]map[string]models.NotificationChannels
+}
+
+func NewNotificationRepository() interfaces.NotificationRepository {
+	return &notificationRepository{
+		preferences: map[primitive.ObjectID]map[string]models.NotificationChannels{},
+	}
+}
+
+func cloneNotification(notification *models.Notification) *models.Notification {
+	clone := *notification
+	if notification.Data != nil {
+		clone.Data = make(map[string]string, len(notification.Data))
+		for key, value := range notification.Data {
+			clone.Data[key] = value
+		}
+	}
+	if notification.ReadAt != nil {
+		readAt := *notification.ReadAt
+		clone.ReadAt = &readAt
+	}
+	return &clone
+}
+
+func (r *notificationRepository) Create(ctx context.Context, notification *models.Notification) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	notification.ID = primitive.NewObjectID()
+	if notification.CreatedAt.IsZero() {
+		notification.CreatedAt = time.Now()
+	}
+
+	r.notifications = append(r.notifications, cloneNotification(notification))
+	return nil
+}
+
+func (r *notificationRepository) List(ctx context.Context, userID string, unreadOnly bool, limit int64) ([]*models.Notification, error) {
+	userObjectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, utils.ErrInvalidID
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var notifications []*models.Notification
+	for _, notification := range r.notifications {
+		if notification.UserID == userObjectID && (!unreadOnly || notification.ReadAt == nil) {
+			notifications = append(notifications, cloneNotification(notification))
+		}
+	}
+
+	sort.SliceStable(notifications, func(i, j int) bool {
+		if !notifications[i].CreatedAt.Equal(notifications[j].CreatedAt) {
+			return notifications[i].CreatedAt.After(notifications[j].CreatedAt)
+		}
+		return notifications[i].ID.Hex() > notifications[j].ID.Hex()
+	})
+	if limit > 0 && int64(len(notifications)) > limit {
+		notifications = notifications[:limit]
+	}
+	return notifications, nil
+}
+
+func (r *notificationRepository) CountUnread(ctx context.Context, userID string) (int64, error) {
+	userObjectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return 0, utils.ErrInvalidID
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var count int64
+	for _, notification := range r.notifications {
+		if notification.UserID == userObjectID && notification.ReadAt == nil {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *notificationRepository) MarkRead(ctx context.Context, userID, id string) error {
+	userObjectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return utils.ErrInvalidID
+	}
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return utils.ErrInvalidID
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, notification := range r.notifications {
+		if notification.ID == objectID && notification.UserID == userObjectID {
+			if notification.ReadAt == nil {
+				now := time.Now()
+				notification.ReadAt = &now
+			}
+			return nil
+		}
+	}
+	return utils.ErrNotificationNotFound
+}
+
+func (r *notificationRepository) MarkAllRead(ctx context.Context, userID string) (int64, error) {
+	userObjectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return 0, utils.ErrInvalidID
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	var marked int64
+	for _, notification := range r.notifications {
+		if notification.UserID == userObjectID && notification.ReadAt == nil {
+			readAt := now
+			notification.ReadAt = &readAt
+			marked++
+		}
+	}
+	return marked, nil
+}
+
+func (r *notificationRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := r.notifications[:0]
+	for _, notification := range r.notifications {
+		if !notification.CreatedAt.Before(before) {
+			kept = append(kept, notification)
+		}
+	}
+
+	deleted := int64(len(r.notifications) - len(kept))
+	r.notifications = kept
+	return deleted, nil
+}
+
+func (r *notificationRepository) GetPreferences(ctx context.Context, userID string) ([]models.NotificationPreference, error) {
+	userObjectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, utils.ErrInvalidID
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var preferences []models.NotificationPreference
+	for eventType, channels := range r.preferences[userObjectID] {
+		preferences = append(preferences, models.NotificationPreference{Type: eventType, NotificationChannels: channels})
+	}
+	sort.Slice(preferences, func(i, j int) bool { return preferences[i].Type < preferences[j].Type })
+	return preferences, nil
+}
+
+func (r *notificationRepository) SavePreferences(ctx context.Context, userID string, preferences []models.NotificationPreference) error {
+	userObjectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return utils.ErrInvalidID
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored := r.preferences[userObjectID]
+	if stored == nil {
+		stored = map[string]models.NotificationChannels{}
+		r.preferences[userObjectID] = stored
+	}
+	for _, preference := range preferences {
+		stored[preference.Type] = preference.NotificationChannels
+	}
+	return nil
+}
+
+func (r *notificationRepository) DeleteByUser(ctx context.Context, userID string) error {
+	userObjectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return utils.ErrInvalidID
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := r.notifications[:0]
+	for _, notification := range r.notifications {
+		if notification.UserID != userObjectID {
+			kept = append(kept, notification)
+		}
+	}
+	r.notifications = kept
+	delete(r.preferences, userObjectID)
+	return nil
+}
@@ -0,0 +1,235 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"backend/models"
+	"backend/repositories/interfaces"
+	"backend/utils"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type outboxRepository struct {
+	mu       sync.RWMutex
+	messages []*models.OutboxMessage
+}
+
+func NewOutboxRepository() interfaces.OutboxRepository {
+	return &outboxRepository{}
+}
+
+func cloneOutboxMessage(message *models.OutboxMessage) *models.OutboxMessage {
+	clone := *message
+	return &clone
+}
+
+// update applies fn to the message with the given ID
+func (r *outboxRepository) update(id string, fn func(message *models.OutboxMessage)) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return utils.ErrInvalidID
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, message := range r.messages {
+		if message.ID == objectID {
+			fn(message)
+			return nil
+		}
+	}
+	return utils.ErrOutboxMessageNotFound
+}
+
+func (r *outboxRepository) Create(ctx context.Context, message *models.OutboxMessage) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	message.ID = primitive.NewObjectID()
+	message.CreatedAt = time.Now()
+	message.NextAttemptAt = message.CreatedAt
+	message.Status = models.OutboxStatusPending
+
+	r.messages = append(r.messages, cloneOutboxMessage(message))
+	return nil
+}
+
+func (r *outboxRepository) GetByID(ctx context.Context, id string) (*models.OutboxMessage, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, utils.ErrInvalidID
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, message := range r.messages {
+		if message.ID == objectID {
+			return cloneOutboxMessage(message), nil
+		}
+	}
+	return nil, utils.ErrOutboxMessageNotFound
+}
+
+func (r *outboxRepository) List(ctx context.Context, status string, limit int64) ([]*models.OutboxMessage, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var messages []*models.OutboxMessage
+	for _, message := range r.messages {
+		if status == "" || message.Status == status {
+			clone := cloneOutboxMessage(message)
+			clone.TextBody, clone.HTMLBody = "", ""
+			messages = append(messages, clone)
+		}
+	}
+
+	sort.SliceStable(messages, func(i, j int) bool {
+		if !messages[i].CreatedAt.Equal(messages[j].CreatedAt) {
+			return messages[i].CreatedAt.After(messages[j].CreatedAt)
+		}
+		return messages[i].ID.Hex() > messages[j].ID.Hex()
+	})
+	if limit > 0 && int64(len(messages)) > limit {
+		messages = messages[:limit]
+	}
+	return messages, nil
+}
+
+func (r *outboxRepository) Summary(ctx context.Context) (*models.OutboxSummary, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	summary := &models.OutboxSummary{}
+	for _, message := range r.messages {
+		switch message.Status {
+		case models.OutboxStatusPending:
+			summary.Pending++
+			if summary.OldestPendingAt == nil || message.CreatedAt.Before(*summary.OldestPendingAt) {
+				createdAt := message.CreatedAt
+				summary.OldestPendingAt = &createdAt
+			}
+		case models.OutboxStatusSent:
+			summary.Sent++
+		case models.OutboxStatusDead:
+			summary.Dead++
+		}
+	}
+	return summary, nil
+}
+
+func (r *outboxRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.OutboxMessage, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var due []*models.OutboxMessage
+	for _, message := range r.messages {
+		if message.Status == models.OutboxStatusPending && !message.NextAttemptAt.After(now) {
+			due = append(due, message)
+		}
+	}
+	sort.SliceStable(due, func(i, j int) bool { return due[i].NextAttemptAt.Before(due[j].NextAttemptAt) })
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	claimed := make([]*models.OutboxMessage, 0, len(due))
+	for _, message := range due {
+		attemptAt := now
+		message.Attempts++
+		message.LastAttemptAt = &attemptAt
+		message.NextAttemptAt = now.Add(lease)
+		claimed = append(claimed, cloneOutboxMessage(message))
+	}
+	return claimed, nil
+}
+
+func (r *outboxRepository) MarkSent(ctx context.Context, claim *models.OutboxMessage) error {
+	return r.updateClaim(claim, func(message *models.OutboxMessage) {
+		now := time.Now()
+		message.Status = models.OutboxStatusSent
+		message.SentAt = &now
+		message.TextBody, message.HTMLBody = "", ""
+		message.LastError = ""
+	})
+}
+
+func (r *outboxRepository) MarkFailed(ctx context.Context, claim *models.OutboxMessage, errMessage string, retryAt *time.Time) error {
+	return r.updateClaim(claim, func(message *models.OutboxMessage) {
+		message.LastError = errMessage
+		if retryAt != nil {
+			message.NextAttemptAt = *retryAt
+		} else {
+			message.Status = models.OutboxStatusDead
+		}
+	})
+}
+
+// updateClaim applies fn to a message while the claim still holds
+func (r *outboxRepository) updateClaim(claim *models.OutboxMessage, fn func(message *models.OutboxMessage)) error {
+	held := true
+	err := r.update(claim.ID.Hex(), func(message *models.OutboxMessage) {
+		held = message.Status == models.OutboxStatusPending && message.Attempts == claim.Attempts &&
+			sameTime(message.LastAttemptAt, claim.LastAttemptAt)
+		if held {
+			fn(message)
+		}
+	})
+	if err == nil && !held {
+		return utils.ErrOutboxClaimLost
+	}
+	return err
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+func (r *outboxRepository) Requeue(ctx context.Context, id string) error {
+	return r.update(id, func(message *models.OutboxMessage) {
+		message.Status = models.OutboxStatusPending
+		message.Attempts = 0
+		message.NextAttemptAt = time.Now()
+		message.LastError = ""
+	})
+}
+
+func (r *outboxRepository) DeleteFinishedBefore(ctx context.Context, before time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := r.messages[:0]
+	for _, message := range r.messages {
+		sent := message.Status == models.OutboxStatusSent && message.SentAt != nil && message.SentAt.Before(before)
+		dead := message.Status == models.OutboxStatusDead && message.LastAttemptAt != nil && message.LastAttemptAt.Before(before)
+		if !sent && !dead {
+			kept = append(kept, message)
+		}
+	}
+
+	deleted := int64(len(r.messages) - len(kept))
+	r.messages = kept
+	return deleted, nil
+}
+
+func (r *outboxRepository) DeleteByRecipient(ctx context.Context, email string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := r.messages[:0]
+	for _, message := range r.messages {
+		if message.Recipient != email {
+			kept = append(kept, message)
+		}
+	}
+	r.messages = kept
+	return nil
+}
@@ -0,0 +1,148 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"backend/models"
+	"backend/repositories/interfaces"
+	"backend/utils"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type permissionRepository struct {
+	mu          sync.RWMutex
+	permissions []*models.RoleMenuPermission
+}
+
+func NewPermissionRepository() interfaces.PermissionRepository {
+	return &permissionRepository{}
+}
+
+// filter returns copies of the permissions matching match, in grant order
+func (r *permissionRepository) filter(match func(permission *models.RoleMenuPermission) bool) []*models.RoleMenuPermission {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var permissions []*models.RoleMenuPermission
+	for _, permission := range r.permissions {
+		if match(permission) {
+			clone := *permission
+			permissions = append(permissions, &clone)
+		}
+	}
+	return permissions
+}
+
+// remove deletes the permissions matching match and reports how many were removed
+func (r *permissionRepository) remove(match func(permission *models.RoleMenuPermission) bool) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := r.permissions[:0]
+	for _, permission := range r.permissions {
+		if !match(permission) {
+			kept = append(kept, permission)
+		}
+	}
+	removed := len(r.permissions) - len(kept)
+	r.permissions = kept
+	return removed
+}
+
+func (r *permissionRepository) GrantPermission(ctx context.Context, permission *models.RoleMenuPermission) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.permissions {
+		if existing.Role == permission.Role && existing.MenuID == permission.MenuID {
+			return utils.ErrPermissionAlreadyExists
+		}
+	}
+
+	permission.ID = primitive.NewObjectID()
+	permission.CreatedAt = time.Now()
+
+	clone := *permission
+	r.permissions = append(r.permissions, &clone)
+	return nil
+}
+
+func (r *permissionRepository) RevokePermission(ctx context.Context, role, menuID string) error {
+	menuObjectID, err := primitive.ObjectIDFromHex(menuID)
+	if err != nil {
+		return utils.ErrInvalidID
+	}
+
+	removed := r.remove(func(permission *models.RoleMenuPermission) bool {
+		return permission.Role == role && permission.MenuID == menuObjectID
+	})
+	if removed == 0 {
+		return utils.ErrPermissionNotFound
+	}
+	return nil
+}
+
+func (r *permissionRepository) GetPermissionsByRole(ctx context.Context, role string) ([]*models.RoleMenuPermission, error) {
+	return r.filter(func(permission *models.RoleMenuPermission) bool { return permission.Role == role }), nil
+}
+
+func (r *permissionRepository) GetRolesByMenu(ctx context.Context, menuID string) ([]*models.RoleMenuPermission, error) {
+	menuObjectID, err := primitive.ObjectIDFromHex(menuID)
+	if err != nil {
+		return nil, utils.ErrInvalidID
+	}
+
+	return r.filter(func(permission *models.RoleMenuPermission) bool { return permission.MenuID == menuObjectID }), nil
+}
+
+func (r *permissionRepository) GetAllPermissions(ctx context.Context) ([]*models.RoleMenuPermission, error) {
+	return r.filter(func(*models.RoleMenuPermission) bool { return true }), nil
+}
+
+func (r *permissionRepository) CheckPermission(ctx context.Context, role, menuID string) (bool, error) {
+	menuObjectID, err := primitive.ObjectIDFromHex(menuID)
+	if err != nil {
+		return false, utils.ErrInvalidID
+	}
+
+	matches := r.filter(func(permission *models.RoleMenuPermission) bool {
+		return permission.Role == role && permission.MenuID == menuObjectID
+	})
+	return len(matches) > 0, nil
+}
+
+func (r *permissionRepository) RevokeAllPermissionsForMenu(ctx context.Context, menuID string) error {
+	menuObjectID, err := primitive.ObjectIDFromHex(menuID)
+	if err != nil {
+		return utils.ErrInvalidID
+	}
+
+	r.remove(func(permission *models.RoleMenuPermission) bool { return permission.MenuID == menuObjectID })
+	return nil
+}
+
+func (r *permissionRepository) RevokeAllPermissionsForRole(ctx context.Context, role string) error {
+	r.remove(func(permission *models.RoleMenuPermission) bool { return permission.Role == role })
+	return nil
+}
+
+// PseudonymizeGrantor replaces the stored name of the admin who granted permissions
+func (r *permissionRepository) PseudonymizeGrantor(ctx context.Context, grantedByID, pseudonym string) error {
+	grantorObjectID, err := primitive.ObjectIDFromHex(grantedByID)
+	if err != nil {
+		return utils.ErrInvalidID
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, permission := range r.permissions {
+		if permission.GrantedByID == grantorObjectID {
+			permission.GrantedByName = pseudonym
+		}
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package memory
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"backend/repositories/interfaces"
+)
+
+// rateLimitSweepInterval is how often expired counters and buckets are
+// dropped, as nothing else removes them from memory
+const rateLimitSweepInterval = time.Minute
+
+type rateLimitEntry struct {
+	count     int64
+	tokens    float64
+	updatedAt time.Time
+	expiresAt time.Time
+}
+
+type rateLimitRepository struct {
+	mu        sync.Mutex
+	entries   map[string]*rateLimitEntry
+	lastSweep time.Time
+}
+
+func NewRateLimitRepository() interfaces.RateLimitRepository {
+	return &rateLimitRepository{entries: make(map[string]*rateLimitEntry)}
+}
+
+// sweep drops expired entries; the caller holds r.mu
+func (r *rateLimitRepository) sweep(now time.Time) {
+	if now.Sub(r.lastSweep) < rateLimitSweepInterval {
+		return
+	}
+	r.lastSweep = now
+
+	for key, entry := range r.entries {
+		if !entry.expiresAt.After(now) {
+			delete(r.entries, key)
+		}
+	}
+}
+
+func (r *rateLimitRepository) Increment(ctx context.Context, key string, expiresAt time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sweep(time.Now())
+
+	entry, ok := r.entries[key]
+	if !ok {
+		entry = &rateLimitEntry{expiresAt: expiresAt}
+		r.entries[key] = entry
+	}
+	entry.count++
+	return entry.count, nil
+}
+
+func (r *rateLimitRepository) Decrement(ctx context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if entry, ok := r.entries[key]; ok && entry.count > 0 {
+		entry.count--
+	}
+	return nil
+}
+
+func (r *rateLimitRepository) Count(ctx context.Context, key string) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry, ok := r.entries[key]
+	if !ok || !entry.expiresAt.After(time.Now()) {
+		return 0, nil
+	}
+	return entry.count, nil
+}
+
+func (r *rateLimitRepository) TakeToken(ctx context.Context, key string, capacity, rate float64, now time.Time) (bool, float64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sweep(now)
+
+	entry, ok := r.entries[key]
+	if !ok {
+		entry = &rateLimitEntry{tokens: capacity, updatedAt: now}
+		r.entries[key] = entry
+	}
+
+	elapsed := math.Max(now.Sub(entry.updatedAt).Seconds(), 0)
+	entry.tokens = math.Min(capacity, entry.tokens+elapsed*rate)
+	allowed := entry.tokens >= 1
+	if allowed {
+		entry.tokens--
+	}
+	entry.updatedAt = now
+	entry.expiresAt = now.Add(time.Duration(capacity / rate * float64(time.Second)))
+	return allowed, entry.tokens, nil
+}
+
+func (r *rateLimitRepository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var deleted int64
+	for key, entry := range r.entries {
+		if entry.expiresAt.Before(before) {
+			delete(r.entries, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
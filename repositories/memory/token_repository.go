@@ -0,0 +1,130 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"backend/models"
+	"backend/repositories/interfaces"
+	"backend/utils"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type tokenRepository struct {
+	mu     sync.RWMutex
+	tokens []*models.RefreshToken
+}
+
+func NewTokenRepository() interfaces.TokenRepository {
+	return &tokenRepository{}
+}
+
+func (r *tokenRepository) Create(ctx context.Context, token *models.RefreshToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	token.ID = primitive.NewObjectID()
+	token.CreatedAt = time.Now()
+	token.IsRevoked = false
+
+	clone := *token
+	r.tokens = append(r.tokens, &clone)
+	return nil
+}
+
+func (r *tokenRepository) GetByToken(ctx context.Context, token string) (*models.RefreshToken, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, stored := range r.tokens {
+		if stored.Token == token {
+			clone := *stored
+			return &clone, nil
+		}
+	}
+	return nil, utils.ErrTokenNotFound
+}
+
+func (r *tokenRepository) GetByUserID(ctx context.Context, userID string) ([]models.RefreshToken, error) {
+	objectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	now := time.Now()
+	tokens := []models.RefreshToken{}
+	for _, stored := range r.tokens {
+		if stored.UserID == objectID && !stored.IsRevoked && stored.ExpiresAt.After(now) {
+			tokens = append(tokens, *stored)
+		}
+	}
+	return tokens, nil
+}
+
+func (r *tokenRepository) RevokeToken(ctx context.Context, token string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, stored := range r.tokens {
+		if stored.Token == token {
+			if stored.IsRevoked {
+				return utils.ErrTokenRevoked
+			}
+			stored.IsRevoked = true
+			return nil
+		}
+	}
+	return utils.ErrTokenNotFound
+}
+
+func (r *tokenRepository) RevokeAllUserTokens(ctx context.Context, userID string) error {
+	objectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, stored := range r.tokens {
+		if stored.UserID == objectID {
+			stored.IsRevoked = true
+		}
+	}
+	return nil
+}
+
+func (r *tokenRepository) DeleteExpiredTokens(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	kept := r.tokens[:0]
+	for _, stored := range r.tokens {
+		if !stored.IsRevoked && !stored.ExpiresAt.Before(now) {
+			kept = append(kept, stored)
+		}
+	}
+	r.tokens = kept
+	return nil
+}
+
+// CountActive counts refresh tokens that are neither revoked nor expired
+func (r *tokenRepository) CountActive(ctx context.Context) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	now := time.Now()
+	var count int64
+	for _, stored := range r.tokens {
+		if !stored.IsRevoked && stored.ExpiresAt.After(now) {
+			count++
+		}
+	}
+	return count, nil
+}
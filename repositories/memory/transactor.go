@@ -0,0 +1,24 @@
+package memory
+
+import (
+	"context"
+
+	"backend/repositories/interfaces"
+)
+
+// transactor runs units of work directly. Every repository is safe for
+// concurrent use, but a unit of work that fails halfway is not rolled back.
+type transactor struct{}
+
+func NewTransactor() interfaces.Transactor {
+	return transactor{}
+}
+
+func (transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// RollsBack reports that a failed unit of work keeps its writes
+func (transactor) RollsBack() bool {
+	return false
+}
@@ -0,0 +1,388 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"backend/models"
+	"backend/repositories/interfaces"
+	"backend/utils"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type userRepository struct {
+	mu    sync.RWMutex
+	users []*models.User
+}
+
+func NewUserRepository() interfaces.UserRepository {
+	return &userRepository{}
+}
+
+func cloneUser(user *models.User) *models.User {
+	clone := *user
+	return &clone
+}
+
+// find returns the stored user with the given hex ID; callers must hold the lock
+func (r *userRepository) find(id string) *models.User {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil
+	}
+	for _, user := range r.users {
+		if user.ID == objectID {
+			return user
+		}
+	}
+	return nil
+}
+
+// emailTaken mirrors the unique email index; callers must hold the lock
+func (r *userRepository) emailTaken(email string, except primitive.ObjectID) bool {
+	for _, user := range r.users {
+		if user.Email == email && user.ID != except {
+			return true
+		}
+	}
+	return false
+}
+
+// update applies fn to the user with the given ID, mapping a bad or unknown ID to ErrUserNotFound
+func (r *userRepository) update(id string, fn func(user *models.User)) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user := r.find(id)
+	if user == nil {
+		return utils.ErrUserNotFound
+	}
+	fn(user)
+	return nil
+}
+
+func (r *userRepository) Create(ctx context.Context, user *models.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user.ID = primitive.NewObjectID()
+	user.CreatedAt = time.Now()
+	user.UpdatedAt = time.Now()
+
+	if r.emailTaken(user.Email, user.ID) {
+		return utils.ErrUserAlreadyExists
+	}
+
+	r.users = append(r.users, cloneUser(user))
+	return nil
+}
+
+func (r *userRepository) GetByID(ctx context.Context, id string) (*models.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	user := r.find(id)
+	if user == nil {
+		return nil, utils.ErrUserNotFound
+	}
+	return cloneUser(user), nil
+}
+
+func (r *userRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, user := range r.users {
+		if user.Email == email {
+			return cloneUser(user), nil
+		}
+	}
+	return nil, utils.ErrUserNotFound
+}
+
+func (r *userRepository) Update(ctx context.Context, user *models.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user.UpdatedAt = time.Now()
+
+	stored := r.find(user.ID.Hex())
+	if stored == nil {
+		return utils.ErrUserNotFound
+	}
+	if r.emailTaken(user.Email, user.ID) {
+		return utils.ErrUserAlreadyExists
+	}
+
+	stored.Name = user.Name
+	stored.Email = user.Email
+	stored.Role = user.Role
+	stored.Locale = user.Locale
+	stored.IsVerified = user.IsVerified
+	stored.VerifiedAt = user.VerifiedAt
+	stored.VerifiedBy = user.VerifiedBy
+	stored.VerificationNotes = user.VerificationNotes
+	stored.UpdatedAt = user.UpdatedAt
+	return nil
+}
+
+func (r *userRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user := r.find(id)
+	if user == nil {
+		return utils.ErrUserNotFound
+	}
+
+	for i, candidate := range r.users {
+		if candidate == user {
+			r.users = append(r.users[:i], r.users[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// filter returns copies of the users matching match, in insertion order
+func (r *userRepository) filter(match func(user *models.User) bool) []*models.User {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var users []*models.User
+	for _, user := range r.users {
+		if match(user) {
+			users = append(users, cloneUser(user))
+		}
+	}
+	return users
+}
+
+func (r *userRepository) GetPendingUsers(ctx context.Context) ([]*models.User, error) {
+	return r.filter(func(user *models.User) bool { return !user.IsVerified }), nil
+}
+
+func (r *userRepository) VerifyUser(ctx context.Context, userID, adminID string, notes string) error {
+	if _, err := primitive.ObjectIDFromHex(userID); err != nil {
+		return err
+	}
+	adminObjectID, err := primitive.ObjectIDFromHex(adminID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	return r.update(userID, func(user *models.User) {
+		user.IsVerified = true
+		user.VerifiedAt = &now
+		user.VerifiedBy = &adminObjectID
+		user.VerificationNotes = notes
+		user.UpdatedAt = now
+	})
+}
+
+// UpdatePassword updates only the user's password
+func (r *userRepository) UpdatePassword(ctx context.Context, userID, hashedPassword string) error {
+	if _, err := primitive.ObjectIDFromHex(userID); err != nil {
+		return err
+	}
+
+	return r.update(userID, func(user *models.User) {
+		user.Password = hashedPassword
+		user.UpdatedAt = time.Now()
+	})
+}
+
+// UpdatePasswordResetInfo updates password reset tracking information
+func (r *userRepository) UpdatePasswordResetInfo(ctx context.Context, userID string) error {
+	if _, err := primitive.ObjectIDFromHex(userID); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	return r.update(userID, func(user *models.User) {
+		user.LastPasswordReset = &now
+		user.UpdatedAt = now
+		user.PasswordResetCount++
+	})
+}
+
+// CountUsersByRole counts the number of users with a specific role
+func (r *userRepository) CountUsersByRole(ctx context.Context, role string) (int64, error) {
+	users := r.filter(func(user *models.User) bool { return user.Role == role })
+	return int64(len(users)), nil
+}
+
+// CountActiveUsersByRole counts users with a specific role that are neither suspended nor erased
+func (r *userRepository) CountActiveUsersByRole(ctx context.Context, role string) (int64, error) {
+	users := r.filter(func(user *models.User) bool { return user.Role == role && !user.IsSuspended && user.ErasedAt == nil })
+	return int64(len(users)), nil
+}
+
+// FindByFilter returns users matching the filter, oldest first. A limit of 0 means no limit.
+func (r *userRepository) FindByFilter(ctx context.Context, filter *models.UserFilter, limit int64) ([]*models.User, error) {
+	users := r.filter(func(user *models.User) bool {
+		if filter == nil {
+			return true
+		}
+		if filter.Role != "" && user.Role != filter.Role {
+			return false
+		}
+		if filter.IsVerified != nil && user.IsVerified != *filter.IsVerified {
+			return false
+		}
+		if filter.IsSuspended != nil && user.IsSuspended != *filter.IsSuspended {
+			return false
+		}
+		if filter.CreatedAfter != nil && user.CreatedAt.Before(*filter.CreatedAfter) {
+			return false
+		}
+		if filter.CreatedBefore != nil && user.CreatedAt.After(*filter.CreatedBefore) {
+			return false
+		}
+		return true
+	})
+
+	sort.SliceStable(users, func(i, j int) bool { return users[i].CreatedAt.Before(users[j].CreatedAt) })
+	if limit > 0 && int64(len(users)) > limit {
+		users = users[:limit]
+	}
+	return users, nil
+}
+
+// SuspendUser marks a user as suspended by the given admin
+func (r *userRepository) SuspendUser(ctx context.Context, userID, adminID string, reason string) error {
+	if _, err := primitive.ObjectIDFromHex(userID); err != nil {
+		return utils.ErrUserNotFound
+	}
+	adminObjectID, err := primitive.ObjectIDFromHex(adminID)
+	if err != nil {
+		return utils.ErrInvalidID
+	}
+
+	now := time.Now()
+	return r.update(userID, func(user *models.User) {
+		user.IsSuspended = true
+		user.SuspendedAt = &now
+		user.SuspendedBy = &adminObjectID
+		user.SuspensionReason = reason
+		user.UpdatedAt = now
+	})
+}
+
+// ReinstateUser lifts a user's suspension
+func (r *userRepository) ReinstateUser(ctx context.Context, userID string) error {
+	return r.update(userID, func(user *models.User) {
+		user.IsSuspended = false
+		user.UpdatedAt = time.Now()
+		user.SuspendedAt = nil
+		user.SuspendedBy = nil
+		user.SuspensionReason = ""
+	})
+}
+
+// UpdateLastLogin records the time of the user's latest successful login
+func (r *userRepository) UpdateLastLogin(ctx context.Context, userID string) error {
+	now := time.Now()
+	return r.update(userID, func(user *models.User) {
+		user.LastLoginAt = &now
+	})
+}
+
+// StreamAll walks every user ordered by email.
+// Iteration stops at the first error returned by fn.
+func (r *userRepository) StreamAll(ctx context.Context, fn func(user *models.User) error) error {
+	users := r.filter(func(*models.User) bool { return true })
+	sort.SliceStable(users, func(i, j int) bool { return users[i].Email < users[j].Email })
+
+	for _, user := range users {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(user); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Anonymize replaces personal data in place so references to the user stay valid
+func (r *userRepository) Anonymize(ctx context.Context, userID, name, email, hashedPassword string) error {
+	now := time.Now()
+	return r.update(userID, func(user *models.User) {
+		user.Name = name
+		user.Email = email
+		user.Password = hashedPassword
+		user.ErasedAt = &now
+		user.UpdatedAt = now
+		user.VerificationNotes = ""
+		user.SuspensionReason = ""
+		user.LastLoginAt = nil
+		user.LastPasswordReset = nil
+	})
+}
+
+// CountByRoleAndStatus returns per-role totals split by verification and suspension status.
+// Erased users are excluded.
+func (r *userRepository) CountByRoleAndStatus(ctx context.Context) ([]models.RoleStatusCount, error) {
+	byRole := map[string]*models.RoleStatusCount{}
+	for _, user := range r.filter(func(user *models.User) bool { return user.ErasedAt == nil }) {
+		count, ok := byRole[user.Role]
+		if !ok {
+			count = &models.RoleStatusCount{Role: user.Role}
+			byRole[user.Role] = count
+		}
+		count.Total++
+		if user.IsVerified {
+			count.Verified++
+		} else {
+			count.Pending++
+		}
+		if user.IsSuspended {
+			count.Suspended++
+		}
+	}
+
+	counts := []models.RoleStatusCount{}
+	for _, count := range byRole {
+		counts = append(counts, *count)
+	}
+	sort.Slice(counts, func(i, j int) bool { return counts[i].Role < counts[j].Role })
+	return counts, nil
+}
+
+// CountPendingByCreatedAt buckets unverified, non-suspended users by registration time.
+// Boundaries must be ascending; each result's Period is the lower boundary of its bucket
+// and users registered outside the boundaries are not counted.
+func (r *userRepository) CountPendingByCreatedAt(ctx context.Context, boundaries []time.Time) ([]models.PeriodCount, error) {
+	counts := []models.PeriodCount{}
+	if len(boundaries) < 2 {
+		return counts, nil
+	}
+
+	pending := r.filter(func(user *models.User) bool { return !user.IsVerified && !user.IsSuspended })
+	for i := 0; i < len(boundaries)-1; i++ {
+		var count int64
+		for _, user := range pending {
+			if !user.CreatedAt.Before(boundaries[i]) && user.CreatedAt.Before(boundaries[i+1]) {
+				count++
+			}
+		}
+		if count > 0 {
+			counts = append(counts, models.PeriodCount{Period: boundaries[i], Count: count})
+		}
+	}
+	return counts, nil
+}
+
+// CountRegistrationsByPeriod counts new users per time bucket
+func (r *userRepository) CountRegistrationsByPeriod(ctx context.Context, query *models.StatsQuery) ([]models.PeriodCount, error) {
+	var dates []time.Time
+	for _, user := range r.filter(func(*models.User) bool { return true }) {
+		dates = append(dates, user.CreatedAt)
+	}
+	return countByPeriod(dates, query), nil
+}
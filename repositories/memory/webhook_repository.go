@@ -0,0 +1,323 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"backend/models"
+	"backend/repositories/interfaces"
+	"backend/utils"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type webhookRepository struct {
+	mu         sync.RWMutex
+	webhooks   []*models.Webhook
+	deliveries []*models.WebhookDelivery
+}
+
+func NewWebhookRepository() interfaces.WebhookRepository {
+	return &webhookRepository{}
+}
+
+func cloneWebhook(webhook *models.Webhook) *models.Webhook {
+	clone := *webhook
+	clone.Events = append([]string(nil), webhook.Events...)
+	if webhook.DisabledAt != nil {
+		disabledAt := *webhook.DisabledAt
+		clone.DisabledAt = &disabledAt
+	}
+	return &clone
+}
+
+func cloneWebhookDelivery(delivery *models.WebhookDelivery) *models.WebhookDelivery {
+	clone := *delivery
+	return &clone
+}
+
+// update applies fn to the webhook with the given ID
+func (r *webhookRepository) update(id string, fn func(webhook *models.Webhook)) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return utils.ErrInvalidID
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, webhook := range r.webhooks {
+		if webhook.ID == objectID {
+			fn(webhook)
+			return nil
+		}
+	}
+	return utils.ErrWebhookNotFound
+}
+
+// updateDelivery applies fn to the delivery with the given ID
+func (r *webhookRepository) updateDelivery(id string, fn func(delivery *models.WebhookDelivery)) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return utils.ErrInvalidID
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, delivery := range r.deliveries {
+		if delivery.ID == objectID {
+			fn(delivery)
+			return nil
+		}
+	}
+	return utils.ErrWebhookDeliveryNotFound
+}
+
+func (r *webhookRepository) Create(ctx context.Context, webhook *models.Webhook) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	webhook.ID = primitive.NewObjectID()
+	webhook.CreatedAt = time.Now()
+	webhook.UpdatedAt = webhook.CreatedAt
+
+	r.webhooks = append(r.webhooks, cloneWebhook(webhook))
+	return nil
+}
+
+func (r *webhookRepository) GetByID(ctx context.Context, id string) (*models.Webhook, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, utils.ErrInvalidID
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, webhook := range r.webhooks {
+		if webhook.ID == objectID {
+			return cloneWebhook(webhook), nil
+		}
+	}
+	return nil, utils.ErrWebhookNotFound
+}
+
+func (r *webhookRepository) List(ctx context.Context) ([]*models.Webhook, error) {
+	return r.find(func(*models.Webhook) bool { return true }), nil
+}
+
+func (r *webhookRepository) ListSubscribed(ctx context.Context, eventType string) ([]*models.Webhook, error) {
+	return r.find(func(webhook *models.Webhook) bool {
+		return webhook.Enabled && webhook.Subscribes(eventType)
+	}), nil
+}
+
+func (r *webhookRepository) find(match func(webhook *models.Webhook) bool) []*models.Webhook {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var webhooks []*models.Webhook
+	for _, webhook := range r.webhooks {
+		if match(webhook) {
+			webhooks = append(webhooks, cloneWebhook(webhook))
+		}
+	}
+
+	sort.SliceStable(webhooks, func(i, j int) bool {
+		if !webhooks[i].CreatedAt.Equal(webhooks[j].CreatedAt) {
+			return webhooks[i].CreatedAt.After(webhooks[j].CreatedAt)
+		}
+		return webhooks[i].ID.Hex() > webhooks[j].ID.Hex()
+	})
+	return webhooks
+}
+
+func (r *webhookRepository) Update(ctx context.Context, webhook *models.Webhook) error {
+	webhook.UpdatedAt = time.Now()
+
+	return r.update(webhook.ID.Hex(), func(stored *models.Webhook) {
+		updated := cloneWebhook(webhook)
+		updated.CreatedBy = stored.CreatedBy
+		updated.CreatedAt = stored.CreatedAt
+		*stored = *updated
+	})
+}
+
+func (r *webhookRepository) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return utils.ErrInvalidID
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, webhook := range r.webhooks {
+		if webhook.ID == objectID {
+			r.webhooks = append(r.webhooks[:i], r.webhooks[i+1:]...)
+
+			kept := r.deliveries[:0]
+			for _, delivery := range r.deliveries {
+				if delivery.WebhookID != objectID {
+					kept = append(kept, delivery)
+				}
+			}
+			r.deliveries = kept
+			return nil
+		}
+	}
+	return utils.ErrWebhookNotFound
+}
+
+func (r *webhookRepository) RecordSuccess(ctx context.Context, id string) error {
+	return r.update(id, func(webhook *models.Webhook) {
+		webhook.ConsecutiveFailures = 0
+	})
+}
+
+func (r *webhookRepository) RecordFailure(ctx context.Context, id string, disableAfter int, reason string) (bool, error) {
+	var disabled bool
+	err := r.update(id, func(webhook *models.Webhook) {
+		webhook.ConsecutiveFailures++
+		if webhook.Enabled && webhook.ConsecutiveFailures >= disableAfter {
+			now := time.Now()
+			webhook.Enabled = false
+			webhook.DisabledAt = &now
+			webhook.DisabledReason = reason
+			disabled = true
+		}
+	})
+	return disabled, err
+}
+
+func (r *webhookRepository) CreateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delivery.ID = primitive.NewObjectID()
+	delivery.CreatedAt = time.Now()
+	delivery.NextAttemptAt = delivery.CreatedAt
+	delivery.Status = models.WebhookDeliveryPending
+
+	r.deliveries = append(r.deliveries, cloneWebhookDelivery(delivery))
+	return nil
+}
+
+func (r *webhookRepository) GetDelivery(ctx context.Context, id string) (*models.WebhookDelivery, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, utils.ErrInvalidID
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, delivery := range r.deliveries {
+		if delivery.ID == objectID {
+			return cloneWebhookDelivery(delivery), nil
+		}
+	}
+	return nil, utils.ErrWebhookDeliveryNotFound
+}
+
+func (r *webhookRepository) ListDeliveries(ctx context.Context, webhookID string, limit int64) ([]*models.WebhookDelivery, error) {
+	objectID, err := primitive.ObjectIDFromHex(webhookID)
+	if err != nil {
+		return nil, utils.ErrInvalidID
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var deliveries []*models.WebhookDelivery
+	for _, delivery := range r.deliveries {
+		if delivery.WebhookID == objectID {
+			deliveries = append(deliveries, cloneWebhookDelivery(delivery))
+		}
+	}
+
+	sort.SliceStable(deliveries, func(i, j int) bool {
+		if !deliveries[i].CreatedAt.Equal(deliveries[j].CreatedAt) {
+			return deliveries[i].CreatedAt.After(deliveries[j].CreatedAt)
+		}
+		return deliveries[i].ID.Hex() > deliveries[j].ID.Hex()
+	})
+	if limit > 0 && int64(len(deliveries)) > limit {
+		deliveries = deliveries[:limit]
+	}
+	return deliveries, nil
+}
+
+func (r *webhookRepository) ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var due []*models.WebhookDelivery
+	for _, delivery := range r.deliveries {
+		if delivery.Status == models.WebhookDeliveryPending && !delivery.NextAttemptAt.After(now) {
+			due = append(due, delivery)
+		}
+	}
+	sort.SliceStable(due, func(i, j int) bool { return due[i].NextAttemptAt.Before(due[j].NextAttemptAt) })
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	claimed := make([]*models.WebhookDelivery, 0, len(due))
+	for _, delivery := range due {
+		attemptAt := now
+		delivery.Attempts++
+		delivery.LastAttemptAt = &attemptAt
+		delivery.NextAttemptAt = now.Add(lease)
+		claimed = append(claimed, cloneWebhookDelivery(delivery))
+	}
+	return claimed, nil
+}
+
+func (r *webhookRepository) MarkDelivered(ctx context.Context, id string, attempt *models.WebhookAttempt) error {
+	return r.updateDelivery(id, func(delivery *models.WebhookDelivery) {
+		now := time.Now()
+		recordAttempt(delivery, attempt)
+		delivery.Status = models.WebhookDeliveryDelivered
+		delivery.DeliveredAt = &now
+		delivery.LastError = ""
+	})
+}
+
+func (r *webhookRepository) MarkFailed(ctx context.Context, id string, attempt *models.WebhookAttempt, retryAt *time.Time) error {
+	return r.updateDelivery(id, func(delivery *models.WebhookDelivery) {
+		recordAttempt(delivery, attempt)
+		delivery.LastError = attempt.Error
+		if retryAt != nil {
+			delivery.NextAttemptAt = *retryAt
+		} else {
+			delivery.Status = models.WebhookDeliveryFailed
+		}
+	})
+}
+
+// recordAttempt replaces the previous attempt's response with this one's
+func recordAttempt(delivery *models.WebhookDelivery, attempt *models.WebhookAttempt) {
+	delivery.ResponseStatus = attempt.ResponseStatus
+	delivery.ResponseBody = attempt.ResponseBody
+	delivery.DurationMs = attempt.Duration.Milliseconds()
+}
+
+func (r *webhookRepository) DeleteDeliveriesBefore(ctx context.Context, before time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := r.deliveries[:0]
+	for _, delivery := range r.deliveries {
+		if delivery.Status == models.WebhookDeliveryPending || !delivery.CreatedAt.Before(before) {
+			kept = append(kept, delivery)
+		}
+	}
+
+	deleted := int64(len(r.deliveries) - len(kept))
+	r.deliveries = kept
+	return deleted, nil
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"time"
 
-	"backend/database"
 	"backend/models"
 	"backend/repositories/interfaces"
 	"backend/utils"
@@ -18,12 +17,14 @@ import (
 type menuRepository struct {
 	collection *mongo.Collection
 	permRepo   interfaces.PermissionRepository
+	transactor interfaces.Transactor
 }
 
-func NewMenuRepository(permRepo interfaces.PermissionRepository) interfaces.MenuRepository {
+func NewMenuRepository(db *mongo.Database, permRepo interfaces.PermissionRepository, transactor interfaces.Transactor) interfaces.MenuRepository {
 	return &menuRepository{
-		collection: database.DB.Collection("menus"),
+		collection: db.Collection("menus"),
 		permRepo:   permRepo,
+		transactor: transactor,
 	}
 }
 
@@ -33,6 +34,9 @@ func (r *menuRepository) Create(ctx context.Context, menu *models.Menu) error {
 	menu.UpdatedAt = time.Now()
 
 	_, err := r.collection.InsertOne(ctx, menu)
+	if mongo.IsDuplicateKeyError(err) {
+		return utils.ErrMenuAlreadyExists
+	}
 	return err
 }
 
@@ -75,7 +79,7 @@ func (r *menuRepository) GetByID(ctx context.Context, id string) (*models.Menu,
 
 func (r *menuRepository) GetActiveMenus(ctx context.Context) ([]*models.Menu, error) {
 	filter := bson.M{"is_active": true}
-	opts := options.Find().SetSort(bson.D{{"order", 1}})
+	opts := options.Find().SetSort(bson.D{{Key: "order", Value: 1}})
 
 	cursor, err := r.collection.Find(ctx, filter, opts)
 	if err != nil {
@@ -106,6 +110,9 @@ func (r *menuRepository) Update(ctx context.Context, id string, menu *models.Men
 
 	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
 	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return utils.ErrMenuAlreadyExists
+		}
 		return err
 	}
 
@@ -122,25 +129,28 @@ func (r *menuRepository) Delete(ctx context.Context, id string) error {
 		return utils.ErrInvalidID
 	}
 
-	// First, revoke all permissions for this menu
-	if err := r.permRepo.RevokeAllPermissionsForMenu(ctx, id); err != nil {
-		return err
-	}
+	// Revoke all permissions for this menu in the same transaction, so a
+	// failed delete does not leave the menu with its grants revoked
+	return r.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := r.permRepo.RevokeAllPermissionsForMenu(ctx, id); err != nil {
+			return err
+		}
 
-	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": objectID})
-	if err != nil {
-		return err
-	}
+		result, err := r.collection.DeleteOne(ctx, bson.M{"_id": objectID})
+		if err != nil {
+			return err
+		}
 
-	if result.DeletedCount == 0 {
-		return utils.ErrMenuNotFound
-	}
+		if result.DeletedCount == 0 {
+			return utils.ErrMenuNotFound
+		}
 
-	return nil
+		return nil
+	})
 }
 
 func (r *menuRepository) GetMenusOrderedByOrder(ctx context.Context) ([]*models.Menu, error) {
-	opts := options.Find().SetSort(bson.D{{"order", 1}})
+	opts := options.Find().SetSort(bson.D{{Key: "order", Value: 1}})
 	cursor, err := r.collection.Find(ctx, bson.M{}, opts)
 	if err != nil {
 		return nil, err
@@ -186,7 +196,7 @@ func (r *menuRepository) GetMenusByRole(ctx context.Context, role string) ([]*mo
 		"_id":       bson.M{"$in": menuIDs},
 		"is_active": true,
 	}
-	opts := options.Find().SetSort(bson.D{{"order", 1}})
+	opts := options.Find().SetSort(bson.D{{Key: "order", Value: 1}})
 
 	cursor, err := r.collection.Find(ctx, filter, opts)
 	if err != nil {
@@ -205,3 +215,35 @@ func (r *menuRepository) GetMenusByRole(ctx context.Context, role string) ([]*mo
 
 	return menus, cursor.Err()
 }
+
+// GetMenusWithoutGrants returns menus that no role has an explicit permission for
+func (r *menuRepository) GetMenusWithoutGrants(ctx context.Context) ([]*models.Menu, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$lookup", Value: bson.M{
+			"from":         "role_menu_permissions",
+			"localField":   "_id",
+			"foreignField": "menu_id",
+			"as":           "grants",
+		}}},
+		{{Key: "$match", Value: bson.M{"grants": bson.M{"$size": 0}}}},
+		{{Key: "$project", Value: bson.M{"grants": 0}}},
+		{{Key: "$sort", Value: bson.M{"order": 1}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var menus []*models.Menu
+	for cursor.Next(ctx) {
+		var menu models.Menu
+		if err := cursor.Decode(&menu); err != nil {
+			return nil, err
+		}
+		menus = append(menus, &menu)
+	}
+
+	return menus, cursor.Err()
+}
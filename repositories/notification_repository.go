@@ -0,0 +1,192 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"backend/models"
+	"backend/repositories/interfaces"
+	"backend/utils"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type notificationRepository struct {
+	collection  *mongo.Collection
+	preferences *mongo.Collection
+}
+
+func NewNotificationRepository(db *mongo.Database) interfaces.NotificationRepository {
+	return &notificationRepository{
+		collection:  db.Collection("notifications"),
+		preferences: db.Collection("notification_preferences"),
+	}
+}
+
+// notificationPreferenceDocument is one stored preference; a user has one
+// document per event type
+type notificationPreferenceDocument struct {
+	UserID                        primitive.ObjectID `bson:"user_id"`
+	models.NotificationPreference `bson:",inline"`
+}
+
+func (r *notificationRepository) Create(ctx context.Context, notification *models.Notification) error {
+	notification.ID = primitive.NewObjectID()
+	if notification.CreatedAt.IsZero() {
+		notification.CreatedAt = time.Now()
+	}
+
+	_, err := r.collection.InsertOne(ctx, notification)
+	return err
+}
+
+func (r *notificationRepository) List(ctx context.Context, userID string, unreadOnly bool, limit int64) ([]*models.Notification, error) {
+	userObjectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, utils.ErrInvalidID
+	}
+
+	filter := bson.M{"user_id": userObjectID}
+	if unreadOnly {
+		filter["read_at"] = bson.M{"$exists": false}
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetLimit(limit)
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var notifications []*models.Notification
+	for cursor.Next(ctx) {
+		var notification models.Notification
+		if err := cursor.Decode(&notification); err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, &notification)
+	}
+
+	return notifications, cursor.Err()
+}
+
+func (r *notificationRepository) CountUnread(ctx context.Context, userID string) (int64, error) {
+	userObjectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return 0, utils.ErrInvalidID
+	}
+
+	return r.collection.CountDocuments(ctx, bson.M{"user_id": userObjectID, "read_at": bson.M{"$exists": false}})
+}
+
+// MarkRead uses a pipeline update so a notification that is already read
+// keeps its read time and still counts as matched
+func (r *notificationRepository) MarkRead(ctx context.Context, userID, id string) error {
+	userObjectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return utils.ErrInvalidID
+	}
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return utils.ErrInvalidID
+	}
+
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{"read_at": bson.M{"$ifNull": bson.A{"$read_at", time.Now()}}}}},
+	}
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": objectID, "user_id": userObjectID}, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return utils.ErrNotificationNotFound
+	}
+
+	return nil
+}
+
+func (r *notificationRepository) MarkAllRead(ctx context.Context, userID string) (int64, error) {
+	userObjectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return 0, utils.ErrInvalidID
+	}
+
+	result, err := r.collection.UpdateMany(ctx,
+		bson.M{"user_id": userObjectID, "read_at": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"read_at": time.Now()}},
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+func (r *notificationRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.collection.DeleteMany(ctx, bson.M{"created_at": bson.M{"$lt": before}})
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
+
+func (r *notificationRepository) GetPreferences(ctx context.Context, userID string) ([]models.NotificationPreference, error) {
+	userObjectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, utils.ErrInvalidID
+	}
+
+	cursor, err := r.preferences.Find(ctx, bson.M{"user_id": userObjectID}, options.Find().SetSort(bson.D{{Key: "type", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var preferences []models.NotificationPreference
+	for cursor.Next(ctx) {
+		var document notificationPreferenceDocument
+		if err := cursor.Decode(&document); err != nil {
+			return nil, err
+		}
+		preferences = append(preferences, document.NotificationPreference)
+	}
+
+	return preferences, cursor.Err()
+}
+
+func (r *notificationRepository) SavePreferences(ctx context.Context, userID string, preferences []models.NotificationPreference) error {
+	userObjectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return utils.ErrInvalidID
+	}
+
+	for _, preference := range preferences {
+		_, err := r.preferences.UpdateOne(ctx,
+			bson.M{"user_id": userObjectID, "type": preference.Type},
+			bson.M{"$set": bson.M{"email": preference.Email, "in_app": preference.InApp}},
+			options.Update().SetUpsert(true),
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *notificationRepository) DeleteByUser(ctx context.Context, userID string) error {
+	userObjectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return utils.ErrInvalidID
+	}
+
+	if _, err := r.collection.DeleteMany(ctx, bson.M{"user_id": userObjectID}); err != nil {
+		return err
+	}
+	_, err = r.preferences.DeleteMany(ctx, bson.M{"user_id": userObjectID})
+	return err
+}
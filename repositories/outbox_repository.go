@@ -0,0 +1,246 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"backend/models"
+	"backend/repositories/interfaces"
+	"backend/utils"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type outboxRepository struct {
+	collection *mongo.Collection
+}
+
+func NewOutboxRepository(db *mongo.Database) interfaces.OutboxRepository {
+	return &outboxRepository{
+		collection: db.Collection("email_outbox"),
+	}
+}
+
+func (r *outboxRepository) Create(ctx context.Context, message *models.OutboxMessage) error {
+	message.ID = primitive.NewObjectID()
+	message.CreatedAt = time.Now()
+	message.NextAttemptAt = message.CreatedAt
+	message.Status = models.OutboxStatusPending
+
+	_, err := r.collection.InsertOne(ctx, message)
+	return err
+}
+
+func (r *outboxRepository) GetByID(ctx context.Context, id string) (*models.OutboxMessage, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, utils.ErrInvalidID
+	}
+
+	var message models.OutboxMessage
+	err = r.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&message)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, utils.ErrOutboxMessageNotFound
+		}
+		return nil, err
+	}
+
+	return &message, nil
+}
+
+func (r *outboxRepository) List(ctx context.Context, status string, limit int64) ([]*models.OutboxMessage, error) {
+	filter := bson.M{}
+	if status != "" {
+		filter["status"] = status
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetLimit(limit).
+		SetProjection(bson.M{"text_body": 0, "html_body": 0})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var messages []*models.OutboxMessage
+	for cursor.Next(ctx) {
+		var message models.OutboxMessage
+		if err := cursor.Decode(&message); err != nil {
+			return nil, err
+		}
+		messages = append(messages, &message)
+	}
+
+	return messages, cursor.Err()
+}
+
+func (r *outboxRepository) Summary(ctx context.Context) (*models.OutboxSummary, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$status"},
+			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+			{Key: "oldest", Value: bson.D{{Key: "$min", Value: "$created_at"}}},
+		}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	summary := &models.OutboxSummary{}
+	for cursor.Next(ctx) {
+		var group struct {
+			Status string    `bson:"_id"`
+			Count  int64     `bson:"count"`
+			Oldest time.Time `bson:"oldest"`
+		}
+		if err := cursor.Decode(&group); err != nil {
+			return nil, err
+		}
+
+		switch group.Status {
+		case models.OutboxStatusPending:
+			summary.Pending = group.Count
+			summary.OldestPendingAt = &group.Oldest
+		case models.OutboxStatusSent:
+			summary.Sent = group.Count
+		case models.OutboxStatusDead:
+			summary.Dead = group.Count
+		}
+	}
+
+	return summary, cursor.Err()
+}
+
+// ClaimDue claims one message at a time; each findOneAndUpdate is atomic, so
+// a message is never handed to two workers
+func (r *outboxRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.OutboxMessage, error) {
+	filter := bson.M{
+		"status":          models.OutboxStatusPending,
+		"next_attempt_at": bson.M{"$lte": now},
+	}
+	update := bson.M{
+		"$inc": bson.M{"attempts": 1},
+		"$set": bson.M{"last_attempt_at": now, "next_attempt_at": now.Add(lease)},
+	}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "next_attempt_at", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var messages []*models.OutboxMessage
+	for len(messages) < limit {
+		var message models.OutboxMessage
+		err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&message)
+		if err == mongo.ErrNoDocuments {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, &message)
+	}
+
+	return messages, nil
+}
+
+func (r *outboxRepository) MarkSent(ctx context.Context, claim *models.OutboxMessage) error {
+	return r.updateClaim(ctx, claim, bson.M{
+		"$set": bson.M{
+			"status":    models.OutboxStatusSent,
+			"sent_at":   time.Now(),
+			"text_body": "",
+			"html_body": "",
+		},
+		"$unset": bson.M{"last_error": ""},
+	})
+}
+
+func (r *outboxRepository) MarkFailed(ctx context.Context, claim *models.OutboxMessage, errMessage string, retryAt *time.Time) error {
+	set := bson.M{"last_error": errMessage}
+	if retryAt != nil {
+		set["next_attempt_at"] = *retryAt
+	} else {
+		set["status"] = models.OutboxStatusDead
+	}
+
+	return r.updateClaim(ctx, claim, bson.M{"$set": set})
+}
+
+// updateClaim applies update to a message while the claim still holds
+func (r *outboxRepository) updateClaim(ctx context.Context, claim *models.OutboxMessage, update bson.M) error {
+	result, err := r.collection.UpdateOne(ctx, bson.M{
+		"_id":             claim.ID,
+		"status":          models.OutboxStatusPending,
+		"attempts":        claim.Attempts,
+		"last_attempt_at": claim.LastAttemptAt,
+	}, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		if _, err := r.GetByID(ctx, claim.ID.Hex()); err != nil {
+			return err
+		}
+		return utils.ErrOutboxClaimLost
+	}
+
+	return nil
+}
+
+func (r *outboxRepository) Requeue(ctx context.Context, id string) error {
+	return r.update(ctx, id, bson.M{
+		"$set": bson.M{
+			"status":          models.OutboxStatusPending,
+			"attempts":        0,
+			"next_attempt_at": time.Now(),
+		},
+		"$unset": bson.M{"last_error": ""},
+	})
+}
+
+// update applies update to the message with the given ID
+func (r *outboxRepository) update(ctx context.Context, id string, update bson.M) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return utils.ErrInvalidID
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return utils.ErrOutboxMessageNotFound
+	}
+
+	return nil
+}
+
+func (r *outboxRepository) DeleteFinishedBefore(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.collection.DeleteMany(ctx, bson.M{
+		"$or": []bson.M{
+			{"status": models.OutboxStatusSent, "sent_at": bson.M{"$lt": before}},
+			{"status": models.OutboxStatusDead, "last_attempt_at": bson.M{"$lt": before}},
+		},
+	})
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
+
+func (r *outboxRepository) DeleteByRecipient(ctx context.Context, email string) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"recipient": email})
+	return err
+}
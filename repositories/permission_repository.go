@@ -4,7 +4,6 @@ import (
 	"context"
 	"time"
 
-	"backend/database"
 	"backend/models"
 	"backend/repositories/interfaces"
 	"backend/utils"
@@ -18,9 +17,9 @@ type permissionRepository struct {
 	collection *mongo.Collection
 }
 
-func NewPermissionRepository() interfaces.PermissionRepository {
+func NewPermissionRepository(db *mongo.Database) interfaces.PermissionRepository {
 	return &permissionRepository{
-		collection: database.DB.Collection("role_menu_permissions"),
+		collection: db.Collection("role_menu_permissions"),
 	}
 }
 
@@ -163,3 +162,16 @@ func (r *permissionRepository) RevokeAllPermissionsForRole(ctx context.Context,
 	_, err := r.collection.DeleteMany(ctx, filter)
 	return err
 }
+
+// PseudonymizeGrantor replaces the stored name of the admin who granted permissions
+func (r *permissionRepository) PseudonymizeGrantor(ctx context.Context, grantedByID, pseudonym string) error {
+	grantorObjectID, err := primitive.ObjectIDFromHex(grantedByID)
+	if err != nil {
+		return utils.ErrInvalidID
+	}
+
+	filter := bson.M{"granted_by_id": grantorObjectID}
+	update := bson.M{"$set": bson.M{"granted_by_name": pseudonym}}
+	_, err = r.collection.UpdateMany(ctx, filter, update)
+	return err
+}
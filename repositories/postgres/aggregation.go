@@ -0,0 +1,40 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"backend/models"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// countByPeriod counts rows of table whose created_at falls in
+// [query.From, query.To), grouped into UTC buckets of query.Bucket size.
+// where is appended to the WHERE clause and may use parameters from $4 on.
+// Empty buckets are omitted.
+func countByPeriod(ctx context.Context, pool *pgxpool.Pool, table, where string, args []any, query *models.StatsQuery) ([]models.PeriodCount, error) {
+	sql := fmt.Sprintf(`
+		SELECT date_trunc($1, created_at, 'UTC') AS period, COUNT(*)
+		FROM %s
+		WHERE created_at >= $2 AND created_at < $3 %s
+		GROUP BY period
+		ORDER BY period`, table, where)
+
+	rows, err := conn(ctx, pool).Query(ctx, sql, append([]any{query.Bucket, query.From, query.To}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := []models.PeriodCount{}
+	for rows.Next() {
+		var count models.PeriodCount
+		if err := rows.Scan(&count.Period, &count.Count); err != nil {
+			return nil, err
+		}
+		counts = append(counts, count)
+	}
+
+	return counts, rows.Err()
+}
@@ -0,0 +1,130 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"backend/models"
+	"backend/repositories/interfaces"
+	"backend/utils"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const auditColumns = `id, action, actor_id, actor_name, target_type, target_id, details, created_at`
+
+type auditRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewAuditRepository(pool *pgxpool.Pool) interfaces.AuditRepository {
+	return &auditRepository{pool: pool}
+}
+
+func scanAuditEvent(row pgx.Row) (*models.AuditEvent, error) {
+	var event models.AuditEvent
+	var id string
+	var actorID *string
+	var details []byte
+	err := row.Scan(&id, &event.Action, &actorID, &event.ActorName, &event.TargetType, &event.TargetID,
+		&details, &event.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if event.ID, err = primitive.ObjectIDFromHex(id); err != nil {
+		return nil, err
+	}
+	if event.ActorID, err = parseOptionalID(actorID); err != nil {
+		return nil, err
+	}
+	if details != nil {
+		if err := json.Unmarshal(details, &event.Details); err != nil {
+			return nil, err
+		}
+	}
+	return &event, nil
+}
+
+// find returns the events matching where, oldest first
+func (r *auditRepository) find(ctx context.Context, where string, args ...any) ([]*models.AuditEvent, error) {
+	rows, err := conn(ctx, r.pool).Query(ctx, `SELECT `+auditColumns+` FROM audit_events WHERE `+where+` ORDER BY created_at, id`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []*models.AuditEvent
+	for rows.Next() {
+		event, err := scanAuditEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
+
+func (r *auditRepository) Create(ctx context.Context, event *models.AuditEvent) error {
+	event.ID = primitive.NewObjectID()
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+
+	var details *string
+	if event.Details != nil {
+		encoded, err := json.Marshal(event.Details)
+		if err != nil {
+			return err
+		}
+		value := string(encoded)
+		details = &value
+	}
+
+	_, err := conn(ctx, r.pool).Exec(ctx, `INSERT INTO audit_events (`+auditColumns+`) VALUES (`+placeholders(1, 8)+`)`,
+		event.ID.Hex(), event.Action, optionalHex(event.ActorID), event.ActorName, event.TargetType,
+		event.TargetID, details, event.CreatedAt,
+	)
+	return err
+}
+
+// GetInvolvingUser returns events where the user is either the actor or the target
+func (r *auditRepository) GetInvolvingUser(ctx context.Context, userID string) ([]*models.AuditEvent, error) {
+	if _, err := primitive.ObjectIDFromHex(userID); err != nil {
+		return nil, utils.ErrInvalidID
+	}
+
+	return r.find(ctx, `actor_id = $1 OR (target_type = $2 AND target_id = $1)`, userID, models.AuditTargetUser)
+}
+
+// GetPermissionHistory returns grant and revoke events for a role
+func (r *auditRepository) GetPermissionHistory(ctx context.Context, role string) ([]*models.AuditEvent, error) {
+	return r.find(ctx, `target_type = $1 AND target_id = $2 AND action IN ($3, $4)`,
+		models.AuditTargetRole, role, models.AuditPermissionGranted, models.AuditPermissionRevoked)
+}
+
+// PseudonymizeActor replaces the stored display name of every event the user performed
+func (r *auditRepository) PseudonymizeActor(ctx context.Context, userID, pseudonym string) error {
+	if _, err := primitive.ObjectIDFromHex(userID); err != nil {
+		return utils.ErrInvalidID
+	}
+
+	_, err := conn(ctx, r.pool).Exec(ctx, `UPDATE audit_events SET actor_name = $2 WHERE actor_id = $1`, userID, pseudonym)
+	return err
+}
+
+// CountByPeriod counts events of one action per time bucket
+func (r *auditRepository) CountByPeriod(ctx context.Context, action string, query *models.StatsQuery) ([]models.PeriodCount, error) {
+	return countByPeriod(ctx, r.pool, "audit_events", "AND action = $4", []any{action}, query)
+}
+
+// CountSince counts events of one action recorded at or after since
+func (r *auditRepository) CountSince(ctx context.Context, action string, since time.Time) (int64, error) {
+	var count int64
+	err := conn(ctx, r.pool).QueryRow(ctx, `SELECT COUNT(*) FROM audit_events WHERE action = $1 AND created_at >= $2`,
+		action, since).Scan(&count)
+	return count, err
+}
@@ -0,0 +1,169 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"backend/models"
+	"backend/repositories/interfaces"
+	"backend/utils"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const bulkJobColumns = `id, operation, status, total, processed, succeeded, failed, error,
+	created_by_id, created_at, started_at, completed_at`
+
+// bulkJobRepository keeps jobs until they are deleted; the week-long expiry
+// of the MongoDB TTL index is not emulated
+type bulkJobRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewBulkJobRepository(pool *pgxpool.Pool) interfaces.BulkJobRepository {
+	return &bulkJobRepository{pool: pool}
+}
+
+// scanBulkJob scans bulkJobColumns, followed by the results column when withResults is set
+func scanBulkJob(row pgx.Row, withResults bool) (*models.BulkJob, error) {
+	var job models.BulkJob
+	var id, createdByID string
+	var results []byte
+	dest := []any{
+		&id, &job.Operation, &job.Status, &job.Total, &job.Processed, &job.Succeeded, &job.Failed,
+		&job.Error, &createdByID, &job.CreatedAt, &job.StartedAt, &job.CompletedAt,
+	}
+	if withResults {
+		dest = append(dest, &results)
+	}
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if job.ID, err = primitive.ObjectIDFromHex(id); err != nil {
+		return nil, err
+	}
+	if job.CreatedByID, err = primitive.ObjectIDFromHex(createdByID); err != nil {
+		return nil, err
+	}
+	if withResults {
+		if err := json.Unmarshal(results, &job.Results); err != nil {
+			return nil, err
+		}
+	}
+	return &job, nil
+}
+
+// update runs an UPDATE on the job with the given ID
+func (r *bulkJobRepository) update(ctx context.Context, sql string, id string, args ...any) error {
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return utils.ErrInvalidID
+	}
+
+	tag, err := conn(ctx, r.pool).Exec(ctx, sql, append([]any{id}, args...)...)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return utils.ErrBulkJobNotFound
+	}
+	return nil
+}
+
+func (r *bulkJobRepository) Create(ctx context.Context, job *models.BulkJob) error {
+	job.ID = primitive.NewObjectID()
+	job.CreatedAt = time.Now()
+	if job.Status == "" {
+		job.Status = models.BulkJobStatusPending
+	}
+	if job.Results == nil {
+		job.Results = []models.BulkItemResult{}
+	}
+
+	results, err := json.Marshal(job.Results)
+	if err != nil {
+		return err
+	}
+
+	_, err = conn(ctx, r.pool).Exec(ctx, `INSERT INTO bulk_jobs (`+bulkJobColumns+`, results) VALUES (`+placeholders(1, 13)+`)`,
+		job.ID.Hex(), job.Operation, job.Status, job.Total, job.Processed, job.Succeeded, job.Failed,
+		job.Error, job.CreatedByID.Hex(), job.CreatedAt, job.StartedAt, job.CompletedAt, string(results),
+	)
+	return err
+}
+
+func (r *bulkJobRepository) GetByID(ctx context.Context, id string) (*models.BulkJob, error) {
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return nil, utils.ErrInvalidID
+	}
+
+	job, err := scanBulkJob(conn(ctx, r.pool).QueryRow(ctx, `SELECT `+bulkJobColumns+`, results FROM bulk_jobs WHERE id = $1`, id), true)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, utils.ErrBulkJobNotFound
+	}
+	return job, err
+}
+
+// GetRecent returns the newest jobs without their per-item results
+func (r *bulkJobRepository) GetRecent(ctx context.Context, limit int64) ([]*models.BulkJob, error) {
+	sql := `SELECT ` + bulkJobColumns + ` FROM bulk_jobs ORDER BY created_at DESC, id DESC`
+	args := []any{}
+	if limit > 0 {
+		sql += ` LIMIT $1`
+		args = append(args, limit)
+	}
+
+	rows, err := conn(ctx, r.pool).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var jobs []*models.BulkJob
+	for rows.Next() {
+		job, err := scanBulkJob(rows, false)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, rows.Err()
+}
+
+func (r *bulkJobRepository) MarkRunning(ctx context.Context, id string) error {
+	return r.update(ctx, `UPDATE bulk_jobs SET status = $2, started_at = $3 WHERE id = $1`,
+		id, models.BulkJobStatusRunning, time.Now())
+}
+
+// AppendResult records one item outcome and advances the progress counters
+func (r *bulkJobRepository) AppendResult(ctx context.Context, id string, item models.BulkItemResult) error {
+	result, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+
+	succeeded, failed := 0, 1
+	if item.Success {
+		succeeded, failed = 1, 0
+	}
+
+	return r.update(ctx, `
+		UPDATE bulk_jobs SET results = results || jsonb_build_array($2::jsonb),
+			processed = processed + 1, succeeded = succeeded + $3, failed = failed + $4
+		WHERE id = $1`,
+		id, string(result), succeeded, failed,
+	)
+}
+
+func (r *bulkJobRepository) Complete(ctx context.Context, id, status, errMessage string) error {
+	return r.update(ctx, `
+		UPDATE bulk_jobs SET status = $2, completed_at = $3, error = COALESCE(NULLIF($4, ''), error)
+		WHERE id = $1`,
+		id, status, time.Now(), errMessage,
+	)
+}
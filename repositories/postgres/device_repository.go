@@ -0,0 +1,84 @@
+package postgres
+
+import (
+	"context"
+
+	"backend/models"
+	"backend/repositories/interfaces"
+	"backend/utils"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type deviceRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewDeviceRepository(pool *pgxpool.Pool) interfaces.DeviceRepository {
+	return &deviceRepository{pool: pool}
+}
+
+// Record upserts on the user and fingerprint key. xmax is zero only on a row
+// the statement inserted, which tells a new device from a known one.
+func (r *deviceRepository) Record(ctx context.Context, device *models.Device) (bool, error) {
+	id := primitive.NewObjectID()
+
+	var inserted bool
+	err := conn(ctx, r.pool).QueryRow(ctx, `
+		INSERT INTO user_devices (id, user_id, fingerprint, user_agent, ip, first_seen_at, last_seen_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $6)
+		ON CONFLICT (user_id, fingerprint) DO UPDATE
+			SET user_agent = EXCLUDED.user_agent, ip = EXCLUDED.ip, last_seen_at = EXCLUDED.last_seen_at
+		RETURNING xmax = 0`,
+		id.Hex(), device.UserID.Hex(), device.Fingerprint, device.UserAgent, device.IP, device.LastSeenAt,
+	).Scan(&inserted)
+	if err != nil || !inserted {
+		return false, err
+	}
+
+	device.ID = id
+	device.FirstSeenAt = device.LastSeenAt
+	return true, nil
+}
+
+func (r *deviceRepository) ListByUser(ctx context.Context, userID string) ([]*models.Device, error) {
+	if _, err := primitive.ObjectIDFromHex(userID); err != nil {
+		return nil, utils.ErrInvalidID
+	}
+
+	rows, err := conn(ctx, r.pool).Query(ctx, `
+		SELECT id, user_id, fingerprint, user_agent, ip, first_seen_at, last_seen_at
+		FROM user_devices WHERE user_id = $1 ORDER BY last_seen_at DESC`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var devices []*models.Device
+	for rows.Next() {
+		var device models.Device
+		var id, deviceUserID string
+		if err := rows.Scan(&id, &deviceUserID, &device.Fingerprint, &device.UserAgent, &device.IP,
+			&device.FirstSeenAt, &device.LastSeenAt); err != nil {
+			return nil, err
+		}
+		if device.ID, err = primitive.ObjectIDFromHex(id); err != nil {
+			return nil, err
+		}
+		if device.UserID, err = primitive.ObjectIDFromHex(deviceUserID); err != nil {
+			return nil, err
+		}
+		devices = append(devices, &device)
+	}
+	return devices, rows.Err()
+}
+
+func (r *deviceRepository) DeleteByUser(ctx context.Context, userID string) error {
+	if _, err := primitive.ObjectIDFromHex(userID); err != nil {
+		return utils.ErrInvalidID
+	}
+
+	_, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM user_devices WHERE user_id = $1`, userID)
+	return err
+}
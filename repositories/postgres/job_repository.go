@@ -0,0 +1,108 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"backend/models"
+	"backend/repositories/interfaces"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const jobRunColumns = `id, job, trigger, triggered_by, instance, started_at, finished_at, duration_ms, error`
+
+// jobRepository keeps leases in the locks table shared with the migrator and
+// run history in job_runs
+type jobRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewJobRepository(pool *pgxpool.Pool) interfaces.JobRepository {
+	return &jobRepository{pool: pool}
+}
+
+func scanJobRun(row pgx.Row) (*models.JobRun, error) {
+	var run models.JobRun
+	var id string
+	var triggeredBy *string
+	err := row.Scan(&id, &run.Job, &run.Trigger, &triggeredBy, &run.Instance, &run.StartedAt,
+		&run.FinishedAt, &run.DurationMs, &run.Error)
+	if err != nil {
+		return nil, err
+	}
+
+	if run.ID, err = primitive.ObjectIDFromHex(id); err != nil {
+		return nil, err
+	}
+	if run.TriggeredBy, err = parseOptionalID(triggeredBy); err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+// AcquireLease inserts the lease row, or takes it over when it is ours or expired
+func (r *jobRepository) AcquireLease(ctx context.Context, name, owner string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	tag, err := conn(ctx, r.pool).Exec(ctx, `
+		INSERT INTO locks (name, owner, expires_at) VALUES ($1, $2, $3)
+		ON CONFLICT (name) DO UPDATE SET owner = EXCLUDED.owner, expires_at = EXCLUDED.expires_at
+		WHERE locks.owner = EXCLUDED.owner OR locks.expires_at < $4`,
+		name, owner, now.Add(ttl), now,
+	)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func (r *jobRepository) ReleaseLease(ctx context.Context, name, owner string) error {
+	_, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM locks WHERE name = $1 AND owner = $2`, name, owner)
+	return err
+}
+
+func (r *jobRepository) CreateRun(ctx context.Context, run *models.JobRun) error {
+	run.ID = primitive.NewObjectID()
+
+	_, err := conn(ctx, r.pool).Exec(ctx, `INSERT INTO job_runs (`+jobRunColumns+`) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		run.ID.Hex(), run.Job, run.Trigger, optionalHex(run.TriggeredBy), run.Instance, run.StartedAt,
+		run.FinishedAt, run.DurationMs, run.Error,
+	)
+	return err
+}
+
+func (r *jobRepository) GetRuns(ctx context.Context, job string, limit int64) ([]*models.JobRun, error) {
+	return r.find(ctx, `SELECT `+jobRunColumns+` FROM job_runs WHERE job = $1 ORDER BY started_at DESC, id DESC LIMIT $2`, job, limit)
+}
+
+func (r *jobRepository) GetLatestRuns(ctx context.Context) ([]*models.JobRun, error) {
+	return r.find(ctx, `SELECT DISTINCT ON (job) `+jobRunColumns+` FROM job_runs ORDER BY job, started_at DESC, id DESC`)
+}
+
+func (r *jobRepository) DeleteRunsBefore(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM job_runs WHERE started_at < $1`, before)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+func (r *jobRepository) find(ctx context.Context, sql string, args ...any) ([]*models.JobRun, error) {
+	rows, err := conn(ctx, r.pool).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var runs []*models.JobRun
+	for rows.Next() {
+		run, err := scanJobRun(rows)
+		if err != nil {
+			return nil, err
+		}
+		runs = append(runs, run)
+	}
+	return runs, rows.Err()
+}
@@ -0,0 +1,172 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"backend/models"
+	"backend/repositories/interfaces"
+	"backend/utils"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const menuColumns = `m.id, m.name, m.description, m.icon, m.path, m.sort_order, m.is_active, m.created_at, m.updated_at`
+
+type menuRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewMenuRepository(pool *pgxpool.Pool) interfaces.MenuRepository {
+	return &menuRepository{pool: pool}
+}
+
+func scanMenu(row pgx.Row) (*models.Menu, error) {
+	var menu models.Menu
+	var id string
+	err := row.Scan(&id, &menu.Name, &menu.Description, &menu.Icon, &menu.Path, &menu.Order,
+		&menu.IsActive, &menu.CreatedAt, &menu.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if menu.ID, err = primitive.ObjectIDFromHex(id); err != nil {
+		return nil, err
+	}
+	return &menu, nil
+}
+
+// query runs a SELECT over menus, aliased as m, and scans every row
+func (r *menuRepository) query(ctx context.Context, sql string, args ...any) ([]*models.Menu, error) {
+	rows, err := conn(ctx, r.pool).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var menus []*models.Menu
+	for rows.Next() {
+		menu, err := scanMenu(rows)
+		if err != nil {
+			return nil, err
+		}
+		menus = append(menus, menu)
+	}
+	return menus, rows.Err()
+}
+
+func (r *menuRepository) Create(ctx context.Context, menu *models.Menu) error {
+	menu.ID = primitive.NewObjectID()
+	menu.CreatedAt = time.Now()
+	menu.UpdatedAt = time.Now()
+
+	_, err := conn(ctx, r.pool).Exec(ctx, `
+		INSERT INTO menus (id, name, description, icon, path, sort_order, is_active, created_at, updated_at)
+		VALUES (`+placeholders(1, 9)+`)`,
+		menu.ID.Hex(), menu.Name, menu.Description, menu.Icon, menu.Path, menu.Order,
+		menu.IsActive, menu.CreatedAt, menu.UpdatedAt,
+	)
+	if errorCode(err) == uniqueViolation {
+		return utils.ErrMenuAlreadyExists
+	}
+	return err
+}
+
+func (r *menuRepository) GetAll(ctx context.Context) ([]*models.Menu, error) {
+	return r.query(ctx, `SELECT `+menuColumns+` FROM menus m ORDER BY m.created_at, m.id`)
+}
+
+func (r *menuRepository) GetByID(ctx context.Context, id string) (*models.Menu, error) {
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return nil, utils.ErrInvalidID
+	}
+
+	menu, err := scanMenu(conn(ctx, r.pool).QueryRow(ctx, `SELECT `+menuColumns+` FROM menus m WHERE m.id = $1`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, utils.ErrMenuNotFound
+	}
+	return menu, err
+}
+
+func (r *menuRepository) GetActiveMenus(ctx context.Context) ([]*models.Menu, error) {
+	return r.query(ctx, `SELECT `+menuColumns+` FROM menus m WHERE m.is_active ORDER BY m.sort_order, m.created_at`)
+}
+
+func (r *menuRepository) Update(ctx context.Context, id string, menu *models.Menu) error {
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return utils.ErrInvalidID
+	}
+
+	menu.UpdatedAt = time.Now()
+
+	tag, err := conn(ctx, r.pool).Exec(ctx, `
+		UPDATE menus SET name = $2, description = $3, icon = $4, path = $5, sort_order = $6,
+			is_active = $7, created_at = $8, updated_at = $9
+		WHERE id = $1`,
+		id, menu.Name, menu.Description, menu.Icon, menu.Path, menu.Order,
+		menu.IsActive, menu.CreatedAt, menu.UpdatedAt,
+	)
+	if err != nil {
+		if errorCode(err) == uniqueViolation {
+			return utils.ErrMenuAlreadyExists
+		}
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return utils.ErrMenuNotFound
+	}
+	return nil
+}
+
+// Delete removes the menu; the foreign key on role_menu_permissions removes
+// its grants in the same statement
+func (r *menuRepository) Delete(ctx context.Context, id string) error {
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return utils.ErrInvalidID
+	}
+
+	tag, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM menus WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return utils.ErrMenuNotFound
+	}
+	return nil
+}
+
+func (r *menuRepository) GetMenusOrderedByOrder(ctx context.Context) ([]*models.Menu, error) {
+	return r.query(ctx, `SELECT `+menuColumns+` FROM menus m ORDER BY m.sort_order, m.created_at`)
+}
+
+func (r *menuRepository) GetMenusByRole(ctx context.Context, role string) ([]*models.Menu, error) {
+	// Admin has access to all active menus
+	if role == "admin" {
+		return r.GetActiveMenus(ctx)
+	}
+
+	menus, err := r.query(ctx, `
+		SELECT `+menuColumns+`
+		FROM menus m
+		JOIN role_menu_permissions p ON p.menu_id = m.id
+		WHERE p.role = $1 AND m.is_active
+		ORDER BY m.sort_order, m.created_at`,
+		role,
+	)
+	if menus == nil && err == nil {
+		menus = []*models.Menu{}
+	}
+	return menus, err
+}
+
+// GetMenusWithoutGrants returns menus that no role has an explicit permission for
+func (r *menuRepository) GetMenusWithoutGrants(ctx context.Context) ([]*models.Menu, error) {
+	return r.query(ctx, `
+		SELECT `+menuColumns+`
+		FROM menus m
+		WHERE NOT EXISTS (SELECT 1 FROM role_menu_permissions p WHERE p.menu_id = m.id)
+		ORDER BY m.sort_order, m.created_at`)
+}
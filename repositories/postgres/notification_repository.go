@@ -0,0 +1,202 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"backend/models"
+	"backend/repositories/interfaces"
+	"backend/utils"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const notificationColumns = `id, user_id, type, title, body, data, read_at, created_at`
+
+type notificationRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewNotificationRepository(pool *pgxpool.Pool) interfaces.NotificationRepository {
+	return &notificationRepository{pool: pool}
+}
+
+func scanNotification(row pgx.Row) (*models.Notification, error) {
+	var notification models.Notification
+	var id, userID string
+	var data []byte
+	err := row.Scan(&id, &userID, &notification.Type, &notification.Title, &notification.Body, &data,
+		&notification.ReadAt, &notification.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if notification.ID, err = primitive.ObjectIDFromHex(id); err != nil {
+		return nil, err
+	}
+	if notification.UserID, err = primitive.ObjectIDFromHex(userID); err != nil {
+		return nil, err
+	}
+	if data != nil {
+		if err := json.Unmarshal(data, &notification.Data); err != nil {
+			return nil, err
+		}
+	}
+	return &notification, nil
+}
+
+func (r *notificationRepository) Create(ctx context.Context, notification *models.Notification) error {
+	notification.ID = primitive.NewObjectID()
+	if notification.CreatedAt.IsZero() {
+		notification.CreatedAt = time.Now()
+	}
+
+	var data *string
+	if notification.Data != nil {
+		encoded, err := json.Marshal(notification.Data)
+		if err != nil {
+			return err
+		}
+		value := string(encoded)
+		data = &value
+	}
+
+	_, err := conn(ctx, r.pool).Exec(ctx, `INSERT INTO notifications (`+notificationColumns+`) VALUES (`+placeholders(1, 8)+`)`,
+		notification.ID.Hex(), notification.UserID.Hex(), notification.Type, notification.Title, notification.Body,
+		data, notification.ReadAt, notification.CreatedAt,
+	)
+	return err
+}
+
+func (r *notificationRepository) List(ctx context.Context, userID string, unreadOnly bool, limit int64) ([]*models.Notification, error) {
+	if _, err := primitive.ObjectIDFromHex(userID); err != nil {
+		return nil, utils.ErrInvalidID
+	}
+
+	rows, err := conn(ctx, r.pool).Query(ctx, `
+		SELECT `+notificationColumns+` FROM notifications
+		WHERE user_id = $1 AND (NOT $2 OR read_at IS NULL)
+		ORDER BY created_at DESC, id DESC LIMIT NULLIF($3, 0)`,
+		userID, unreadOnly, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var notifications []*models.Notification
+	for rows.Next() {
+		notification, err := scanNotification(rows)
+		if err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, notification)
+	}
+	return notifications, rows.Err()
+}
+
+func (r *notificationRepository) CountUnread(ctx context.Context, userID string) (int64, error) {
+	if _, err := primitive.ObjectIDFromHex(userID); err != nil {
+		return 0, utils.ErrInvalidID
+	}
+
+	var count int64
+	err := conn(ctx, r.pool).QueryRow(ctx, `SELECT COUNT(*) FROM notifications WHERE user_id = $1 AND read_at IS NULL`, userID).Scan(&count)
+	return count, err
+}
+
+func (r *notificationRepository) MarkRead(ctx context.Context, userID, id string) error {
+	if _, err := primitive.ObjectIDFromHex(userID); err != nil {
+		return utils.ErrInvalidID
+	}
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return utils.ErrInvalidID
+	}
+
+	tag, err := conn(ctx, r.pool).Exec(ctx, `
+		UPDATE notifications SET read_at = COALESCE(read_at, $3)
+		WHERE id = $1 AND user_id = $2`,
+		id, userID, time.Now())
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return utils.ErrNotificationNotFound
+	}
+	return nil
+}
+
+func (r *notificationRepository) MarkAllRead(ctx context.Context, userID string) (int64, error) {
+	if _, err := primitive.ObjectIDFromHex(userID); err != nil {
+		return 0, utils.ErrInvalidID
+	}
+
+	tag, err := conn(ctx, r.pool).Exec(ctx, `UPDATE notifications SET read_at = $2 WHERE user_id = $1 AND read_at IS NULL`,
+		userID, time.Now())
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+func (r *notificationRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM notifications WHERE created_at < $1`, before)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+func (r *notificationRepository) GetPreferences(ctx context.Context, userID string) ([]models.NotificationPreference, error) {
+	if _, err := primitive.ObjectIDFromHex(userID); err != nil {
+		return nil, utils.ErrInvalidID
+	}
+
+	rows, err := conn(ctx, r.pool).Query(ctx, `
+		SELECT type, email, in_app FROM notification_preferences WHERE user_id = $1 ORDER BY type`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var preferences []models.NotificationPreference
+	for rows.Next() {
+		var preference models.NotificationPreference
+		if err := rows.Scan(&preference.Type, &preference.Email, &preference.InApp); err != nil {
+			return nil, err
+		}
+		preferences = append(preferences, preference)
+	}
+	return preferences, rows.Err()
+}
+
+func (r *notificationRepository) SavePreferences(ctx context.Context, userID string, preferences []models.NotificationPreference) error {
+	if _, err := primitive.ObjectIDFromHex(userID); err != nil {
+		return utils.ErrInvalidID
+	}
+
+	for _, preference := range preferences {
+		_, err := conn(ctx, r.pool).Exec(ctx, `
+			INSERT INTO notification_preferences (user_id, type, email, in_app) VALUES ($1, $2, $3, $4)
+			ON CONFLICT (user_id, type) DO UPDATE SET email = EXCLUDED.email, in_app = EXCLUDED.in_app`,
+			userID, preference.Type, preference.Email, preference.InApp)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *notificationRepository) DeleteByUser(ctx context.Context, userID string) error {
+	if _, err := primitive.ObjectIDFromHex(userID); err != nil {
+		return utils.ErrInvalidID
+	}
+
+	if _, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM notifications WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+	_, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM notification_preferences WHERE user_id = $1`, userID)
+	return err
+}
@@ -0,0 +1,210 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"backend/models"
+	"backend/repositories/interfaces"
+	"backend/utils"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const outboxColumns = `id, kind, recipient, recipient_name, subject, text_body, html_body, status, attempts,
+	last_error, next_attempt_at, last_attempt_at, sent_at, created_at`
+
+type outboxRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewOutboxRepository(pool *pgxpool.Pool) interfaces.OutboxRepository {
+	return &outboxRepository{pool: pool}
+}
+
+func scanOutboxMessage(row pgx.Row) (*models.OutboxMessage, error) {
+	var message models.OutboxMessage
+	var id string
+	err := row.Scan(&id, &message.Kind, &message.Recipient, &message.RecipientName, &message.Subject,
+		&message.TextBody, &message.HTMLBody, &message.Status, &message.Attempts, &message.LastError,
+		&message.NextAttemptAt, &message.LastAttemptAt, &message.SentAt, &message.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if message.ID, err = primitive.ObjectIDFromHex(id); err != nil {
+		return nil, err
+	}
+	return &message, nil
+}
+
+// update runs an UPDATE on the message with the given ID
+func (r *outboxRepository) update(ctx context.Context, sql string, id string, args ...any) error {
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return utils.ErrInvalidID
+	}
+
+	tag, err := conn(ctx, r.pool).Exec(ctx, sql, append([]any{id}, args...)...)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return utils.ErrOutboxMessageNotFound
+	}
+	return nil
+}
+
+func (r *outboxRepository) Create(ctx context.Context, message *models.OutboxMessage) error {
+	message.ID = primitive.NewObjectID()
+	message.CreatedAt = time.Now()
+	message.NextAttemptAt = message.CreatedAt
+	message.Status = models.OutboxStatusPending
+
+	_, err := conn(ctx, r.pool).Exec(ctx, `
+		INSERT INTO email_outbox (`+outboxColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`,
+		message.ID.Hex(), message.Kind, message.Recipient, message.RecipientName, message.Subject,
+		message.TextBody, message.HTMLBody, message.Status, message.Attempts, message.LastError,
+		message.NextAttemptAt, message.LastAttemptAt, message.SentAt, message.CreatedAt,
+	)
+	return err
+}
+
+func (r *outboxRepository) GetByID(ctx context.Context, id string) (*models.OutboxMessage, error) {
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return nil, utils.ErrInvalidID
+	}
+
+	message, err := scanOutboxMessage(conn(ctx, r.pool).QueryRow(ctx, `SELECT `+outboxColumns+` FROM email_outbox WHERE id = $1`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, utils.ErrOutboxMessageNotFound
+	}
+	return message, err
+}
+
+// List leaves the bodies out, like the MongoDB projection
+func (r *outboxRepository) List(ctx context.Context, status string, limit int64) ([]*models.OutboxMessage, error) {
+	return r.find(ctx, `
+		SELECT id, kind, recipient, recipient_name, subject, '', '', status, attempts,
+			last_error, next_attempt_at, last_attempt_at, sent_at, created_at
+		FROM email_outbox WHERE $1 = '' OR status = $1
+		ORDER BY created_at DESC, id DESC LIMIT $2`, status, limit)
+}
+
+func (r *outboxRepository) Summary(ctx context.Context) (*models.OutboxSummary, error) {
+	rows, err := conn(ctx, r.pool).Query(ctx, `SELECT status, COUNT(*), MIN(created_at) FROM email_outbox GROUP BY status`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	summary := &models.OutboxSummary{}
+	for rows.Next() {
+		var status string
+		var count int64
+		var oldest time.Time
+		if err := rows.Scan(&status, &count, &oldest); err != nil {
+			return nil, err
+		}
+
+		switch status {
+		case models.OutboxStatusPending:
+			summary.Pending = count
+			summary.OldestPendingAt = &oldest
+		case models.OutboxStatusSent:
+			summary.Sent = count
+		case models.OutboxStatusDead:
+			summary.Dead = count
+		}
+	}
+	return summary, rows.Err()
+}
+
+// ClaimDue skips rows another worker has locked, so concurrent claims never
+// overlap
+func (r *outboxRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.OutboxMessage, error) {
+	return r.find(ctx, `
+		UPDATE email_outbox SET attempts = attempts + 1, last_attempt_at = $1, next_attempt_at = $2
+		WHERE id IN (
+			SELECT id FROM email_outbox WHERE status = $3 AND next_attempt_at <= $1
+			ORDER BY next_attempt_at LIMIT $4 FOR UPDATE SKIP LOCKED
+		)
+		RETURNING `+outboxColumns,
+		now, now.Add(lease), models.OutboxStatusPending, limit)
+}
+
+func (r *outboxRepository) MarkSent(ctx context.Context, claim *models.OutboxMessage) error {
+	return r.updateClaim(ctx, claim, `status = $5, sent_at = $6, text_body = '', html_body = '', last_error = ''`,
+		models.OutboxStatusSent, time.Now())
+}
+
+func (r *outboxRepository) MarkFailed(ctx context.Context, claim *models.OutboxMessage, errMessage string, retryAt *time.Time) error {
+	if retryAt == nil {
+		return r.updateClaim(ctx, claim, `status = $5, last_error = $6`, models.OutboxStatusDead, errMessage)
+	}
+	return r.updateClaim(ctx, claim, `next_attempt_at = $5, last_error = $6`, *retryAt, errMessage)
+}
+
+// updateClaim sets columns on a message while the claim still holds. The
+// assignments use $5 onwards.
+func (r *outboxRepository) updateClaim(ctx context.Context, claim *models.OutboxMessage, set string, args ...any) error {
+	tag, err := conn(ctx, r.pool).Exec(ctx, `
+		UPDATE email_outbox SET `+set+`
+		WHERE id = $1 AND status = $2 AND attempts = $3 AND last_attempt_at IS NOT DISTINCT FROM $4`,
+		append([]any{claim.ID.Hex(), models.OutboxStatusPending, claim.Attempts, claim.LastAttemptAt}, args...)...)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		if _, err := r.GetByID(ctx, claim.ID.Hex()); err != nil {
+			return err
+		}
+		return utils.ErrOutboxClaimLost
+	}
+	return nil
+}
+
+func (r *outboxRepository) Requeue(ctx context.Context, id string) error {
+	return r.update(ctx, `
+		UPDATE email_outbox SET status = $2, attempts = 0, next_attempt_at = $3, last_error = ''
+		WHERE id = $1`,
+		id, models.OutboxStatusPending, time.Now())
+}
+
+func (r *outboxRepository) DeleteFinishedBefore(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := conn(ctx, r.pool).Exec(ctx, `
+		DELETE FROM email_outbox
+		WHERE (status = $1 AND sent_at < $3) OR (status = $2 AND last_attempt_at < $3)`,
+		models.OutboxStatusSent, models.OutboxStatusDead, before)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+func (r *outboxRepository) DeleteByRecipient(ctx context.Context, email string) error {
+	_, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM email_outbox WHERE recipient = $1`, email)
+	return err
+}
+
+func (r *outboxRepository) find(ctx context.Context, sql string, args ...any) ([]*models.OutboxMessage, error) {
+	rows, err := conn(ctx, r.pool).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var messages []*models.OutboxMessage
+	for rows.Next() {
+		message, err := scanOutboxMessage(rows)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, message)
+	}
+	return messages, rows.Err()
+}
@@ -0,0 +1,148 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"backend/models"
+	"backend/repositories/interfaces"
+	"backend/utils"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const permissionColumns = `id, role, menu_id, granted_by_id, granted_by_name, created_at`
+
+type permissionRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPermissionRepository(pool *pgxpool.Pool) interfaces.PermissionRepository {
+	return &permissionRepository{pool: pool}
+}
+
+func scanPermission(row pgx.Row) (*models.RoleMenuPermission, error) {
+	var permission models.RoleMenuPermission
+	var id, menuID, grantedByID string
+	if err := row.Scan(&id, &permission.Role, &menuID, &grantedByID, &permission.GrantedByName, &permission.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if permission.ID, err = primitive.ObjectIDFromHex(id); err != nil {
+		return nil, err
+	}
+	if permission.MenuID, err = primitive.ObjectIDFromHex(menuID); err != nil {
+		return nil, err
+	}
+	if permission.GrantedByID, err = primitive.ObjectIDFromHex(grantedByID); err != nil {
+		return nil, err
+	}
+	return &permission, nil
+}
+
+// query runs a SELECT over permissions and scans every row, in grant order
+func (r *permissionRepository) query(ctx context.Context, where string, args ...any) ([]*models.RoleMenuPermission, error) {
+	rows, err := conn(ctx, r.pool).Query(ctx, `SELECT `+permissionColumns+` FROM role_menu_permissions WHERE `+where+` ORDER BY created_at, id`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var permissions []*models.RoleMenuPermission
+	for rows.Next() {
+		permission, err := scanPermission(rows)
+		if err != nil {
+			return nil, err
+		}
+		permissions = append(permissions, permission)
+	}
+	return permissions, rows.Err()
+}
+
+// GrantPermission stores a grant. The foreign key on menu_id rejects grants
+// for menus that do not exist.
+func (r *permissionRepository) GrantPermission(ctx context.Context, permission *models.RoleMenuPermission) error {
+	permission.ID = primitive.NewObjectID()
+	permission.CreatedAt = time.Now()
+
+	_, err := conn(ctx, r.pool).Exec(ctx, `INSERT INTO role_menu_permissions (`+permissionColumns+`) VALUES (`+placeholders(1, 6)+`)`,
+		permission.ID.Hex(), permission.Role, permission.MenuID.Hex(), permission.GrantedByID.Hex(),
+		permission.GrantedByName, permission.CreatedAt)
+	switch errorCode(err) {
+	case uniqueViolation:
+		return utils.ErrPermissionAlreadyExists
+	case foreignKeyViolation:
+		return utils.ErrMenuNotFound
+	}
+	return err
+}
+
+func (r *permissionRepository) RevokePermission(ctx context.Context, role, menuID string) error {
+	if _, err := primitive.ObjectIDFromHex(menuID); err != nil {
+		return utils.ErrInvalidID
+	}
+
+	tag, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM role_menu_permissions WHERE role = $1 AND menu_id = $2`, role, menuID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return utils.ErrPermissionNotFound
+	}
+	return nil
+}
+
+func (r *permissionRepository) GetPermissionsByRole(ctx context.Context, role string) ([]*models.RoleMenuPermission, error) {
+	return r.query(ctx, `role = $1`, role)
+}
+
+func (r *permissionRepository) GetRolesByMenu(ctx context.Context, menuID string) ([]*models.RoleMenuPermission, error) {
+	if _, err := primitive.ObjectIDFromHex(menuID); err != nil {
+		return nil, utils.ErrInvalidID
+	}
+
+	return r.query(ctx, `menu_id = $1`, menuID)
+}
+
+func (r *permissionRepository) GetAllPermissions(ctx context.Context) ([]*models.RoleMenuPermission, error) {
+	return r.query(ctx, `TRUE`)
+}
+
+func (r *permissionRepository) CheckPermission(ctx context.Context, role, menuID string) (bool, error) {
+	if _, err := primitive.ObjectIDFromHex(menuID); err != nil {
+		return false, utils.ErrInvalidID
+	}
+
+	var exists bool
+	err := conn(ctx, r.pool).QueryRow(ctx,
+		`SELECT EXISTS (SELECT 1 FROM role_menu_permissions WHERE role = $1 AND menu_id = $2)`, role, menuID).Scan(&exists)
+	return exists, err
+}
+
+func (r *permissionRepository) RevokeAllPermissionsForMenu(ctx context.Context, menuID string) error {
+	if _, err := primitive.ObjectIDFromHex(menuID); err != nil {
+		return utils.ErrInvalidID
+	}
+
+	_, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM role_menu_permissions WHERE menu_id = $1`, menuID)
+	return err
+}
+
+func (r *permissionRepository) RevokeAllPermissionsForRole(ctx context.Context, role string) error {
+	_, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM role_menu_permissions WHERE role = $1`, role)
+	return err
+}
+
+// PseudonymizeGrantor replaces the stored name of the admin who granted permissions
+func (r *permissionRepository) PseudonymizeGrantor(ctx context.Context, grantedByID, pseudonym string) error {
+	if _, err := primitive.ObjectIDFromHex(grantedByID); err != nil {
+		return utils.ErrInvalidID
+	}
+
+	_, err := conn(ctx, r.pool).Exec(ctx, `UPDATE role_menu_permissions SET granted_by_name = $2 WHERE granted_by_id = $1`,
+		grantedByID, pseudonym)
+	return err
+}
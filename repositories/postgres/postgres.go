@@ -0,0 +1,83 @@
+// Package postgres implements the repository interfaces on PostgreSQL. The
+// schema lives in the database package. IDs are stored as ObjectID hex
+// strings and every error is mapped to the same sentinel errors the MongoDB
+// repositories return.
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"backend/repositories"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// PostgreSQL error codes mapped to sentinel errors or retried
+const (
+	uniqueViolation      = "23505"
+	foreignKeyViolation  = "23503"
+	serializationFailure = "40001"
+	deadlockDetected     = "40P01"
+)
+
+// NewRepositories builds every repository on the given connection pool
+func NewRepositories(pool *pgxpool.Pool) *repositories.Repositories {
+	return &repositories.Repositories{
+		Users:         NewUserRepository(pool),
+		Tokens:        NewTokenRepository(pool),
+		Permissions:   NewPermissionRepository(pool),
+		Menus:         NewMenuRepository(pool),
+		BulkJobs:      NewBulkJobRepository(pool),
+		Audit:         NewAuditRepository(pool),
+		Jobs:          NewJobRepository(pool),
+		Outbox:        NewOutboxRepository(pool),
+		Notifications: NewNotificationRepository(pool),
+		Devices:       NewDeviceRepository(pool),
+		Webhooks:      NewWebhookRepository(pool),
+		RateLimits:    NewRateLimitRepository(pool),
+		Transactor:    NewTransactor(pool),
+	}
+}
+
+// errorCode returns the SQLSTATE of a PostgreSQL error, or "" for other errors
+func errorCode(err error) string {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code
+	}
+	return ""
+}
+
+// parseOptionalID converts a nullable hex column to an ObjectID pointer
+func parseOptionalID(hex *string) (*primitive.ObjectID, error) {
+	if hex == nil {
+		return nil, nil
+	}
+	id, err := primitive.ObjectIDFromHex(*hex)
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
+
+// optionalHex converts an ObjectID pointer to a nullable hex column value
+func optionalHex(id *primitive.ObjectID) *string {
+	if id == nil {
+		return nil
+	}
+	hex := id.Hex()
+	return &hex
+}
+
+// placeholders returns "$from, $from+1, ..." for n parameters
+func placeholders(from, n int) string {
+	params := make([]string, n)
+	for i := range params {
+		params[i] = fmt.Sprintf("$%d", from+i)
+	}
+	return strings.Join(params, ", ")
+}
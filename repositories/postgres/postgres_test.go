@@ -0,0 +1,42 @@
+package postgres_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"backend/database"
+	"backend/migrations"
+	"backend/repositories"
+	"backend/repositories/conformance"
+	"backend/repositories/postgres"
+)
+
+// TestConformance runs against the database in POSTGRES_TEST_URL. Every
+// table is truncated before each subtest, so never point it at real data.
+func TestConformance(t *testing.T) {
+	url := os.Getenv("POSTGRES_TEST_URL")
+	if url == "" {
+		t.Skip("POSTGRES_TEST_URL not set")
+	}
+
+	ctx := context.Background()
+	pool, err := database.OpenPostgres(ctx, url)
+	if err != nil {
+		t.Fatalf("failed to open PostgreSQL: %v", err)
+	}
+	defer pool.Close()
+
+	if err := migrations.ForPostgres(pool).Up(ctx); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+
+	conformance.Run(t, func(t *testing.T) *repositories.Repositories {
+		_, err := pool.Exec(ctx, `TRUNCATE users, refresh_tokens, menus, role_menu_permissions, bulk_jobs, audit_events, job_runs, locks, email_outbox,
+			notifications, notification_preferences, user_devices, webhooks, webhook_deliveries, rate_limits`)
+		if err != nil {
+			t.Fatalf("failed to truncate tables: %v", err)
+		}
+		return postgres.NewRepositories(pool)
+	})
+}
@@ -0,0 +1,79 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"backend/repositories/interfaces"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// refilledTokens is the bucket's tokens after refilling at $3 tokens per
+// second, up to $2, for the time since it was last used until $4
+const refilledTokens = `LEAST($2::float8, rate_limits.tokens +
+	GREATEST(EXTRACT(EPOCH FROM $4::timestamptz - rate_limits.updated_at)::float8, 0) * $3::float8)`
+
+// rateLimitRepository keeps counters and token buckets in one table. Every
+// update is a single upsert, so replicas share limits without locking.
+type rateLimitRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewRateLimitRepository(pool *pgxpool.Pool) interfaces.RateLimitRepository {
+	return &rateLimitRepository{pool: pool}
+}
+
+func (r *rateLimitRepository) Increment(ctx context.Context, key string, expiresAt time.Time) (int64, error) {
+	var count int64
+	err := conn(ctx, r.pool).QueryRow(ctx, `
+		INSERT INTO rate_limits (key, count, expires_at) VALUES ($1, 1, $2)
+		ON CONFLICT (key) DO UPDATE SET count = rate_limits.count + 1
+		RETURNING count`,
+		key, expiresAt,
+	).Scan(&count)
+	return count, err
+}
+
+func (r *rateLimitRepository) Decrement(ctx context.Context, key string) error {
+	_, err := conn(ctx, r.pool).Exec(ctx, `UPDATE rate_limits SET count = count - 1 WHERE key = $1 AND count > 0`, key)
+	return err
+}
+
+func (r *rateLimitRepository) Count(ctx context.Context, key string) (int64, error) {
+	var count int64
+	err := conn(ctx, r.pool).QueryRow(ctx, `SELECT count FROM rate_limits WHERE key = $1 AND expires_at > $2`, key, time.Now()).Scan(&count)
+	if err == pgx.ErrNoRows {
+		return 0, nil
+	}
+	return count, err
+}
+
+// TakeToken evaluates the refill against the row the upsert locks, so
+// concurrent requests each see the bucket the previous one left
+func (r *rateLimitRepository) TakeToken(ctx context.Context, key string, capacity, rate float64, now time.Time) (bool, float64, error) {
+	var (
+		allowed bool
+		tokens  float64
+	)
+	err := conn(ctx, r.pool).QueryRow(ctx, `
+		INSERT INTO rate_limits (key, tokens, allowed, updated_at, expires_at) VALUES ($1, $2::float8 - 1, TRUE, $4, $5)
+		ON CONFLICT (key) DO UPDATE SET
+			allowed = `+refilledTokens+` >= 1,
+			tokens = `+refilledTokens+` - CASE WHEN `+refilledTokens+` >= 1 THEN 1 ELSE 0 END,
+			updated_at = EXCLUDED.updated_at,
+			expires_at = EXCLUDED.expires_at
+		RETURNING allowed, tokens`,
+		key, capacity, rate, now, now.Add(time.Duration(capacity/rate*float64(time.Second))),
+	).Scan(&allowed, &tokens)
+	return allowed, tokens, err
+}
+
+func (r *rateLimitRepository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM rate_limits WHERE expires_at < $1`, before)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,128 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"backend/models"
+	"backend/repositories/interfaces"
+	"backend/utils"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const tokenColumns = `id, user_id, token, expires_at, created_at, is_revoked`
+
+type tokenRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewTokenRepository(pool *pgxpool.Pool) interfaces.TokenRepository {
+	return &tokenRepository{pool: pool}
+}
+
+func scanToken(row pgx.Row) (*models.RefreshToken, error) {
+	var token models.RefreshToken
+	var id, userID string
+	if err := row.Scan(&id, &userID, &token.Token, &token.ExpiresAt, &token.CreatedAt, &token.IsRevoked); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if token.ID, err = primitive.ObjectIDFromHex(id); err != nil {
+		return nil, err
+	}
+	if token.UserID, err = primitive.ObjectIDFromHex(userID); err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *tokenRepository) Create(ctx context.Context, token *models.RefreshToken) error {
+	token.ID = primitive.NewObjectID()
+	token.CreatedAt = time.Now()
+	token.IsRevoked = false
+
+	_, err := conn(ctx, r.pool).Exec(ctx, `INSERT INTO refresh_tokens (`+tokenColumns+`) VALUES (`+placeholders(1, 6)+`)`,
+		token.ID.Hex(), token.UserID.Hex(), token.Token, token.ExpiresAt, token.CreatedAt, token.IsRevoked)
+	return err
+}
+
+func (r *tokenRepository) GetByToken(ctx context.Context, token string) (*models.RefreshToken, error) {
+	refreshToken, err := scanToken(conn(ctx, r.pool).QueryRow(ctx,
+		`SELECT `+tokenColumns+` FROM refresh_tokens WHERE token = $1 LIMIT 1`, token))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, utils.ErrTokenNotFound
+	}
+	return refreshToken, err
+}
+
+func (r *tokenRepository) GetByUserID(ctx context.Context, userID string) ([]models.RefreshToken, error) {
+	if _, err := primitive.ObjectIDFromHex(userID); err != nil {
+		return nil, err
+	}
+
+	rows, err := conn(ctx, r.pool).Query(ctx, `
+		SELECT `+tokenColumns+` FROM refresh_tokens
+		WHERE user_id = $1 AND NOT is_revoked AND expires_at > $2
+		ORDER BY created_at`,
+		userID, time.Now(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tokens := []models.RefreshToken{}
+	for rows.Next() {
+		token, err := scanToken(rows)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, *token)
+	}
+	return tokens, rows.Err()
+}
+
+func (r *tokenRepository) RevokeToken(ctx context.Context, token string) error {
+	tag, err := conn(ctx, r.pool).Exec(ctx, `UPDATE refresh_tokens SET is_revoked = TRUE WHERE token = $1 AND NOT is_revoked`, token)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		var exists bool
+		if err := conn(ctx, r.pool).QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM refresh_tokens WHERE token = $1)`, token).Scan(&exists); err != nil {
+			return err
+		}
+		if exists {
+			return utils.ErrTokenRevoked
+		}
+		return utils.ErrTokenNotFound
+	}
+	return nil
+}
+
+func (r *tokenRepository) RevokeAllUserTokens(ctx context.Context, userID string) error {
+	if _, err := primitive.ObjectIDFromHex(userID); err != nil {
+		return err
+	}
+
+	_, err := conn(ctx, r.pool).Exec(ctx, `UPDATE refresh_tokens SET is_revoked = TRUE WHERE user_id = $1`, userID)
+	return err
+}
+
+func (r *tokenRepository) DeleteExpiredTokens(ctx context.Context) error {
+	_, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM refresh_tokens WHERE is_revoked OR expires_at < $1`, time.Now())
+	return err
+}
+
+// CountActive counts refresh tokens that are neither revoked nor expired
+func (r *tokenRepository) CountActive(ctx context.Context) (int64, error) {
+	var count int64
+	err := conn(ctx, r.pool).QueryRow(ctx,
+		`SELECT COUNT(*) FROM refresh_tokens WHERE NOT is_revoked AND expires_at > $1`, time.Now()).Scan(&count)
+	return count, err
+}
@@ -0,0 +1,70 @@
+package postgres
+
+import (
+	"context"
+
+	"backend/repositories/interfaces"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// maxTransactionAttempts bounds retries after serialization failures and deadlocks
+const maxTransactionAttempts = 3
+
+type txKey struct{}
+
+// querier is implemented by both the pool and a transaction
+type querier interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// conn returns the transaction carried by ctx, or the pool outside a unit of work
+func conn(ctx context.Context, pool *pgxpool.Pool) querier {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx
+	}
+	return pool
+}
+
+// transactor runs units of work at repeatable read, the closest match to
+// MongoDB's snapshot transactions. Concurrent updates of the same row then
+// fail with a serialization error and the unit of work is retried.
+type transactor struct {
+	pool *pgxpool.Pool
+}
+
+func NewTransactor(pool *pgxpool.Pool) interfaces.Transactor {
+	return &transactor{pool: pool}
+}
+
+func (t *transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	// Join the caller's transaction
+	if _, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return fn(ctx)
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := t.run(ctx, fn)
+		code := errorCode(err)
+		if attempt == maxTransactionAttempts || (code != serializationFailure && code != deadlockDetected) {
+			return err
+		}
+	}
+}
+
+func (t *transactor) run(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx, err := t.pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,377 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"backend/models"
+	"backend/repositories/interfaces"
+	"backend/utils"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const userColumns = `id, name, email, password, role, is_verified, verified_at, verified_by,
+	verification_notes, last_password_reset, password_reset_count, is_suspended, suspended_at,
+	suspended_by, suspension_reason, last_login_at, erased_at, created_at, updated_at, locale`
+
+type userRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewUserRepository(pool *pgxpool.Pool) interfaces.UserRepository {
+	return &userRepository{pool: pool}
+}
+
+func scanUser(row pgx.Row) (*models.User, error) {
+	var user models.User
+	var id string
+	var verifiedBy, suspendedBy *string
+	err := row.Scan(
+		&id, &user.Name, &user.Email, &user.Password, &user.Role, &user.IsVerified,
+		&user.VerifiedAt, &verifiedBy, &user.VerificationNotes, &user.LastPasswordReset,
+		&user.PasswordResetCount, &user.IsSuspended, &user.SuspendedAt, &suspendedBy,
+		&user.SuspensionReason, &user.LastLoginAt, &user.ErasedAt, &user.CreatedAt, &user.UpdatedAt,
+		&user.Locale,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.ID, err = primitive.ObjectIDFromHex(id); err != nil {
+		return nil, err
+	}
+	if user.VerifiedBy, err = parseOptionalID(verifiedBy); err != nil {
+		return nil, err
+	}
+	if user.SuspendedBy, err = parseOptionalID(suspendedBy); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// query runs a SELECT over users and scans every row
+func (r *userRepository) query(ctx context.Context, sql string, args ...any) ([]*models.User, error) {
+	rows, err := conn(ctx, r.pool).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []*models.User
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	return users, rows.Err()
+}
+
+// update runs an UPDATE on the user with the given ID, mapping an unknown ID to ErrUserNotFound
+func (r *userRepository) update(ctx context.Context, sql string, args ...any) error {
+	tag, err := conn(ctx, r.pool).Exec(ctx, sql, args...)
+	if err != nil {
+		if errorCode(err) == uniqueViolation {
+			return utils.ErrUserAlreadyExists
+		}
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return utils.ErrUserNotFound
+	}
+	return nil
+}
+
+func (r *userRepository) Create(ctx context.Context, user *models.User) error {
+	user.ID = primitive.NewObjectID()
+	user.CreatedAt = time.Now()
+	user.UpdatedAt = time.Now()
+
+	_, err := conn(ctx, r.pool).Exec(ctx, `INSERT INTO users (`+userColumns+`) VALUES (`+placeholders(1, 20)+`)`,
+		user.ID.Hex(), user.Name, user.Email, user.Password, user.Role, user.IsVerified,
+		user.VerifiedAt, optionalHex(user.VerifiedBy), user.VerificationNotes, user.LastPasswordReset,
+		user.PasswordResetCount, user.IsSuspended, user.SuspendedAt, optionalHex(user.SuspendedBy),
+		user.SuspensionReason, user.LastLoginAt, user.ErasedAt, user.CreatedAt, user.UpdatedAt,
+		user.Locale,
+	)
+	if errorCode(err) == uniqueViolation {
+		return utils.ErrUserAlreadyExists
+	}
+	return err
+}
+
+func (r *userRepository) GetByID(ctx context.Context, id string) (*models.User, error) {
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return nil, utils.ErrUserNotFound
+	}
+
+	user, err := scanUser(conn(ctx, r.pool).QueryRow(ctx, `SELECT `+userColumns+` FROM users WHERE id = $1`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, utils.ErrUserNotFound
+	}
+	return user, err
+}
+
+func (r *userRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
+	user, err := scanUser(conn(ctx, r.pool).QueryRow(ctx, `SELECT `+userColumns+` FROM users WHERE email = $1`, email))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, utils.ErrUserNotFound
+	}
+	return user, err
+}
+
+func (r *userRepository) Update(ctx context.Context, user *models.User) error {
+	user.UpdatedAt = time.Now()
+
+	return r.update(ctx, `
+		UPDATE users SET name = $2, email = $3, role = $4, is_verified = $5, verified_at = $6,
+			verified_by = $7, verification_notes = $8, updated_at = $9, locale = $10
+		WHERE id = $1`,
+		user.ID.Hex(), user.Name, user.Email, user.Role, user.IsVerified, user.VerifiedAt,
+		optionalHex(user.VerifiedBy), user.VerificationNotes, user.UpdatedAt, user.Locale,
+	)
+}
+
+func (r *userRepository) Delete(ctx context.Context, id string) error {
+	tag, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM users WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return utils.ErrUserNotFound
+	}
+	return nil
+}
+
+func (r *userRepository) GetPendingUsers(ctx context.Context) ([]*models.User, error) {
+	return r.query(ctx, `SELECT `+userColumns+` FROM users WHERE NOT is_verified ORDER BY created_at, id`)
+}
+
+func (r *userRepository) VerifyUser(ctx context.Context, userID, adminID string, notes string) error {
+	if _, err := primitive.ObjectIDFromHex(userID); err != nil {
+		return err
+	}
+	if _, err := primitive.ObjectIDFromHex(adminID); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	return r.update(ctx, `
+		UPDATE users SET is_verified = TRUE, verified_at = $2, verified_by = $3,
+			verification_notes = $4, updated_at = $2
+		WHERE id = $1`,
+		userID, now, adminID, notes,
+	)
+}
+
+// UpdatePassword updates only the user's password
+func (r *userRepository) UpdatePassword(ctx context.Context, userID, hashedPassword string) error {
+	if _, err := primitive.ObjectIDFromHex(userID); err != nil {
+		return err
+	}
+
+	return r.update(ctx, `UPDATE users SET password = $2, updated_at = $3 WHERE id = $1`,
+		userID, hashedPassword, time.Now())
+}
+
+// UpdatePasswordResetInfo updates password reset tracking information
+func (r *userRepository) UpdatePasswordResetInfo(ctx context.Context, userID string) error {
+	if _, err := primitive.ObjectIDFromHex(userID); err != nil {
+		return err
+	}
+
+	return r.update(ctx, `
+		UPDATE users SET last_password_reset = $2, updated_at = $2,
+			password_reset_count = password_reset_count + 1
+		WHERE id = $1`,
+		userID, time.Now(),
+	)
+}
+
+// CountUsersByRole counts the number of users with a specific role
+func (r *userRepository) CountUsersByRole(ctx context.Context, role string) (int64, error) {
+	var count int64
+	err := conn(ctx, r.pool).QueryRow(ctx, `SELECT COUNT(*) FROM users WHERE role = $1`, role).Scan(&count)
+	return count, err
+}
+
+// CountActiveUsersByRole counts users with a specific role that are neither suspended nor erased
+func (r *userRepository) CountActiveUsersByRole(ctx context.Context, role string) (int64, error) {
+	var count int64
+	err := conn(ctx, r.pool).QueryRow(ctx, `SELECT COUNT(*) FROM users WHERE role = $1 AND NOT is_suspended AND erased_at IS NULL`, role).Scan(&count)
+	return count, err
+}
+
+// FindByFilter returns users matching the filter, oldest first. A limit of 0 means no limit.
+func (r *userRepository) FindByFilter(ctx context.Context, filter *models.UserFilter, limit int64) ([]*models.User, error) {
+	conditions := []string{"TRUE"}
+	var args []any
+	where := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter != nil {
+		if filter.Role != "" {
+			where("role = $%d", filter.Role)
+		}
+		if filter.IsVerified != nil {
+			where("is_verified = $%d", *filter.IsVerified)
+		}
+		if filter.IsSuspended != nil {
+			where("is_suspended = $%d", *filter.IsSuspended)
+		}
+		if filter.CreatedAfter != nil {
+			where("created_at >= $%d", *filter.CreatedAfter)
+		}
+		if filter.CreatedBefore != nil {
+			where("created_at <= $%d", *filter.CreatedBefore)
+		}
+	}
+
+	sql := `SELECT ` + userColumns + ` FROM users WHERE ` + strings.Join(conditions, " AND ") + ` ORDER BY created_at, id`
+	if limit > 0 {
+		args = append(args, limit)
+		sql += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	return r.query(ctx, sql, args...)
+}
+
+// SuspendUser marks a user as suspended by the given admin
+func (r *userRepository) SuspendUser(ctx context.Context, userID, adminID string, reason string) error {
+	if _, err := primitive.ObjectIDFromHex(userID); err != nil {
+		return utils.ErrUserNotFound
+	}
+	if _, err := primitive.ObjectIDFromHex(adminID); err != nil {
+		return utils.ErrInvalidID
+	}
+
+	return r.update(ctx, `
+		UPDATE users SET is_suspended = TRUE, suspended_at = $2, suspended_by = $3,
+			suspension_reason = $4, updated_at = $2
+		WHERE id = $1`,
+		userID, time.Now(), adminID, reason,
+	)
+}
+
+// ReinstateUser lifts a user's suspension
+func (r *userRepository) ReinstateUser(ctx context.Context, userID string) error {
+	return r.update(ctx, `
+		UPDATE users SET is_suspended = FALSE, updated_at = $2, suspended_at = NULL,
+			suspended_by = NULL, suspension_reason = ''
+		WHERE id = $1`,
+		userID, time.Now(),
+	)
+}
+
+// UpdateLastLogin records the time of the user's latest successful login
+func (r *userRepository) UpdateLastLogin(ctx context.Context, userID string) error {
+	return r.update(ctx, `UPDATE users SET last_login_at = $2 WHERE id = $1`, userID, time.Now())
+}
+
+// StreamAll walks every user ordered by email.
+// Iteration stops at the first error returned by fn.
+func (r *userRepository) StreamAll(ctx context.Context, fn func(user *models.User) error) error {
+	rows, err := conn(ctx, r.pool).Query(ctx, `SELECT `+userColumns+` FROM users ORDER BY email`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return err
+		}
+		if err := fn(user); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// Anonymize replaces personal data in place so references to the user stay valid
+func (r *userRepository) Anonymize(ctx context.Context, userID, name, email, hashedPassword string) error {
+	return r.update(ctx, `
+		UPDATE users SET name = $2, email = $3, password = $4, erased_at = $5, updated_at = $5,
+			verification_notes = '', suspension_reason = '', last_login_at = NULL,
+			last_password_reset = NULL
+		WHERE id = $1`,
+		userID, name, email, hashedPassword, time.Now(),
+	)
+}
+
+// CountByRoleAndStatus returns per-role totals split by verification and suspension status.
+// Erased users are excluded.
+func (r *userRepository) CountByRoleAndStatus(ctx context.Context) ([]models.RoleStatusCount, error) {
+	rows, err := conn(ctx, r.pool).Query(ctx, `
+		SELECT role, COUNT(*),
+			COUNT(*) FILTER (WHERE is_verified),
+			COUNT(*) FILTER (WHERE NOT is_verified),
+			COUNT(*) FILTER (WHERE is_suspended)
+		FROM users
+		WHERE erased_at IS NULL
+		GROUP BY role
+		ORDER BY role`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := []models.RoleStatusCount{}
+	for rows.Next() {
+		var count models.RoleStatusCount
+		if err := rows.Scan(&count.Role, &count.Total, &count.Verified, &count.Pending, &count.Suspended); err != nil {
+			return nil, err
+		}
+		counts = append(counts, count)
+	}
+	return counts, rows.Err()
+}
+
+// CountPendingByCreatedAt buckets unverified, non-suspended users by registration time.
+// Boundaries must be ascending; each result's Period is the lower boundary of its bucket
+// and users registered outside the boundaries are not counted.
+func (r *userRepository) CountPendingByCreatedAt(ctx context.Context, boundaries []time.Time) ([]models.PeriodCount, error) {
+	counts := []models.PeriodCount{}
+	if len(boundaries) < 2 {
+		return counts, nil
+	}
+
+	rows, err := conn(ctx, r.pool).Query(ctx, `
+		SELECT b.lower, COUNT(*)
+		FROM users u
+		JOIN unnest($1::timestamptz[], $2::timestamptz[]) AS b(lower, upper)
+			ON u.created_at >= b.lower AND u.created_at < b.upper
+		WHERE NOT u.is_verified AND NOT u.is_suspended
+		GROUP BY b.lower
+		ORDER BY b.lower`,
+		boundaries[:len(boundaries)-1], boundaries[1:],
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var count models.PeriodCount
+		if err := rows.Scan(&count.Period, &count.Count); err != nil {
+			return nil, err
+		}
+		counts = append(counts, count)
+	}
+	return counts, rows.Err()
+}
+
+// CountRegistrationsByPeriod counts new users per time bucket
+func (r *userRepository) CountRegistrationsByPeriod(ctx context.Context, query *models.StatsQuery) ([]models.PeriodCount, error) {
+	return countByPeriod(ctx, r.pool, "users", "", nil, query)
+}
@@ -0,0 +1,331 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"backend/models"
+	"backend/repositories/interfaces"
+	"backend/utils"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	webhookColumns = `id, url, description, events, secret, enabled, consecutive_failures,
+	disabled_reason, disabled_at, created_by, created_at, updated_at`
+	webhookDeliveryColumns = `id, webhook_id, event_id, event_type, payload, redelivery_of, status, attempts,
+	response_status, response_body, duration_ms, last_error, next_attempt_at, last_attempt_at, delivered_at, created_at`
+)
+
+type webhookRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewWebhookRepository(pool *pgxpool.Pool) interfaces.WebhookRepository {
+	return &webhookRepository{pool: pool}
+}
+
+func scanWebhook(row pgx.Row) (*models.Webhook, error) {
+	var webhook models.Webhook
+	var id, createdBy string
+	err := row.Scan(&id, &webhook.URL, &webhook.Description, &webhook.Events, &webhook.Secret, &webhook.Enabled,
+		&webhook.ConsecutiveFailures, &webhook.DisabledReason, &webhook.DisabledAt, &createdBy,
+		&webhook.CreatedAt, &webhook.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if webhook.ID, err = primitive.ObjectIDFromHex(id); err != nil {
+		return nil, err
+	}
+	if webhook.CreatedBy, err = primitive.ObjectIDFromHex(createdBy); err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+func scanWebhookDelivery(row pgx.Row) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	var id, webhookID string
+	var redeliveryOf *string
+	err := row.Scan(&id, &webhookID, &delivery.EventID, &delivery.EventType, &delivery.Payload, &redeliveryOf,
+		&delivery.Status, &delivery.Attempts, &delivery.ResponseStatus, &delivery.ResponseBody, &delivery.DurationMs,
+		&delivery.LastError, &delivery.NextAttemptAt, &delivery.LastAttemptAt, &delivery.DeliveredAt, &delivery.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if delivery.ID, err = primitive.ObjectIDFromHex(id); err != nil {
+		return nil, err
+	}
+	if delivery.WebhookID, err = primitive.ObjectIDFromHex(webhookID); err != nil {
+		return nil, err
+	}
+	if delivery.RedeliveryOf, err = parseOptionalID(redeliveryOf); err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+func (r *webhookRepository) Create(ctx context.Context, webhook *models.Webhook) error {
+	webhook.ID = primitive.NewObjectID()
+	webhook.CreatedAt = time.Now()
+	webhook.UpdatedAt = webhook.CreatedAt
+
+	_, err := conn(ctx, r.pool).Exec(ctx, `INSERT INTO webhooks (`+webhookColumns+`) VALUES (`+placeholders(1, 12)+`)`,
+		webhook.ID.Hex(), webhook.URL, webhook.Description, webhook.Events, webhook.Secret, webhook.Enabled,
+		webhook.ConsecutiveFailures, webhook.DisabledReason, webhook.DisabledAt, webhook.CreatedBy.Hex(),
+		webhook.CreatedAt, webhook.UpdatedAt,
+	)
+	return err
+}
+
+func (r *webhookRepository) GetByID(ctx context.Context, id string) (*models.Webhook, error) {
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return nil, utils.ErrInvalidID
+	}
+
+	webhook, err := scanWebhook(conn(ctx, r.pool).QueryRow(ctx, `SELECT `+webhookColumns+` FROM webhooks WHERE id = $1`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, utils.ErrWebhookNotFound
+	}
+	return webhook, err
+}
+
+func (r *webhookRepository) List(ctx context.Context) ([]*models.Webhook, error) {
+	return r.find(ctx, `SELECT `+webhookColumns+` FROM webhooks ORDER BY created_at DESC, id DESC`)
+}
+
+func (r *webhookRepository) ListSubscribed(ctx context.Context, eventType string) ([]*models.Webhook, error) {
+	return r.find(ctx, `
+		SELECT `+webhookColumns+` FROM webhooks
+		WHERE enabled AND events && ARRAY[$1, $2]
+		ORDER BY created_at DESC, id DESC`,
+		eventType, models.WebhookAllEvents)
+}
+
+func (r *webhookRepository) find(ctx context.Context, sql string, args ...any) ([]*models.Webhook, error) {
+	rows, err := conn(ctx, r.pool).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var webhooks []*models.Webhook
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, webhook)
+	}
+	return webhooks, rows.Err()
+}
+
+func (r *webhookRepository) Update(ctx context.Context, webhook *models.Webhook) error {
+	webhook.UpdatedAt = time.Now()
+
+	reason := webhook.DisabledReason
+	if webhook.DisabledAt == nil {
+		reason = ""
+	}
+
+	tag, err := conn(ctx, r.pool).Exec(ctx, `
+		UPDATE webhooks SET url = $2, description = $3, events = $4, secret = $5, enabled = $6,
+			consecutive_failures = $7, disabled_reason = $8, disabled_at = $9, updated_at = $10
+		WHERE id = $1`,
+		webhook.ID.Hex(), webhook.URL, webhook.Description, webhook.Events, webhook.Secret, webhook.Enabled,
+		webhook.ConsecutiveFailures, reason, webhook.DisabledAt, webhook.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return utils.ErrWebhookNotFound
+	}
+	return nil
+}
+
+// Delete removes the deliveries through the foreign key's ON DELETE CASCADE
+func (r *webhookRepository) Delete(ctx context.Context, id string) error {
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return utils.ErrInvalidID
+	}
+
+	tag, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM webhooks WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return utils.ErrWebhookNotFound
+	}
+	return nil
+}
+
+func (r *webhookRepository) RecordSuccess(ctx context.Context, id string) error {
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return utils.ErrInvalidID
+	}
+
+	tag, err := conn(ctx, r.pool).Exec(ctx, `UPDATE webhooks SET consecutive_failures = 0 WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return utils.ErrWebhookNotFound
+	}
+	return nil
+}
+
+// RecordFailure locks the row before reading whether the webhook was
+// enabled, so concurrent failures disable it exactly once
+func (r *webhookRepository) RecordFailure(ctx context.Context, id string, disableAfter int, reason string) (bool, error) {
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return false, utils.ErrInvalidID
+	}
+
+	var disabled bool
+	err := conn(ctx, r.pool).QueryRow(ctx, `
+		UPDATE webhooks w SET
+			consecutive_failures = w.consecutive_failures + 1,
+			enabled = w.enabled AND w.consecutive_failures + 1 < $2,
+			disabled_at = CASE WHEN w.enabled AND w.consecutive_failures + 1 >= $2 THEN $3 ELSE w.disabled_at END,
+			disabled_reason = CASE WHEN w.enabled AND w.consecutive_failures + 1 >= $2 THEN $4 ELSE w.disabled_reason END
+		FROM (SELECT id, enabled FROM webhooks WHERE id = $1 FOR UPDATE) previous
+		WHERE w.id = previous.id
+		RETURNING previous.enabled AND NOT w.enabled`,
+		id, disableAfter, time.Now(), reason,
+	).Scan(&disabled)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, utils.ErrWebhookNotFound
+	}
+	return disabled, err
+}
+
+func (r *webhookRepository) CreateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	delivery.ID = primitive.NewObjectID()
+	delivery.CreatedAt = time.Now()
+	delivery.NextAttemptAt = delivery.CreatedAt
+	delivery.Status = models.WebhookDeliveryPending
+
+	_, err := conn(ctx, r.pool).Exec(ctx, `INSERT INTO webhook_deliveries (`+webhookDeliveryColumns+`) VALUES (`+placeholders(1, 16)+`)`,
+		delivery.ID.Hex(), delivery.WebhookID.Hex(), delivery.EventID, delivery.EventType, delivery.Payload,
+		optionalHex(delivery.RedeliveryOf), delivery.Status, delivery.Attempts, delivery.ResponseStatus,
+		delivery.ResponseBody, delivery.DurationMs, delivery.LastError, delivery.NextAttemptAt,
+		delivery.LastAttemptAt, delivery.DeliveredAt, delivery.CreatedAt,
+	)
+	if errorCode(err) == foreignKeyViolation {
+		return utils.ErrWebhookNotFound
+	}
+	return err
+}
+
+func (r *webhookRepository) GetDelivery(ctx context.Context, id string) (*models.WebhookDelivery, error) {
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return nil, utils.ErrInvalidID
+	}
+
+	delivery, err := scanWebhookDelivery(conn(ctx, r.pool).QueryRow(ctx,
+		`SELECT `+webhookDeliveryColumns+` FROM webhook_deliveries WHERE id = $1`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, utils.ErrWebhookDeliveryNotFound
+	}
+	return delivery, err
+}
+
+func (r *webhookRepository) ListDeliveries(ctx context.Context, webhookID string, limit int64) ([]*models.WebhookDelivery, error) {
+	if _, err := primitive.ObjectIDFromHex(webhookID); err != nil {
+		return nil, utils.ErrInvalidID
+	}
+
+	return r.findDeliveries(ctx, `
+		SELECT `+webhookDeliveryColumns+` FROM webhook_deliveries WHERE webhook_id = $1
+		ORDER BY created_at DESC, id DESC LIMIT NULLIF($2, 0)`,
+		webhookID, limit)
+}
+
+// ClaimDueDeliveries skips rows another worker has locked, so concurrent
+// claims never overlap
+func (r *webhookRepository) ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.WebhookDelivery, error) {
+	return r.findDeliveries(ctx, `
+		UPDATE webhook_deliveries SET attempts = attempts + 1, last_attempt_at = $1, next_attempt_at = $2
+		WHERE id IN (
+			SELECT id FROM webhook_deliveries WHERE status = $3 AND next_attempt_at <= $1
+			ORDER BY next_attempt_at LIMIT $4 FOR UPDATE SKIP LOCKED
+		)
+		RETURNING `+webhookDeliveryColumns,
+		now, now.Add(lease), models.WebhookDeliveryPending, limit)
+}
+
+func (r *webhookRepository) MarkDelivered(ctx context.Context, id string, attempt *models.WebhookAttempt) error {
+	return r.updateDelivery(ctx, `
+		UPDATE webhook_deliveries SET status = $2, delivered_at = $3, last_error = '',
+			response_status = $4, response_body = $5, duration_ms = $6
+		WHERE id = $1`,
+		id, models.WebhookDeliveryDelivered, time.Now(),
+		attempt.ResponseStatus, attempt.ResponseBody, attempt.Duration.Milliseconds())
+}
+
+func (r *webhookRepository) MarkFailed(ctx context.Context, id string, attempt *models.WebhookAttempt, retryAt *time.Time) error {
+	if retryAt == nil {
+		return r.updateDelivery(ctx, `
+			UPDATE webhook_deliveries SET status = $2, last_error = $3,
+				response_status = $4, response_body = $5, duration_ms = $6
+			WHERE id = $1`,
+			id, models.WebhookDeliveryFailed, attempt.Error,
+			attempt.ResponseStatus, attempt.ResponseBody, attempt.Duration.Milliseconds())
+	}
+	return r.updateDelivery(ctx, `
+		UPDATE webhook_deliveries SET next_attempt_at = $2, last_error = $3,
+			response_status = $4, response_body = $5, duration_ms = $6
+		WHERE id = $1`,
+		id, *retryAt, attempt.Error,
+		attempt.ResponseStatus, attempt.ResponseBody, attempt.Duration.Milliseconds())
+}
+
+// updateDelivery runs an UPDATE on the delivery with the given ID
+func (r *webhookRepository) updateDelivery(ctx context.Context, sql string, id string, args ...any) error {
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return utils.ErrInvalidID
+	}
+
+	tag, err := conn(ctx, r.pool).Exec(ctx, sql, append([]any{id}, args...)...)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return utils.ErrWebhookDeliveryNotFound
+	}
+	return nil
+}
+
+func (r *webhookRepository) DeleteDeliveriesBefore(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := conn(ctx, r.pool).Exec(ctx, `DELETE FROM webhook_deliveries WHERE status <> $1 AND created_at < $2`,
+		models.WebhookDeliveryPending, before)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+func (r *webhookRepository) findDeliveries(ctx context.Context, sql string, args ...any) ([]*models.WebhookDelivery, error) {
+	rows, err := conn(ctx, r.pool).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []*models.WebhookDelivery
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, rows.Err()
+}
@@ -0,0 +1,104 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"backend/repositories/interfaces"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// rateLimitRepository keeps counters and token buckets in one collection,
+// keyed by _id. Every update is a single atomic upsert, so replicas share
+// limits without locking.
+type rateLimitRepository struct {
+	collection *mongo.Collection
+}
+
+func NewRateLimitRepository(db *mongo.Database) interfaces.RateLimitRepository {
+	return &rateLimitRepository{
+		collection: db.Collection("rate_limits"),
+	}
+}
+
+// Increment upserts the counter. When two requests create it at once, the
+// losing upsert fails on _id and is retried as an update.
+func (r *rateLimitRepository) Increment(ctx context.Context, key string, expiresAt time.Time) (int64, error) {
+	update := bson.M{
+		"$inc":         bson.M{"count": int64(1)},
+		"$setOnInsert": bson.M{"expires_at": expiresAt},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var counter struct {
+		Count int64 `bson:"count"`
+	}
+	err := r.collection.FindOneAndUpdate(ctx, bson.M{"_id": key}, update, opts).Decode(&counter)
+	if mongo.IsDuplicateKeyError(err) {
+		err = r.collection.FindOneAndUpdate(ctx, bson.M{"_id": key}, update, opts).Decode(&counter)
+	}
+	return counter.Count, err
+}
+
+func (r *rateLimitRepository) Decrement(ctx context.Context, key string) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": key, "count": bson.M{"$gt": 0}}, bson.M{"$inc": bson.M{"count": int64(-1)}})
+	return err
+}
+
+func (r *rateLimitRepository) Count(ctx context.Context, key string) (int64, error) {
+	var counter struct {
+		Count int64 `bson:"count"`
+	}
+	err := r.collection.FindOne(ctx, bson.M{"_id": key, "expires_at": bson.M{"$gt": time.Now()}}).Decode(&counter)
+	if err == mongo.ErrNoDocuments {
+		return 0, nil
+	}
+	return counter.Count, err
+}
+
+// TakeToken refills and takes in one pipeline update, so concurrent requests
+// each see the bucket the previous one left
+func (r *rateLimitRepository) TakeToken(ctx context.Context, key string, capacity, rate float64, now time.Time) (bool, float64, error) {
+	elapsed := bson.M{"$max": bson.A{0, bson.M{"$divide": bson.A{
+		bson.M{"$subtract": bson.A{now, bson.M{"$ifNull": bson.A{"$updated_at", now}}}},
+		1000,
+	}}}}
+	refilled := bson.M{"$min": bson.A{capacity, bson.M{"$add": bson.A{
+		bson.M{"$ifNull": bson.A{"$tokens", capacity}},
+		bson.M{"$multiply": bson.A{elapsed, rate}},
+	}}}}
+	pipeline := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{"tokens": refilled}}},
+		{{Key: "$set", Value: bson.M{"allowed": bson.M{"$gte": bson.A{"$tokens", 1}}}}},
+		{{Key: "$set", Value: bson.M{
+			"tokens":     bson.M{"$cond": bson.A{"$allowed", bson.M{"$subtract": bson.A{"$tokens", 1}}, "$tokens"}},
+			"updated_at": now,
+			"expires_at": now.Add(time.Duration(capacity / rate * float64(time.Second))),
+		}}},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var bucket struct {
+		Tokens  float64 `bson:"tokens"`
+		Allowed bool    `bson:"allowed"`
+	}
+	err := r.collection.FindOneAndUpdate(ctx, bson.M{"_id": key}, pipeline, opts).Decode(&bucket)
+	if mongo.IsDuplicateKeyError(err) {
+		err = r.collection.FindOneAndUpdate(ctx, bson.M{"_id": key}, pipeline, opts).Decode(&bucket)
+	}
+	if err != nil {
+		return false, 0, err
+	}
+	return bucket.Allowed, bucket.Tokens, nil
+}
+
+func (r *rateLimitRepository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.collection.DeleteMany(ctx, bson.M{"expires_at": bson.M{"$lt": before}})
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
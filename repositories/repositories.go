@@ -0,0 +1,46 @@
+package repositories
+
+import (
+	"backend/repositories/interfaces"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Repositories bundles the data access layer of one storage backend
+type Repositories struct {
+	Users         interfaces.UserRepository
+	Tokens        interfaces.TokenRepository
+	Permissions   interfaces.PermissionRepository
+	Menus         interfaces.MenuRepository
+	BulkJobs      interfaces.BulkJobRepository
+	Audit         interfaces.AuditRepository
+	Jobs          interfaces.JobRepository
+	Outbox        interfaces.OutboxRepository
+	Notifications interfaces.NotificationRepository
+	Devices       interfaces.DeviceRepository
+	Webhooks      interfaces.WebhookRepository
+	RateLimits    interfaces.RateLimitRepository
+	Transactor    interfaces.Transactor
+}
+
+// NewMongoRepositories builds every repository on the given MongoDB database
+func NewMongoRepositories(db *mongo.Database) *Repositories {
+	permissions := NewPermissionRepository(db)
+	transactor := NewTransactor(db.Client())
+
+	return &Repositories{
+		Users:         NewUserRepository(db),
+		Tokens:        NewTokenRepository(db),
+		Permissions:   permissions,
+		Menus:         NewMenuRepository(db, permissions, transactor),
+		BulkJobs:      NewBulkJobRepository(db),
+		Audit:         NewAuditRepository(db),
+		Jobs:          NewJobRepository(db),
+		Outbox:        NewOutboxRepository(db),
+		Notifications: NewNotificationRepository(db),
+		Devices:       NewDeviceRepository(db),
+		Webhooks:      NewWebhookRepository(db),
+		RateLimits:    NewRateLimitRepository(db),
+		Transactor:    transactor,
+	}
+}
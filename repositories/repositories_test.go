@@ -0,0 +1,46 @@
+package repositories_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"backend/migrations"
+	"backend/repositories"
+	"backend/repositories/conformance"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// TestConformance runs against the esp_backend_conformance database on the
+// server in MONGODB_TEST_URI, which must be a replica set member so that
+// transactions roll back. Every collection is emptied before each subtest.
+func TestConformance(t *testing.T) {
+	uri := os.Getenv("MONGODB_TEST_URI")
+	if uri == "" {
+		t.Skip("MONGODB_TEST_URI not set")
+	}
+
+	ctx := context.Background()
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	if err != nil {
+		t.Fatalf("failed to connect to MongoDB: %v", err)
+	}
+	defer client.Disconnect(ctx)
+
+	db := client.Database("esp_backend_conformance")
+	if err := migrations.ForMongo(db).Up(ctx); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+
+	conformance.Run(t, func(t *testing.T) *repositories.Repositories {
+		for _, name := range []string{"users", "refresh_tokens", "menus", "role_menu_permissions", "bulk_jobs", "audit_events", "job_runs", "locks", "email_outbox", "notifications", "notification_preferences", "user_devices", "webhooks", "webhook_deliveries", "rate_limits"} {
+			if _, err := db.Collection(name).DeleteMany(ctx, bson.M{}); err != nil {
+				t.Fatalf("failed to empty %s: %v", name, err)
+			}
+		}
+		return repositories.NewMongoRepositories(db)
+	})
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"time"
 
-	"backend/database"
 	"backend/models"
 	"backend/repositories/interfaces"
 	"backend/utils"
@@ -18,9 +17,9 @@ type tokenRepository struct {
 	collection *mongo.Collection
 }
 
-func NewTokenRepository() interfaces.TokenRepository {
+func NewTokenRepository(db *mongo.Database) interfaces.TokenRepository {
 	return &tokenRepository{
-		collection: database.DB.Collection("refresh_tokens"),
+		collection: db.Collection("refresh_tokens"),
 	}
 }
 
@@ -66,8 +65,10 @@ func (r *tokenRepository) GetByUserID(ctx context.Context, userID string) ([]mod
 	return tokens, err
 }
 
+// RevokeToken revokes a token that is not revoked yet, so of two concurrent
+// revocations only one succeeds
 func (r *tokenRepository) RevokeToken(ctx context.Context, token string) error {
-	filter := bson.M{"token": token}
+	filter := bson.M{"token": token, "is_revoked": false}
 	update := bson.M{"$set": bson.M{"is_revoked": true}}
 
 	result, err := r.collection.UpdateOne(ctx, filter, update)
@@ -76,6 +77,13 @@ func (r *tokenRepository) RevokeToken(ctx context.Context, token string) error {
 	}
 
 	if result.MatchedCount == 0 {
+		count, err := r.collection.CountDocuments(ctx, bson.M{"token": token})
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return utils.ErrTokenRevoked
+		}
 		return utils.ErrTokenNotFound
 	}
 
@@ -106,3 +114,12 @@ func (r *tokenRepository) DeleteExpiredTokens(ctx context.Context) error {
 	_, err := r.collection.DeleteMany(ctx, filter)
 	return err
 }
+
+// CountActive counts refresh tokens that are neither revoked nor expired
+func (r *tokenRepository) CountActive(ctx context.Context) (int64, error) {
+	filter := bson.M{
+		"is_revoked": false,
+		"expires_at": bson.M{"$gt": time.Now()},
+	}
+	return r.collection.CountDocuments(ctx, filter)
+}
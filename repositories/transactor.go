@@ -0,0 +1,78 @@
+package repositories
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"backend/repositories/interfaces"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// transactor runs units of work in MongoDB session transactions. The driver
+// retries the whole unit on TransientTransactionError and the commit on
+// UnknownTransactionCommitResult. Standalone servers cannot run transactions,
+// so there the unit of work runs without one.
+type transactor struct {
+	client *mongo.Client
+
+	mu        sync.Mutex
+	checked   bool
+	supported bool
+}
+
+func NewTransactor(client *mongo.Client) interfaces.Transactor {
+	return &transactor{client: client}
+}
+
+func (t *transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	// Join the caller's transaction
+	if mongo.SessionFromContext(ctx) != nil || !t.transactionsSupported() {
+		return fn(ctx)
+	}
+
+	session, err := t.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sessionCtx mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sessionCtx)
+	})
+	return err
+}
+
+// transactionsSupported reports whether the server is a replica set member
+// or mongos. A failed check is retried on the next call.
+func (t *transactor) transactionsSupported() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.checked {
+		return t.supported
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var hello struct {
+		SetName string `bson:"setName"`
+		Msg     string `bson:"msg"`
+	}
+	err := t.client.Database("admin").RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&hello)
+	if err != nil {
+		slog.Warn("Failed to detect MongoDB transaction support", "error", err)
+		return false
+	}
+
+	t.checked = true
+	t.supported = hello.SetName != "" || hello.Msg == "isdbgrid"
+	if !t.supported {
+		slog.Warn("MongoDB is a standalone server; multi-document operations run without transactions")
+	}
+	return t.supported
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"time"
 
-	"backend/database"
 	"backend/models"
 	"backend/repositories/interfaces"
 	"backend/utils"
@@ -12,15 +11,16 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type userRepository struct {
 	collection *mongo.Collection
 }
 
-func NewUserRepository() interfaces.UserRepository {
+func NewUserRepository(db *mongo.Database) interfaces.UserRepository {
 	return &userRepository{
-		collection: database.DB.Collection("users"),
+		collection: db.Collection("users"),
 	}
 }
 
@@ -77,6 +77,7 @@ func (r *userRepository) Update(ctx context.Context, user *models.User) error {
 			"name":               user.Name,
 			"email":              user.Email,
 			"role":               user.Role,
+			"locale":             user.Locale,
 			"is_verified":        user.IsVerified,
 			"verified_at":        user.VerifiedAt,
 			"verified_by":        user.VerifiedBy,
@@ -240,3 +241,303 @@ func (r *userRepository) CountUsersByRole(ctx context.Context, role string) (int
 	}
 	return count, nil
 }
+
+// CountActiveUsersByRole counts users with a specific role that are neither suspended nor erased
+func (r *userRepository) CountActiveUsersByRole(ctx context.Context, role string) (int64, error) {
+	filter := bson.M{
+		"role":         role,
+		"is_suspended": bson.M{"$ne": true},
+		"erased_at":    bson.M{"$exists": false},
+	}
+
+	count, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// FindByFilter returns users matching the filter, oldest first. A limit of 0 means no limit.
+func (r *userRepository) FindByFilter(ctx context.Context, filter *models.UserFilter, limit int64) ([]*models.User, error) {
+	query := bson.M{}
+	if filter != nil {
+		if filter.Role != "" {
+			query["role"] = filter.Role
+		}
+		if filter.IsVerified != nil {
+			query["is_verified"] = *filter.IsVerified
+		}
+		if filter.IsSuspended != nil {
+			if *filter.IsSuspended {
+				query["is_suspended"] = true
+			} else {
+				query["is_suspended"] = bson.M{"$ne": true}
+			}
+		}
+
+		createdAt := bson.M{}
+		if filter.CreatedAfter != nil {
+			createdAt["$gte"] = *filter.CreatedAfter
+		}
+		if filter.CreatedBefore != nil {
+			createdAt["$lte"] = *filter.CreatedBefore
+		}
+		if len(createdAt) > 0 {
+			query["created_at"] = createdAt
+		}
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	if limit > 0 {
+		opts.SetLimit(limit)
+	}
+
+	cursor, err := r.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var users []*models.User
+	for cursor.Next(ctx) {
+		var user models.User
+		if err := cursor.Decode(&user); err != nil {
+			return nil, err
+		}
+		users = append(users, &user)
+	}
+
+	return users, cursor.Err()
+}
+
+// SuspendUser marks a user as suspended by the given admin
+func (r *userRepository) SuspendUser(ctx context.Context, userID, adminID string, reason string) error {
+	userObjectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return utils.ErrUserNotFound
+	}
+
+	adminObjectID, err := primitive.ObjectIDFromHex(adminID)
+	if err != nil {
+		return utils.ErrInvalidID
+	}
+
+	now := time.Now()
+	update := bson.M{
+		"$set": bson.M{
+			"is_suspended":      true,
+			"suspended_at":      &now,
+			"suspended_by":      &adminObjectID,
+			"suspension_reason": reason,
+			"updated_at":        now,
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": userObjectID}, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return utils.ErrUserNotFound
+	}
+
+	return nil
+}
+
+// ReinstateUser lifts a user's suspension
+func (r *userRepository) ReinstateUser(ctx context.Context, userID string) error {
+	userObjectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return utils.ErrUserNotFound
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"is_suspended": false,
+			"updated_at":   time.Now(),
+		},
+		"$unset": bson.M{
+			"suspended_at":      "",
+			"suspended_by":      "",
+			"suspension_reason": "",
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": userObjectID}, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return utils.ErrUserNotFound
+	}
+
+	return nil
+}
+
+// UpdateLastLogin records the time of the user's latest successful login
+func (r *userRepository) UpdateLastLogin(ctx context.Context, userID string) error {
+	userObjectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return utils.ErrUserNotFound
+	}
+
+	now := time.Now()
+	update := bson.M{"$set": bson.M{"last_login_at": &now}}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": userObjectID}, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return utils.ErrUserNotFound
+	}
+
+	return nil
+}
+
+// StreamAll walks every user ordered by email without loading them all into memory.
+// Iteration stops at the first error returned by fn.
+func (r *userRepository) StreamAll(ctx context.Context, fn func(user *models.User) error) error {
+	opts := options.Find().SetSort(bson.D{{Key: "email", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var user models.User
+		if err := cursor.Decode(&user); err != nil {
+			return err
+		}
+		if err := fn(&user); err != nil {
+			return err
+		}
+	}
+
+	return cursor.Err()
+}
+
+// Anonymize replaces personal data in place so references to the user stay valid
+func (r *userRepository) Anonymize(ctx context.Context, userID, name, email, hashedPassword string) error {
+	userObjectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return utils.ErrUserNotFound
+	}
+
+	now := time.Now()
+	update := bson.M{
+		"$set": bson.M{
+			"name":       name,
+			"email":      email,
+			"password":   hashedPassword,
+			"erased_at":  &now,
+			"updated_at": now,
+		},
+		"$unset": bson.M{
+			"verification_notes":  "",
+			"suspension_reason":   "",
+			"last_login_at":       "",
+			"last_password_reset": "",
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": userObjectID}, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return utils.ErrUserNotFound
+	}
+
+	return nil
+}
+
+// CountByRoleAndStatus returns per-role totals split by verification and suspension status.
+// Erased users are excluded.
+func (r *userRepository) CountByRoleAndStatus(ctx context.Context) ([]models.RoleStatusCount, error) {
+	countIf := func(condition interface{}) bson.M {
+		return bson.M{"$sum": bson.M{"$cond": bson.A{condition, 1, 0}}}
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"erased_at": bson.M{"$exists": false}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":       "$role",
+			"total":     bson.M{"$sum": 1},
+			"verified":  countIf(bson.M{"$eq": bson.A{"$is_verified", true}}),
+			"pending":   countIf(bson.M{"$ne": bson.A{"$is_verified", true}}),
+			"suspended": countIf(bson.M{"$eq": bson.A{"$is_suspended", true}}),
+		}}},
+		{{Key: "$sort", Value: bson.M{"_id": 1}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	counts := []models.RoleStatusCount{}
+	for cursor.Next(ctx) {
+		var count models.RoleStatusCount
+		if err := cursor.Decode(&count); err != nil {
+			return nil, err
+		}
+		counts = append(counts, count)
+	}
+
+	return counts, cursor.Err()
+}
+
+// CountPendingByCreatedAt buckets unverified, non-suspended users by registration time.
+// Boundaries must be ascending; each result's Period is the lower boundary of its bucket
+// and users registered outside the boundaries are not counted.
+func (r *userRepository) CountPendingByCreatedAt(ctx context.Context, boundaries []time.Time) ([]models.PeriodCount, error) {
+	if len(boundaries) < 2 {
+		return []models.PeriodCount{}, nil
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"is_verified":  false,
+			"is_suspended": bson.M{"$ne": true},
+			"created_at": bson.M{
+				"$gte": boundaries[0],
+				"$lt":  boundaries[len(boundaries)-1],
+			},
+		}}},
+		{{Key: "$bucket", Value: bson.M{
+			"groupBy":    "$created_at",
+			"boundaries": boundaries,
+			"output":     bson.M{"count": bson.M{"$sum": 1}},
+		}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	counts := []models.PeriodCount{}
+	for cursor.Next(ctx) {
+		var count models.PeriodCount
+		if err := cursor.Decode(&count); err != nil {
+			return nil, err
+		}
+		counts = append(counts, count)
+	}
+
+	return counts, cursor.Err()
+}
+
+// CountRegistrationsByPeriod counts new users per time bucket
+func (r *userRepository) CountRegistrationsByPeriod(ctx context.Context, query *models.StatsQuery) ([]models.PeriodCount, error) {
+	return countByPeriod(ctx, r.collection, bson.M{}, "created_at", query)
+}
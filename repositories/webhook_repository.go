@@ -0,0 +1,341 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"backend/models"
+	"backend/repositories/interfaces"
+	"backend/utils"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type webhookRepository struct {
+	collection *mongo.Collection
+	deliveries *mongo.Collection
+}
+
+func NewWebhookRepository(db *mongo.Database) interfaces.WebhookRepository {
+	return &webhookRepository{
+		collection: db.Collection("webhooks"),
+		deliveries: db.Collection("webhook_deliveries"),
+	}
+}
+
+func (r *webhookRepository) Create(ctx context.Context, webhook *models.Webhook) error {
+	webhook.ID = primitive.NewObjectID()
+	webhook.CreatedAt = time.Now()
+	webhook.UpdatedAt = webhook.CreatedAt
+
+	_, err := r.collection.InsertOne(ctx, webhook)
+	return err
+}
+
+func (r *webhookRepository) GetByID(ctx context.Context, id string) (*models.Webhook, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, utils.ErrInvalidID
+	}
+
+	var webhook models.Webhook
+	err = r.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&webhook)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, utils.ErrWebhookNotFound
+		}
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+func (r *webhookRepository) List(ctx context.Context) ([]*models.Webhook, error) {
+	return r.find(ctx, bson.M{})
+}
+
+func (r *webhookRepository) ListSubscribed(ctx context.Context, eventType string) ([]*models.Webhook, error) {
+	return r.find(ctx, bson.M{
+		"enabled": true,
+		"events":  bson.M{"$in": bson.A{eventType, models.WebhookAllEvents}},
+	})
+}
+
+func (r *webhookRepository) find(ctx context.Context, filter bson.M) ([]*models.Webhook, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var webhooks []*models.Webhook
+	for cursor.Next(ctx) {
+		var webhook models.Webhook
+		if err := cursor.Decode(&webhook); err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, &webhook)
+	}
+
+	return webhooks, cursor.Err()
+}
+
+func (r *webhookRepository) Update(ctx context.Context, webhook *models.Webhook) error {
+	webhook.UpdatedAt = time.Now()
+
+	set := bson.M{
+		"url":                  webhook.URL,
+		"description":          webhook.Description,
+		"events":               webhook.Events,
+		"secret":               webhook.Secret,
+		"enabled":              webhook.Enabled,
+		"consecutive_failures": webhook.ConsecutiveFailures,
+		"updated_at":           webhook.UpdatedAt,
+	}
+	unset := bson.M{}
+	if webhook.DisabledAt != nil {
+		set["disabled_at"] = webhook.DisabledAt
+		set["disabled_reason"] = webhook.DisabledReason
+	} else {
+		unset["disabled_at"] = ""
+		unset["disabled_reason"] = ""
+	}
+
+	update := bson.M{"$set": set}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": webhook.ID}, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return utils.ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+func (r *webhookRepository) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return utils.ErrInvalidID
+	}
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": objectID})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return utils.ErrWebhookNotFound
+	}
+
+	_, err = r.deliveries.DeleteMany(ctx, bson.M{"webhook_id": objectID})
+	return err
+}
+
+func (r *webhookRepository) RecordSuccess(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return utils.ErrInvalidID
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": objectID}, bson.M{"$set": bson.M{"consecutive_failures": 0}})
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return utils.ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+// RecordFailure counts and disables in one pipeline update; fields in a
+// $set stage see the values from before the stage, so the webhook is
+// disabled exactly once even when workers record failures concurrently
+func (r *webhookRepository) RecordFailure(ctx context.Context, id string, disableAfter int, reason string) (bool, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, utils.ErrInvalidID
+	}
+
+	failures := bson.M{"$add": bson.A{"$consecutive_failures", 1}}
+	disabling := bson.M{"$and": bson.A{"$enabled", bson.M{"$gte": bson.A{failures, disableAfter}}}}
+	pipeline := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"consecutive_failures": failures,
+			"enabled":              bson.M{"$and": bson.A{"$enabled", bson.M{"$not": disabling}}},
+			"disabled_at":          bson.M{"$cond": bson.A{disabling, time.Now(), "$disabled_at"}},
+			"disabled_reason":      bson.M{"$cond": bson.A{disabling, reason, "$disabled_reason"}},
+		}}},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.Before)
+
+	var before models.Webhook
+	err = r.collection.FindOneAndUpdate(ctx, bson.M{"_id": objectID}, pipeline, opts).Decode(&before)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return false, utils.ErrWebhookNotFound
+		}
+		return false, err
+	}
+
+	return before.Enabled && before.ConsecutiveFailures+1 >= disableAfter, nil
+}
+
+func (r *webhookRepository) CreateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	delivery.ID = primitive.NewObjectID()
+	delivery.CreatedAt = time.Now()
+	delivery.NextAttemptAt = delivery.CreatedAt
+	delivery.Status = models.WebhookDeliveryPending
+
+	_, err := r.deliveries.InsertOne(ctx, delivery)
+	return err
+}
+
+func (r *webhookRepository) GetDelivery(ctx context.Context, id string) (*models.WebhookDelivery, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, utils.ErrInvalidID
+	}
+
+	var delivery models.WebhookDelivery
+	err = r.deliveries.FindOne(ctx, bson.M{"_id": objectID}).Decode(&delivery)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, utils.ErrWebhookDeliveryNotFound
+		}
+		return nil, err
+	}
+
+	return &delivery, nil
+}
+
+func (r *webhookRepository) ListDeliveries(ctx context.Context, webhookID string, limit int64) ([]*models.WebhookDelivery, error) {
+	objectID, err := primitive.ObjectIDFromHex(webhookID)
+	if err != nil {
+		return nil, utils.ErrInvalidID
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetLimit(limit)
+
+	cursor, err := r.deliveries.Find(ctx, bson.M{"webhook_id": objectID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var deliveries []*models.WebhookDelivery
+	for cursor.Next(ctx) {
+		var delivery models.WebhookDelivery
+		if err := cursor.Decode(&delivery); err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, &delivery)
+	}
+
+	return deliveries, cursor.Err()
+}
+
+// ClaimDueDeliveries claims one delivery at a time; each findOneAndUpdate is
+// atomic, so a delivery is never handed to two workers
+func (r *webhookRepository) ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.WebhookDelivery, error) {
+	filter := bson.M{
+		"status":          models.WebhookDeliveryPending,
+		"next_attempt_at": bson.M{"$lte": now},
+	}
+	update := bson.M{
+		"$inc": bson.M{"attempts": 1},
+		"$set": bson.M{"last_attempt_at": now, "next_attempt_at": now.Add(lease)},
+	}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "next_attempt_at", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var deliveries []*models.WebhookDelivery
+	for len(deliveries) < limit {
+		var delivery models.WebhookDelivery
+		err := r.deliveries.FindOneAndUpdate(ctx, filter, update, opts).Decode(&delivery)
+		if err == mongo.ErrNoDocuments {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, &delivery)
+	}
+
+	return deliveries, nil
+}
+
+func (r *webhookRepository) MarkDelivered(ctx context.Context, id string, attempt *models.WebhookAttempt) error {
+	set := attemptFields(attempt)
+	set["status"] = models.WebhookDeliveryDelivered
+	set["delivered_at"] = time.Now()
+
+	return r.updateDelivery(ctx, id, bson.M{"$set": set, "$unset": bson.M{"last_error": ""}})
+}
+
+func (r *webhookRepository) MarkFailed(ctx context.Context, id string, attempt *models.WebhookAttempt, retryAt *time.Time) error {
+	set := attemptFields(attempt)
+	set["last_error"] = attempt.Error
+	if retryAt != nil {
+		set["next_attempt_at"] = *retryAt
+	} else {
+		set["status"] = models.WebhookDeliveryFailed
+	}
+
+	return r.updateDelivery(ctx, id, bson.M{"$set": set})
+}
+
+// attemptFields sets the response of the latest attempt, replacing the
+// previous attempt's
+func attemptFields(attempt *models.WebhookAttempt) bson.M {
+	return bson.M{
+		"response_status": attempt.ResponseStatus,
+		"response_body":   attempt.ResponseBody,
+		"duration_ms":     attempt.Duration.Milliseconds(),
+	}
+}
+
+// updateDelivery applies update to the delivery with the given ID
+func (r *webhookRepository) updateDelivery(ctx context.Context, id string, update bson.M) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return utils.ErrInvalidID
+	}
+
+	result, err := r.deliveries.UpdateOne(ctx, bson.M{"_id": objectID}, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return utils.ErrWebhookDeliveryNotFound
+	}
+
+	return nil
+}
+
+func (r *webhookRepository) DeleteDeliveriesBefore(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.deliveries.DeleteMany(ctx, bson.M{
+		"status":     bson.M{"$ne": models.WebhookDeliveryPending},
+		"created_at": bson.M{"$lt": before},
+	})
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
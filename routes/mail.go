@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"log/slog"
+
+	"backend/config"
+	"backend/handlers"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SetupMailCaptureRoutes exposes the emails kept by the capture transport on
+// /dev/mail. They carry passwords, so the routes exist only in development.
+func SetupMailCaptureRoutes(app *fiber.App, mailCaptureHandler *handlers.MailCaptureHandler) {
+	if mailCaptureHandler == nil {
+		return
+	}
+	if !config.AppConfig.IsDevelopment() {
+		slog.Warn("Captured emails are not browsable outside development", "environment", config.AppConfig.AppEnv)
+		return
+	}
+
+	mail := app.Group("/dev/mail")
+	mail.Get("/", mailCaptureHandler.GetMessages)
+	mail.Delete("/", mailCaptureHandler.ClearMessages)
+	mail.Get("/:id", mailCaptureHandler.GetMessage)
+	mail.Get("/:id/html", mailCaptureHandler.GetMessageHTML)
+}
@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"backend/config"
+	"backend/metrics"
+	"backend/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// SetupMetricsRoutes exposes the Prometheus registry on /metrics when enabled
+func SetupMetricsRoutes(app *fiber.App) {
+	if !config.AppConfig.MetricsEnabled {
+		return
+	}
+
+	handler := promhttp.HandlerFor(metrics.Registry, promhttp.HandlerOpts{})
+	app.Get("/metrics", middleware.MetricsTokenMiddleware(config.AppConfig.MetricsToken), adaptor.HTTPHandler(handler))
+}
@@ -1,21 +1,42 @@
 package routes
 
 import (
+	"time"
+
+	"backend/config"
 	"backend/handlers"
 	"backend/middleware"
+	"backend/ratelimit"
 	"backend/repositories/interfaces"
 
 	"github.com/gofiber/fiber/v2"
 )
 
-func SetupRoutes(app *fiber.App, authHandler *handlers.AuthHandler, userHandler *handlers.UserHandler, adminHandler *handlers.AdminHandler, menuHandler *handlers.MenuHandler, userRepo interfaces.UserRepository) {
+func SetupRoutes(app *fiber.App, authHandler *handlers.AuthHandler, userHandler *handlers.UserHandler, adminHandler *handlers.AdminHandler, bulkAdminHandler *handlers.BulkAdminHandler, userImportHandler *handlers.UserImportHandler, reportHandler *handlers.ReportHandler, menuHandler *handlers.MenuHandler, privacyHandler *handlers.PrivacyHandler, statsHandler *handlers.StatsHandler, jobHandler *handlers.JobHandler, outboxHandler *handlers.OutboxHandler, notificationHandler *handlers.NotificationHandler, webhookHandler *handlers.WebhookHandler, realtimeHandler *handlers.RealtimeHandler, emailTemplateHandler *handlers.EmailTemplateHandler, mailCaptureHandler *handlers.MailCaptureHandler, healthHandler *handlers.HealthHandler, userRepo interfaces.UserRepository, limiter *ratelimit.Limiter) {
 	// Middleware
+	app.Use(middleware.RequestIDMiddleware())
+	app.Use(middleware.TracingMiddleware())
+	app.Use(middleware.MetricsMiddleware())
 	app.Use(middleware.LoggerMiddleware())
 	app.Use(middleware.CorsMiddleware())
+	app.Use(middleware.Timeout(config.AppConfig.RequestTimeout))
+
+	// Routes doing heavy work get a longer deadline
+	long := middleware.Timeout(config.AppConfig.LongRequestTimeout)
 
 	// Setup Swagger routes (conditional based on configuration)
 	SetupSwaggerRoutes(app)
 
+	// Orchestrator probes
+	app.Get("/livez", healthHandler.Livez)
+	app.Get("/readyz", healthHandler.Readyz)
+
+	// Prometheus scrape endpoint
+	SetupMetricsRoutes(app)
+
+	// Captured emails (development only)
+	SetupMailCaptureRoutes(app, mailCaptureHandler)
+
 	// API v1 routes
 	api := app.Group("/api/v1")
 
@@ -39,29 +60,108 @@ func SetupRoutes(app *fiber.App, authHandler *handlers.AuthHandler, userHandler
 		return c.JSON(GetSwaggerStatus())
 	})
 
+	// Rate limit policies. Public routes are limited by address, with tighter
+	// limits on account creation and on password guessing. Logins are limited
+	// per account and address, so others cannot lock an account out, and per
+	// address across accounts; signed-in users are limited per user.
+	authLimit := middleware.RateLimit(limiter, ratelimit.Policy{
+		Name: "auth", Algorithm: ratelimit.TokenBucket, Limit: 20, Window: time.Minute, KeyBy: ratelimit.KeyByIP,
+	})
+	registerLimit := middleware.RateLimit(limiter, ratelimit.Policy{
+		Name: "register", Algorithm: ratelimit.SlidingWindow, Limit: 10, Window: time.Hour, KeyBy: ratelimit.KeyByIP,
+	})
+	loginLimit := middleware.RateLimit(limiter, ratelimit.Policy{
+		Name: "login", Algorithm: ratelimit.SlidingWindow, Limit: 10, Window: 15 * time.Minute, KeyBy: ratelimit.KeyByEmailAndIP,
+	})
+	loginAddressLimit := middleware.RateLimit(limiter, ratelimit.Policy{
+		Name: "login-address", Algorithm: ratelimit.SlidingWindow, Limit: 30, Window: 15 * time.Minute, KeyBy: ratelimit.KeyByIP,
+	})
+	userLimit := middleware.RateLimit(limiter, ratelimit.Policy{
+		Name: "users", Algorithm: ratelimit.TokenBucket, Limit: 120, Window: time.Minute, KeyBy: ratelimit.KeyByUser,
+	})
+	adminLimit := middleware.RateLimit(limiter, ratelimit.Policy{
+		Name: "admin", Algorithm: ratelimit.TokenBucket, Limit: 300, Window: time.Minute, KeyBy: ratelimit.KeyByUser,
+	})
+
 	// Authentication routes (public)
-	auth := api.Group("/auth")
-	auth.Post("/register", authHandler.Register)
-	auth.Post("/login", authHandler.Login)
+	auth := api.Group("/auth", authLimit)
+	auth.Post("/register", registerLimit, authHandler.Register)
+	auth.Post("/login", loginAddressLimit, loginLimit, authHandler.Login)
 	auth.Post("/refresh", authHandler.RefreshToken)
 	auth.Post("/logout", authHandler.Logout)
-	auth.Post("/forgot-password", authHandler.ForgotPassword)
+	auth.Post("/forgot-password", long, authHandler.ForgotPassword)
+
+	// Real-time event stream; registered ahead of the protected group so the
+	// token can also come from the query string
+	api.Get("/users/events", middleware.QueryTokenMiddleware(), middleware.AuthMiddleware(), userLimit, realtimeHandler.StreamEvents)
 
 	// Protected routes
-	protected := api.Group("/users", middleware.AuthMiddleware())
+	protected := api.Group("/users", middleware.AuthMiddleware(), userLimit)
 	protected.Get("/profile", userHandler.GetProfile)
 	protected.Put("/profile", userHandler.UpdateProfile)
 	protected.Delete("/profile", userHandler.DeleteProfile)
+	protected.Get("/profile/export", long, privacyHandler.ExportMyData)
 	protected.Put("/change-password", userHandler.ChangePassword)
 	protected.Post("/logout-all", authHandler.LogoutAll)
 	protected.Get("/menus", menuHandler.GetUserMenus)
 
+	// Notification inbox and preferences
+	protected.Get("/notifications", notificationHandler.GetNotifications)
+	protected.Get("/notifications/unread-count", notificationHandler.GetUnreadCount)
+	protected.Post("/notifications/read-all", notificationHandler.MarkAllNotificationsRead)
+	protected.Get("/notifications/preferences", notificationHandler.GetNotificationPreferences)
+	protected.Put("/notifications/preferences", notificationHandler.UpdateNotificationPreferences)
+	protected.Post("/notifications/:id/read", notificationHandler.MarkNotificationRead)
+
 	// Admin-only routes
-	admin := api.Group("/admin", middleware.AuthMiddleware(), middleware.AdminMiddleware(userRepo))
+	admin := api.Group("/admin", middleware.AuthMiddleware(), adminLimit, middleware.AdminMiddleware(userRepo))
+	admin.Get("/stats", long, statsHandler.GetStats)
+	admin.Get("/health", healthHandler.GetSystemHealth)
 	admin.Get("/users/pending", adminHandler.GetPendingUsers)
+
+	// Bulk user administration routes (Admin only)
+	admin.Post("/users/bulk/verify", long, bulkAdminHandler.BulkVerifyUsers)
+	admin.Post("/users/bulk/role", long, bulkAdminHandler.BulkUpdateUserRoles)
+	admin.Post("/users/bulk/suspend", long, bulkAdminHandler.BulkSuspendUsers)
+	admin.Post("/users/bulk/revoke-sessions", long, bulkAdminHandler.BulkRevokeSessions)
+	admin.Get("/users/bulk/jobs", bulkAdminHandler.GetBulkJobs)
+	admin.Get("/users/bulk/jobs/:id", bulkAdminHandler.GetBulkJob)
+	admin.Post("/users/import", long, userImportHandler.ImportUsers)
+
 	admin.Post("/users/:id/verify", adminHandler.VerifyUser)
 	admin.Get("/users/:id", adminHandler.GetUserDetails)
 	admin.Put("/users/:id/role", adminHandler.UpdateUserRole)
+	admin.Post("/users/:id/suspend", adminHandler.SuspendUser)
+	admin.Post("/users/:id/reinstate", adminHandler.ReinstateUser)
+	admin.Post("/users/:id/revoke-sessions", adminHandler.RevokeUserSessions)
+	admin.Post("/users/:id/erase", long, privacyHandler.EraseUser)
+
+	// Scheduled job routes (Admin only)
+	admin.Get("/jobs", jobHandler.GetJobs)
+	admin.Get("/jobs/:name/runs", jobHandler.GetJobRuns)
+	admin.Post("/jobs/:name/run", jobHandler.RunJob)
+
+	// Email outbox routes (Admin only)
+	admin.Get("/outbox", outboxHandler.GetOutboxMessages)
+	admin.Get("/outbox/summary", outboxHandler.GetOutboxSummary)
+	admin.Post("/outbox/:id/resend", outboxHandler.ResendOutboxMessage)
+
+	// Webhook routes (Admin only)
+	admin.Get("/webhooks/events", webhookHandler.GetWebhookEvents)
+	admin.Post("/webhooks", webhookHandler.CreateWebhook)
+	admin.Get("/webhooks", webhookHandler.GetWebhooks)
+	admin.Get("/webhooks/:id", webhookHandler.GetWebhook)
+	admin.Put("/webhooks/:id", webhookHandler.UpdateWebhook)
+	admin.Delete("/webhooks/:id", webhookHandler.DeleteWebhook)
+	admin.Get("/webhooks/:id/deliveries", webhookHandler.GetWebhookDeliveries)
+	admin.Post("/webhooks/:id/deliveries/:deliveryId/redeliver", webhookHandler.RedeliverWebhookDelivery)
+
+	// Email template routes (Admin only)
+	admin.Get("/email-templates", emailTemplateHandler.GetEmailTemplates)
+	admin.Get("/email-templates/:kind/preview", emailTemplateHandler.PreviewEmailTemplate)
+
+	// Compliance report routes (Admin only)
+	admin.Get("/reports/access-review", reportHandler.GetAccessReview)
 
 	// Menu management routes (Admin only)
 	admin.Post("/menus", menuHandler.CreateMenu)
@@ -0,0 +1,477 @@
+// Package scheduler runs maintenance jobs on cron-style schedules. Every
+// replica runs a scheduler, but only the one holding the leader lease starts
+// scheduled runs. Each run also holds a lease on its job, so a manual trigger
+// never overlaps a run on another replica. Runs are recorded in the job
+// history with their duration and error.
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"backend/health"
+	"backend/lifecycle"
+	"backend/metrics"
+	"backend/models"
+	"backend/repositories/interfaces"
+	"backend/tracing"
+	"backend/utils"
+
+	"github.com/robfig/cron/v3"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	leaderLease = "scheduler"
+	// The leader renews its lease well before it expires; a crashed leader
+	// is replaced within leaderTTL
+	leaderTTL     = 30 * time.Second
+	renewInterval = 10 * time.Second
+	tickInterval  = time.Second
+
+	defaultTimeout = 10 * time.Minute
+	// bookkeepingTimeout bounds recording a run and releasing its lease, which
+	// happen on a fresh context so they survive shutdown
+	bookkeepingTimeout = 10 * time.Second
+)
+
+// Job is a unit of periodic maintenance work
+type Job struct {
+	Name        string
+	Description string
+	// Schedule is a five-field cron expression or a descriptor such as
+	// @hourly or @every 15m, evaluated in UTC
+	Schedule string
+	// Timeout cancels a run that takes longer; zero means 10 minutes
+	Timeout time.Duration
+	Run     func(ctx context.Context) error
+}
+
+func (j Job) timeout() time.Duration {
+	if j.Timeout > 0 {
+		return j.Timeout
+	}
+	return defaultTimeout
+}
+
+// lease returns the name of the job's lease. It outlives the run's timeout
+// so a cancelled run can return before another replica starts the job.
+func (j Job) lease() (string, time.Duration) {
+	return "job:" + j.Name, j.timeout() + time.Minute
+}
+
+type entry struct {
+	job      Job
+	schedule cron.Schedule
+	next     time.Time
+}
+
+type Scheduler struct {
+	repo      interfaces.JobRepository
+	instance  string
+	heartbeat *health.Heartbeat
+	// now is the clock schedules are evaluated by
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*entry
+	running map[string]bool
+	leader  bool
+	// ctx is set while the scheduler runs; runs are started on it
+	ctx    context.Context
+	cancel context.CancelFunc
+	loop   chan struct{}
+	runs   sync.WaitGroup
+}
+
+func New(repo interfaces.JobRepository) *Scheduler {
+	hostname, _ := os.Hostname()
+
+	return &Scheduler{
+		repo:      repo,
+		instance:  fmt.Sprintf("%s-%d", hostname, os.Getpid()),
+		heartbeat: health.NewHeartbeat(time.Minute),
+		now:       time.Now,
+		entries:   make(map[string]*entry),
+		running:   make(map[string]bool),
+	}
+}
+
+// Register adds a job. Jobs must be registered before the scheduler starts.
+func (s *Scheduler) Register(job Job) error {
+	schedule, err := cron.ParseStandard(job.Schedule)
+	if err != nil {
+		return fmt.Errorf("job %s: invalid schedule %q: %w", job.Name, job.Schedule, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.entries[job.Name]; exists {
+		return fmt.Errorf("job %s is already registered", job.Name)
+	}
+	s.entries[job.Name] = &entry{job: job, schedule: schedule, next: schedule.Next(s.now().UTC())}
+	return nil
+}
+
+// Hook starts the scheduler with the application. Stopping it cancels
+// running jobs and hands the leader lease to another replica.
+func (s *Scheduler) Hook() lifecycle.Hook {
+	return lifecycle.Hook{Name: "scheduler", OnStart: s.start, OnStop: s.stop}
+}
+
+// Check reports a stalled scheduler loop
+func (s *Scheduler) Check(ctx context.Context) error {
+	return s.heartbeat.Check(ctx)
+}
+
+func (s *Scheduler) start(context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+	s.loop = make(chan struct{})
+	go s.run(s.ctx, s.loop)
+	return nil
+}
+
+func (s *Scheduler) stop(ctx context.Context) error {
+	// Clearing ctx under the lock stops new runs, so no runs.Add can race runs.Wait
+	s.mu.Lock()
+	cancel, loop := s.cancel, s.loop
+	s.ctx = nil
+	s.mu.Unlock()
+
+	cancel()
+
+	stopped := make(chan struct{})
+	go func() {
+		<-loop
+		s.runs.Wait()
+		close(stopped)
+	}()
+	if err := lifecycle.Wait(ctx, stopped); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	leader := s.leader
+	s.leader = false
+	s.mu.Unlock()
+
+	if leader {
+		metrics.SchedulerLeader.Set(0)
+		return s.repo.ReleaseLease(ctx, leaderLease, s.instance)
+	}
+	return nil
+}
+
+func (s *Scheduler) run(ctx context.Context, done chan struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(tickInterval)
+	defer ticker.Stop()
+
+	var renewAt time.Time
+	for {
+		now := s.now()
+		if !now.Before(renewAt) {
+			s.renewLeadership(ctx)
+			renewAt = now.Add(renewInterval)
+		}
+		s.startDue(now.UTC())
+		s.heartbeat.Beat()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Scheduler) renewLeadership(ctx context.Context) {
+	leader, err := s.repo.AcquireLease(ctx, leaderLease, s.instance, leaderTTL)
+	if err != nil {
+		if ctx.Err() != nil {
+			return
+		}
+		slog.Warn("Failed to renew the scheduler leader lease", "error", err)
+		leader = false
+	}
+
+	s.mu.Lock()
+	changed := leader != s.leader
+	s.leader = leader
+	s.mu.Unlock()
+
+	if !changed {
+		return
+	}
+	if leader {
+		metrics.SchedulerLeader.Set(1)
+		slog.Info("Became the job scheduler leader", "instance", s.instance)
+	} else {
+		metrics.SchedulerLeader.Set(0)
+		slog.Info("No longer the job scheduler leader", "instance", s.instance)
+	}
+}
+
+// startDue starts the jobs whose time has come. Every replica advances its
+// schedules, so a new leader does not start runs its predecessor already made.
+func (s *Scheduler) startDue(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, e := range s.entries {
+		if now.Before(e.next) {
+			continue
+		}
+		e.next = e.schedule.Next(now)
+
+		if s.leader {
+			job := e.job
+			s.spawnLocked(func(ctx context.Context) {
+				if err := s.claim(ctx, job); err != nil {
+					if err != utils.ErrJobAlreadyRunning && ctx.Err() == nil {
+						slog.Warn("Failed to start scheduled job", "job", job.Name, "error", err)
+					}
+					return
+				}
+				s.execute(ctx, job, models.JobTriggerSchedule, nil)
+			})
+		}
+	}
+}
+
+// spawnLocked runs fn in the background on the scheduler's context. It
+// reports false once the scheduler is stopped.
+func (s *Scheduler) spawnLocked(fn func(ctx context.Context)) bool {
+	if s.ctx == nil {
+		return false
+	}
+
+	ctx := s.ctx
+	s.runs.Add(1)
+	go func() {
+		defer s.runs.Done()
+		fn(ctx)
+	}()
+	return true
+}
+
+// Trigger starts a run of the named job now, on this replica. It returns
+// once the run has started; the outcome is recorded in the job history.
+func (s *Scheduler) Trigger(ctx context.Context, name, adminID string) error {
+	ctx, span := tracing.Start(ctx, "Scheduler.Trigger", attribute.String("job.name", name))
+	defer span.End()
+
+	s.mu.Lock()
+	e, ok := s.entries[name]
+	started := s.ctx != nil
+	s.mu.Unlock()
+
+	if !ok {
+		return utils.ErrJobNotFound
+	}
+	if !started {
+		return utils.ErrSchedulerNotRunning
+	}
+
+	if err := s.claim(ctx, e.job); err != nil {
+		return err
+	}
+
+	var triggeredBy *primitive.ObjectID
+	if adminObjectID, err := primitive.ObjectIDFromHex(adminID); err == nil {
+		triggeredBy = &adminObjectID
+	}
+
+	s.mu.Lock()
+	spawned := s.spawnLocked(func(ctx context.Context) {
+		s.execute(ctx, e.job, models.JobTriggerManual, triggeredBy)
+	})
+	s.mu.Unlock()
+
+	if !spawned {
+		s.release(e.job)
+		return utils.ErrSchedulerNotRunning
+	}
+	return nil
+}
+
+// claim marks the job as running on this replica and takes its lease, so no
+// other replica runs it at the same time
+func (s *Scheduler) claim(ctx context.Context, job Job) error {
+	s.mu.Lock()
+	if s.running[job.Name] {
+		s.mu.Unlock()
+		return utils.ErrJobAlreadyRunning
+	}
+	s.running[job.Name] = true
+	s.mu.Unlock()
+
+	name, ttl := job.lease()
+	acquired, err := s.repo.AcquireLease(ctx, name, s.instance, ttl)
+	if err == nil && !acquired {
+		err = utils.ErrJobAlreadyRunning
+	}
+	if err != nil {
+		s.mu.Lock()
+		delete(s.running, job.Name)
+		s.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// release undoes claim
+func (s *Scheduler) release(job Job) {
+	ctx, cancel := context.WithTimeout(context.Background(), bookkeepingTimeout)
+	defer cancel()
+
+	name, _ := job.lease()
+	if err := s.repo.ReleaseLease(ctx, name, s.instance); err != nil {
+		slog.Warn("Failed to release job lease", "job", job.Name, "error", err)
+	}
+
+	s.mu.Lock()
+	delete(s.running, job.Name)
+	s.mu.Unlock()
+}
+
+// execute runs a claimed job and records the run
+func (s *Scheduler) execute(ctx context.Context, job Job, trigger string, triggeredBy *primitive.ObjectID) {
+	defer s.release(job)
+
+	ctx, span := tracing.Start(ctx, "Scheduler.Run",
+		attribute.String("job.name", job.Name),
+		attribute.String("job.trigger", trigger),
+	)
+	defer span.End()
+
+	run := &models.JobRun{
+		Job:         job.Name,
+		Trigger:     trigger,
+		TriggeredBy: triggeredBy,
+		Instance:    s.instance,
+		StartedAt:   time.Now(),
+	}
+
+	runCtx, cancel := context.WithTimeout(ctx, job.timeout())
+	err := runJob(runCtx, job)
+	cancel()
+
+	run.FinishedAt = time.Now()
+	run.DurationMs = run.FinishedAt.Sub(run.StartedAt).Milliseconds()
+
+	result := "success"
+	if err != nil {
+		result = "failure"
+		run.Error = err.Error()
+		tracing.RecordError(span, err)
+		slog.ErrorContext(ctx, "Job failed", "job", job.Name, "trigger", trigger, "duration_ms", run.DurationMs, "error", err)
+	} else {
+		slog.InfoContext(ctx, "Job finished", "job", job.Name, "trigger", trigger, "duration_ms", run.DurationMs)
+	}
+	metrics.JobRuns.WithLabelValues(job.Name, result).Inc()
+	metrics.JobDuration.WithLabelValues(job.Name).Observe(run.FinishedAt.Sub(run.StartedAt).Seconds())
+
+	// Record the run even when shutdown cancelled it
+	recordCtx, cancelRecord := context.WithTimeout(context.WithoutCancel(ctx), bookkeepingTimeout)
+	defer cancelRecord()
+	if err := s.repo.CreateRun(recordCtx, run); err != nil {
+		slog.ErrorContext(ctx, "Failed to record job run", "job", job.Name, "error", err)
+	}
+}
+
+// runJob turns a panicking job into a failed run
+func runJob(ctx context.Context, job Job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	return job.Run(ctx)
+}
+
+// Jobs lists the registered jobs with their next run on this replica and
+// their most recent run on any replica
+func (s *Scheduler) Jobs(ctx context.Context) ([]models.JobResponse, error) {
+	ctx, span := tracing.Start(ctx, "Scheduler.Jobs")
+	defer span.End()
+
+	latest, err := s.repo.GetLatestRuns(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	lastRuns := make(map[string]*models.JobRun, len(latest))
+	for _, run := range latest {
+		lastRuns[run.Job] = run
+	}
+
+	s.mu.Lock()
+	jobs := make([]models.JobResponse, 0, len(s.entries))
+	for _, e := range s.entries {
+		job := models.JobResponse{
+			Name:        e.job.Name,
+			Description: e.job.Description,
+			Schedule:    e.job.Schedule,
+			NextRunAt:   e.next,
+		}
+		if run, ok := lastRuns[e.job.Name]; ok {
+			response := run.ToResponse()
+			job.LastRun = &response
+		}
+		jobs = append(jobs, job)
+	}
+	s.mu.Unlock()
+
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].Name < jobs[j].Name })
+	return jobs, nil
+}
+
+// Runs returns the history of the named job, newest first
+func (s *Scheduler) Runs(ctx context.Context, name string, limit int64) ([]models.JobRunResponse, error) {
+	ctx, span := tracing.Start(ctx, "Scheduler.Runs", attribute.String("job.name", name))
+	defer span.End()
+
+	s.mu.Lock()
+	_, ok := s.entries[name]
+	s.mu.Unlock()
+	if !ok {
+		return nil, utils.ErrJobNotFound
+	}
+
+	runs, err := s.repo.GetRuns(ctx, name, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]models.JobRunResponse, 0, len(runs))
+	for _, run := range runs {
+		responses = append(responses, run.ToResponse())
+	}
+	return responses, nil
+}
+
+// PruneHistory returns a job that deletes runs older than retention
+func (s *Scheduler) PruneHistory(retention time.Duration) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		deleted, err := s.repo.DeleteRunsBefore(ctx, time.Now().Add(-retention))
+		if err != nil {
+			return err
+		}
+
+		slog.InfoContext(ctx, "Pruned job history", "deleted", deleted)
+		return nil
+	}
+}
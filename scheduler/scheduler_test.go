@@ -0,0 +1,235 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"backend/repositories/interfaces"
+	"backend/repositories/memory"
+	"backend/utils"
+)
+
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// newTestScheduler returns a scheduler that can start runs but whose loop
+// does not run; tests drive it by calling renewLeadership and startDue
+func newTestScheduler(t *testing.T, repo interfaces.JobRepository, clock *fakeClock, instance string, jobs ...Job) *Scheduler {
+	t.Helper()
+
+	s := New(repo)
+	s.instance = instance
+	s.now = clock.Now
+	for _, job := range jobs {
+		if err := s.Register(job); err != nil {
+			t.Fatalf("register %s: %v", job.Name, err)
+		}
+	}
+
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+	t.Cleanup(func() {
+		s.cancel()
+		s.runs.Wait()
+	})
+	return s
+}
+
+func isLeader(s *Scheduler) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.leader
+}
+
+func TestLeaderFailover(t *testing.T) {
+	clock := newFakeClock()
+	repo := memory.NewJobRepositoryWithClock(clock.Now)
+	ctx := context.Background()
+
+	var runs atomic.Int32
+	job := Job{Name: "count", Schedule: "@every 1m", Run: func(context.Context) error {
+		runs.Add(1)
+		return nil
+	}}
+	a := newTestScheduler(t, repo, clock, "a", job)
+	b := newTestScheduler(t, repo, clock, "b", job)
+
+	a.renewLeadership(ctx)
+	b.renewLeadership(ctx)
+	if !isLeader(a) || isLeader(b) {
+		t.Fatalf("expected a to lead, got a=%v b=%v", isLeader(a), isLeader(b))
+	}
+
+	// Only the leader starts the due run
+	clock.Advance(time.Minute)
+	a.startDue(clock.Now())
+	b.startDue(clock.Now())
+	a.runs.Wait()
+	b.runs.Wait()
+	if got := runs.Load(); got != 1 {
+		t.Fatalf("expected 1 run, got %d", got)
+	}
+
+	// a renews once more, then stops; b takes over once the lease expires
+	a.renewLeadership(ctx)
+	clock.Advance(leaderTTL / 2)
+	b.renewLeadership(ctx)
+	if isLeader(b) {
+		t.Fatal("b took over before the leader lease expired")
+	}
+	clock.Advance(leaderTTL)
+	b.renewLeadership(ctx)
+	if !isLeader(b) {
+		t.Fatal("b did not take over after the leader lease expired")
+	}
+	a.renewLeadership(ctx)
+	if isLeader(a) {
+		t.Fatal("a still leads after b took over")
+	}
+
+	clock.Advance(time.Minute)
+	a.startDue(clock.Now())
+	b.startDue(clock.Now())
+	a.runs.Wait()
+	b.runs.Wait()
+	if got := runs.Load(); got != 2 {
+		t.Fatalf("expected the new leader to start the next run, got %d runs", got)
+	}
+}
+
+func TestClaimAndRelease(t *testing.T) {
+	clock := newFakeClock()
+	repo := memory.NewJobRepositoryWithClock(clock.Now)
+	ctx := context.Background()
+
+	release := make(chan struct{})
+	started := make(chan struct{}, 1)
+	job := Job{Name: "block", Schedule: "@daily", Timeout: time.Minute, Run: func(context.Context) error {
+		started <- struct{}{}
+		<-release
+		return nil
+	}}
+	a := newTestScheduler(t, repo, clock, "a", job)
+	b := newTestScheduler(t, repo, clock, "b", job)
+
+	if err := a.Trigger(ctx, job.Name, ""); err != nil {
+		t.Fatalf("trigger on a: %v", err)
+	}
+	<-started
+
+	if err := a.Trigger(ctx, job.Name, ""); err != utils.ErrJobAlreadyRunning {
+		t.Fatalf("expected a second run on a to be refused, got %v", err)
+	}
+	if err := b.Trigger(ctx, job.Name, ""); err != utils.ErrJobAlreadyRunning {
+		t.Fatalf("expected a run on b to be refused while a holds the lease, got %v", err)
+	}
+
+	// Finishing the run releases the lease
+	close(release)
+	a.runs.Wait()
+	if err := b.Trigger(ctx, job.Name, ""); err != nil {
+		t.Fatalf("trigger on b after a finished: %v", err)
+	}
+	<-started
+	b.runs.Wait()
+
+	// A replica that dies holding the lease blocks the job until it expires
+	if err := a.claim(ctx, job); err != nil {
+		t.Fatalf("claim on a: %v", err)
+	}
+	if err := b.Trigger(ctx, job.Name, ""); err != utils.ErrJobAlreadyRunning {
+		t.Fatalf("expected the orphaned lease to block b, got %v", err)
+	}
+	_, ttl := job.lease()
+	clock.Advance(ttl + time.Second)
+	if err := b.Trigger(ctx, job.Name, ""); err != nil {
+		t.Fatalf("trigger on b after the lease expired: %v", err)
+	}
+	<-started
+}
+
+func TestTriggerWhileStopped(t *testing.T) {
+	repo := memory.NewJobRepository()
+	s := New(repo)
+	job := Job{Name: "noop", Schedule: "@daily", Run: func(context.Context) error { return nil }}
+	if err := s.Register(job); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	if err := s.Trigger(ctx, job.Name, ""); err != utils.ErrSchedulerNotRunning {
+		t.Fatalf("expected ErrSchedulerNotRunning before start, got %v", err)
+	}
+
+	if err := s.start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Trigger(ctx, "missing", ""); err != utils.ErrJobNotFound {
+		t.Fatalf("expected ErrJobNotFound, got %v", err)
+	}
+	for deadline := time.Now().Add(time.Second); !isLeader(s); time.Sleep(10 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatal("the only scheduler did not become leader")
+		}
+	}
+	if err := s.stop(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Trigger(ctx, job.Name, ""); err != utils.ErrSchedulerNotRunning {
+		t.Fatalf("expected ErrSchedulerNotRunning after stop, got %v", err)
+	}
+	// Stopping gives up the leader lease
+	acquired, err := repo.AcquireLease(ctx, leaderLease, "other", leaderTTL)
+	if err != nil || !acquired {
+		t.Fatalf("expected the leader lease to be free after stop, got %v, %v", acquired, err)
+	}
+}
+
+func TestPanickingJobFails(t *testing.T) {
+	clock := newFakeClock()
+	repo := memory.NewJobRepositoryWithClock(clock.Now)
+	ctx := context.Background()
+
+	job := Job{Name: "panic", Schedule: "@daily", Run: func(context.Context) error { panic("boom") }}
+	s := newTestScheduler(t, repo, clock, "a", job)
+
+	if err := s.Trigger(ctx, job.Name, ""); err != nil {
+		t.Fatalf("trigger: %v", err)
+	}
+	s.runs.Wait()
+
+	runs, err := repo.GetRuns(ctx, job.Name, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(runs) != 1 || runs[0].Error != "panic: boom" {
+		t.Fatalf("expected one run failed with the panic, got %+v", runs)
+	}
+
+	// The panic released the job
+	if err := s.Trigger(ctx, job.Name, ""); err != nil {
+		t.Fatalf("trigger after the panic: %v", err)
+	}
+	s.runs.Wait()
+}
@@ -2,23 +2,40 @@ package services
 
 import (
 	"context"
+	"time"
 
 	"backend/models"
 	"backend/repositories/interfaces"
+	"backend/tracing"
 	"backend/utils"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type AdminService struct {
-	userRepo interfaces.UserRepository
+	userRepo            interfaces.UserRepository
+	tokenRepo           interfaces.TokenRepository
+	auditService        *AuditService
+	notificationService *NotificationService
+	webhookService      *WebhookService
+	realtimeService     *RealtimeService
 }
 
-func NewAdminService(userRepo interfaces.UserRepository) *AdminService {
+func NewAdminService(userRepo interfaces.UserRepository, tokenRepo interfaces.TokenRepository, auditService *AuditService, notificationService *NotificationService, webhookService *WebhookService, realtimeService *RealtimeService) *AdminService {
 	return &AdminService{
-		userRepo: userRepo,
+		userRepo:            userRepo,
+		tokenRepo:           tokenRepo,
+		auditService:        auditService,
+		notificationService: notificationService,
+		webhookService:      webhookService,
+		realtimeService:     realtimeService,
 	}
 }
 
 func (s *AdminService) GetPendingUsers(ctx context.Context) ([]*models.PendingUserResponse, error) {
+	ctx, span := tracing.Start(ctx, "AdminService.GetPendingUsers")
+	defer span.End()
+
 	users, err := s.userRepo.GetPendingUsers(ctx)
 	if err != nil {
 		return nil, err
@@ -34,6 +51,9 @@ func (s *AdminService) GetPendingUsers(ctx context.Context) ([]*models.PendingUs
 }
 
 func (s *AdminService) VerifyUser(ctx context.Context, userID, adminID string, req *models.VerificationRequest) error {
+	ctx, span := tracing.Start(ctx, "AdminService.VerifyUser")
+	defer span.End()
+
 	// Validate input
 	if err := utils.ValidateStruct(req); err != nil {
 		return err
@@ -50,14 +70,113 @@ func (s *AdminService) VerifyUser(ctx context.Context, userID, adminID string, r
 	}
 
 	// Verify the user
-	return s.userRepo.VerifyUser(ctx, userID, adminID, req.Notes)
+	if err := s.userRepo.VerifyUser(ctx, userID, adminID, req.Notes); err != nil {
+		return err
+	}
+
+	s.auditService.Record(ctx, models.AuditUserVerified, adminID, models.AuditTargetUser, userID, nil)
+	s.notificationService.Notify(ctx, models.NotificationUserVerified, []string{userID}, nil)
+	s.webhookService.Publish(ctx, models.WebhookUserVerified, map[string]string{
+		"user_id":  userID,
+		"actor_id": adminID,
+	})
+	return nil
+}
+
+// CreateAdmin creates a verified administrator. adminID may be empty when no
+// administrator exists yet; the account then has no recorded verifier.
+func (s *AdminService) CreateAdmin(ctx context.Context, req *models.AdminCreateRequest, adminID string) (*models.User, error) {
+	ctx, span := tracing.Start(ctx, "AdminService.CreateAdmin")
+	defer span.End()
+
+	// Validate input
+	if err := utils.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+
+	// Check if user already exists
+	_, err := s.userRepo.GetByEmail(ctx, req.Email)
+	if err == nil {
+		return nil, utils.ErrUserAlreadyExists
+	}
+	if err != utils.ErrUserNotFound {
+		return nil, err
+	}
+
+	hashedPassword, err := utils.HashPasswordContext(ctx, req.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	user := &models.User{
+		Name:              req.Name,
+		Email:             req.Email,
+		Password:          hashedPassword,
+		Role:              "admin",
+		IsVerified:        true,
+		VerifiedAt:        &now,
+		VerificationNotes: "Created as an administrator",
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+	if adminObjectID, err := primitive.ObjectIDFromHex(adminID); err == nil {
+		user.VerifiedBy = &adminObjectID
+	}
+
+	if err := s.userRepo.Create(ctx, user); err != nil {
+		return nil, err
+	}
+
+	s.auditService.Record(ctx, models.AuditUserCreated, adminID, models.AuditTargetUser, user.ID.Hex(), map[string]string{
+		"role": user.Role,
+	})
+	return user, nil
+}
+
+// ResetPassword sets a new password for the user and revokes all of their sessions
+func (s *AdminService) ResetPassword(ctx context.Context, userID, adminID string, req *models.AdminPasswordResetRequest) error {
+	ctx, span := tracing.Start(ctx, "AdminService.ResetPassword")
+	defer span.End()
+
+	// Validate input
+	if err := utils.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		return err
+	}
+
+	hashedPassword, err := utils.HashPasswordContext(ctx, req.Password)
+	if err != nil {
+		return err
+	}
+
+	if err := s.userRepo.UpdatePassword(ctx, userID, hashedPassword); err != nil {
+		return err
+	}
+
+	if err := s.tokenRepo.RevokeAllUserTokens(ctx, userID); err != nil {
+		return err
+	}
+
+	s.auditService.Record(ctx, models.AuditUserPasswordReset, adminID, models.AuditTargetUser, userID, nil)
+	s.realtimeService.Logout(ctx, userID, logoutPasswordReset)
+	return nil
 }
 
 func (s *AdminService) GetUserByID(ctx context.Context, userID string) (*models.User, error) {
+	ctx, span := tracing.Start(ctx, "AdminService.GetUserByID")
+	defer span.End()
+
 	return s.userRepo.GetByID(ctx, userID)
 }
 
-func (s *AdminService) UpdateUserRole(ctx context.Context, userID string, req *models.AdminUserRoleUpdateRequest) (*models.User, error) {
+func (s *AdminService) UpdateUserRole(ctx context.Context, userID, adminID string, req *models.AdminUserRoleUpdateRequest) (*models.User, error) {
+	ctx, span := tracing.Start(ctx, "AdminService.UpdateUserRole")
+	defer span.End()
+
 	// Validate input
 	if err := utils.ValidateStruct(req); err != nil {
 		return nil, err
@@ -75,8 +194,8 @@ func (s *AdminService) UpdateUserRole(ctx context.Context, userID string, req *m
 	}
 
 	// Security check: Prevent demoting the last admin
-	if user.Role == "admin" && req.Role != "admin" {
-		adminCount, err := s.userRepo.CountUsersByRole(ctx, "admin")
+	if user.Role == "admin" && req.Role != "admin" && !user.IsSuspended {
+		adminCount, err := s.userRepo.CountActiveUsersByRole(ctx, "admin")
 		if err != nil {
 			return nil, err
 		}
@@ -86,11 +205,114 @@ func (s *AdminService) UpdateUserRole(ctx context.Context, userID string, req *m
 	}
 
 	// Update role
+	previousRole := user.Role
 	user.Role = req.Role
 	err = s.userRepo.Update(ctx, user)
 	if err != nil {
 		return nil, err
 	}
 
+	details := map[string]string{
+		"from": previousRole,
+		"to":   req.Role,
+	}
+	s.auditService.Record(ctx, models.AuditUserRoleChanged, adminID, models.AuditTargetUser, userID, details)
+	s.notificationService.Notify(ctx, models.NotificationUserRoleChanged, []string{userID}, details)
+	s.webhookService.Publish(ctx, models.WebhookUserRoleChanged, map[string]string{
+		"user_id":  userID,
+		"actor_id": adminID,
+		"from":     previousRole,
+		"to":       req.Role,
+	})
+	// A demoted admin's client may hold admin-only state; make it start over
+	if previousRole == "admin" {
+		s.realtimeService.Logout(ctx, userID, logoutDemoted)
+	} else {
+		s.realtimeService.RoleChanged(ctx, userID, req.Role)
+	}
+
 	return user, nil
 }
+
+// SuspendUser blocks a user from logging in and revokes all of their sessions
+func (s *AdminService) SuspendUser(ctx context.Context, userID, adminID string, req *models.SuspensionRequest) error {
+	ctx, span := tracing.Start(ctx, "AdminService.SuspendUser")
+	defer span.End()
+
+	// Validate input
+	if err := utils.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if user.IsSuspended {
+		return utils.ErrUserAlreadySuspended
+	}
+
+	// Security check: Prevent locking out the last active admin
+	if user.Role == "admin" {
+		adminCount, err := s.userRepo.CountActiveUsersByRole(ctx, "admin")
+		if err != nil {
+			return err
+		}
+		if adminCount <= 1 {
+			return utils.ErrLastAdminSuspension
+		}
+	}
+
+	if err := s.userRepo.SuspendUser(ctx, userID, adminID, req.Reason); err != nil {
+		return err
+	}
+
+	if err := s.tokenRepo.RevokeAllUserTokens(ctx, userID); err != nil {
+		return err
+	}
+
+	s.auditService.Record(ctx, models.AuditUserSuspended, adminID, models.AuditTargetUser, userID, nil)
+	s.realtimeService.Logout(ctx, userID, logoutSuspended)
+	return nil
+}
+
+// ReinstateUser lifts a suspension so the user can log in again
+func (s *AdminService) ReinstateUser(ctx context.Context, userID, adminID string) error {
+	ctx, span := tracing.Start(ctx, "AdminService.ReinstateUser")
+	defer span.End()
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if !user.IsSuspended {
+		return utils.ErrUserNotSuspended
+	}
+
+	if err := s.userRepo.ReinstateUser(ctx, userID); err != nil {
+		return err
+	}
+
+	s.auditService.Record(ctx, models.AuditUserReinstated, adminID, models.AuditTargetUser, userID, nil)
+	return nil
+}
+
+// RevokeUserSessions revokes every refresh token issued to the user
+func (s *AdminService) RevokeUserSessions(ctx context.Context, userID, adminID string) error {
+	ctx, span := tracing.Start(ctx, "AdminService.RevokeUserSessions")
+	defer span.End()
+
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		return err
+	}
+
+	if err := s.tokenRepo.RevokeAllUserTokens(ctx, userID); err != nil {
+		return err
+	}
+
+	s.auditService.Record(ctx, models.AuditUserSessionsRevoked, adminID, models.AuditTargetUser, userID, nil)
+	s.realtimeService.Logout(ctx, userID, logoutSessionsRevoked)
+	return nil
+}
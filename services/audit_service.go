@@ -0,0 +1,70 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+
+	"backend/models"
+	"backend/repositories/interfaces"
+	"backend/tracing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AuditService records administrative and privacy-relevant actions
+type AuditService struct {
+	auditRepo interfaces.AuditRepository
+	userRepo  interfaces.UserRepository
+}
+
+func NewAuditService(auditRepo interfaces.AuditRepository, userRepo interfaces.UserRepository) *AuditService {
+	return &AuditService{
+		auditRepo: auditRepo,
+		userRepo:  userRepo,
+	}
+}
+
+// Record stores an audit event
+func (s *AuditService) Record(ctx context.Context, action, actorID, targetType, targetID string, details map[string]string) {
+	ctx, span := tracing.Start(ctx, "AuditService.Record")
+	defer span.End()
+
+	event := &models.AuditEvent{
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Details:    details,
+	}
+
+	if actorObjectID, err := primitive.ObjectIDFromHex(actorID); err == nil {
+		event.ActorID = &actorObjectID
+		if actor, err := s.userRepo.GetByID(ctx, actorID); err == nil {
+			event.ActorName = actor.Name
+		}
+	}
+
+	if err := s.auditRepo.Create(ctx, event); err != nil {
+		slog.ErrorContext(ctx, "Failed to record audit event", "action", action, "target_type", targetType, "target_id", targetID, "error", err)
+	}
+}
+
+func (s *AuditService) GetEventsInvolvingUser(ctx context.Context, userID string) ([]*models.AuditEvent, error) {
+	ctx, span := tracing.Start(ctx, "AuditService.GetEventsInvolvingUser")
+	defer span.End()
+
+	return s.auditRepo.GetInvolvingUser(ctx, userID)
+}
+
+func (s *AuditService) GetPermissionHistory(ctx context.Context, role string) ([]*models.AuditEvent, error) {
+	ctx, span := tracing.Start(ctx, "AuditService.GetPermissionHistory")
+	defer span.End()
+
+	return s.auditRepo.GetPermissionHistory(ctx, role)
+}
+
+func (s *AuditService) PseudonymizeActor(ctx context.Context, userID, pseudonym string) error {
+	ctx, span := tracing.Start(ctx, "AuditService.PseudonymizeActor")
+	defer span.End()
+
+	return s.auditRepo.PseudonymizeActor(ctx, userID, pseudonym)
+}
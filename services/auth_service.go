@@ -2,29 +2,49 @@ package services
 
 import (
 	"context"
+	"errors"
+	"log/slog"
 	"time"
 
 	"backend/config"
+	"backend/metrics"
 	"backend/models"
 	"backend/repositories/interfaces"
+	"backend/tracing"
 	"backend/utils"
+
+	"github.com/go-playground/validator/v10"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type AuthService struct {
-	userRepo     interfaces.UserRepository
-	tokenRepo    interfaces.TokenRepository
-	emailService *EmailService
+	userRepo            interfaces.UserRepository
+	tokenRepo           interfaces.TokenRepository
+	emailService        *EmailService
+	outboxService       *OutboxService
+	auditService        *AuditService
+	notificationService *NotificationService
+	realtimeService     *RealtimeService
+	transactor          interfaces.Transactor
 }
 
-func NewAuthService(userRepo interfaces.UserRepository, tokenRepo interfaces.TokenRepository, emailService *EmailService) *AuthService {
+func NewAuthService(userRepo interfaces.UserRepository, tokenRepo interfaces.TokenRepository, emailService *EmailService, outboxService *OutboxService, auditService *AuditService, notificationService *NotificationService, realtimeService *RealtimeService, transactor interfaces.Transactor) *AuthService {
 	return &AuthService{
-		userRepo:     userRepo,
-		tokenRepo:    tokenRepo,
-		emailService: emailService,
+		userRepo:            userRepo,
+		tokenRepo:           tokenRepo,
+		emailService:        emailService,
+		outboxService:       outboxService,
+		auditService:        auditService,
+		notificationService: notificationService,
+		realtimeService:     realtimeService,
+		transactor:          transactor,
 	}
 }
 
 func (s *AuthService) Register(ctx context.Context, req *models.UserCreateRequest) (*models.RegisterPendingResponse, error) {
+	ctx, span := tracing.Start(ctx, "AuthService.Register")
+	defer span.End()
+
 	// Validate input
 	if err := utils.ValidateStruct(req); err != nil {
 		return nil, err
@@ -40,7 +60,7 @@ func (s *AuthService) Register(ctx context.Context, req *models.UserCreateReques
 	}
 
 	// Hash password
-	hashedPassword, err := utils.HashPassword(req.Password)
+	hashedPassword, err := utils.HashPasswordContext(ctx, req.Password)
 	if err != nil {
 		return nil, err
 	}
@@ -51,6 +71,7 @@ func (s *AuthService) Register(ctx context.Context, req *models.UserCreateReques
 		Email:      req.Email,
 		Password:   hashedPassword,
 		Role:       req.Role,
+		Locale:     req.Locale,
 		IsVerified: false, // New users need admin verification
 		CreatedAt:  time.Now(),
 		UpdatedAt:  time.Now(),
@@ -68,7 +89,22 @@ func (s *AuthService) Register(ctx context.Context, req *models.UserCreateReques
 	}, nil
 }
 
+// Login authenticates a user and counts the attempt by outcome
 func (s *AuthService) Login(ctx context.Context, req *models.UserLoginRequest) (*models.LoginResponse, error) {
+	ctx, span := tracing.Start(ctx, "AuthService.Login")
+	defer span.End()
+
+	response, err := s.login(ctx, req)
+	result, reason := "success", "none"
+	if err != nil {
+		result, reason = "failure", loginFailureReason(err)
+		span.SetAttributes(attribute.String("auth.failure_reason", reason))
+	}
+	metrics.Logins.WithLabelValues(result, reason).Inc()
+	return response, err
+}
+
+func (s *AuthService) login(ctx context.Context, req *models.UserLoginRequest) (*models.LoginResponse, error) {
 	// Validate input
 	if err := utils.ValidateStruct(req); err != nil {
 		return nil, err
@@ -83,8 +119,13 @@ func (s *AuthService) Login(ctx context.Context, req *models.UserLoginRequest) (
 		return nil, err
 	}
 
+	// Erased accounts can never log in again
+	if user.ErasedAt != nil {
+		return nil, utils.ErrInvalidCredentials
+	}
+
 	// Check password
-	if !utils.CheckPasswordHash(req.Password, user.Password) {
+	if !utils.CheckPasswordHashContext(ctx, req.Password, user.Password) {
 		return nil, utils.ErrInvalidCredentials
 	}
 
@@ -93,19 +134,51 @@ func (s *AuthService) Login(ctx context.Context, req *models.UserLoginRequest) (
 		return nil, utils.ErrUserNotVerified
 	}
 
+	// Check if user is suspended
+	if user.IsSuspended {
+		return nil, utils.ErrUserSuspended
+	}
+
 	// Generate tokens
 	tokens, err := s.generateTokenPair(ctx, user)
 	if err != nil {
 		return nil, err
 	}
 
+	// Record the login; a failure here should not block the user
+	if err := s.userRepo.UpdateLastLogin(ctx, user.ID.Hex()); err != nil {
+		slog.WarnContext(ctx, "Failed to record last login", "user_id", user.ID.Hex(), "error", err)
+	}
+	s.auditService.Record(ctx, models.AuditUserLoggedIn, user.ID.Hex(), models.AuditTargetUser, user.ID.Hex(), nil)
+	s.notificationService.RecordLogin(ctx, user)
+
 	return &models.LoginResponse{
 		User:   user.ToResponse(),
 		Tokens: *tokens,
 	}, nil
 }
 
+// RefreshToken rotates a refresh token and counts the rotation by outcome
 func (s *AuthService) RefreshToken(ctx context.Context, refreshTokenString string) (*models.TokenPair, error) {
+	ctx, span := tracing.Start(ctx, "AuthService.RefreshToken")
+	defer span.End()
+
+	// Revoking the old token and issuing the new pair happen atomically. The
+	// revocation only matches a token that is still active, so of concurrent
+	// rotations of the same token one succeeds and the rest get ErrTokenRevoked.
+	var tokens *models.TokenPair
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		tokens, err = s.refreshToken(ctx, refreshTokenString)
+		return err
+	})
+	result := refreshResult(err)
+	span.SetAttributes(attribute.String("auth.refresh_result", result))
+	metrics.TokenRefreshes.WithLabelValues(result).Inc()
+	return tokens, err
+}
+
+func (s *AuthService) refreshToken(ctx context.Context, refreshTokenString string) (*models.TokenPair, error) {
 	// Get refresh token from database
 	refreshToken, err := s.tokenRepo.GetByToken(ctx, refreshTokenString)
 	if err != nil {
@@ -128,6 +201,11 @@ func (s *AuthService) RefreshToken(ctx context.Context, refreshTokenString strin
 		return nil, err
 	}
 
+	// Suspended users cannot rotate their sessions
+	if user.IsSuspended {
+		return nil, utils.ErrUserSuspended
+	}
+
 	// Revoke old refresh token
 	err = s.tokenRepo.RevokeToken(ctx, refreshTokenString)
 	if err != nil {
@@ -138,12 +216,63 @@ func (s *AuthService) RefreshToken(ctx context.Context, refreshTokenString strin
 	return s.generateTokenPair(ctx, user)
 }
 
+// loginFailureReason maps a login error to a low-cardinality metric label.
+// Suspended accounts are the service's notion of a locked account.
+func loginFailureReason(err error) string {
+	var validationErrors validator.ValidationErrors
+	switch {
+	case err == utils.ErrInvalidCredentials:
+		return "invalid_credentials"
+	case err == utils.ErrUserNotVerified:
+		return "not_verified"
+	case err == utils.ErrUserSuspended:
+		return "locked"
+	case errors.As(err, &validationErrors):
+		return "invalid_request"
+	default:
+		return "error"
+	}
+}
+
+// refreshResult maps a refresh error to a low-cardinality metric label
+func refreshResult(err error) string {
+	switch err {
+	case nil:
+		return "rotated"
+	case utils.ErrInvalidToken:
+		return "invalid"
+	case utils.ErrTokenRevoked:
+		return "revoked"
+	case utils.ErrTokenExpired:
+		return "expired"
+	case utils.ErrUserSuspended:
+		return "suspended"
+	default:
+		return "error"
+	}
+}
+
 func (s *AuthService) Logout(ctx context.Context, refreshTokenString string) error {
-	return s.tokenRepo.RevokeToken(ctx, refreshTokenString)
+	ctx, span := tracing.Start(ctx, "AuthService.Logout")
+	defer span.End()
+
+	// Logging out twice is not an error
+	if err := s.tokenRepo.RevokeToken(ctx, refreshTokenString); err != utils.ErrTokenRevoked {
+		return err
+	}
+	return nil
 }
 
 func (s *AuthService) LogoutAll(ctx context.Context, userID string) error {
-	return s.tokenRepo.RevokeAllUserTokens(ctx, userID)
+	ctx, span := tracing.Start(ctx, "AuthService.LogoutAll")
+	defer span.End()
+
+	if err := s.tokenRepo.RevokeAllUserTokens(ctx, userID); err != nil {
+		return err
+	}
+
+	s.realtimeService.Logout(ctx, userID, logoutSignedOut)
+	return nil
 }
 
 func (s *AuthService) generateTokenPair(ctx context.Context, user *models.User) (*models.TokenPair, error) {
@@ -188,11 +317,17 @@ func (s *AuthService) generateTokenPair(ctx context.Context, user *models.User)
 }
 
 func (s *AuthService) CleanupExpiredTokens(ctx context.Context) error {
+	ctx, span := tracing.Start(ctx, "AuthService.CleanupExpiredTokens")
+	defer span.End()
+
 	return s.tokenRepo.DeleteExpiredTokens(ctx)
 }
 
 // ForgotPassword generates a new password and sends it via email
 func (s *AuthService) ForgotPassword(ctx context.Context, req *models.ForgotPasswordRequest) (*models.ForgotPasswordResponse, error) {
+	ctx, span := tracing.Start(ctx, "AuthService.ForgotPassword")
+	defer span.End()
+
 	// Validate input
 	if err := utils.ValidateStruct(req); err != nil {
 		return nil, err
@@ -227,31 +362,39 @@ func (s *AuthService) ForgotPassword(ctx context.Context, req *models.ForgotPass
 	}
 
 	// Hash the new password
-	hashedPassword, err := utils.HashPassword(newPassword)
+	hashedPassword, err := utils.HashPasswordContext(ctx, newPassword)
 	if err != nil {
 		return nil, err
 	}
 
-	// Update user's password in database
-	if err := s.userRepo.UpdatePassword(ctx, user.ID.Hex(), hashedPassword); err != nil {
+	// Update the password, the reset tracking, revoke all existing tokens and
+	// queue the email atomically, so the rate limit and revocation cannot be
+	// skipped and the user is never left without the new password
+	email, err := s.emailService.PasswordResetEmail(user, newPassword)
+	if err != nil {
 		return nil, err
 	}
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.userRepo.UpdatePassword(ctx, user.ID.Hex(), hashedPassword); err != nil {
+			return err
+		}
+		s.auditService.Record(ctx, models.AuditUserPasswordReset, user.ID.Hex(), models.AuditTargetUser, user.ID.Hex(), nil)
 
-	// Update password reset tracking
-	if err := s.userRepo.UpdatePasswordResetInfo(ctx, user.ID.Hex()); err != nil {
-		// Log error but don't fail the request
-		// The password has already been updated successfully
-	}
+		if err := s.userRepo.UpdatePasswordResetInfo(ctx, user.ID.Hex()); err != nil {
+			return err
+		}
 
-	// Send email with new password
-	if err := s.emailService.SendPasswordResetEmail(user.Email, user.Name, newPassword); err != nil {
-		// Log the error but still return success to user for security
-		// In production, you might want to queue this for retry
-		return nil, utils.ErrEmailDeliveryFailed
-	}
+		if err := s.tokenRepo.RevokeAllUserTokens(ctx, user.ID.Hex()); err != nil {
+			return err
+		}
 
-	// Revoke all existing tokens for security
-	_ = s.tokenRepo.RevokeAllUserTokens(ctx, user.ID.Hex())
+		return s.outboxService.Enqueue(ctx, email)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.outboxService.Wake()
+	s.realtimeService.Logout(ctx, user.ID.Hex(), logoutPasswordReset)
 
 	return &models.ForgotPasswordResponse{
 		Message: "A new password has been sent to your email address.",
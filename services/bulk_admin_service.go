@@ -0,0 +1,318 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"backend/config"
+	"backend/lifecycle"
+	"backend/models"
+	"backend/repositories/interfaces"
+	"backend/tracing"
+	"backend/utils"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// bulkItemTimeout bounds the work done for a single user inside a bulk run
+const bulkItemTimeout = 10 * time.Second
+
+// bulkItemFunc applies a bulk operation to one user
+type bulkItemFunc func(ctx context.Context, userID string) error
+
+// BulkAdminService applies AdminService operations to many users at once.
+// Every item goes through the single-user AdminService method so the same
+// validation and safety rules (such as the last-admin protection) apply.
+type BulkAdminService struct {
+	adminService *AdminService
+	userRepo     interfaces.UserRepository
+	jobRepo      interfaces.BulkJobRepository
+
+	// jobsCtx is cancelled on shutdown to interrupt running background jobs
+	jobsCtx  context.Context
+	stopJobs context.CancelFunc
+	jobs     sync.WaitGroup
+}
+
+func NewBulkAdminService(adminService *AdminService, userRepo interfaces.UserRepository, jobRepo interfaces.BulkJobRepository) *BulkAdminService {
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+
+	return &BulkAdminService{
+		adminService: adminService,
+		userRepo:     userRepo,
+		jobRepo:      jobRepo,
+		jobsCtx:      jobsCtx,
+		stopJobs:     stopJobs,
+	}
+}
+
+// Shutdown interrupts running background jobs and waits for them to record
+// their final status or for ctx to expire. Interrupted jobs are marked failed.
+func (s *BulkAdminService) Shutdown(ctx context.Context) error {
+	s.stopJobs()
+
+	done := make(chan struct{})
+	go func() {
+		s.jobs.Wait()
+		close(done)
+	}()
+
+	return lifecycle.Wait(ctx, done)
+}
+
+// BulkResult is returned by every bulk operation. Exactly one of Report
+// (synchronous run) or Job (asynchronous run) is set.
+type BulkResult struct {
+	Report *models.BulkOperationReport
+	Job    *models.BulkJobResponse
+}
+
+func (s *BulkAdminService) VerifyUsers(ctx context.Context, adminID string, req *models.BulkVerifyRequest) (*BulkResult, error) {
+	ctx, span := tracing.Start(ctx, "BulkAdminService.VerifyUsers")
+	defer span.End()
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+
+	verification := &models.VerificationRequest{Notes: req.Notes}
+	return s.run(ctx, adminID, models.BulkOperationVerify, &req.BulkUserSelector, func(ctx context.Context, userID string) error {
+		return s.adminService.VerifyUser(ctx, userID, adminID, verification)
+	})
+}
+
+func (s *BulkAdminService) UpdateUserRoles(ctx context.Context, adminID string, req *models.BulkRoleUpdateRequest) (*BulkResult, error) {
+	ctx, span := tracing.Start(ctx, "BulkAdminService.UpdateUserRoles")
+	defer span.End()
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+
+	roleUpdate := &models.AdminUserRoleUpdateRequest{Role: req.Role}
+	return s.run(ctx, adminID, models.BulkOperationChangeRole, &req.BulkUserSelector, func(ctx context.Context, userID string) error {
+		_, err := s.adminService.UpdateUserRole(ctx, userID, adminID, roleUpdate)
+		return err
+	})
+}
+
+func (s *BulkAdminService) SuspendUsers(ctx context.Context, adminID string, req *models.BulkSuspendRequest) (*BulkResult, error) {
+	ctx, span := tracing.Start(ctx, "BulkAdminService.SuspendUsers")
+	defer span.End()
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+
+	suspension := &models.SuspensionRequest{Reason: req.Reason}
+	return s.run(ctx, adminID, models.BulkOperationSuspend, &req.BulkUserSelector, func(ctx context.Context, userID string) error {
+		return s.adminService.SuspendUser(ctx, userID, adminID, suspension)
+	})
+}
+
+func (s *BulkAdminService) RevokeSessions(ctx context.Context, adminID string, req *models.BulkRevokeSessionsRequest) (*BulkResult, error) {
+	ctx, span := tracing.Start(ctx, "BulkAdminService.RevokeSessions")
+	defer span.End()
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+
+	return s.run(ctx, adminID, models.BulkOperationRevokeSessions, &req.BulkUserSelector, func(ctx context.Context, userID string) error {
+		return s.adminService.RevokeUserSessions(ctx, userID, adminID)
+	})
+}
+
+func (s *BulkAdminService) GetJob(ctx context.Context, id string) (*models.BulkJobResponse, error) {
+	ctx, span := tracing.Start(ctx, "BulkAdminService.GetJob")
+	defer span.End()
+
+	job, err := s.jobRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	response := job.ToResponse(true)
+	return &response, nil
+}
+
+func (s *BulkAdminService) GetRecentJobs(ctx context.Context, limit int64) ([]*models.BulkJobResponse, error) {
+	ctx, span := tracing.Start(ctx, "BulkAdminService.GetRecentJobs")
+	defer span.End()
+
+	jobs, err := s.jobRepo.GetRecent(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var responses []*models.BulkJobResponse
+	for _, job := range jobs {
+		response := job.ToResponse(false)
+		responses = append(responses, &response)
+	}
+
+	return responses, nil
+}
+
+// run resolves the target users and either processes them inline or hands
+// them to a background job when the batch is large or async was requested
+func (s *BulkAdminService) run(ctx context.Context, adminID, operation string, selector *models.BulkUserSelector, apply bulkItemFunc) (*BulkResult, error) {
+	userIDs, err := s.resolveTargets(ctx, selector)
+	if err != nil {
+		return nil, err
+	}
+
+	if selector.Async || len(userIDs) > config.AppConfig.BulkAsyncThreshold {
+		job, err := s.startJob(ctx, adminID, operation, userIDs, apply)
+		if err != nil {
+			return nil, err
+		}
+		return &BulkResult{Job: job}, nil
+	}
+
+	report := &models.BulkOperationReport{
+		Operation: operation,
+		Total:     len(userIDs),
+		Results:   make([]models.BulkItemResult, 0, len(userIDs)),
+	}
+
+	for _, userID := range userIDs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		result := s.applyOne(ctx, userID, apply)
+		if result.Success {
+			report.Succeeded++
+		} else {
+			report.Failed++
+		}
+		report.Results = append(report.Results, result)
+	}
+
+	return &BulkResult{Report: report}, nil
+}
+
+// resolveTargets turns the selector into a de-duplicated list of user IDs
+func (s *BulkAdminService) resolveTargets(ctx context.Context, selector *models.BulkUserSelector) ([]string, error) {
+	maxItems := config.AppConfig.BulkMaxItems
+
+	if len(selector.IDs) == 0 && selector.Filter == nil {
+		return nil, utils.ErrBulkSelectionRequired
+	}
+
+	var userIDs []string
+	seen := make(map[string]bool)
+
+	for _, id := range selector.IDs {
+		if !seen[id] {
+			seen[id] = true
+			userIDs = append(userIDs, id)
+		}
+	}
+
+	if selector.Filter != nil {
+		// Fetch one more than allowed so oversized selections can be rejected
+		var limit int64
+		if maxItems > 0 {
+			limit = int64(maxItems + 1)
+		}
+		users, err := s.userRepo.FindByFilter(ctx, selector.Filter, limit)
+		if err != nil {
+			return nil, err
+		}
+		for _, user := range users {
+			id := user.ID.Hex()
+			if !seen[id] {
+				seen[id] = true
+				userIDs = append(userIDs, id)
+			}
+		}
+	}
+
+	if maxItems > 0 && len(userIDs) > maxItems {
+		return nil, utils.ErrBulkTooManyItems
+	}
+
+	return userIDs, nil
+}
+
+func (s *BulkAdminService) startJob(ctx context.Context, adminID, operation string, userIDs []string, apply bulkItemFunc) (*models.BulkJobResponse, error) {
+	adminObjectID, err := primitive.ObjectIDFromHex(adminID)
+	if err != nil {
+		return nil, utils.ErrInvalidID
+	}
+
+	job := &models.BulkJob{
+		Operation:   operation,
+		Status:      models.BulkJobStatusPending,
+		Total:       len(userIDs),
+		CreatedByID: adminObjectID,
+	}
+
+	if err := s.jobRepo.Create(ctx, job); err != nil {
+		return nil, err
+	}
+
+	// The job outlives the request: it keeps the request's values but is
+	// only cancelled by Shutdown
+	jobCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	stop := context.AfterFunc(s.jobsCtx, cancel)
+
+	s.jobs.Add(1)
+	go func() {
+		defer s.jobs.Done()
+		defer cancel()
+		defer stop()
+
+		s.processJob(jobCtx, job.ID.Hex(), userIDs, apply)
+	}()
+
+	response := job.ToResponse(false)
+	return &response, nil
+}
+
+// processJob works through the job's users until done or until ctx is
+// cancelled by Shutdown
+func (s *BulkAdminService) processJob(ctx context.Context, jobID string, userIDs []string, apply bulkItemFunc) {
+	if err := s.jobRepo.MarkRunning(ctx, jobID); err != nil {
+		slog.ErrorContext(ctx, "Bulk job failed to mark running", "job_id", jobID, "error", err)
+		return
+	}
+
+	for _, userID := range userIDs {
+		if ctx.Err() != nil {
+			// Record the interruption even though the job's own context is gone
+			finalCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), bulkItemTimeout)
+			defer cancel()
+
+			if err := s.jobRepo.Complete(finalCtx, jobID, models.BulkJobStatusFailed, "interrupted by server shutdown"); err != nil {
+				slog.ErrorContext(ctx, "Bulk job failed to mark interrupted", "job_id", jobID, "error", err)
+			}
+			return
+		}
+
+		result := s.applyOne(ctx, userID, apply)
+		if err := s.jobRepo.AppendResult(ctx, jobID, result); err != nil {
+			slog.ErrorContext(ctx, "Bulk job failed to record result", "job_id", jobID, "error", err)
+			_ = s.jobRepo.Complete(ctx, jobID, models.BulkJobStatusFailed, err.Error())
+			return
+		}
+	}
+
+	if err := s.jobRepo.Complete(ctx, jobID, models.BulkJobStatusCompleted, ""); err != nil {
+		slog.ErrorContext(ctx, "Bulk job failed to mark completed", "job_id", jobID, "error", err)
+	}
+}
+
+func (s *BulkAdminService) applyOne(ctx context.Context, userID string, apply bulkItemFunc) models.BulkItemResult {
+	itemCtx, cancel := context.WithTimeout(ctx, bulkItemTimeout)
+	defer cancel()
+
+	if err := apply(itemCtx, userID); err != nil {
+		return models.BulkItemResult{UserID: userID, Success: false, Error: err.Error()}
+	}
+	return models.BulkItemResult{UserID: userID, Success: true}
+}
@@ -0,0 +1,49 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"backend/config"
+	"backend/models"
+	"backend/repositories/memory"
+	"backend/utils"
+)
+
+func TestResolveTargetsLimit(t *testing.T) {
+	userRepo := memory.NewUserRepository()
+	for i := 0; i < 3; i++ {
+		if err := userRepo.Create(context.Background(), &models.User{Email: fmt.Sprintf("user%d@example.com", i), Role: "user", CreatedAt: time.Now()}); err != nil {
+			t.Fatalf("create user: %v", err)
+		}
+	}
+	s := NewBulkAdminService(nil, userRepo, memory.NewBulkJobRepository())
+	selector := &models.BulkUserSelector{Filter: &models.UserFilter{Role: "user"}}
+
+	tests := []struct {
+		name     string
+		maxItems int
+		want     int
+		wantErr  error
+	}{
+		{name: "unlimited", maxItems: 0, want: 3},
+		{name: "within the limit", maxItems: 3, want: 3},
+		{name: "over the limit", maxItems: 2, wantErr: utils.ErrBulkTooManyItems},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config.AppConfig = &config.Config{BulkMaxItems: tt.maxItems}
+
+			userIDs, err := s.resolveTargets(context.Background(), selector)
+			if err != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if len(userIDs) != tt.want {
+				t.Fatalf("expected %d targets, got %d", tt.want, len(userIDs))
+			}
+		})
+	}
+}
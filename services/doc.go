@@ -0,0 +1,8 @@
+// Package services holds the application's business logic, between the HTTP
+// handlers and the repositories.
+//
+// A change is announced after its unit of work commits: audit events,
+// notifications, webhooks and realtime events. The change has already
+// happened by then, so the methods recording these side effects log their
+// failures rather than return them.
+package services
@@ -1,151 +1,144 @@
 package services
 
 import (
-	"fmt"
-	"log"
+	"context"
+	"log/slog"
+	"sort"
 
 	"backend/config"
+	"backend/mailer"
+	"backend/models"
+	"backend/tracing"
+	"backend/utils"
 
-	"github.com/sendgrid/sendgrid-go"
-	"github.com/sendgrid/sendgrid-go/helpers/mail"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type EmailService struct {
-	client *sendgrid.Client
+	transport mailer.Transport
+	templates *mailer.Templates
 }
 
-func NewEmailService() *EmailService {
-	client := sendgrid.NewSendClient(config.AppConfig.SendGridAPIKey)
+func NewEmailService(transport mailer.Transport, templates *mailer.Templates) *EmailService {
 	return &EmailService{
-		client: client,
+		transport: transport,
+		templates: templates,
 	}
 }
 
-// SendPasswordResetEmail sends a new password to the user's email
-func (s *EmailService) SendPasswordResetEmail(userEmail, userName, newPassword string) error {
-	from := mail.NewEmail(config.AppConfig.SendGridFromName, config.AppConfig.SendGridFromEmail)
-	to := mail.NewEmail(userName, userEmail)
-
-	subject := config.AppConfig.ResetPasswordSubject
-
-	// Create email content
-	plainTextContent := s.formatPlainTextEmail(userName, newPassword)
-	htmlContent := s.formatHTMLEmail(userName, newPassword)
-
-	message := mail.NewSingleEmail(from, subject, to, plainTextContent, htmlContent)
+// CheckConfiguration reports whether the sender and the transport are configured
+func (s *EmailService) CheckConfiguration(ctx context.Context) error {
+	if config.AppConfig.MailFromEmail == "" {
+		return utils.ErrEmailNotConfigured
+	}
+	return s.transport.Check(ctx)
+}
 
-	response, err := s.client.Send(message)
+// Send delivers a queued message through the configured transport inside a
+// client span
+func (s *EmailService) Send(ctx context.Context, email *models.OutboxMessage) error {
+	ctx, span := tracing.StartWithKind(ctx, "email.send", trace.SpanKindClient,
+		attribute.String("email.kind", email.Kind),
+		attribute.String("email.transport", s.transport.Name()),
+	)
+	defer span.End()
+
+	err := s.transport.Send(ctx, &mailer.Message{
+		FromEmail: config.AppConfig.MailFromEmail,
+		FromName:  config.AppConfig.MailFromName,
+		ToEmail:   email.Recipient,
+		ToName:    email.RecipientName,
+		Subject:   email.Subject,
+		TextBody:  email.TextBody,
+		HTMLBody:  email.HTMLBody,
+	})
 	if err != nil {
-		log.Printf("Failed to send email: %v", err)
+		tracing.RecordError(span, err)
+		slog.ErrorContext(ctx, "Failed to send email", "transport", s.transport.Name(), "error", err)
 		return err
 	}
-
-	// Check response status
-	if response.StatusCode >= 400 {
-		log.Printf("SendGrid returned error status: %d, Response: %s", response.StatusCode, response.Body)
-		return fmt.Errorf("email service returned status %d", response.StatusCode)
-	}
-
-	log.Printf("Password reset email sent successfully to %s", userEmail)
 	return nil
 }
 
-// formatPlainTextEmail creates the plain text version of the password reset email
-func (s *EmailService) formatPlainTextEmail(userName, newPassword string) string {
-	return fmt.Sprintf(`Hello %s,
+// PasswordResetEmail builds the email that sends a new password to the user,
+// in the user's locale
+func (s *EmailService) PasswordResetEmail(user *models.User, newPassword string) (*models.OutboxMessage, error) {
+	return s.build(models.EmailKindPasswordReset, user, newPassword)
+}
 
-Your password has been reset as requested.
+// InvitationEmail builds the email that sends the initial credentials to a
+// user created by an administrator
+func (s *EmailService) InvitationEmail(user *models.User, temporaryPassword string) (*models.OutboxMessage, error) {
+	return s.build(models.EmailKindInvitation, user, temporaryPassword)
+}
 
-Your new temporary password is: %s
+// NotificationEmail renders a notification event in the user's locale. The
+// returned summary is the body of the matching in-app notification; its
+// title is the email subject.
+func (s *EmailService) NotificationEmail(kind string, user *models.User, details map[string]string) (*models.OutboxMessage, string, error) {
+	content, err := s.templates.Render(kind, user.Locale, mailer.TemplateData{
+		Name:    user.Name,
+		Email:   user.Email,
+		Details: details,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return message(kind, user, content), content.Summary, nil
+}
 
-For security reasons, please log in with this password and change it immediately to something you can remember.
+func (s *EmailService) build(kind string, user *models.User, password string) (*models.OutboxMessage, error) {
+	content, err := s.templates.Render(kind, user.Locale, mailer.TemplateData{
+		Name:     user.Name,
+		Email:    user.Email,
+		Password: password,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return message(kind, user, content), nil
+}
 
-Steps to change your password:
-1. Log in with the temporary password above
-2. Go to your profile settings
-3. Update your password
+func message(kind string, user *models.User, content *mailer.Content) *models.OutboxMessage {
+	return &models.OutboxMessage{
+		Kind:          kind,
+		Recipient:     user.Email,
+		RecipientName: user.Name,
+		Subject:       content.Subject,
+		TextBody:      content.TextBody,
+		HTMLBody:      content.HTMLBody,
+	}
+}
 
-If you did not request this password reset, please contact our support team immediately.
+// GetTemplates lists the email templates with the locales each is available in
+func (s *EmailService) GetTemplates() models.EmailTemplatesResponse {
+	kinds := s.templates.Kinds()
 
-Best regards,
-The Support Team`, userName, newPassword)
+	response := models.EmailTemplatesResponse{
+		DefaultLocale: s.templates.DefaultLocale(),
+		Templates:     make([]models.EmailTemplateResponse, 0, len(kinds)),
+	}
+	for kind, locales := range kinds {
+		response.Templates = append(response.Templates, models.EmailTemplateResponse{Kind: kind, Locales: locales})
+	}
+	sort.Slice(response.Templates, func(i, j int) bool { return response.Templates[i].Kind < response.Templates[j].Kind })
+	return response
 }
 
-// formatHTMLEmail creates the HTML version of the password reset email
-func (s *EmailService) formatHTMLEmail(userName, newPassword string) string {
-	return fmt.Sprintf(`
-<!DOCTYPE html>
-<html>
-<head>
-    <meta charset="utf-8">
-    <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>Password Reset</title>
-    <style>
-        body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; }
-        .container { max-width: 600px; margin: 0 auto; padding: 20px; }
-        .header { background-color: #f8f9fa; padding: 20px; text-align: center; border-radius: 5px; }
-        .content { padding: 20px 0; }
-        .password-box { 
-            background-color: #e9ecef; 
-            padding: 15px; 
-            border-radius: 5px; 
-            text-align: center;
-            font-family: monospace;
-            font-size: 18px;
-            font-weight: bold;
-            margin: 20px 0;
-        }
-        .warning { 
-            background-color: #fff3cd; 
-            border: 1px solid #ffeaa7; 
-            padding: 15px; 
-            border-radius: 5px; 
-            margin: 20px 0;
-        }
-        .footer { 
-            margin-top: 30px; 
-            padding-top: 20px; 
-            border-top: 1px solid #dee2e6; 
-            font-size: 14px; 
-            color: #6c757d; 
-        }
-        ol { padding-left: 20px; }
-        li { margin: 10px 0; }
-    </style>
-</head>
-<body>
-    <div class="container">
-        <div class="header">
-            <h1>Password Reset</h1>
-        </div>
-        
-        <div class="content">
-            <p>Hello <strong>%s</strong>,</p>
-            
-            <p>Your password has been reset as requested.</p>
-            
-            <p>Your new temporary password is:</p>
-            <div class="password-box">%s</div>
-            
-            <div class="warning">
-                <strong>⚠️ Important:</strong> For security reasons, please log in with this password and change it immediately to something you can remember.
-            </div>
-            
-            <p><strong>Steps to change your password:</strong></p>
-            <ol>
-                <li>Log in with the temporary password above</li>
-                <li>Go to your profile settings</li>
-                <li>Update your password</li>
-            </ol>
-            
-            <p>If you did not request this password reset, please contact our support team immediately.</p>
-        </div>
-        
-        <div class="footer">
-            <p>Best regards,<br>The Support Team</p>
-            <p><em>This is an automated message. Please do not reply to this email.</em></p>
-        </div>
-    </div>
-</body>
-</html>`, userName, newPassword)
+// Preview renders a template with sample data
+func (s *EmailService) Preview(kind, locale string) (*models.EmailPreviewResponse, error) {
+	content, err := s.templates.Render(kind, locale, mailer.SampleData())
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.EmailPreviewResponse{
+		Kind:     kind,
+		Locale:   content.Locale,
+		Subject:  content.Subject,
+		Summary:  content.Summary,
+		TextBody: content.TextBody,
+		HTMLBody: content.HTMLBody,
+	}, nil
 }
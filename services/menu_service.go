@@ -2,32 +2,48 @@ package services
 
 import (
 	"context"
+	"log/slog"
+	"sort"
 	"time"
 
 	"backend/models"
 	"backend/repositories/interfaces"
+	"backend/tracing"
 	"backend/utils"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type MenuService struct {
-	menuRepo       interfaces.MenuRepository
-	permissionRepo interfaces.PermissionRepository
-	userRepo       interfaces.UserRepository
+	menuRepo            interfaces.MenuRepository
+	permissionRepo      interfaces.PermissionRepository
+	userRepo            interfaces.UserRepository
+	auditService        *AuditService
+	notificationService *NotificationService
+	webhookService      *WebhookService
+	realtimeService     *RealtimeService
+	transactor          interfaces.Transactor
 }
 
-func NewMenuService(menuRepo interfaces.MenuRepository, permissionRepo interfaces.PermissionRepository, userRepo interfaces.UserRepository) *MenuService {
+func NewMenuService(menuRepo interfaces.MenuRepository, permissionRepo interfaces.PermissionRepository, userRepo interfaces.UserRepository, auditService *AuditService, notificationService *NotificationService, webhookService *WebhookService, realtimeService *RealtimeService, transactor interfaces.Transactor) *MenuService {
 	return &MenuService{
-		menuRepo:       menuRepo,
-		permissionRepo: permissionRepo,
-		userRepo:       userRepo,
+		menuRepo:            menuRepo,
+		permissionRepo:      permissionRepo,
+		userRepo:            userRepo,
+		auditService:        auditService,
+		notificationService: notificationService,
+		webhookService:      webhookService,
+		realtimeService:     realtimeService,
+		transactor:          transactor,
 	}
 }
 
 // Menu CRUD operations
 
 func (s *MenuService) CreateMenu(ctx context.Context, req *models.MenuCreateRequest) (*models.MenuResponse, error) {
+	ctx, span := tracing.Start(ctx, "MenuService.CreateMenu")
+	defer span.End()
+
 	// Validate input
 	if err := utils.ValidateStruct(req); err != nil {
 		return nil, err
@@ -48,11 +64,17 @@ func (s *MenuService) CreateMenu(ctx context.Context, req *models.MenuCreateRequ
 		return nil, err
 	}
 
+	// Admins see every active menu without a grant
+	s.realtimeService.MenusChanged(ctx, "admin")
+
 	response := menu.ToResponse()
 	return &response, nil
 }
 
 func (s *MenuService) GetAllMenus(ctx context.Context) ([]*models.MenuResponse, error) {
+	ctx, span := tracing.Start(ctx, "MenuService.GetAllMenus")
+	defer span.End()
+
 	menus, err := s.menuRepo.GetAll(ctx)
 	if err != nil {
 		return nil, err
@@ -68,6 +90,9 @@ func (s *MenuService) GetAllMenus(ctx context.Context) ([]*models.MenuResponse,
 }
 
 func (s *MenuService) GetMenuByID(ctx context.Context, id string) (*models.MenuResponse, error) {
+	ctx, span := tracing.Start(ctx, "MenuService.GetMenuByID")
+	defer span.End()
+
 	menu, err := s.menuRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
@@ -78,6 +103,9 @@ func (s *MenuService) GetMenuByID(ctx context.Context, id string) (*models.MenuR
 }
 
 func (s *MenuService) UpdateMenu(ctx context.Context, id string, req *models.MenuUpdateRequest) (*models.MenuResponse, error) {
+	ctx, span := tracing.Start(ctx, "MenuService.UpdateMenu")
+	defer span.End()
+
 	// Validate input
 	if err := utils.ValidateStruct(req); err != nil {
 		return nil, err
@@ -115,19 +143,62 @@ func (s *MenuService) UpdateMenu(ctx context.Context, id string, req *models.Men
 		return nil, err
 	}
 
+	grants, err := s.permissionRepo.GetRolesByMenu(ctx, id)
+	if err != nil {
+		// Reloading everyone's menus beats leaving the affected roles stale
+		slog.WarnContext(ctx, "Failed to load menu grants for realtime push", "menu_id", id, "error", err)
+		s.realtimeService.MenusChanged(ctx)
+	} else {
+		s.realtimeService.MenusChanged(ctx, menuRoles(grants)...)
+	}
+
 	response := existingMenu.ToResponse()
 	return &response, nil
 }
 
 func (s *MenuService) DeleteMenu(ctx context.Context, id string) error {
-	return s.menuRepo.Delete(ctx, id)
+	ctx, span := tracing.Start(ctx, "MenuService.DeleteMenu")
+	defer span.End()
+
+	menu, err := s.menuRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	grants, err := s.permissionRepo.GetRolesByMenu(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.menuRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	// Deleting a menu revokes its grants; tell webhooks, as for a revocation
+	for _, grant := range grants {
+		s.webhookService.Publish(ctx, models.WebhookPermissionRevoked, permissionEvent(grant.Role, id, menu, ""))
+	}
+	s.realtimeService.MenusChanged(ctx, menuRoles(grants)...)
+	return nil
+}
+
+// menuRoles lists the roles that see a menu: admins, who see every menu,
+// and the roles granted it
+func menuRoles(grants []*models.RoleMenuPermission) []string {
+	roles := []string{"admin"}
+	for _, grant := range grants {
+		roles = append(roles, grant.Role)
+	}
+	return roles
 }
 
 // Permission operations
 
 func (s *MenuService) GrantPermission(ctx context.Context, role, menuID, adminID string) error {
+	ctx, span := tracing.Start(ctx, "MenuService.GrantPermission")
+	defer span.End()
+
 	// Validate that menu exists
-	_, err := s.menuRepo.GetByID(ctx, menuID)
+	menu, err := s.menuRepo.GetByID(ctx, menuID)
 	if err != nil {
 		return err
 	}
@@ -156,14 +227,75 @@ func (s *MenuService) GrantPermission(ctx context.Context, role, menuID, adminID
 		CreatedAt:     time.Now(),
 	}
 
-	return s.permissionRepo.GrantPermission(ctx, permission)
+	if err := s.permissionRepo.GrantPermission(ctx, permission); err != nil {
+		return err
+	}
+
+	s.auditService.Record(ctx, models.AuditPermissionGranted, adminID, models.AuditTargetRole, role, map[string]string{
+		"menu_id": menuID,
+	})
+	s.notificationService.NotifyRole(ctx, models.NotificationPermissionGranted, role, permissionDetails(role, menu))
+	s.webhookService.Publish(ctx, models.WebhookPermissionGranted, permissionEvent(role, menuID, menu, adminID))
+	s.realtimeService.MenusChanged(ctx, role)
+	return nil
+}
+
+func (s *MenuService) RevokePermission(ctx context.Context, role, menuID, adminID string) error {
+	ctx, span := tracing.Start(ctx, "MenuService.RevokePermission")
+	defer span.End()
+
+	if err := s.permissionRepo.RevokePermission(ctx, role, menuID); err != nil {
+		return err
+	}
+
+	s.auditService.Record(ctx, models.AuditPermissionRevoked, adminID, models.AuditTargetRole, role, map[string]string{
+		"menu_id": menuID,
+	})
+
+	// Menus disappear only for roles that no longer see them
+	menu, err := s.menuRepo.GetByID(ctx, menuID)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to load revoked menu for notifications", "menu_id", menuID, "error", err)
+		menu = nil
+	} else {
+		s.notificationService.NotifyRole(ctx, models.NotificationPermissionRevoked, role, permissionDetails(role, menu))
+	}
+	s.webhookService.Publish(ctx, models.WebhookPermissionRevoked, permissionEvent(role, menuID, menu, adminID))
+	s.realtimeService.MenusChanged(ctx, role)
+	return nil
 }
 
-func (s *MenuService) RevokePermission(ctx context.Context, role, menuID string) error {
-	return s.permissionRepo.RevokePermission(ctx, role, menuID)
+// permissionDetails describes a grant or revocation to the role's members
+func permissionDetails(role string, menu *models.Menu) map[string]string {
+	return map[string]string{
+		"role":      role,
+		"menu_id":   menu.ID.Hex(),
+		"menu_name": menu.Name,
+		"menu_path": menu.Path,
+	}
+}
+
+// permissionEvent describes a grant or revocation to webhooks. The menu's
+// name and path are left out when it could not be loaded.
+func permissionEvent(role, menuID string, menu *models.Menu, actorID string) map[string]string {
+	data := map[string]string{
+		"role":    role,
+		"menu_id": menuID,
+	}
+	if actorID != "" {
+		data["actor_id"] = actorID
+	}
+	if menu != nil {
+		data["menu_name"] = menu.Name
+		data["menu_path"] = menu.Path
+	}
+	return data
 }
 
 func (s *MenuService) GetPermissionsByRole(ctx context.Context, role string) ([]*models.RoleMenuPermissionResponse, error) {
+	ctx, span := tracing.Start(ctx, "MenuService.GetPermissionsByRole")
+	defer span.End()
+
 	permissions, err := s.permissionRepo.GetPermissionsByRole(ctx, role)
 	if err != nil {
 		return nil, err
@@ -185,6 +317,9 @@ func (s *MenuService) GetPermissionsByRole(ctx context.Context, role string) ([]
 }
 
 func (s *MenuService) GetRolesByMenu(ctx context.Context, menuID string) ([]*models.RoleMenuPermissionResponse, error) {
+	ctx, span := tracing.Start(ctx, "MenuService.GetRolesByMenu")
+	defer span.End()
+
 	permissions, err := s.permissionRepo.GetRolesByMenu(ctx, menuID)
 	if err != nil {
 		return nil, err
@@ -206,6 +341,9 @@ func (s *MenuService) GetRolesByMenu(ctx context.Context, menuID string) ([]*mod
 }
 
 func (s *MenuService) GetAllPermissions(ctx context.Context) ([]*models.RoleMenuPermissionResponse, error) {
+	ctx, span := tracing.Start(ctx, "MenuService.GetAllPermissions")
+	defer span.End()
+
 	permissions, err := s.permissionRepo.GetAllPermissions(ctx)
 	if err != nil {
 		return nil, err
@@ -229,6 +367,9 @@ func (s *MenuService) GetAllPermissions(ctx context.Context) ([]*models.RoleMenu
 // User menu access
 
 func (s *MenuService) GetUserMenus(ctx context.Context, userRole string) ([]*models.UserMenuResponse, error) {
+	ctx, span := tracing.Start(ctx, "MenuService.GetUserMenus")
+	defer span.End()
+
 	menus, err := s.menuRepo.GetMenusByRole(ctx, userRole)
 	if err != nil {
 		return nil, err
@@ -246,6 +387,9 @@ func (s *MenuService) GetUserMenus(ctx context.Context, userRole string) ([]*mod
 // Permission summary
 
 func (s *MenuService) GetRolePermissionSummary(ctx context.Context) ([]*models.RolePermissionSummary, error) {
+	ctx, span := tracing.Start(ctx, "MenuService.GetRolePermissionSummary")
+	defer span.End()
+
 	roles := []string{"admin", "liaison", "voice", "finance"}
 	var summaries []*models.RolePermissionSummary
 
@@ -271,7 +415,204 @@ func (s *MenuService) GetRolePermissionSummary(ctx context.Context) ([]*models.R
 	return summaries, nil
 }
 
-// Helper method to get user by ID
+// GetUserByID returns the authenticated user. A token that outlived its user
+// is an authentication failure rather than a missing resource.
 func (s *MenuService) GetUserByID(ctx context.Context, userID string) (*models.User, error) {
-	return s.userRepo.GetByID(ctx, userID)
+	ctx, span := tracing.Start(ctx, "MenuService.GetUserByID")
+	defer span.End()
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err == utils.ErrUserNotFound {
+		return nil, utils.ErrTokenUserNotFound
+	}
+	return user, err
+}
+
+// Access policy
+
+// ExportPolicy returns every menu and role grant in portable form
+func (s *MenuService) ExportPolicy(ctx context.Context) (*models.AccessPolicy, error) {
+	ctx, span := tracing.Start(ctx, "MenuService.ExportPolicy")
+	defer span.End()
+
+	menus, err := s.menuRepo.GetMenusOrderedByOrder(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions, err := s.permissionRepo.GetAllPermissions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := &models.AccessPolicy{
+		Menus:  make([]models.PolicyMenu, 0, len(menus)),
+		Grants: make([]models.PolicyGrant, 0, len(permissions)),
+	}
+	paths := make(map[string]string, len(menus))
+	for _, menu := range menus {
+		policy.Menus = append(policy.Menus, menu.ToPolicyMenu())
+		paths[menu.ID.Hex()] = menu.Path
+	}
+
+	for _, perm := range permissions {
+		path, ok := paths[perm.MenuID.Hex()]
+		if !ok {
+			continue // Skip if menu not found
+		}
+		policy.Grants = append(policy.Grants, models.PolicyGrant{Role: perm.Role, MenuPath: path})
+	}
+
+	// Stable order keeps exports diffable
+	sort.Slice(policy.Grants, func(i, j int) bool {
+		if policy.Grants[i].Role != policy.Grants[j].Role {
+			return policy.Grants[i].Role < policy.Grants[j].Role
+		}
+		return policy.Grants[i].MenuPath < policy.Grants[j].MenuPath
+	})
+
+	return policy, nil
+}
+
+// ImportPolicy creates and updates menus to match the policy, matching them by
+// path, and grants the listed permissions. With prune, menus and grants that
+// are not in the policy are removed. The import is applied in one transaction.
+func (s *MenuService) ImportPolicy(ctx context.Context, policy *models.AccessPolicy, adminID string, prune bool) (*models.PolicyImportResult, error) {
+	ctx, span := tracing.Start(ctx, "MenuService.ImportPolicy")
+	defer span.End()
+
+	// Validate input
+	if err := utils.ValidateStruct(policy); err != nil {
+		return nil, err
+	}
+
+	var result *models.PolicyImportResult
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.importPolicy(ctx, policy, adminID, prune)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Grants pushed their own events, possibly before the commit; menus
+	// changed in bulk may concern anyone, so everyone reloads once more
+	if *result != (models.PolicyImportResult{}) {
+		s.realtimeService.MenusChanged(ctx)
+	}
+
+	return result, nil
+}
+
+func (s *MenuService) importPolicy(ctx context.Context, policy *models.AccessPolicy, adminID string, prune bool) (*models.PolicyImportResult, error) {
+	result := &models.PolicyImportResult{}
+
+	menus, err := s.menuRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byPath := make(map[string]*models.Menu, len(menus))
+	for _, menu := range menus {
+		byPath[menu.Path] = menu
+	}
+
+	listed := make(map[string]bool, len(policy.Menus))
+	for _, policyMenu := range policy.Menus {
+		listed[policyMenu.Path] = true
+
+		menu, ok := byPath[policyMenu.Path]
+		if !ok {
+			menu = &models.Menu{
+				Name:        policyMenu.Name,
+				Description: policyMenu.Description,
+				Icon:        policyMenu.Icon,
+				Path:        policyMenu.Path,
+				Order:       policyMenu.Order,
+				IsActive:    policyMenu.IsActive,
+				CreatedAt:   time.Now(),
+				UpdatedAt:   time.Now(),
+			}
+			if err := s.menuRepo.Create(ctx, menu); err != nil {
+				return nil, err
+			}
+			byPath[menu.Path] = menu
+			result.MenusCreated++
+			continue
+		}
+
+		if menu.ToPolicyMenu() == policyMenu {
+			continue
+		}
+
+		menu.Name = policyMenu.Name
+		menu.Description = policyMenu.Description
+		menu.Icon = policyMenu.Icon
+		menu.Order = policyMenu.Order
+		menu.IsActive = policyMenu.IsActive
+		menu.UpdatedAt = time.Now()
+		if err := s.menuRepo.Update(ctx, menu.ID.Hex(), menu); err != nil {
+			return nil, err
+		}
+		result.MenusUpdated++
+	}
+
+	// Deleting a menu also revokes its grants
+	if prune {
+		for path, menu := range byPath {
+			if listed[path] {
+				continue
+			}
+			if err := s.menuRepo.Delete(ctx, menu.ID.Hex()); err != nil {
+				return nil, err
+			}
+			delete(byPath, path)
+			result.MenusDeleted++
+		}
+	}
+
+	permissions, err := s.permissionRepo.GetAllPermissions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	granted := make(map[models.PolicyGrant]bool, len(permissions))
+	for _, perm := range permissions {
+		granted[models.PolicyGrant{Role: perm.Role, MenuPath: perm.MenuID.Hex()}] = true
+	}
+
+	wanted := make(map[models.PolicyGrant]bool, len(policy.Grants))
+	for _, grant := range policy.Grants {
+		menu, ok := byPath[grant.MenuPath]
+		if !ok {
+			return nil, utils.ErrMenuNotFound
+		}
+
+		key := models.PolicyGrant{Role: grant.Role, MenuPath: menu.ID.Hex()}
+		wanted[key] = true
+		if granted[key] {
+			continue
+		}
+
+		if err := s.GrantPermission(ctx, grant.Role, menu.ID.Hex(), adminID); err != nil {
+			return nil, err
+		}
+		granted[key] = true
+		result.GrantsAdded++
+	}
+
+	if prune {
+		for _, perm := range permissions {
+			if wanted[models.PolicyGrant{Role: perm.Role, MenuPath: perm.MenuID.Hex()}] {
+				continue
+			}
+			if err := s.RevokePermission(ctx, perm.Role, perm.MenuID.Hex(), adminID); err != nil {
+				return nil, err
+			}
+			result.GrantsRevoked++
+		}
+	}
+
+	return result, nil
 }
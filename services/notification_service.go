@@ -0,0 +1,337 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"backend/lifecycle"
+	"backend/models"
+	"backend/repositories/interfaces"
+	"backend/tracing"
+	"backend/utils"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// NotificationService fans domain events out to the channels each recipient
+// has chosen: an email queued in the outbox and an entry in the in-app inbox.
+// Both are rendered from the email template named after the event type, with
+// dots replaced by underscores, in the recipient's locale.
+type NotificationService struct {
+	notificationRepo interfaces.NotificationRepository
+	deviceRepo       interfaces.DeviceRepository
+	userRepo         interfaces.UserRepository
+	emailService     *EmailService
+	outboxService    *OutboxService
+
+	// fanoutCtx is cancelled on shutdown to interrupt running role fan-outs
+	fanoutCtx  context.Context
+	stopFanout context.CancelFunc
+	fanouts    sync.WaitGroup
+}
+
+func NewNotificationService(notificationRepo interfaces.NotificationRepository, deviceRepo interfaces.DeviceRepository, userRepo interfaces.UserRepository, emailService *EmailService, outboxService *OutboxService) *NotificationService {
+	fanoutCtx, stopFanout := context.WithCancel(context.Background())
+
+	return &NotificationService{
+		notificationRepo: notificationRepo,
+		deviceRepo:       deviceRepo,
+		userRepo:         userRepo,
+		emailService:     emailService,
+		outboxService:    outboxService,
+		fanoutCtx:        fanoutCtx,
+		stopFanout:       stopFanout,
+	}
+}
+
+// Shutdown interrupts running role fan-outs and waits for them to stop or
+// for ctx to expire. Members not reached yet are not notified.
+func (s *NotificationService) Shutdown(ctx context.Context) error {
+	s.stopFanout()
+
+	done := make(chan struct{})
+	go func() {
+		s.fanouts.Wait()
+		close(done)
+	}()
+
+	return lifecycle.Wait(ctx, done)
+}
+
+// Notify delivers an event to the given users
+func (s *NotificationService) Notify(ctx context.Context, eventType string, userIDs []string, details map[string]string) {
+	ctx, span := tracing.Start(ctx, "NotificationService.Notify",
+		attribute.String("notification.type", eventType),
+		attribute.Int("notification.recipients", len(userIDs)),
+	)
+	defer span.End()
+
+	for _, userID := range userIDs {
+		user, err := s.userRepo.GetByID(ctx, userID)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to load notification recipient", "type", eventType, "user_id", userID, "error", err)
+			continue
+		}
+		s.deliver(ctx, eventType, user, details)
+	}
+}
+
+// NotifyRole delivers an event to every verified, active member of a role.
+// A role can have any number of members, so the fan-out runs in the
+// background instead of using up the caller's request timeout.
+func (s *NotificationService) NotifyRole(ctx context.Context, eventType, role string, details map[string]string) {
+	// The fan-out keeps the request's values but is only cancelled by Shutdown
+	fanoutCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	stop := context.AfterFunc(s.fanoutCtx, cancel)
+
+	s.fanouts.Add(1)
+	go func() {
+		defer s.fanouts.Done()
+		defer cancel()
+		defer stop()
+
+		s.notifyRole(fanoutCtx, eventType, role, details)
+	}()
+}
+
+func (s *NotificationService) notifyRole(ctx context.Context, eventType, role string, details map[string]string) {
+	ctx, span := tracing.Start(ctx, "NotificationService.NotifyRole",
+		attribute.String("notification.type", eventType),
+		attribute.String("notification.role", role),
+	)
+	defer span.End()
+
+	verified, suspended := true, false
+	members, err := s.userRepo.FindByFilter(ctx, &models.UserFilter{Role: role, IsVerified: &verified, IsSuspended: &suspended}, 0)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to load role members to notify", "type", eventType, "role", role, "error", err)
+		return
+	}
+
+	span.SetAttributes(attribute.Int("notification.recipients", len(members)))
+	for _, member := range members {
+		if ctx.Err() != nil {
+			slog.WarnContext(ctx, "Role notification interrupted by shutdown", "type", eventType, "role", role)
+			return
+		}
+		s.deliver(ctx, eventType, member, details)
+	}
+}
+
+func (s *NotificationService) deliver(ctx context.Context, eventType string, user *models.User, details map[string]string) {
+	if user.ErasedAt != nil {
+		return
+	}
+
+	channels, err := s.channels(ctx, user.ID.Hex(), eventType)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to load notification preferences", "type", eventType, "user_id", user.ID.Hex(), "error", err)
+		return
+	}
+	if !channels.Email && !channels.InApp {
+		return
+	}
+
+	kind := strings.ReplaceAll(eventType, ".", "_")
+	email, summary, err := s.emailService.NotificationEmail(kind, user, details)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to render notification", "type", eventType, "user_id", user.ID.Hex(), "error", err)
+		return
+	}
+
+	if channels.InApp {
+		notification := &models.Notification{
+			UserID: user.ID,
+			Type:   eventType,
+			Title:  email.Subject,
+			Body:   summary,
+			Data:   details,
+		}
+		if err := s.notificationRepo.Create(ctx, notification); err != nil {
+			slog.ErrorContext(ctx, "Failed to store notification", "type", eventType, "user_id", user.ID.Hex(), "error", err)
+		}
+	}
+
+	if channels.Email {
+		if err := s.outboxService.Enqueue(ctx, email); err != nil {
+			slog.ErrorContext(ctx, "Failed to queue notification email", "type", eventType, "user_id", user.ID.Hex(), "error", err)
+			return
+		}
+		s.outboxService.Wake()
+	}
+}
+
+// channels returns the user's stored preference for an event type, or its
+// default
+func (s *NotificationService) channels(ctx context.Context, userID, eventType string) (models.NotificationChannels, error) {
+	preferences, err := s.notificationRepo.GetPreferences(ctx, userID)
+	if err != nil {
+		return models.NotificationChannels{}, err
+	}
+
+	for _, preference := range preferences {
+		if preference.Type == eventType {
+			return preference.NotificationChannels, nil
+		}
+	}
+	return models.NotificationDefaults[eventType], nil
+}
+
+// RecordLogin remembers the device a user logged in from and notifies the
+// user when it is new. The first device a user is seen with is not reported,
+// so accounts that logged in before devices were tracked are not alerted.
+func (s *NotificationService) RecordLogin(ctx context.Context, user *models.User) {
+	ctx, span := tracing.Start(ctx, "NotificationService.RecordLogin")
+	defer span.End()
+
+	client := utils.ClientFromContext(ctx)
+	if client.UserAgent == "" {
+		return
+	}
+
+	now := time.Now()
+	fingerprint := sha256.Sum256([]byte(client.UserAgent))
+	isNew, err := s.deviceRepo.Record(ctx, &models.Device{
+		UserID:      user.ID,
+		Fingerprint: hex.EncodeToString(fingerprint[:]),
+		UserAgent:   client.UserAgent,
+		IP:          client.IP,
+		LastSeenAt:  now,
+	})
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to record login device", "user_id", user.ID.Hex(), "error", err)
+		return
+	}
+	if !isNew {
+		return
+	}
+
+	devices, err := s.deviceRepo.ListByUser(ctx, user.ID.Hex())
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to list login devices", "user_id", user.ID.Hex(), "error", err)
+		return
+	}
+	if len(devices) > 1 {
+		s.deliver(ctx, models.NotificationNewDevice, user, map[string]string{
+			"ip":         client.IP,
+			"user_agent": client.UserAgent,
+			"time":       now.UTC().Format("2006-01-02 15:04 MST"),
+		})
+	}
+}
+
+// GetNotifications returns the user's notifications, newest first
+func (s *NotificationService) GetNotifications(ctx context.Context, userID string, unreadOnly bool, limit int64) ([]models.NotificationResponse, error) {
+	ctx, span := tracing.Start(ctx, "NotificationService.GetNotifications")
+	defer span.End()
+
+	notifications, err := s.notificationRepo.List(ctx, userID, unreadOnly, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]models.NotificationResponse, 0, len(notifications))
+	for _, notification := range notifications {
+		responses = append(responses, notification.ToResponse())
+	}
+	return responses, nil
+}
+
+func (s *NotificationService) CountUnread(ctx context.Context, userID string) (*models.UnreadCountResponse, error) {
+	ctx, span := tracing.Start(ctx, "NotificationService.CountUnread")
+	defer span.End()
+
+	unread, err := s.notificationRepo.CountUnread(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &models.UnreadCountResponse{Unread: unread}, nil
+}
+
+func (s *NotificationService) MarkRead(ctx context.Context, userID, id string) error {
+	ctx, span := tracing.Start(ctx, "NotificationService.MarkRead")
+	defer span.End()
+
+	return s.notificationRepo.MarkRead(ctx, userID, id)
+}
+
+func (s *NotificationService) MarkAllRead(ctx context.Context, userID string) (*models.MarkAllReadResponse, error) {
+	ctx, span := tracing.Start(ctx, "NotificationService.MarkAllRead")
+	defer span.End()
+
+	marked, err := s.notificationRepo.MarkAllRead(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &models.MarkAllReadResponse{Marked: marked}, nil
+}
+
+// GetPreferences returns the user's channels for every event type, with
+// defaults filled in for types the user has not chosen
+func (s *NotificationService) GetPreferences(ctx context.Context, userID string) (*models.NotificationPreferencesResponse, error) {
+	ctx, span := tracing.Start(ctx, "NotificationService.GetPreferences")
+	defer span.End()
+
+	stored, err := s.notificationRepo.GetPreferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	channels := make(map[string]models.NotificationChannels, len(models.NotificationDefaults))
+	for eventType, defaults := range models.NotificationDefaults {
+		channels[eventType] = defaults
+	}
+	for _, preference := range stored {
+		if _, known := channels[preference.Type]; known {
+			channels[preference.Type] = preference.NotificationChannels
+		}
+	}
+
+	preferences := make([]models.NotificationPreference, 0, len(channels))
+	for eventType, choice := range channels {
+		preferences = append(preferences, models.NotificationPreference{Type: eventType, NotificationChannels: choice})
+	}
+	sort.Slice(preferences, func(i, j int) bool { return preferences[i].Type < preferences[j].Type })
+	return &models.NotificationPreferencesResponse{Preferences: preferences}, nil
+}
+
+// UpdatePreferences stores the user's channels for the given event types;
+// other types keep their current choice
+func (s *NotificationService) UpdatePreferences(ctx context.Context, userID string, req *models.NotificationPreferencesRequest) (*models.NotificationPreferencesResponse, error) {
+	ctx, span := tracing.Start(ctx, "NotificationService.UpdatePreferences")
+	defer span.End()
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+	for _, preference := range req.Preferences {
+		if _, known := models.NotificationDefaults[preference.Type]; !known {
+			return nil, utils.ErrUnknownNotificationType
+		}
+	}
+
+	if err := s.notificationRepo.SavePreferences(ctx, userID, req.Preferences); err != nil {
+		return nil, err
+	}
+	return s.GetPreferences(ctx, userID)
+}
+
+// Prune returns a job that deletes notifications created longer than
+// retention ago
+func (s *NotificationService) Prune(retention time.Duration) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		deleted, err := s.notificationRepo.DeleteBefore(ctx, time.Now().Add(-retention))
+		if err != nil {
+			return err
+		}
+
+		slog.InfoContext(ctx, "Pruned notifications", "deleted", deleted)
+		return nil
+	}
+}
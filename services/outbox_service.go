@@ -0,0 +1,195 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"backend/config"
+	"backend/metrics"
+	"backend/models"
+	"backend/repositories/interfaces"
+	"backend/tracing"
+	"backend/utils"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	// outboxBatchSize is how many messages a worker claims at a time
+	outboxBatchSize = 20
+	// outboxSendTimeout bounds a single delivery attempt
+	outboxSendTimeout = 30 * time.Second
+	// outboxClaimLease outlasts a batch in which every delivery times out
+	outboxClaimLease = outboxBatchSize*outboxSendTimeout + time.Minute
+	// maxRetryDelay caps the exponential backoff of the outbox and webhook
+	// workers
+	maxRetryDelay = 6 * time.Hour
+)
+
+// OutboxService queues outbound email in the caller's unit of work and
+// delivers it in the background, retrying with exponential backoff
+type OutboxService struct {
+	*poller[*models.OutboxMessage]
+
+	outboxRepo   interfaces.OutboxRepository
+	emailService *EmailService
+	auditService *AuditService
+}
+
+func NewOutboxService(outboxRepo interfaces.OutboxRepository, emailService *EmailService, auditService *AuditService) *OutboxService {
+	s := &OutboxService{
+		outboxRepo:   outboxRepo,
+		emailService: emailService,
+		auditService: auditService,
+	}
+	s.poller = newPoller("email outbox", config.AppConfig.OutboxPollInterval, outboxBatchSize, outboxClaimLease, outboxRepo.ClaimDue, s.deliver)
+	return s
+}
+
+// Enqueue queues a message. Call it with the context of the transaction that
+// makes the change the message announces, so either both or neither persist,
+// and call Wake once the transaction has committed.
+func (s *OutboxService) Enqueue(ctx context.Context, message *models.OutboxMessage) error {
+	ctx, span := tracing.Start(ctx, "OutboxService.Enqueue", attribute.String("email.kind", message.Kind))
+	defer span.End()
+
+	message.Attempts = 0
+	message.LastError = ""
+	return s.outboxRepo.Create(ctx, message)
+}
+
+// deliver sends one claimed message and records the outcome
+func (s *OutboxService) deliver(ctx context.Context, message *models.OutboxMessage) {
+	ctx, span := tracing.Start(ctx, "OutboxService.Deliver",
+		attribute.String("email.kind", message.Kind),
+		attribute.Int("email.attempt", message.Attempts),
+	)
+	defer span.End()
+
+	sendCtx, cancel := context.WithTimeout(ctx, outboxSendTimeout)
+	err := s.emailService.Send(sendCtx, message)
+	cancel()
+
+	// Shutting down: leave the claim to expire rather than count the attempt as failed
+	if err != nil && ctx.Err() != nil {
+		return
+	}
+
+	logger := slog.With("outbox_id", message.ID.Hex(), "kind", message.Kind, "attempt", message.Attempts)
+
+	// Record the outcome even if shutdown starts now, or a sent message is sent again
+	recordCtx := context.WithoutCancel(ctx)
+
+	if err == nil {
+		if err := s.outboxRepo.MarkSent(recordCtx, message); err != nil {
+			logger.ErrorContext(ctx, "Failed to mark outbox message as sent", "error", err)
+		}
+		metrics.EmailDeliveries.WithLabelValues(message.Kind, "sent").Inc()
+		logger.InfoContext(ctx, "Email sent")
+		return
+	}
+
+	tracing.RecordError(span, err)
+
+	var retryAt *time.Time
+	result := "dead"
+	if message.Attempts < config.AppConfig.OutboxMaxAttempts {
+		next := time.Now().Add(retryDelay(config.AppConfig.OutboxRetryBase, message.Attempts))
+		retryAt = &next
+		result = "retry"
+	}
+
+	if err := s.outboxRepo.MarkFailed(recordCtx, message, err.Error(), retryAt); err != nil {
+		logger.ErrorContext(ctx, "Failed to record outbox delivery failure", "error", err)
+	}
+	metrics.EmailDeliveries.WithLabelValues(message.Kind, result).Inc()
+
+	if retryAt != nil {
+		logger.WarnContext(ctx, "Email delivery failed; will retry", "retry_at", *retryAt, "error", err)
+	} else {
+		logger.ErrorContext(ctx, "Email delivery failed for the last time", "error", err)
+	}
+}
+
+// retryDelay doubles the base delay with every attempt
+func retryDelay(base time.Duration, attempts int) time.Duration {
+	delay := base
+	for i := 1; i < attempts && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxRetryDelay)
+}
+
+// GetMessages lists messages with the given status, or all of them, newest first
+func (s *OutboxService) GetMessages(ctx context.Context, status string, limit int64) ([]models.OutboxMessageResponse, error) {
+	ctx, span := tracing.Start(ctx, "OutboxService.GetMessages")
+	defer span.End()
+
+	switch status {
+	case "", models.OutboxStatusPending, models.OutboxStatusSent, models.OutboxStatusDead:
+	default:
+		return nil, utils.ErrInvalidOutboxStatus
+	}
+
+	messages, err := s.outboxRepo.List(ctx, status, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]models.OutboxMessageResponse, 0, len(messages))
+	for _, message := range messages {
+		responses = append(responses, message.ToResponse())
+	}
+	return responses, nil
+}
+
+func (s *OutboxService) GetSummary(ctx context.Context) (*models.OutboxSummary, error) {
+	ctx, span := tracing.Start(ctx, "OutboxService.GetSummary")
+	defer span.End()
+
+	return s.outboxRepo.Summary(ctx)
+}
+
+// Resend queues a pending or dead message for immediate delivery with a fresh
+// attempt count. Sent messages no longer have their bodies.
+func (s *OutboxService) Resend(ctx context.Context, id, adminID string) error {
+	ctx, span := tracing.Start(ctx, "OutboxService.Resend")
+	defer span.End()
+
+	message, err := s.outboxRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if message.Status == models.OutboxStatusSent {
+		return utils.ErrOutboxMessageAlreadySent
+	}
+
+	if err := s.outboxRepo.Requeue(ctx, id); err != nil {
+		return err
+	}
+	s.Wake()
+
+	s.auditService.Record(ctx, models.AuditEmailResent, adminID, models.AuditTargetEmail, id, map[string]string{
+		"kind":     message.Kind,
+		"status":   message.Status,
+		"attempts": strconv.Itoa(message.Attempts),
+	})
+	return nil
+}
+
+// PruneFinished returns a job that deletes messages sent, or dead-lettered,
+// longer than retention ago. Dead messages keep their bodies so they can be
+// resent, which may include a temporary password, so they are not kept forever.
+func (s *OutboxService) PruneFinished(retention time.Duration) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		deleted, err := s.outboxRepo.DeleteFinishedBefore(ctx, time.Now().Add(-retention))
+		if err != nil {
+			return err
+		}
+
+		slog.InfoContext(ctx, "Pruned finished emails", "deleted", deleted)
+		return nil
+	}
+}
@@ -0,0 +1,129 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"backend/health"
+	"backend/lifecycle"
+)
+
+// poller is the background worker of a queue stored in the database, such
+// as the email outbox. Every replica runs one. It claims due items in
+// batches and delivers them one by one, on every poll and whenever it is
+// woken; the claims keep replicas from delivering an item twice.
+type poller[T any] struct {
+	name      string
+	interval  time.Duration
+	batchSize int
+	// lease must outlast a batch in which every delivery times out, or
+	// another worker claims the end of the batch while it is still queued.
+	// An item claimed by a worker that died is retried once it passes.
+	lease time.Duration
+
+	claim   func(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]T, error)
+	deliver func(ctx context.Context, item T)
+
+	heartbeat *health.Heartbeat
+	wake      chan struct{}
+}
+
+func newPoller[T any](name string, interval time.Duration, batchSize int, lease time.Duration,
+	claim func(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]T, error),
+	deliver func(ctx context.Context, item T),
+) *poller[T] {
+	return &poller[T]{
+		name:      name,
+		interval:  interval,
+		batchSize: batchSize,
+		lease:     lease,
+		claim:     claim,
+		deliver:   deliver,
+		// Allow a slow batch before the worker is reported as stalled
+		heartbeat: health.NewHeartbeat(2*interval + lease),
+		wake:      make(chan struct{}, 1),
+	}
+}
+
+// Wake makes this replica's worker look for due items now instead of at its
+// next poll
+func (p *poller[T]) Wake() {
+	select {
+	case p.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Check reports a stalled worker
+func (p *poller[T]) Check(ctx context.Context) error {
+	return p.heartbeat.Check(ctx)
+}
+
+// Hook runs the worker. Stopping it abandons the current batch; the claims
+// expire and the items are retried.
+func (p *poller[T]) Hook() lifecycle.Hook {
+	var (
+		cancel context.CancelFunc
+		done   chan struct{}
+	)
+
+	return lifecycle.Hook{
+		Name: p.name,
+		OnStart: func(context.Context) error {
+			var ctx context.Context
+			ctx, cancel = context.WithCancel(context.Background())
+			done = make(chan struct{})
+
+			go func() {
+				defer close(done)
+				p.run(ctx)
+			}()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			cancel()
+			return lifecycle.Wait(ctx, done)
+		},
+	}
+}
+
+func (p *poller[T]) run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		p.deliverDue(ctx)
+		p.heartbeat.Beat()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-p.wake:
+		}
+	}
+}
+
+// deliverDue delivers claimed batches until no item is due
+func (p *poller[T]) deliverDue(ctx context.Context) {
+	for ctx.Err() == nil {
+		items, err := p.claim(ctx, time.Now(), p.lease, p.batchSize)
+		if err != nil {
+			if ctx.Err() == nil {
+				slog.Error("Failed to claim due items", "worker", p.name, "error", err)
+			}
+			return
+		}
+
+		for _, item := range items {
+			if ctx.Err() != nil {
+				return
+			}
+			p.deliver(ctx, item)
+		}
+		if len(items) < p.batchSize {
+			return
+		}
+	}
+}
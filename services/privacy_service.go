@@ -0,0 +1,232 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"backend/models"
+	"backend/repositories/interfaces"
+	"backend/tracing"
+	"backend/utils"
+)
+
+// PrivacyService answers data subject requests: export of everything held
+// about a user and admin-initiated erasure of their personal data
+type PrivacyService struct {
+	userRepo         interfaces.UserRepository
+	tokenRepo        interfaces.TokenRepository
+	permissionRepo   interfaces.PermissionRepository
+	menuRepo         interfaces.MenuRepository
+	outboxRepo       interfaces.OutboxRepository
+	notificationRepo interfaces.NotificationRepository
+	deviceRepo       interfaces.DeviceRepository
+	auditService     *AuditService
+	webhookService   *WebhookService
+	realtimeService  *RealtimeService
+	transactor       interfaces.Transactor
+}
+
+func NewPrivacyService(userRepo interfaces.UserRepository, tokenRepo interfaces.TokenRepository, permissionRepo interfaces.PermissionRepository, menuRepo interfaces.MenuRepository, outboxRepo interfaces.OutboxRepository, notificationRepo interfaces.NotificationRepository, deviceRepo interfaces.DeviceRepository, auditService *AuditService, webhookService *WebhookService, realtimeService *RealtimeService, transactor interfaces.Transactor) *PrivacyService {
+	return &PrivacyService{
+		userRepo:         userRepo,
+		tokenRepo:        tokenRepo,
+		permissionRepo:   permissionRepo,
+		menuRepo:         menuRepo,
+		outboxRepo:       outboxRepo,
+		notificationRepo: notificationRepo,
+		deviceRepo:       deviceRepo,
+		auditService:     auditService,
+		webhookService:   webhookService,
+		realtimeService:  realtimeService,
+		transactor:       transactor,
+	}
+}
+
+// ExportUserData collects the user's record, sessions, devices, notifications,
+// audit trail and permission history
+func (s *PrivacyService) ExportUserData(ctx context.Context, userID string) (*models.DataSubjectExport, error) {
+	ctx, span := tracing.Start(ctx, "PrivacyService.ExportUserData")
+	defer span.End()
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := s.tokenRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]models.SessionExport, 0, len(tokens))
+	for _, token := range tokens {
+		// The token value itself is a credential and is never exported
+		sessions = append(sessions, models.SessionExport{
+			ID:        token.ID.Hex(),
+			CreatedAt: token.CreatedAt,
+			ExpiresAt: token.ExpiresAt,
+			IsRevoked: token.IsRevoked,
+		})
+	}
+
+	devices, err := s.deviceRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	notifications, err := s.notificationRepo.List(ctx, userID, false, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := s.auditService.GetEventsInvolvingUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := s.auditService.GetPermissionHistory(ctx, user.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions, err := s.permissionRepo.GetPermissionsByRole(ctx, user.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	currentPermissions := make([]models.RoleMenuPermissionResponse, 0, len(permissions))
+	for _, perm := range permissions {
+		menuName := ""
+		if menu, err := s.menuRepo.GetByID(ctx, perm.MenuID.Hex()); err == nil {
+			menuName = menu.Name
+		}
+		currentPermissions = append(currentPermissions, perm.ToResponse(menuName))
+	}
+
+	if devices == nil {
+		devices = []*models.Device{}
+	}
+	if notifications == nil {
+		notifications = []*models.Notification{}
+	}
+	if events == nil {
+		events = []*models.AuditEvent{}
+	}
+	if history == nil {
+		history = []*models.AuditEvent{}
+	}
+
+	s.auditService.Record(ctx, models.AuditUserDataExported, userID, models.AuditTargetUser, userID, nil)
+
+	return &models.DataSubjectExport{
+		GeneratedAt:        time.Now(),
+		User:               *user,
+		Sessions:           sessions,
+		Devices:            devices,
+		Notifications:      notifications,
+		AuditEvents:        events,
+		CurrentPermissions: currentPermissions,
+		PermissionHistory:  history,
+	}, nil
+}
+
+// EraseUser anonymizes a user's personal data. The user document, audit
+// events and grants are kept so references stay intact; every copy of the
+// user's name is replaced by a stable pseudonym.
+func (s *PrivacyService) EraseUser(ctx context.Context, userID, adminID string) (*models.ErasureResponse, error) {
+	ctx, span := tracing.Start(ctx, "PrivacyService.EraseUser")
+	defer span.End()
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.ErasedAt != nil {
+		return nil, utils.ErrUserAlreadyErased
+	}
+
+	// Security check: Prevent erasing the last active admin
+	if user.Role == "admin" && !user.IsSuspended {
+		adminCount, err := s.userRepo.CountActiveUsersByRole(ctx, "admin")
+		if err != nil {
+			return nil, err
+		}
+		if adminCount <= 1 {
+			return nil, utils.ErrLastAdminErasure
+		}
+	}
+
+	pseudonym := erasurePseudonym(userID)
+
+	// Replace the password with an unusable random one
+	randomPassword, err := utils.GenerateSecurePassword(32)
+	if err != nil {
+		return nil, utils.ErrPasswordGenerationFailed
+	}
+	hashedPassword, err := utils.HashPasswordContext(ctx, randomPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	// The address keeps the unique email index satisfied without being routable
+	email := fmt.Sprintf("erased-%s@erased.invalid", userID)
+
+	// Every copy is removed in one transaction: a partial erasure would be
+	// marked erased and could not be retried
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.userRepo.Anonymize(ctx, userID, pseudonym, email, hashedPassword); err != nil {
+			return err
+		}
+
+		if err := s.tokenRepo.RevokeAllUserTokens(ctx, userID); err != nil {
+			return err
+		}
+
+		// Queued and sent emails hold the address, the name and possibly a password
+		if err := s.outboxRepo.DeleteByRecipient(ctx, user.Email); err != nil {
+			return err
+		}
+
+		// Notifications quote the user's name; devices hold addresses and browsers
+		if err := s.notificationRepo.DeleteByUser(ctx, userID); err != nil {
+			return err
+		}
+		if err := s.deviceRepo.DeleteByUser(ctx, userID); err != nil {
+			return err
+		}
+
+		if err := s.permissionRepo.PseudonymizeGrantor(ctx, userID, pseudonym); err != nil {
+			return err
+		}
+
+		return s.auditService.PseudonymizeActor(ctx, userID, pseudonym)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.auditService.Record(ctx, models.AuditUserErased, adminID, models.AuditTargetUser, userID, nil)
+	// Other systems holding copies of the user's data must erase them too
+	s.webhookService.Publish(ctx, models.WebhookUserDeleted, map[string]string{
+		"user_id":  userID,
+		"actor_id": adminID,
+		"reason":   "erased",
+	})
+	s.realtimeService.Logout(ctx, userID, logoutDeleted)
+
+	return &models.ErasureResponse{
+		UserID:    userID,
+		Pseudonym: pseudonym,
+		ErasedAt:  time.Now(),
+	}, nil
+}
+
+// erasurePseudonym derives a stable, non-reversible display name from the user ID
+func erasurePseudonym(userID string) string {
+	sum := sha256.Sum256([]byte(userID))
+	return "Erased User " + hex.EncodeToString(sum[:4])
+}
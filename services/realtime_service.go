@@ -0,0 +1,249 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"backend/config"
+	"backend/lifecycle"
+	"backend/metrics"
+	"backend/realtime"
+	"backend/repositories/interfaces"
+	"backend/tracing"
+	"backend/utils"
+)
+
+const (
+	// realtimeStreamBuffer is how many events a stream holds for a slow
+	// client; one that falls further behind is disconnected and reloads its
+	// menus when it reconnects
+	realtimeStreamBuffer = 16
+	// realtimeRetryDelay is how long to wait before subscribing again after
+	// the broker fails
+	realtimeRetryDelay = 5 * time.Second
+)
+
+// Reasons given to clients in logout events
+const (
+	logoutSignedOut       = "signed_out"
+	logoutPasswordReset   = "password_reset"
+	logoutSessionsRevoked = "sessions_revoked"
+	logoutSuspended       = "suspended"
+	logoutDemoted         = "demoted"
+	logoutDeleted         = "deleted"
+)
+
+// EventStream queues the events for one connected client
+type EventStream struct {
+	userID string
+	role   string
+	events chan *realtime.Event
+	closed bool
+}
+
+// Events delivers the stream's events; it is closed when the stream ends
+func (st *EventStream) Events() <-chan *realtime.Event {
+	return st.events
+}
+
+// RealtimeService pushes menu and role changes to connected clients, and
+// logs clients out when their sessions end. Changes are published through
+// the broker, so they reach the streams held by every replica.
+type RealtimeService struct {
+	broker   realtime.Broker
+	userRepo interfaces.UserRepository
+
+	mu      sync.Mutex
+	streams map[string]map[*EventStream]struct{}
+	stopped bool
+}
+
+func NewRealtimeService(broker realtime.Broker, userRepo interfaces.UserRepository) *RealtimeService {
+	return &RealtimeService{
+		broker:   broker,
+		userRepo: userRepo,
+		streams:  make(map[string]map[*EventStream]struct{}),
+	}
+}
+
+// Connect opens a stream for the user, starting with a ready event that
+// carries their role. Suspended users cannot connect.
+func (s *RealtimeService) Connect(ctx context.Context, userID string) (*EventStream, error) {
+	ctx, span := tracing.Start(ctx, "RealtimeService.Connect")
+	defer span.End()
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err == utils.ErrUserNotFound {
+		// The token outlived its user, which is an authentication failure here
+		return nil, utils.ErrTokenUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if user.IsSuspended {
+		return nil, utils.ErrUserSuspended
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stopped {
+		return nil, utils.ErrShuttingDown
+	}
+	if len(s.streams[userID]) >= config.AppConfig.RealtimeMaxStreamsPerUser {
+		return nil, utils.ErrTooManyEventStreams
+	}
+
+	stream := &EventStream{
+		userID: userID,
+		role:   user.Role,
+		events: make(chan *realtime.Event, realtimeStreamBuffer),
+	}
+	stream.events <- &realtime.Event{Type: realtime.EventReady, Data: map[string]string{"role": user.Role}}
+
+	if s.streams[userID] == nil {
+		s.streams[userID] = make(map[*EventStream]struct{})
+	}
+	s.streams[userID][stream] = struct{}{}
+	metrics.RealtimeStreams.Inc()
+	return stream, nil
+}
+
+// Disconnect ends the stream once its client has gone
+func (s *RealtimeService) Disconnect(stream *EventStream) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.close(stream)
+}
+
+// close ends the stream; the caller holds s.mu
+func (s *RealtimeService) close(stream *EventStream) {
+	if stream.closed {
+		return
+	}
+	stream.closed = true
+	close(stream.events)
+
+	delete(s.streams[stream.userID], stream)
+	if len(s.streams[stream.userID]) == 0 {
+		delete(s.streams, stream.userID)
+	}
+	metrics.RealtimeStreams.Dec()
+}
+
+// dispatch queues an event from the broker on the matching streams of this
+// replica
+func (s *RealtimeService) dispatch(event *realtime.Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for userID, streams := range s.streams {
+		if event.UserID != "" && event.UserID != userID {
+			continue
+		}
+
+		for stream := range streams {
+			if len(event.Roles) > 0 && !slices.Contains(event.Roles, stream.role) {
+				continue
+			}
+			// Later menu changes are addressed by role
+			if event.Type == realtime.EventRoleChanged {
+				stream.role = event.Data["role"]
+			}
+
+			select {
+			case stream.events <- event:
+			default:
+				s.close(stream)
+				continue
+			}
+			if event.Type == realtime.EventLogout {
+				s.close(stream)
+			}
+		}
+	}
+}
+
+// publish sends the event to every replica
+func (s *RealtimeService) publish(ctx context.Context, event *realtime.Event) {
+	if err := s.broker.Publish(ctx, event); err != nil {
+		slog.ErrorContext(ctx, "Failed to publish realtime event", "type", event.Type, "broker", s.broker.Name(), "error", err)
+	}
+}
+
+// MenusChanged tells the members of the given roles, or every connected user
+// when none are given, to reload their menus
+func (s *RealtimeService) MenusChanged(ctx context.Context, roles ...string) {
+	s.publish(ctx, &realtime.Event{Type: realtime.EventMenusChanged, Roles: roles})
+}
+
+// RoleChanged tells a user their new role
+func (s *RealtimeService) RoleChanged(ctx context.Context, userID, role string) {
+	s.publish(ctx, &realtime.Event{Type: realtime.EventRoleChanged, UserID: userID, Data: map[string]string{"role": role}})
+}
+
+// Logout tells every client of a user to discard its tokens and closes their
+// streams
+func (s *RealtimeService) Logout(ctx context.Context, userID, reason string) {
+	s.publish(ctx, &realtime.Event{Type: realtime.EventLogout, UserID: userID, Data: map[string]string{"reason": reason}})
+}
+
+// Hook receives events from the broker while the server runs. Stopping it
+// closes every stream, which would otherwise hold up the server's shutdown;
+// clients reconnect to another replica.
+func (s *RealtimeService) Hook() lifecycle.Hook {
+	var (
+		cancel context.CancelFunc
+		done   chan struct{}
+	)
+
+	return lifecycle.Hook{
+		Name: "realtime",
+		OnStart: func(context.Context) error {
+			var ctx context.Context
+			ctx, cancel = context.WithCancel(context.Background())
+			done = make(chan struct{})
+
+			go func() {
+				defer close(done)
+				s.run(ctx)
+			}()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			cancel()
+
+			s.mu.Lock()
+			s.stopped = true
+			for _, streams := range s.streams {
+				for stream := range streams {
+					s.close(stream)
+				}
+			}
+			s.mu.Unlock()
+
+			return lifecycle.Wait(ctx, done)
+		},
+	}
+}
+
+// run subscribes to the broker, subscribing again whenever it fails
+func (s *RealtimeService) run(ctx context.Context) {
+	for {
+		err := s.broker.Subscribe(ctx, s.dispatch)
+		if ctx.Err() != nil {
+			return
+		}
+
+		slog.Error("Realtime broker subscription failed; retrying", "broker", s.broker.Name(), "error", err, "retry_in", realtimeRetryDelay)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(realtimeRetryDelay):
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package services
+
+import (
+	"context"
+
+	"backend/models"
+	"backend/repositories/interfaces"
+	"backend/tracing"
+	"backend/utils"
+)
+
+// ReportService builds compliance reports over users and their menu access
+type ReportService struct {
+	userRepo       interfaces.UserRepository
+	menuRepo       interfaces.MenuRepository
+	permissionRepo interfaces.PermissionRepository
+}
+
+func NewReportService(userRepo interfaces.UserRepository, menuRepo interfaces.MenuRepository, permissionRepo interfaces.PermissionRepository) *ReportService {
+	return &ReportService{
+		userRepo:       userRepo,
+		menuRepo:       menuRepo,
+		permissionRepo: permissionRepo,
+	}
+}
+
+// StreamAccessReview emits one row per user x effective menu. Users are read
+// from a cursor; only menus, grants and verifier names are held in memory.
+func (s *ReportService) StreamAccessReview(ctx context.Context, emit func(row *models.AccessReviewRow) error) error {
+	ctx, span := tracing.Start(ctx, "ReportService.StreamAccessReview")
+	defer span.End()
+
+	activeMenus, err := s.menuRepo.GetActiveMenus(ctx)
+	if err != nil {
+		return err
+	}
+
+	permissions, err := s.permissionRepo.GetAllPermissions(ctx)
+	if err != nil {
+		return err
+	}
+
+	menusByID := make(map[string]*models.Menu, len(activeMenus))
+	for _, menu := range activeMenus {
+		menusByID[menu.ID.Hex()] = menu
+	}
+
+	// Only grants on active menus are effective
+	grantsByRole := make(map[string][]*models.RoleMenuPermission)
+	for _, perm := range permissions {
+		if _, ok := menusByID[perm.MenuID.Hex()]; ok {
+			grantsByRole[perm.Role] = append(grantsByRole[perm.Role], perm)
+		}
+	}
+
+	userNames := make(map[string]string)
+	lookupName := func(userID string) string {
+		if name, ok := userNames[userID]; ok {
+			return name
+		}
+		name := ""
+		if user, err := s.userRepo.GetByID(ctx, userID); err == nil {
+			name = user.Name
+		}
+		userNames[userID] = name
+		return name
+	}
+
+	return s.userRepo.StreamAll(ctx, func(user *models.User) error {
+		base := models.AccessReviewRow{
+			UserID:      user.ID.Hex(),
+			Name:        user.Name,
+			Email:       user.Email,
+			Role:        user.Role,
+			IsVerified:  user.IsVerified,
+			IsSuspended: user.IsSuspended,
+			VerifiedAt:  user.VerifiedAt,
+			LastLoginAt: user.LastLoginAt,
+			GrantSource: models.GrantSourceNone,
+		}
+		if user.VerifiedBy != nil {
+			base.VerifiedByID = user.VerifiedBy.Hex()
+			base.VerifiedByName = lookupName(base.VerifiedByID)
+		}
+
+		emitted := false
+
+		// Admin has access to all active menus without explicit grants
+		if user.Role == utils.RoleAdmin {
+			for _, menu := range activeMenus {
+				row := base
+				row.MenuID = menu.ID.Hex()
+				row.MenuName = menu.Name
+				row.MenuPath = menu.Path
+				row.GrantSource = models.GrantSourceRole
+				if err := emit(&row); err != nil {
+					return err
+				}
+				emitted = true
+			}
+		} else {
+			for _, perm := range grantsByRole[user.Role] {
+				menu := menusByID[perm.MenuID.Hex()]
+				grantedAt := perm.CreatedAt
+
+				row := base
+				row.MenuID = menu.ID.Hex()
+				row.MenuName = menu.Name
+				row.MenuPath = menu.Path
+				row.GrantSource = models.GrantSourceExplicit
+				row.GrantedAt = &grantedAt
+				row.GrantedByID = perm.GrantedByID.Hex()
+				row.GrantedByName = perm.GrantedByName
+				if err := emit(&row); err != nil {
+					return err
+				}
+				emitted = true
+			}
+		}
+
+		if !emitted {
+			return emit(&base)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,248 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"backend/config"
+	"backend/models"
+	"backend/repositories/interfaces"
+	"backend/tracing"
+	"backend/utils"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// statsMaxRange caps the time series range so day buckets stay bounded
+const statsMaxRange = 731 * 24 * time.Hour
+
+// pendingAgeBuckets defines the pending verification age groups in days;
+// a MaxDays of 0 means open-ended
+var pendingAgeBuckets = []models.AgeBucketCount{
+	{Label: "<1d", MinDays: 0, MaxDays: 1},
+	{Label: "1-3d", MinDays: 1, MaxDays: 3},
+	{Label: "3-7d", MinDays: 3, MaxDays: 7},
+	{Label: "7-30d", MinDays: 7, MaxDays: 30},
+	{Label: "30d+", MinDays: 30},
+}
+
+type cachedStats struct {
+	stats     *models.AdminStatsResponse
+	expiresAt time.Time
+}
+
+// StatsService computes the admin dashboard statistics. Results are cached
+// per query for config.AppConfig.StatsCacheTTL so dashboard refreshes do not
+// rerun the aggregations, and concurrent requests for a query that is not
+// cached share one computation.
+type StatsService struct {
+	userRepo  interfaces.UserRepository
+	tokenRepo interfaces.TokenRepository
+	menuRepo  interfaces.MenuRepository
+	auditRepo interfaces.AuditRepository
+
+	mu       sync.Mutex
+	cache    map[string]cachedStats
+	computes singleflight.Group
+}
+
+func NewStatsService(userRepo interfaces.UserRepository, tokenRepo interfaces.TokenRepository, menuRepo interfaces.MenuRepository, auditRepo interfaces.AuditRepository) *StatsService {
+	return &StatsService{
+		userRepo:  userRepo,
+		tokenRepo: tokenRepo,
+		menuRepo:  menuRepo,
+		auditRepo: auditRepo,
+		cache:     make(map[string]cachedStats),
+	}
+}
+
+// DefaultStatsQuery covers the last 30 days, including today, in day buckets
+func DefaultStatsQuery() *models.StatsQuery {
+	to := time.Now().UTC().Truncate(24 * time.Hour).Add(24 * time.Hour)
+	return &models.StatsQuery{
+		From:   to.AddDate(0, 0, -30),
+		To:     to,
+		Bucket: models.StatsBucketDay,
+	}
+}
+
+func (s *StatsService) GetStats(ctx context.Context, query *models.StatsQuery) (*models.AdminStatsResponse, error) {
+	ctx, span := tracing.Start(ctx, "StatsService.GetStats")
+	defer span.End()
+
+	if err := validateStatsQuery(query); err != nil {
+		return nil, err
+	}
+
+	key := fmt.Sprintf("%d|%d|%s", query.From.Unix(), query.To.Unix(), query.Bucket)
+	if stats := s.cached(key); stats != nil {
+		return stats, nil
+	}
+
+	result := s.computes.DoChan(key, func() (interface{}, error) {
+		// Every caller waiting on the key shares this computation, so it must
+		// not fail because the caller that started it gave up
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), config.AppConfig.RequestTimeout)
+		defer cancel()
+
+		stats, err := s.compute(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		s.store(key, stats)
+		return stats, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case r := <-result:
+		if r.Err != nil {
+			return nil, r.Err
+		}
+		return r.Val.(*models.AdminStatsResponse), nil
+	}
+}
+
+func (s *StatsService) compute(ctx context.Context, query *models.StatsQuery) (*models.AdminStatsResponse, error) {
+	now := time.Now().UTC()
+
+	usersByRole, err := s.userRepo.CountByRoleAndStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pendingByAge, err := s.pendingByAge(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+
+	registrations, err := s.userRepo.CountRegistrationsByPeriod(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	logins, err := s.auditRepo.CountByPeriod(ctx, models.AuditUserLoggedIn, query)
+	if err != nil {
+		return nil, err
+	}
+
+	activeSessions, err := s.tokenRepo.CountActive(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	passwordResets, err := s.auditRepo.CountSince(ctx, models.AuditUserPasswordReset, now.Add(-24*time.Hour))
+	if err != nil {
+		return nil, err
+	}
+
+	menus, err := s.menuRepo.GetMenusWithoutGrants(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ungranted := make([]models.UngrantedMenu, 0, len(menus))
+	for _, menu := range menus {
+		ungranted = append(ungranted, models.UngrantedMenu{
+			ID:   menu.ID.Hex(),
+			Name: menu.Name,
+			Path: menu.Path,
+		})
+	}
+
+	return &models.AdminStatsResponse{
+		GeneratedAt:           now,
+		From:                  query.From,
+		To:                    query.To,
+		Bucket:                query.Bucket,
+		UsersByRole:           usersByRole,
+		PendingByAge:          pendingByAge,
+		Registrations:         registrations,
+		Logins:                logins,
+		ActiveSessions:        activeSessions,
+		PasswordResetsLast24h: passwordResets,
+		MenusWithoutGrants:    ungranted,
+	}, nil
+}
+
+// pendingByAge converts the age buckets into registration time boundaries,
+// oldest first, and maps the aggregation results back onto the buckets
+func (s *StatsService) pendingByAge(ctx context.Context, now time.Time) ([]models.AgeBucketCount, error) {
+	// MongoDB stores dates with millisecond precision
+	now = now.Truncate(time.Millisecond)
+
+	boundaries := []time.Time{time.Unix(0, 0).UTC()}
+	for i := len(pendingAgeBuckets) - 1; i >= 0; i-- {
+		if pendingAgeBuckets[i].MinDays > 0 {
+			boundaries = append(boundaries, now.AddDate(0, 0, -pendingAgeBuckets[i].MinDays))
+		}
+	}
+	boundaries = append(boundaries, now.Add(time.Minute))
+
+	counts, err := s.userRepo.CountPendingByCreatedAt(ctx, boundaries)
+	if err != nil {
+		return nil, err
+	}
+
+	byBoundary := make(map[int64]int64, len(counts))
+	for _, count := range counts {
+		byBoundary[count.Period.UnixMilli()] = count.Count
+	}
+
+	result := make([]models.AgeBucketCount, len(pendingAgeBuckets))
+	for i, bucket := range pendingAgeBuckets {
+		lower := boundaries[len(pendingAgeBuckets)-1-i]
+		bucket.Count = byBoundary[lower.UnixMilli()]
+		result[i] = bucket
+	}
+
+	return result, nil
+}
+
+func (s *StatsService) cached(key string) *models.AdminStatsResponse {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.cache[key]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil
+	}
+	return entry.stats
+}
+
+func (s *StatsService) store(key string, stats *models.AdminStatsResponse) {
+	ttl := config.AppConfig.StatsCacheTTL
+	if ttl <= 0 {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Drop expired entries so ad-hoc date ranges do not accumulate
+	now := time.Now()
+	for k, entry := range s.cache {
+		if now.After(entry.expiresAt) {
+			delete(s.cache, k)
+		}
+	}
+
+	s.cache[key] = cachedStats{stats: stats, expiresAt: now.Add(ttl)}
+}
+
+func validateStatsQuery(query *models.StatsQuery) error {
+	switch query.Bucket {
+	case models.StatsBucketDay, models.StatsBucketWeek, models.StatsBucketMonth:
+	default:
+		return utils.ErrInvalidStatsBucket
+	}
+
+	if !query.To.After(query.From) || query.To.Sub(query.From) > statsMaxRange {
+		return utils.ErrInvalidStatsRange
+	}
+
+	return nil
+}
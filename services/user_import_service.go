@@ -0,0 +1,379 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"backend/config"
+	"backend/models"
+	"backend/repositories/interfaces"
+	"backend/tracing"
+	"backend/utils"
+
+	"github.com/xuri/excelize/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// importColumns are the required header names, matched case-insensitively
+var importColumns = []string{"name", "email", "role"}
+
+// UserImportService creates verified accounts in bulk from CSV or XLSX uploads
+type UserImportService struct {
+	userRepo      interfaces.UserRepository
+	emailService  *EmailService
+	outboxService *OutboxService
+	transactor    interfaces.Transactor
+}
+
+func NewUserImportService(userRepo interfaces.UserRepository, emailService *EmailService, outboxService *OutboxService, transactor interfaces.Transactor) *UserImportService {
+	return &UserImportService{
+		userRepo:      userRepo,
+		emailService:  emailService,
+		outboxService: outboxService,
+		transactor:    transactor,
+	}
+}
+
+// parsedImportRow keeps the spreadsheet row number for reporting
+type parsedImportRow struct {
+	number int
+	row    models.UserImportRow
+}
+
+// ImportUsers validates every row and, unless this is a dry run, creates the valid ones
+func (s *UserImportService) ImportUsers(ctx context.Context, adminID, filename string, file io.Reader, opts *models.UserImportOptions) (*models.UserImportReport, error) {
+	ctx, span := tracing.Start(ctx, "UserImportService.ImportUsers")
+	defer span.End()
+
+	// Validate input
+	if err := utils.ValidateStruct(opts); err != nil {
+		return nil, err
+	}
+
+	delivery := opts.Delivery
+	if delivery == "" {
+		delivery = models.ImportDeliveryInvite
+	}
+
+	adminObjectID, err := primitive.ObjectIDFromHex(adminID)
+	if err != nil {
+		return nil, utils.ErrInvalidID
+	}
+
+	rows, err := parseImportFile(filename, file)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.UserImportReport{
+		DryRun: opts.DryRun,
+		Total:  len(rows),
+		Rows:   make([]models.UserImportRowResult, 0, len(rows)),
+	}
+	if !opts.DryRun {
+		report.Delivery = delivery
+	}
+
+	seenEmails := make(map[string]int)
+	for _, parsed := range rows {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		result, err := s.checkRow(ctx, parsed, seenEmails)
+		if err != nil {
+			return nil, err
+		}
+		if result.Status == models.ImportRowValid && !opts.DryRun {
+			s.createUser(ctx, &result, parsed.row, adminObjectID, opts.Notes, delivery)
+		}
+
+		switch result.Status {
+		case models.ImportRowValid:
+			report.Valid++
+		case models.ImportRowInvalid:
+			report.Invalid++
+		case models.ImportRowDuplicate:
+			report.Duplicates++
+		case models.ImportRowCreated:
+			report.Valid++
+			report.Created++
+		case models.ImportRowFailed:
+			report.Valid++
+			report.Failed++
+		}
+
+		report.Rows = append(report.Rows, result)
+	}
+
+	if report.Created > 0 {
+		s.outboxService.Wake()
+	}
+	return report, nil
+}
+
+// checkRow applies validation and duplicate detection to a single row
+func (s *UserImportService) checkRow(ctx context.Context, parsed parsedImportRow, seenEmails map[string]int) (models.UserImportRowResult, error) {
+	result := models.UserImportRowResult{
+		Row:    parsed.number,
+		Name:   parsed.row.Name,
+		Email:  parsed.row.Email,
+		Role:   parsed.row.Role,
+		Status: models.ImportRowValid,
+	}
+
+	if err := utils.ValidateStruct(&parsed.row); err != nil {
+		result.Status = models.ImportRowInvalid
+		result.Errors = describeValidationErrors(err)
+		return result, nil
+	}
+
+	emailKey := strings.ToLower(parsed.row.Email)
+	if firstRow, ok := seenEmails[emailKey]; ok {
+		result.Status = models.ImportRowDuplicate
+		result.Errors = []string{fmt.Sprintf("email duplicates row %d", firstRow)}
+		return result, nil
+	}
+	seenEmails[emailKey] = parsed.number
+
+	_, err := s.userRepo.GetByEmail(ctx, parsed.row.Email)
+	if err == nil {
+		result.Status = models.ImportRowDuplicate
+		result.Errors = []string{"a user with this email already exists"}
+		return result, nil
+	}
+	if err != utils.ErrUserNotFound {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// createUser persists a verified user and delivers the generated credentials
+func (s *UserImportService) createUser(ctx context.Context, result *models.UserImportRowResult, row models.UserImportRow, adminID primitive.ObjectID, notes, delivery string) {
+	password, err := utils.GenerateSecurePassword(config.AppConfig.PasswordResetLength)
+	if err != nil {
+		result.Status = models.ImportRowFailed
+		result.Errors = []string{utils.ErrPasswordGenerationFailed.Error()}
+		return
+	}
+
+	hashedPassword, err := utils.HashPasswordContext(ctx, password)
+	if err != nil {
+		result.Status = models.ImportRowFailed
+		result.Errors = []string{err.Error()}
+		return
+	}
+
+	if notes == "" {
+		notes = "Imported by administrator"
+	}
+
+	now := time.Now()
+	user := &models.User{
+		Name:              row.Name,
+		Email:             row.Email,
+		Password:          hashedPassword,
+		Role:              row.Role,
+		Locale:            row.Locale,
+		IsVerified:        true,
+		VerifiedAt:        &now,
+		VerifiedBy:        &adminID,
+		VerificationNotes: notes,
+	}
+
+	// The account and its credentials email are created together, so an
+	// account is never left without a way to learn its password
+	var email *models.OutboxMessage
+	switch delivery {
+	case models.ImportDeliveryReset:
+		email, err = s.emailService.PasswordResetEmail(user, password)
+	case models.ImportDeliveryInvite:
+		email, err = s.emailService.InvitationEmail(user, password)
+	}
+	if err != nil {
+		result.Status = models.ImportRowFailed
+		result.Errors = []string{err.Error()}
+		return
+	}
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.userRepo.Create(ctx, user); err != nil {
+			return err
+		}
+		if email != nil {
+			return s.outboxService.Enqueue(ctx, email)
+		}
+		return nil
+	})
+	if err != nil {
+		if err == utils.ErrUserAlreadyExists {
+			result.Status = models.ImportRowDuplicate
+			result.Errors = []string{"a user with this email already exists"}
+			return
+		}
+		result.Status = models.ImportRowFailed
+		result.Errors = []string{err.Error()}
+		return
+	}
+
+	result.Status = models.ImportRowCreated
+	result.UserID = user.ID.Hex()
+	if email != nil {
+		result.EmailQueued = true
+	} else {
+		result.Password = password
+	}
+}
+
+// parseImportFile reads a CSV or XLSX upload into rows keyed by the header columns
+func parseImportFile(filename string, file io.Reader) ([]parsedImportRow, error) {
+	var records [][]string
+	var err error
+
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		reader := csv.NewReader(file)
+		reader.FieldsPerRecord = -1
+		reader.TrimLeadingSpace = true
+		records, err = reader.ReadAll()
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			err = fmt.Errorf("%w: line %d: %v", utils.ErrImportMalformedFile, parseErr.Line, parseErr.Err)
+		}
+	case ".xlsx":
+		records, err = readXLSXRecords(file)
+	default:
+		return nil, utils.ErrImportUnsupportedFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) < 2 {
+		return nil, utils.ErrImportEmptyFile
+	}
+
+	columnIndex := make(map[string]int)
+	for i, header := range records[0] {
+		header = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(header, "\ufeff")))
+		columnIndex[header] = i
+	}
+	for _, column := range importColumns {
+		if _, ok := columnIndex[column]; !ok {
+			return nil, utils.ErrImportMissingColumns
+		}
+	}
+
+	cell := func(record []string, column string) string {
+		i, ok := columnIndex[column]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var rows []parsedImportRow
+	for i, record := range records[1:] {
+		if isBlankRecord(record) {
+			continue
+		}
+
+		rows = append(rows, parsedImportRow{
+			// Row numbers are 1-based and include the header row
+			number: i + 2,
+			row: models.UserImportRow{
+				Name:   cell(record, "name"),
+				Email:  cell(record, "email"),
+				Role:   strings.ToLower(cell(record, "role")),
+				Locale: cell(record, "locale"),
+			},
+		})
+	}
+
+	if len(rows) == 0 {
+		return nil, utils.ErrImportEmptyFile
+	}
+
+	maxRows := config.AppConfig.ImportMaxRows
+	if maxRows > 0 && len(rows) > maxRows {
+		return nil, utils.ErrImportTooManyRows
+	}
+
+	return rows, nil
+}
+
+// readXLSXRecords returns the rows of the first sheet in the workbook. A
+// workbook is a zip archive, so its uncompressed size is checked before it
+// is opened: a small upload can expand far beyond what ImportMaxRows allows.
+func readXLSXRecords(file io.Reader) ([][]string, error) {
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, utils.ErrImportUnsupportedFormat
+	}
+
+	var options excelize.Options
+	if limit := config.AppConfig.ImportMaxUnzippedSize; limit > 0 {
+		var size uint64
+		for _, entry := range archive.File {
+			size += entry.UncompressedSize64
+			if size > uint64(limit) {
+				return nil, utils.ErrImportFileTooLarge
+			}
+		}
+
+		// The archive reader enforces the declared sizes while extracting, and
+		// the limits stop excelize itself should they be exceeded anyway
+		options.UnzipSizeLimit = limit
+		options.UnzipXMLSizeLimit = limit
+	}
+
+	workbook, err := excelize.OpenReader(bytes.NewReader(data), options)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", utils.ErrImportMalformedFile, err)
+	}
+	defer workbook.Close()
+
+	sheets := workbook.GetSheetList()
+	if len(sheets) == 0 {
+		return nil, utils.ErrImportEmptyFile
+	}
+
+	return workbook.GetRows(sheets[0])
+}
+
+func isBlankRecord(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// describeValidationErrors turns validator errors into short per-field messages
+func describeValidationErrors(err error) []string {
+	details := utils.ValidationDetails(err)
+	if details == nil {
+		return []string{err.Error()}
+	}
+
+	messages := make([]string, 0, len(details))
+	for _, detail := range details {
+		messages = append(messages, detail.Message)
+	}
+	return messages
+}
@@ -0,0 +1,38 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"backend/config"
+	"backend/utils"
+)
+
+func TestParseImportFileErrors(t *testing.T) {
+	config.AppConfig = &config.Config{ImportMaxRows: 2}
+
+	tests := []struct {
+		name       string
+		content    string
+		wantErr    error
+		wantDetail string
+	}{
+		{name: "valid", content: "name,email,role\nAda,ada@example.com,user\n"},
+		{name: "malformed quote", content: "name,email,role\nAda,ada@example.com,user\n\"Bob,bob@example.com,user\n", wantErr: utils.ErrImportMalformedFile, wantDetail: "line 3"},
+		{name: "missing columns", content: "name,email\nAda,ada@example.com\n", wantErr: utils.ErrImportMissingColumns},
+		{name: "too many rows", content: "name,email,role\na,a@example.com,user\nb,b@example.com,user\nc,c@example.com,user\n", wantErr: utils.ErrImportTooManyRows},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseImportFile("users.csv", strings.NewReader(tt.content))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if err != nil && !strings.Contains(err.Error(), tt.wantDetail) {
+				t.Fatalf("expected %q in %q", tt.wantDetail, err.Error())
+			}
+		})
+	}
+}
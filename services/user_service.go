@@ -2,25 +2,32 @@ package services
 
 import (
 	"context"
-	"errors"
 	"time"
 
 	"backend/models"
 	"backend/repositories/interfaces"
+	"backend/tracing"
 	"backend/utils"
 )
 
 type UserService struct {
-	userRepo interfaces.UserRepository
+	userRepo        interfaces.UserRepository
+	webhookService  *WebhookService
+	realtimeService *RealtimeService
 }
 
-func NewUserService(userRepo interfaces.UserRepository) *UserService {
+func NewUserService(userRepo interfaces.UserRepository, webhookService *WebhookService, realtimeService *RealtimeService) *UserService {
 	return &UserService{
-		userRepo: userRepo,
+		userRepo:        userRepo,
+		webhookService:  webhookService,
+		realtimeService: realtimeService,
 	}
 }
 
 func (s *UserService) CreateUser(ctx context.Context, req *models.UserCreateRequest) (*models.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.CreateUser")
+	defer span.End()
+
 	// Validate input
 	if err := utils.ValidateStruct(req); err != nil {
 		return nil, err
@@ -36,7 +43,7 @@ func (s *UserService) CreateUser(ctx context.Context, req *models.UserCreateRequ
 	}
 
 	// Hash password
-	hashedPassword, err := utils.HashPassword(req.Password)
+	hashedPassword, err := utils.HashPasswordContext(ctx, req.Password)
 	if err != nil {
 		return nil, err
 	}
@@ -47,6 +54,7 @@ func (s *UserService) CreateUser(ctx context.Context, req *models.UserCreateRequ
 		Email:     req.Email,
 		Password:  hashedPassword,
 		Role:      req.Role,
+		Locale:    req.Locale,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
@@ -60,14 +68,23 @@ func (s *UserService) CreateUser(ctx context.Context, req *models.UserCreateRequ
 }
 
 func (s *UserService) GetUserByID(ctx context.Context, id string) (*models.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetUserByID")
+	defer span.End()
+
 	return s.userRepo.GetByID(ctx, id)
 }
 
 func (s *UserService) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetUserByEmail")
+	defer span.End()
+
 	return s.userRepo.GetByEmail(ctx, email)
 }
 
 func (s *UserService) UpdateUser(ctx context.Context, userID string, req *models.UserUpdateRequest) (*models.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.UpdateUser")
+	defer span.End()
+
 	// Validate input
 	if err := utils.ValidateStruct(req); err != nil {
 		return nil, err
@@ -94,6 +111,9 @@ func (s *UserService) UpdateUser(ctx context.Context, userID string, req *models
 		}
 		user.Email = req.Email
 	}
+	if req.Locale != "" {
+		user.Locale = req.Locale
+	}
 
 	// Update user
 	err = s.userRepo.Update(ctx, user)
@@ -105,10 +125,26 @@ func (s *UserService) UpdateUser(ctx context.Context, userID string, req *models
 }
 
 func (s *UserService) DeleteUser(ctx context.Context, userID string) error {
-	return s.userRepo.Delete(ctx, userID)
+	ctx, span := tracing.Start(ctx, "UserService.DeleteUser")
+	defer span.End()
+
+	if err := s.userRepo.Delete(ctx, userID); err != nil {
+		return err
+	}
+
+	s.webhookService.Publish(ctx, models.WebhookUserDeleted, map[string]string{
+		"user_id":  userID,
+		"actor_id": userID,
+		"reason":   "deleted",
+	})
+	s.realtimeService.Logout(ctx, userID, logoutDeleted)
+	return nil
 }
 
 func (s *UserService) ValidateUserCredentials(ctx context.Context, email, password string) (*models.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.ValidateUserCredentials")
+	defer span.End()
+
 	user, err := s.userRepo.GetByEmail(ctx, email)
 	if err != nil {
 		if err == utils.ErrUserNotFound {
@@ -117,7 +153,7 @@ func (s *UserService) ValidateUserCredentials(ctx context.Context, email, passwo
 		return nil, err
 	}
 
-	if !utils.CheckPasswordHash(password, user.Password) {
+	if !utils.CheckPasswordHashContext(ctx, password, user.Password) {
 		return nil, utils.ErrInvalidCredentials
 	}
 
@@ -125,6 +161,9 @@ func (s *UserService) ValidateUserCredentials(ctx context.Context, email, passwo
 }
 
 func (s *UserService) ChangePassword(ctx context.Context, userID string, req *models.ChangePasswordRequest) error {
+	ctx, span := tracing.Start(ctx, "UserService.ChangePassword")
+	defer span.End()
+
 	// Validate input
 	if err := utils.ValidateStruct(req); err != nil {
 		return err
@@ -132,7 +171,7 @@ func (s *UserService) ChangePassword(ctx context.Context, userID string, req *mo
 
 	// Check if new password matches confirm password
 	if req.NewPassword != req.ConfirmPassword {
-		return errors.New("password confirmation does not match")
+		return utils.ErrPasswordMismatch
 	}
 
 	// Get existing user
@@ -142,17 +181,17 @@ func (s *UserService) ChangePassword(ctx context.Context, userID string, req *mo
 	}
 
 	// Verify current password
-	if !utils.CheckPasswordHash(req.CurrentPassword, user.Password) {
+	if !utils.CheckPasswordHashContext(ctx, req.CurrentPassword, user.Password) {
 		return utils.ErrInvalidCredentials
 	}
 
 	// Check if new password is different from current password
-	if utils.CheckPasswordHash(req.NewPassword, user.Password) {
-		return errors.New("new password must be different from current password")
+	if utils.CheckPasswordHashContext(ctx, req.NewPassword, user.Password) {
+		return utils.ErrPasswordUnchanged
 	}
 
 	// Hash new password
-	hashedPassword, err := utils.HashPassword(req.NewPassword)
+	hashedPassword, err := utils.HashPasswordContext(ctx, req.NewPassword)
 	if err != nil {
 		return err
 	}
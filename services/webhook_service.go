@@ -0,0 +1,472 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"backend/config"
+	"backend/metrics"
+	"backend/models"
+	"backend/repositories/interfaces"
+	"backend/tracing"
+	"backend/utils"
+	"backend/webhook"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// webhookBatchSize is how many deliveries a worker claims at a time
+	webhookBatchSize = 20
+	// webhookResponseLimit is how much of a response body the delivery log keeps
+	webhookResponseLimit = 1024
+)
+
+// WebhookService notifies other systems of identity and access events. An
+// event is queued as one delivery per subscribed webhook and posted, signed,
+// in the background with retries and exponential backoff.
+type WebhookService struct {
+	*poller[*models.WebhookDelivery]
+
+	webhookRepo  interfaces.WebhookRepository
+	auditService *AuditService
+	client       *http.Client
+}
+
+func NewWebhookService(webhookRepo interfaces.WebhookRepository, auditService *AuditService) *WebhookService {
+	s := &WebhookService{
+		webhookRepo:  webhookRepo,
+		auditService: auditService,
+		client:       webhook.NewClient(config.AppConfig.WebhookTimeout, config.AppConfig.WebhookAllowPrivateTargets),
+	}
+	// The lease outlasts a batch of deliveries that all time out
+	lease := webhookBatchSize*config.AppConfig.WebhookTimeout + time.Minute
+	s.poller = newPoller("webhooks", config.AppConfig.WebhookPollInterval, webhookBatchSize, lease, webhookRepo.ClaimDueDeliveries, s.deliver)
+	return s
+}
+
+// Publish queues an event for every enabled webhook subscribed to its type
+func (s *WebhookService) Publish(ctx context.Context, eventType string, data map[string]string) {
+	ctx, span := tracing.Start(ctx, "WebhookService.Publish", attribute.String("webhook.event", eventType))
+	defer span.End()
+
+	webhooks, err := s.webhookRepo.ListSubscribed(ctx, eventType)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to load webhooks to publish to", "event", eventType, "error", err)
+		return
+	}
+	span.SetAttributes(attribute.Int("webhook.subscribers", len(webhooks)))
+	if len(webhooks) == 0 {
+		return
+	}
+
+	eventID := primitive.NewObjectID().Hex()
+	payload, err := json.Marshal(&models.WebhookPayload{
+		ID:        eventID,
+		Type:      eventType,
+		CreatedAt: time.Now().UTC(),
+		Data:      data,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to encode webhook payload", "event", eventType, "error", err)
+		return
+	}
+
+	for _, hook := range webhooks {
+		delivery := &models.WebhookDelivery{
+			WebhookID: hook.ID,
+			EventID:   eventID,
+			EventType: eventType,
+			Payload:   string(payload),
+		}
+		if err := s.webhookRepo.CreateDelivery(ctx, delivery); err != nil {
+			slog.ErrorContext(ctx, "Failed to queue webhook delivery", "event", eventType, "webhook_id", hook.ID.Hex(), "error", err)
+		}
+	}
+	s.Wake()
+}
+
+// deliver posts one claimed delivery and records the outcome
+func (s *WebhookService) deliver(ctx context.Context, delivery *models.WebhookDelivery) {
+	ctx, span := tracing.StartWithKind(ctx, "WebhookService.Deliver", trace.SpanKindClient,
+		attribute.String("webhook.event", delivery.EventType),
+		attribute.String("webhook.id", delivery.WebhookID.Hex()),
+		attribute.Int("webhook.attempt", delivery.Attempts),
+	)
+	defer span.End()
+
+	id := delivery.ID.Hex()
+	logger := slog.With("delivery_id", id, "webhook_id", delivery.WebhookID.Hex(), "event", delivery.EventType, "attempt", delivery.Attempts)
+
+	// Record the outcome even if shutdown starts now, or a delivered event is posted again
+	recordCtx := context.WithoutCancel(ctx)
+
+	hook, err := s.webhookRepo.GetByID(ctx, delivery.WebhookID.Hex())
+	if err != nil {
+		// A deleted webhook takes its deliveries with it
+		if err != utils.ErrWebhookNotFound && ctx.Err() == nil {
+			logger.ErrorContext(ctx, "Failed to load webhook for delivery", "error", err)
+		}
+		return
+	}
+	if !hook.Enabled {
+		attempt := &models.WebhookAttempt{Error: utils.ErrWebhookDisabled.Error()}
+		if err := s.webhookRepo.MarkFailed(recordCtx, id, attempt, nil); err != nil {
+			logger.ErrorContext(ctx, "Failed to record webhook delivery failure", "error", err)
+		}
+		metrics.WebhookDeliveries.WithLabelValues(delivery.EventType, "failed").Inc()
+		return
+	}
+
+	attempt := s.post(ctx, hook, delivery)
+
+	// Shutting down: leave the claim to expire rather than count the attempt as failed
+	if attempt.Error != "" && ctx.Err() != nil {
+		return
+	}
+	if attempt.ResponseStatus != 0 {
+		span.SetAttributes(attribute.Int("http.response.status_code", attempt.ResponseStatus))
+	}
+
+	if attempt.Error == "" {
+		if err := s.webhookRepo.MarkDelivered(recordCtx, id, attempt); err != nil {
+			logger.ErrorContext(ctx, "Failed to mark webhook delivery as delivered", "error", err)
+		}
+		if err := s.webhookRepo.RecordSuccess(recordCtx, hook.ID.Hex()); err != nil {
+			logger.ErrorContext(ctx, "Failed to record webhook success", "error", err)
+		}
+		metrics.WebhookDeliveries.WithLabelValues(delivery.EventType, "delivered").Inc()
+		logger.InfoContext(ctx, "Webhook delivered", "status", attempt.ResponseStatus)
+		return
+	}
+
+	tracing.RecordError(span, errors.New(attempt.Error))
+
+	disableAfter := config.AppConfig.WebhookDisableAfterFailures
+	reason := fmt.Sprintf("%d consecutive failed deliveries", disableAfter)
+	disabled, err := s.webhookRepo.RecordFailure(recordCtx, hook.ID.Hex(), disableAfter, reason)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to record webhook failure", "error", err)
+	}
+	if disabled {
+		logger.WarnContext(ctx, "Webhook disabled after repeated failures", "url", hook.URL, "failures", disableAfter)
+		s.auditService.Record(recordCtx, models.AuditWebhookDisabled, "", models.AuditTargetWebhook, hook.ID.Hex(), map[string]string{
+			"reason": reason,
+		})
+	}
+
+	var retryAt *time.Time
+	result := "failed"
+	if !disabled && delivery.Attempts < config.AppConfig.WebhookMaxAttempts {
+		next := time.Now().Add(retryDelay(config.AppConfig.WebhookRetryBase, delivery.Attempts))
+		retryAt = &next
+		result = "retry"
+	}
+
+	if err := s.webhookRepo.MarkFailed(recordCtx, id, attempt, retryAt); err != nil {
+		logger.ErrorContext(ctx, "Failed to record webhook delivery failure", "error", err)
+	}
+	metrics.WebhookDeliveries.WithLabelValues(delivery.EventType, result).Inc()
+
+	if retryAt != nil {
+		logger.WarnContext(ctx, "Webhook delivery failed; will retry", "retry_at", *retryAt, "error", attempt.Error)
+	} else {
+		logger.ErrorContext(ctx, "Webhook delivery failed for the last time", "error", attempt.Error)
+	}
+}
+
+// post sends the delivery, signed with a fresh timestamp. Any response other
+// than 2xx is a failure.
+func (s *WebhookService) post(ctx context.Context, hook *models.Webhook, delivery *models.WebhookDelivery) *models.WebhookAttempt {
+	body := []byte(delivery.Payload)
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return &models.WebhookAttempt{Error: err.Error()}
+	}
+	webhook.SetHeaders(request.Header, hook.Secret, delivery.EventType, delivery.ID.Hex(), time.Now(), body)
+
+	started := time.Now()
+	response, err := s.client.Do(request)
+	if err != nil {
+		return &models.WebhookAttempt{Duration: time.Since(started), Error: err.Error()}
+	}
+	defer response.Body.Close()
+
+	responseBody, _ := io.ReadAll(io.LimitReader(response.Body, webhookResponseLimit))
+	attempt := &models.WebhookAttempt{
+		ResponseStatus: response.StatusCode,
+		ResponseBody:   strings.ToValidUTF8(string(responseBody), ""),
+		Duration:       time.Since(started),
+	}
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		attempt.Error = fmt.Sprintf("endpoint returned status %d", response.StatusCode)
+	}
+	return attempt
+}
+
+// GetEvents lists the event types webhooks can subscribe to
+func (s *WebhookService) GetEvents() *models.WebhookEventsResponse {
+	return &models.WebhookEventsResponse{Events: models.WebhookEvents}
+}
+
+// validateEvents rejects event types that do not exist, so a typo does not
+// leave a webhook silently receiving nothing
+func validateEvents(events []string) error {
+	for _, event := range events {
+		if event != models.WebhookAllEvents && !slices.Contains(models.WebhookEvents, event) {
+			return utils.ErrUnknownWebhookEvent
+		}
+	}
+	return nil
+}
+
+func (s *WebhookService) CreateWebhook(ctx context.Context, req *models.WebhookCreateRequest, adminID string) (*models.WebhookCreatedResponse, error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.CreateWebhook")
+	defer span.End()
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+	if err := validateEvents(req.Events); err != nil {
+		return nil, err
+	}
+
+	adminObjectID, err := primitive.ObjectIDFromHex(adminID)
+	if err != nil {
+		return nil, utils.ErrInvalidID
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		if secret, err = webhook.NewSecret(); err != nil {
+			return nil, err
+		}
+	}
+
+	hook := &models.Webhook{
+		URL:         req.URL,
+		Description: req.Description,
+		Events:      slices.Compact(slices.Sorted(slices.Values(req.Events))),
+		Secret:      secret,
+		Enabled:     true,
+		CreatedBy:   adminObjectID,
+	}
+	if err := s.webhookRepo.Create(ctx, hook); err != nil {
+		return nil, err
+	}
+
+	s.auditService.Record(ctx, models.AuditWebhookCreated, adminID, models.AuditTargetWebhook, hook.ID.Hex(), map[string]string{
+		"url":    hook.URL,
+		"events": strings.Join(hook.Events, ","),
+	})
+
+	return &models.WebhookCreatedResponse{WebhookResponse: hook.ToResponse(), Secret: secret}, nil
+}
+
+func (s *WebhookService) GetWebhooks(ctx context.Context) ([]models.WebhookResponse, error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.GetWebhooks")
+	defer span.End()
+
+	webhooks, err := s.webhookRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]models.WebhookResponse, 0, len(webhooks))
+	for _, hook := range webhooks {
+		responses = append(responses, hook.ToResponse())
+	}
+	return responses, nil
+}
+
+func (s *WebhookService) GetWebhook(ctx context.Context, id string) (*models.WebhookResponse, error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.GetWebhook")
+	defer span.End()
+
+	hook, err := s.webhookRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	response := hook.ToResponse()
+	return &response, nil
+}
+
+// UpdateWebhook changes the given settings. Re-enabling a webhook clears its
+// failure count; disabling it leaves its pending deliveries to fail.
+func (s *WebhookService) UpdateWebhook(ctx context.Context, id string, req *models.WebhookUpdateRequest, adminID string) (*models.WebhookResponse, error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.UpdateWebhook")
+	defer span.End()
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+	if err := validateEvents(req.Events); err != nil {
+		return nil, err
+	}
+
+	hook, err := s.webhookRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// The secret is never written to the audit log, only that it changed
+	var changed []string
+	if req.URL != nil && *req.URL != hook.URL {
+		hook.URL = *req.URL
+		changed = append(changed, "url")
+	}
+	if req.Description != nil && *req.Description != hook.Description {
+		hook.Description = *req.Description
+		changed = append(changed, "description")
+	}
+	if len(req.Events) > 0 {
+		events := slices.Compact(slices.Sorted(slices.Values(req.Events)))
+		if !slices.Equal(events, hook.Events) {
+			hook.Events = events
+			changed = append(changed, "events")
+		}
+	}
+	if req.Secret != nil && *req.Secret != hook.Secret {
+		hook.Secret = *req.Secret
+		changed = append(changed, "secret")
+	}
+	if req.Enabled != nil && *req.Enabled != hook.Enabled {
+		hook.Enabled = *req.Enabled
+		if hook.Enabled {
+			hook.ConsecutiveFailures = 0
+			hook.DisabledAt = nil
+			hook.DisabledReason = ""
+		} else {
+			now := time.Now()
+			hook.DisabledAt = &now
+			hook.DisabledReason = "disabled by an administrator"
+		}
+		changed = append(changed, "enabled")
+	}
+
+	if len(changed) > 0 {
+		if err := s.webhookRepo.Update(ctx, hook); err != nil {
+			return nil, err
+		}
+		s.auditService.Record(ctx, models.AuditWebhookUpdated, adminID, models.AuditTargetWebhook, id, map[string]string{
+			"changed": strings.Join(changed, ","),
+			"enabled": strconv.FormatBool(hook.Enabled),
+		})
+	}
+
+	response := hook.ToResponse()
+	return &response, nil
+}
+
+// DeleteWebhook removes the webhook and its delivery log
+func (s *WebhookService) DeleteWebhook(ctx context.Context, id, adminID string) error {
+	ctx, span := tracing.Start(ctx, "WebhookService.DeleteWebhook")
+	defer span.End()
+
+	hook, err := s.webhookRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.webhookRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	s.auditService.Record(ctx, models.AuditWebhookDeleted, adminID, models.AuditTargetWebhook, id, map[string]string{
+		"url": hook.URL,
+	})
+	return nil
+}
+
+// GetDeliveries returns a webhook's delivery log, newest first
+func (s *WebhookService) GetDeliveries(ctx context.Context, webhookID string, limit int64) ([]models.WebhookDeliveryResponse, error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.GetDeliveries")
+	defer span.End()
+
+	if _, err := s.webhookRepo.GetByID(ctx, webhookID); err != nil {
+		return nil, err
+	}
+
+	deliveries, err := s.webhookRepo.ListDeliveries(ctx, webhookID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]models.WebhookDeliveryResponse, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		responses = append(responses, delivery.ToResponse())
+	}
+	return responses, nil
+}
+
+// Redeliver queues a new delivery of the same event and payload, for an
+// event the endpoint missed or failed to process. The original delivery is
+// left as it was in the log.
+func (s *WebhookService) Redeliver(ctx context.Context, webhookID, deliveryID, adminID string) (*models.WebhookDeliveryResponse, error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.Redeliver")
+	defer span.End()
+
+	hook, err := s.webhookRepo.GetByID(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	original, err := s.webhookRepo.GetDelivery(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	if original.WebhookID != hook.ID {
+		return nil, utils.ErrWebhookDeliveryNotFound
+	}
+	if !hook.Enabled {
+		return nil, utils.ErrWebhookDisabled
+	}
+
+	delivery := &models.WebhookDelivery{
+		WebhookID:    hook.ID,
+		EventID:      original.EventID,
+		EventType:    original.EventType,
+		Payload:      original.Payload,
+		RedeliveryOf: &original.ID,
+	}
+	if err := s.webhookRepo.CreateDelivery(ctx, delivery); err != nil {
+		return nil, err
+	}
+	s.Wake()
+
+	s.auditService.Record(ctx, models.AuditWebhookRedelivered, adminID, models.AuditTargetWebhook, webhookID, map[string]string{
+		"delivery_id": deliveryID,
+		"event_id":    original.EventID,
+		"event":       original.EventType,
+	})
+
+	response := delivery.ToResponse()
+	return &response, nil
+}
+
+// Prune returns a job that deletes finished deliveries created longer than
+// retention ago
+func (s *WebhookService) Prune(retention time.Duration) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		deleted, err := s.webhookRepo.DeleteDeliveriesBefore(ctx, time.Now().Add(-retention))
+		if err != nil {
+			return err
+		}
+
+		slog.InfoContext(ctx, "Pruned webhook deliveries", "deleted", deleted)
+		return nil
+	}
+}
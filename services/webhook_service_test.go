@@ -0,0 +1,233 @@
+package services
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"backend/config"
+	"backend/models"
+	"backend/repositories/interfaces"
+	"backend/repositories/memory"
+	"backend/utils"
+	"backend/webhook"
+)
+
+const testAdminID = "64b7f0c2e1a4d2a3b4c5d6e7"
+
+func newTestWebhookService(t *testing.T, retryBase time.Duration) (*WebhookService, interfaces.WebhookRepository) {
+	t.Helper()
+
+	config.AppConfig = &config.Config{
+		WebhookPollInterval:         time.Second,
+		WebhookTimeout:              2 * time.Second,
+		WebhookMaxAttempts:          3,
+		WebhookRetryBase:            retryBase,
+		WebhookDisableAfterFailures: 3,
+		// The receivers are httptest servers on loopback
+		WebhookAllowPrivateTargets: true,
+	}
+	utils.InitValidator()
+
+	webhookRepo := memory.NewWebhookRepository()
+	auditService := NewAuditService(memory.NewAuditRepository(), memory.NewUserRepository())
+	return NewWebhookService(webhookRepo, auditService), webhookRepo
+}
+
+func createTestWebhook(t *testing.T, s *WebhookService, url string) *models.WebhookCreatedResponse {
+	t.Helper()
+
+	created, err := s.CreateWebhook(context.Background(), &models.WebhookCreateRequest{
+		URL:    url,
+		Events: []string{models.WebhookAllEvents},
+	}, testAdminID)
+	if err != nil {
+		t.Fatalf("create webhook: %v", err)
+	}
+	return created
+}
+
+func onlyDelivery(t *testing.T, repo interfaces.WebhookRepository, webhookID string) *models.WebhookDelivery {
+	t.Helper()
+
+	deliveries, err := repo.ListDeliveries(context.Background(), webhookID, 0)
+	if err != nil {
+		t.Fatalf("list deliveries: %v", err)
+	}
+	if len(deliveries) != 1 {
+		t.Fatalf("expected 1 delivery, got %d", len(deliveries))
+	}
+	return deliveries[0]
+}
+
+func TestWebhookDeliveryIsSigned(t *testing.T) {
+	s, repo := newTestWebhookService(t, time.Minute)
+
+	var secret string
+	verified := make(chan error, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		verified <- webhook.Verify(secret, r.Header, body, webhook.DefaultTolerance, time.Now())
+	}))
+	defer server.Close()
+
+	created := createTestWebhook(t, s, server.URL)
+	secret = created.Secret
+
+	s.Publish(context.Background(), models.WebhookUserVerified, map[string]string{"user_id": testAdminID})
+	s.deliverDue(context.Background())
+
+	if err := <-verified; err != nil {
+		t.Fatalf("receiver could not verify the delivery: %v", err)
+	}
+	if delivery := onlyDelivery(t, repo, created.ID); delivery.Status != models.WebhookDeliveryDelivered {
+		t.Fatalf("expected delivered, got %s: %s", delivery.Status, delivery.LastError)
+	}
+}
+
+func TestWebhookRetriesWithBackoffThenDisables(t *testing.T) {
+	const retryBase = 20 * time.Millisecond
+	s, repo := newTestWebhookService(t, retryBase)
+
+	var received atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	created := createTestWebhook(t, s, server.URL)
+	s.Publish(context.Background(), models.WebhookUserVerified, map[string]string{"user_id": testAdminID})
+
+	for attempt := 1; attempt <= 3; attempt++ {
+		s.deliverDue(context.Background())
+		if got := received.Load(); got != int32(attempt) {
+			t.Fatalf("attempt %d: expected %d requests, got %d", attempt, attempt, got)
+		}
+
+		delivery := onlyDelivery(t, repo, created.ID)
+		if attempt == 3 {
+			if delivery.Status != models.WebhookDeliveryFailed {
+				t.Fatalf("expected the last attempt to fail the delivery, got %s", delivery.Status)
+			}
+			break
+		}
+		if delivery.Status != models.WebhookDeliveryPending || delivery.LastError != "endpoint returned status 500" {
+			t.Fatalf("attempt %d: expected a pending retry, got %s: %s", attempt, delivery.Status, delivery.LastError)
+		}
+		delay := delivery.NextAttemptAt.Sub(*delivery.LastAttemptAt)
+		if want := retryDelay(retryBase, attempt); delay < want || delay > want+time.Second {
+			t.Fatalf("attempt %d: expected a retry after %s, got %s", attempt, want, delay)
+		}
+
+		// Not due yet: nothing is posted
+		s.deliverDue(context.Background())
+		if got := received.Load(); got != int32(attempt) {
+			t.Fatalf("attempt %d: delivery retried before it was due", attempt)
+		}
+		time.Sleep(time.Until(delivery.NextAttemptAt))
+	}
+
+	hook, err := repo.GetByID(context.Background(), created.ID)
+	if err != nil {
+		t.Fatalf("get webhook: %v", err)
+	}
+	if hook.Enabled || hook.DisabledAt == nil || hook.ConsecutiveFailures != 3 {
+		t.Fatalf("expected the webhook to be disabled after 3 failures: %+v", hook)
+	}
+}
+
+func TestRetryDelayDoubles(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{3, 2 * time.Minute},
+		{12, maxRetryDelay},
+	}
+	for _, tt := range tests {
+		if got := retryDelay(30*time.Second, tt.attempts); got != tt.want {
+			t.Errorf("retryDelay after %d attempts = %s, want %s", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestWebhookDoesNotFollowRedirects(t *testing.T) {
+	s, repo := newTestWebhookService(t, time.Minute)
+
+	var redirected atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/hook", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/elsewhere", http.StatusTemporaryRedirect)
+	})
+	mux.HandleFunc("/elsewhere", func(w http.ResponseWriter, r *http.Request) {
+		redirected.Add(1)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	created := createTestWebhook(t, s, server.URL+"/hook")
+	s.Publish(context.Background(), models.WebhookUserVerified, map[string]string{"user_id": testAdminID})
+	s.deliverDue(context.Background())
+
+	if redirected.Load() != 0 {
+		t.Fatal("expected the redirect not to be followed")
+	}
+	delivery := onlyDelivery(t, repo, created.ID)
+	if delivery.Status != models.WebhookDeliveryPending || delivery.ResponseStatus != http.StatusTemporaryRedirect {
+		t.Fatalf("expected a failed attempt with status 307, got %s with %d", delivery.Status, delivery.ResponseStatus)
+	}
+}
+
+func TestWebhookRefusesPrivateTargetsByDefault(t *testing.T) {
+	s, repo := newTestWebhookService(t, time.Minute)
+	s.client = webhook.NewClient(time.Second, false)
+
+	var received atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received.Add(1)
+	}))
+	defer server.Close()
+
+	created := createTestWebhook(t, s, server.URL)
+	s.Publish(context.Background(), models.WebhookUserVerified, map[string]string{"user_id": testAdminID})
+	s.deliverDue(context.Background())
+
+	if received.Load() != 0 {
+		t.Fatal("expected the loopback receiver not to be contacted")
+	}
+	if delivery := onlyDelivery(t, repo, created.ID); delivery.ResponseStatus != 0 || delivery.LastError == "" {
+		t.Fatalf("expected a connection failure, got status %d: %q", delivery.ResponseStatus, delivery.LastError)
+	}
+}
+
+func TestRedeliverRejectsAnotherWebhooksDelivery(t *testing.T) {
+	s, repo := newTestWebhookService(t, time.Minute)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	first := createTestWebhook(t, s, server.URL+"/first")
+	second := createTestWebhook(t, s, server.URL+"/second")
+	s.Publish(context.Background(), models.WebhookUserVerified, map[string]string{"user_id": testAdminID})
+
+	delivery := onlyDelivery(t, repo, second.ID)
+	_, err := s.Redeliver(context.Background(), first.ID, delivery.ID.Hex(), testAdminID)
+	if err != utils.ErrWebhookDeliveryNotFound {
+		t.Fatalf("expected ErrWebhookDeliveryNotFound, got %v", err)
+	}
+
+	redelivery, err := s.Redeliver(context.Background(), second.ID, delivery.ID.Hex(), testAdminID)
+	if err != nil {
+		t.Fatalf("redeliver: %v", err)
+	}
+	if redelivery.RedeliveryOf != delivery.ID.Hex() {
+		t.Fatalf("expected a redelivery of %s, got %+v", delivery.ID.Hex(), redelivery)
+	}
+}
@@ -0,0 +1,62 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/event"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// commandKey identifies an in-flight command; request IDs are only unique per connection
+type commandKey struct {
+	connectionID string
+	requestID    int64
+}
+
+// MongoMonitor returns a command monitor that records a client span for every
+// MongoDB command. Command documents are not recorded because filters and
+// updates contain user data.
+func MongoMonitor() *event.CommandMonitor {
+	var spans sync.Map
+
+	finish := func(evt event.CommandFinishedEvent, err error) {
+		value, ok := spans.LoadAndDelete(commandKey{evt.ConnectionID, evt.RequestID})
+		if !ok {
+			return
+		}
+		span := value.(trace.Span)
+		RecordError(span, err)
+		span.End()
+	}
+
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, evt *event.CommandStartedEvent) {
+			attrs := []attribute.KeyValue{
+				attribute.String("db.system.name", "mongodb"),
+				attribute.String("db.namespace", evt.DatabaseName),
+				attribute.String("db.operation.name", evt.CommandName),
+			}
+
+			name := evt.CommandName
+			// The first element of most commands names the collection, e.g. {find: "users"}
+			if first, err := evt.Command.IndexErr(0); err == nil {
+				if collection, ok := first.Value().StringValueOK(); ok {
+					attrs = append(attrs, attribute.String("db.collection.name", collection))
+					name += " " + collection
+				}
+			}
+
+			_, span := StartWithKind(ctx, name, trace.SpanKindClient, attrs...)
+			spans.Store(commandKey{evt.ConnectionID, evt.RequestID}, span)
+		},
+		Succeeded: func(_ context.Context, evt *event.CommandSucceededEvent) {
+			finish(evt.CommandFinishedEvent, nil)
+		},
+		Failed: func(_ context.Context, evt *event.CommandFailedEvent) {
+			finish(evt.CommandFinishedEvent, errors.New(evt.Failure))
+		},
+	}
+}
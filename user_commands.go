@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"backend/models"
+)
+
+func runCreateAdmin(args []string) error {
+	fs := newFlagSet("create-admin")
+	name := fs.String("name", "", "full name")
+	email := fs.String("email", "", "email address")
+	passwordStdin := fs.Bool("password-stdin", false, "read the password from standard input instead of generating one")
+	actor := fs.String("actor", "", "email of the administrator creating the account; optional while there is none")
+	if err := parseFlags(fs, args, "name", "email"); err != nil {
+		return err
+	}
+
+	password, generated, err := readPassword(*passwordStdin)
+	if err != nil {
+		return err
+	}
+
+	app, err := openCLI()
+	if err != nil {
+		return err
+	}
+	defer app.close()
+
+	ctx := context.Background()
+	var actorID string
+	if *actor != "" {
+		if actorID, err = app.actorID(ctx, *actor); err != nil {
+			return err
+		}
+	} else {
+		// Only the first administrator may be created anonymously
+		admins, err := app.repos.Users.CountActiveUsersByRole(ctx, "admin")
+		if err != nil {
+			return err
+		}
+		if admins > 0 {
+			return errors.New("create-admin: --actor is required once an administrator exists")
+		}
+	}
+
+	user, err := app.admin.CreateAdmin(ctx, &models.AdminCreateRequest{
+		Name:     *name,
+		Email:    *email,
+		Password: password,
+	}, actorID)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Created administrator %s (%s)\n", user.Email, user.ID.Hex())
+	if generated {
+		fmt.Printf("Password: %s\n", password)
+	}
+	return nil
+}
+
+func runResetPassword(args []string) error {
+	fs := newFlagSet("reset-password")
+	email := fs.String("email", "", "email of the user")
+	passwordStdin := fs.Bool("password-stdin", false, "read the password from standard input instead of generating one")
+	actor := fs.String("actor", "", "email of the administrator resetting the password")
+	if err := parseFlags(fs, args, "email", "actor"); err != nil {
+		return err
+	}
+
+	password, generated, err := readPassword(*passwordStdin)
+	if err != nil {
+		return err
+	}
+
+	return withUser(*email, *actor, func(ctx context.Context, app *cli, userID, actorID string) error {
+		err := app.admin.ResetPassword(ctx, userID, actorID, &models.AdminPasswordResetRequest{Password: password})
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Reset the password of %s and revoked their sessions\n", *email)
+		if generated {
+			fmt.Printf("Password: %s\n", password)
+		}
+		return nil
+	})
+}
+
+func runVerifyUser(args []string) error {
+	fs := newFlagSet("verify-user")
+	email := fs.String("email", "", "email of the pending user")
+	notes := fs.String("notes", "", "verification notes")
+	actor := fs.String("actor", "", "email of the administrator verifying the user")
+	if err := parseFlags(fs, args, "email", "actor"); err != nil {
+		return err
+	}
+
+	return withUser(*email, *actor, func(ctx context.Context, app *cli, userID, actorID string) error {
+		if err := app.admin.VerifyUser(ctx, userID, actorID, &models.VerificationRequest{Notes: *notes}); err != nil {
+			return err
+		}
+
+		fmt.Printf("Verified %s\n", *email)
+		return nil
+	})
+}
+
+func runSetRole(args []string) error {
+	fs := newFlagSet("set-role")
+	email := fs.String("email", "", "email of the user")
+	role := fs.String("role", "", "new role: admin, liaison, voice or finance")
+	actor := fs.String("actor", "", "email of the administrator changing the role")
+	if err := parseFlags(fs, args, "email", "role", "actor"); err != nil {
+		return err
+	}
+
+	return withUser(*email, *actor, func(ctx context.Context, app *cli, userID, actorID string) error {
+		user, err := app.admin.UpdateUserRole(ctx, userID, actorID, &models.AdminUserRoleUpdateRequest{Role: *role})
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("%s now has the %s role\n", user.Email, user.Role)
+		return nil
+	})
+}
+
+func runRevokeSessions(args []string) error {
+	fs := newFlagSet("revoke-sessions")
+	email := fs.String("email", "", "email of the user")
+	actor := fs.String("actor", "", "email of the administrator revoking the sessions")
+	if err := parseFlags(fs, args, "email", "actor"); err != nil {
+		return err
+	}
+
+	return withUser(*email, *actor, func(ctx context.Context, app *cli, userID, actorID string) error {
+		if err := app.admin.RevokeUserSessions(ctx, userID, actorID); err != nil {
+			return err
+		}
+
+		fmt.Printf("Revoked every session of %s\n", *email)
+		return nil
+	})
+}
+
+// withUser opens the storage backend, resolves the target user and the acting
+// administrator by email and runs fn
+func withUser(email, actor string, fn func(ctx context.Context, app *cli, userID, actorID string) error) error {
+	app, err := openCLI()
+	if err != nil {
+		return err
+	}
+	defer app.close()
+
+	ctx := context.Background()
+	actorID, err := app.actorID(ctx, actor)
+	if err != nil {
+		return err
+	}
+
+	userID, err := app.userID(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	return fn(ctx, app, userID, actorID)
+}
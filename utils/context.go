@@ -0,0 +1,75 @@
+package utils
+
+import (
+	"context"
+	"errors"
+)
+
+// StatusClientClosedRequest is the non-standard status used when the client
+// disconnected before the handler could finish
+const StatusClientClosedRequest = 499
+
+type contextKey string
+
+const (
+	userIDContextKey    contextKey = "userID"
+	requestIDContextKey contextKey = "requestID"
+	routeContextKey     contextKey = "route"
+	clientContextKey    contextKey = "client"
+)
+
+// Client describes where a request came from
+type Client struct {
+	IP        string
+	UserAgent string
+}
+
+// WithUserID returns a copy of ctx carrying the authenticated user's ID
+func WithUserID(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, userIDContextKey, userID)
+}
+
+// UserIDFromContext returns the authenticated user's ID, or "" if there is none
+func UserIDFromContext(ctx context.Context) string {
+	userID, _ := ctx.Value(userIDContextKey).(string)
+	return userID
+}
+
+// WithRequestID returns a copy of ctx carrying the request's correlation ID
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey, requestID)
+}
+
+// RequestIDFromContext returns the request's correlation ID, or "" if there is none
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDContextKey).(string)
+	return requestID
+}
+
+// WithRoute returns a copy of ctx carrying the request's method and path
+func WithRoute(ctx context.Context, route string) context.Context {
+	return context.WithValue(ctx, routeContextKey, route)
+}
+
+// RouteFromContext returns the request's method and path, or "" if there is none
+func RouteFromContext(ctx context.Context) string {
+	route, _ := ctx.Value(routeContextKey).(string)
+	return route
+}
+
+// WithClient returns a copy of ctx carrying the caller's address and User-Agent
+func WithClient(ctx context.Context, client Client) context.Context {
+	return context.WithValue(ctx, clientContextKey, client)
+}
+
+// ClientFromContext returns the caller's address and User-Agent, or a zero
+// Client outside a request
+func ClientFromContext(ctx context.Context) Client {
+	client, _ := ctx.Value(clientContextKey).(Client)
+	return client
+}
+
+// IsContextError reports whether err was caused by a cancelled or expired context
+func IsContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
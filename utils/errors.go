@@ -2,39 +2,119 @@ package utils
 
 import (
 	"errors"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
 )
 
+// Error is a domain error with a stable machine-readable code and the HTTP
+// status it is reported with. Codes are part of the API: clients match on
+// them instead of messages, so existing codes must not change.
+type Error struct {
+	Code    string
+	Status  int
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// NewError declares a domain error. Errors are compared by identity, so
+// declare each one once as a package variable.
+func NewError(code string, status int, message string) error {
+	return &Error{Code: code, Status: status, Message: message}
+}
+
 var (
-	ErrUserNotFound               = errors.New("user not found")
-	ErrInvalidCredentials         = errors.New("invalid credentials")
-	ErrTokenNotFound              = errors.New("token not found")
-	ErrTokenExpired               = errors.New("token expired")
-	ErrTokenRevoked               = errors.New("token revoked")
-	ErrUserAlreadyExists          = errors.New("user already exists")
-	ErrInvalidToken               = errors.New("invalid token")
-	ErrUnauthorized               = errors.New("unauthorized")
-	ErrUserNotVerified            = errors.New("user account not verified by admin")
-	ErrUserAlreadyVerified        = errors.New("user already verified")
-	ErrUnauthorizedAdmin          = errors.New("admin access required")
-	ErrEmailDeliveryFailed        = errors.New("failed to send email")
-	ErrUserNotEligibleForReset    = errors.New("user not eligible for password reset")
-	ErrPasswordResetLimitExceeded = errors.New("password reset limit exceeded")
-	ErrPasswordGenerationFailed   = errors.New("failed to generate secure password")
-	ErrLastAdminDemotion          = errors.New("cannot demote the last admin user")
+	ErrUserNotFound               = NewError("user_not_found", fiber.StatusNotFound, "user not found")
+	ErrInvalidCredentials         = NewError("invalid_credentials", fiber.StatusUnauthorized, "invalid credentials")
+	ErrTokenNotFound              = NewError("token_not_found", fiber.StatusBadRequest, "token not found")
+	ErrTokenExpired               = NewError("token_expired", fiber.StatusUnauthorized, "token expired")
+	ErrTokenRevoked               = NewError("token_revoked", fiber.StatusUnauthorized, "token revoked")
+	ErrUserAlreadyExists          = NewError("user_already_exists", fiber.StatusConflict, "user already exists")
+	ErrInvalidToken               = NewError("invalid_token", fiber.StatusUnauthorized, "invalid token")
+	ErrUnauthorized               = NewError("unauthorized", fiber.StatusUnauthorized, "unauthorized")
+	ErrTokenUserNotFound          = NewError("token_user_not_found", fiber.StatusUnauthorized, "user not found")
+	ErrUserNotVerified            = NewError("user_not_verified", fiber.StatusForbidden, "user account not verified by admin")
+	ErrUserAlreadyVerified        = NewError("user_already_verified", fiber.StatusConflict, "user already verified")
+	ErrUnauthorizedAdmin          = NewError("admin_required", fiber.StatusForbidden, "admin access required")
+	ErrEmailNotConfigured         = NewError("email_not_configured", fiber.StatusServiceUnavailable, "email provider is not configured")
+	ErrUserNotEligibleForReset    = NewError("user_not_eligible_for_reset", fiber.StatusBadRequest, "user not eligible for password reset")
+	ErrPasswordResetLimitExceeded = NewError("password_reset_limit_exceeded", fiber.StatusTooManyRequests, "password reset limit exceeded")
+	ErrPasswordGenerationFailed   = NewError("password_generation_failed", fiber.StatusInternalServerError, "failed to generate secure password")
+	ErrPasswordMismatch           = NewError("password_mismatch", fiber.StatusBadRequest, "password confirmation does not match")
+	ErrPasswordUnchanged          = NewError("password_unchanged", fiber.StatusBadRequest, "new password must be different from current password")
+	ErrLastAdminDemotion          = NewError("last_admin_demotion", fiber.StatusConflict, "cannot demote the last admin user")
+	ErrLastAdminSuspension        = NewError("last_admin_suspension", fiber.StatusConflict, "cannot suspend the last admin user")
+	ErrUserSuspended              = NewError("user_suspended", fiber.StatusForbidden, "user account is suspended")
+	ErrUserAlreadySuspended       = NewError("user_already_suspended", fiber.StatusConflict, "user already suspended")
+	ErrUserNotSuspended           = NewError("user_not_suspended", fiber.StatusConflict, "user is not suspended")
+
+	// Data subject request errors
+	ErrUserAlreadyErased = NewError("user_already_erased", fiber.StatusConflict, "user data already erased")
+	ErrLastAdminErasure  = NewError("last_admin_erasure", fiber.StatusConflict, "cannot erase the last admin user")
+
+	// Bulk operation errors
+	ErrBulkSelectionRequired = NewError("bulk_selection_required", fiber.StatusBadRequest, "either ids or filter is required")
+	ErrBulkTooManyItems      = NewError("bulk_too_many_items", fiber.StatusBadRequest, "bulk operation exceeds the maximum number of items")
+	ErrBulkJobNotFound       = NewError("bulk_job_not_found", fiber.StatusNotFound, "bulk job not found")
+
+	// User import errors
+	ErrImportUnsupportedFormat = NewError("import_unsupported_format", fiber.StatusBadRequest, "unsupported import file format")
+	ErrImportMissingColumns    = NewError("import_missing_columns", fiber.StatusBadRequest, "import file must contain name, email and role columns")
+	ErrImportEmptyFile         = NewError("import_empty_file", fiber.StatusBadRequest, "import file contains no rows")
+	ErrImportTooManyRows       = NewError("import_too_many_rows", fiber.StatusBadRequest, "import file exceeds the maximum number of rows")
+	ErrImportFileTooLarge      = NewError("import_file_too_large", fiber.StatusBadRequest, "import file exceeds the maximum uncompressed size")
+	ErrImportMalformedFile     = NewError("import_malformed_file", fiber.StatusBadRequest, "import file could not be read")
+
+	// Scheduled job errors
+	ErrJobNotFound         = NewError("job_not_found", fiber.StatusNotFound, "job not found")
+	ErrJobAlreadyRunning   = NewError("job_already_running", fiber.StatusConflict, "job is already running")
+	ErrSchedulerNotRunning = NewError("scheduler_not_running", fiber.StatusServiceUnavailable, "job scheduler is not running")
+
+	// Email outbox errors
+	ErrOutboxMessageNotFound    = NewError("outbox_message_not_found", fiber.StatusNotFound, "outbox message not found")
+	ErrOutboxMessageAlreadySent = NewError("outbox_message_already_sent", fiber.StatusConflict, "outbox message has already been sent")
+	ErrOutboxClaimLost          = NewError("outbox_claim_lost", fiber.StatusConflict, "outbox message was claimed again or requeued")
+	ErrInvalidOutboxStatus      = NewError("invalid_outbox_status", fiber.StatusBadRequest, "status must be pending, sent or dead")
+	ErrCapturedEmailNotFound    = NewError("captured_email_not_found", fiber.StatusNotFound, "captured email not found")
+	ErrEmailTemplateNotFound    = NewError("email_template_not_found", fiber.StatusNotFound, "email template not found")
+
+	// Notification errors
+	ErrNotificationNotFound    = NewError("notification_not_found", fiber.StatusNotFound, "notification not found")
+	ErrUnknownNotificationType = NewError("unknown_notification_type", fiber.StatusBadRequest, "unknown notification type")
+
+	// Webhook errors
+	ErrWebhookNotFound         = NewError("webhook_not_found", fiber.StatusNotFound, "webhook not found")
+	ErrWebhookDeliveryNotFound = NewError("webhook_delivery_not_found", fiber.StatusNotFound, "webhook delivery not found")
+	ErrUnknownWebhookEvent     = NewError("unknown_webhook_event", fiber.StatusBadRequest, "unknown webhook event type")
+	ErrWebhookDisabled         = NewError("webhook_disabled", fiber.StatusConflict, "webhook is disabled")
+
+	// Realtime errors
+	ErrTooManyEventStreams = NewError("too_many_event_streams", fiber.StatusTooManyRequests, "too many open event streams")
+	ErrShuttingDown        = NewError("shutting_down", fiber.StatusServiceUnavailable, "server is shutting down")
+
+	// Rate limit errors
+	ErrRateLimited = NewError("rate_limited", fiber.StatusTooManyRequests, "too many requests")
+
+	// Statistics errors
+	ErrInvalidStatsBucket = NewError("invalid_stats_bucket", fiber.StatusBadRequest, "bucket must be day, week or month")
+	ErrInvalidStatsRange  = NewError("invalid_stats_range", fiber.StatusBadRequest, "invalid statistics date range")
 
 	// Menu related errors
-	ErrMenuNotFound            = errors.New("menu not found")
-	ErrInvalidID               = errors.New("invalid id format")
-	ErrMenuAlreadyExists       = errors.New("menu already exists")
-	ErrPermissionNotFound      = errors.New("permission not found")
-	ErrPermissionAlreadyExists = errors.New("permission already exists")
-	ErrMenuAccessDenied        = errors.New("menu access denied")
+	ErrMenuNotFound            = NewError("menu_not_found", fiber.StatusNotFound, "menu not found")
+	ErrInvalidID               = NewError("invalid_id", fiber.StatusBadRequest, "invalid id format")
+	ErrMenuAlreadyExists       = NewError("menu_already_exists", fiber.StatusConflict, "menu already exists")
+	ErrPermissionNotFound      = NewError("permission_not_found", fiber.StatusNotFound, "permission not found")
+	ErrPermissionAlreadyExists = NewError("permission_already_exists", fiber.StatusConflict, "permission already exists")
+	ErrMenuAccessDenied        = NewError("menu_access_denied", fiber.StatusForbidden, "menu access denied")
 )
 
+// IsValidationError reports whether err is a failed struct validation
 func IsValidationError(err error) bool {
-	return err != nil && (err.Error() == "validation failed" ||
-		err == ErrInvalidCredentials ||
-		err == ErrUserAlreadyExists)
+	var validationErrors validator.ValidationErrors
+	return errors.As(err, &validationErrors)
 }
 
 func IsNotFoundError(err error) bool {
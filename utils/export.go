@@ -0,0 +1,167 @@
+package utils
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Export formats
+const (
+	ExportFormatCSV  = "csv"
+	ExportFormatXLSX = "xlsx"
+	ExportFormatJSON = "json"
+)
+
+var ErrUnsupportedExportFormat = errors.New("unsupported export format")
+
+// ExportWriter writes report rows one at a time. Tabular formats use the
+// values slice; JSON encodes the record itself. Close completes the file;
+// Abort releases the writer without completing it, after a failure.
+type ExportWriter interface {
+	WriteRow(record interface{}, values []string) error
+	Close() error
+	Abort()
+}
+
+// ExportContentType returns the MIME type for an export format
+func ExportContentType(format string) string {
+	switch format {
+	case ExportFormatCSV:
+		return "text/csv; charset=utf-8"
+	case ExportFormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	default:
+		return "application/json"
+	}
+}
+
+// NewExportWriter creates a writer for the given format that writes to w
+func NewExportWriter(format string, w io.Writer, columns []string) (ExportWriter, error) {
+	switch format {
+	case ExportFormatCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(columns); err != nil {
+			return nil, err
+		}
+		return &csvExportWriter{writer: writer}, nil
+	case ExportFormatXLSX:
+		return newXLSXExportWriter(w, columns)
+	case ExportFormatJSON:
+		if _, err := io.WriteString(w, "["); err != nil {
+			return nil, err
+		}
+		return &jsonExportWriter{w: w, encoder: json.NewEncoder(w)}, nil
+	default:
+		return nil, ErrUnsupportedExportFormat
+	}
+}
+
+// neutralizeFormula keeps spreadsheet applications from evaluating a
+// user-controlled value as a formula, by prefixing it with a quote
+func neutralizeFormula(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+type csvExportWriter struct {
+	writer *csv.Writer
+}
+
+func (e *csvExportWriter) WriteRow(_ interface{}, values []string) error {
+	row := make([]string, len(values))
+	for i, value := range values {
+		row[i] = neutralizeFormula(value)
+	}
+	return e.writer.Write(row)
+}
+
+func (e *csvExportWriter) Close() error {
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+func (e *csvExportWriter) Abort() {}
+
+// jsonExportWriter streams a JSON array, one element per row
+type jsonExportWriter struct {
+	w       io.Writer
+	encoder *json.Encoder
+	count   int
+}
+
+func (e *jsonExportWriter) WriteRow(record interface{}, _ []string) error {
+	if e.count > 0 {
+		if _, err := io.WriteString(e.w, ","); err != nil {
+			return err
+		}
+	}
+	e.count++
+	return e.encoder.Encode(record)
+}
+
+func (e *jsonExportWriter) Close() error {
+	_, err := io.WriteString(e.w, "]\n")
+	return err
+}
+
+func (e *jsonExportWriter) Abort() {}
+
+// xlsxExportWriter uses excelize's stream writer, which spills rows to a
+// temporary file instead of keeping the whole sheet in memory
+type xlsxExportWriter struct {
+	w      io.Writer
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	row    int
+}
+
+func newXLSXExportWriter(w io.Writer, columns []string) (*xlsxExportWriter, error) {
+	file := excelize.NewFile()
+	stream, err := file.NewStreamWriter("Sheet1")
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	e := &xlsxExportWriter{w: w, file: file, stream: stream}
+	if err := e.WriteRow(nil, columns); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return e, nil
+}
+
+func (e *xlsxExportWriter) WriteRow(_ interface{}, values []string) error {
+	e.row++
+	cell, err := excelize.CoordinatesToCellName(1, e.row)
+	if err != nil {
+		return err
+	}
+
+	row := make([]interface{}, len(values))
+	for i, value := range values {
+		row[i] = neutralizeFormula(value)
+	}
+	return e.stream.SetRow(cell, row)
+}
+
+func (e *xlsxExportWriter) Close() error {
+	defer e.file.Close()
+
+	if err := e.stream.Flush(); err != nil {
+		return err
+	}
+	return e.file.Write(e.w)
+}
+
+// Abort removes the temporary files of the stream writer
+func (e *xlsxExportWriter) Abort() {
+	e.file.Close()
+}
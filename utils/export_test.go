@@ -0,0 +1,61 @@
+package utils
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestExportNeutralizesFormulas(t *testing.T) {
+	values := []string{"=HYPERLINK(\"http://example.com\")", "+1", "-1", "@SUM(A1)", "\tx", "\rx", "Alice", ""}
+	want := []string{"'=HYPERLINK(\"http://example.com\")", "'+1", "'-1", "'@SUM(A1)", "'\tx", "'\rx", "Alice", ""}
+
+	t.Run("csv", func(t *testing.T) {
+		var buf bytes.Buffer
+		writer, err := NewExportWriter(ExportFormatCSV, &buf, []string{"a", "b", "c", "d", "e", "f", "g", "h"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := writer.WriteRow(nil, values); err != nil {
+			t.Fatal(err)
+		}
+		if err := writer.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		const expected = "a,b,c,d,e,f,g,h\n\"'=HYPERLINK(\"\"http://example.com\"\")\",'+1,'-1,'@SUM(A1),'\tx,\"'\rx\",Alice,\n"
+		if buf.String() != expected {
+			t.Fatalf("expected %q, got %q", expected, buf.String())
+		}
+	})
+
+	t.Run("xlsx", func(t *testing.T) {
+		var buf bytes.Buffer
+		writer, err := NewExportWriter(ExportFormatXLSX, &buf, []string{"a", "b", "c", "d", "e", "f", "g", "h"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := writer.WriteRow(nil, values); err != nil {
+			t.Fatal(err)
+		}
+		if err := writer.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		file, err := excelize.OpenReader(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer file.Close()
+		rows, err := file.GetRows("Sheet1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, value := range want[:7] {
+			if rows[1][i] != value {
+				t.Fatalf("column %d: expected %q, got %q", i, value, rows[1][i])
+			}
+		}
+	})
+}
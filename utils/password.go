@@ -2,9 +2,12 @@ package utils
 
 import (
 	"backend/config"
+	"backend/tracing"
+	"context"
 	"crypto/rand"
 	"math/big"
 
+	"go.opentelemetry.io/otel/attribute"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -28,6 +31,21 @@ func CheckPasswordHash(password, hash string) bool {
 	return err == nil
 }
 
+// HashPasswordContext is HashPassword inside a span, since bcrypt dominates
+// the latency of requests that set passwords
+func HashPasswordContext(ctx context.Context, password string) (string, error) {
+	_, span := tracing.Start(ctx, "bcrypt.hash", attribute.Int("bcrypt.cost", config.AppConfig.BcryptRounds))
+	defer span.End()
+	return HashPassword(password)
+}
+
+// CheckPasswordHashContext is CheckPasswordHash inside a span
+func CheckPasswordHashContext(ctx context.Context, password, hash string) bool {
+	_, span := tracing.Start(ctx, "bcrypt.compare")
+	defer span.End()
+	return CheckPasswordHash(password, hash)
+}
+
 // GenerateSecurePassword generates a cryptographically secure random password
 func GenerateSecurePassword(length int) (string, error) {
 	if length < 8 {
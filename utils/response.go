@@ -1,14 +1,70 @@
 package utils
 
 import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
 	"github.com/gofiber/fiber/v2"
 )
 
+// MIMEProblemJSON is the media type of RFC 7807 problem details. Clients
+// that accept it get errors in that format instead of the Response envelope.
+const MIMEProblemJSON = "application/problem+json"
+
 type Response struct {
 	Success bool        `json:"success"`
 	Message string      `json:"message"`
+	Code    string      `json:"code,omitempty"`
 	Data    interface{} `json:"data,omitempty"`
 	Error   string      `json:"error,omitempty"`
+	// Details lists the fields that failed validation
+	Details []FieldError `json:"details,omitempty"`
+	// RequestID is given with internal errors, whose cause is only logged
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// Problem is an RFC 7807 problem details document. The type is always
+// about:blank, so the title is the status text; code identifies the problem.
+type Problem struct {
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Detail    string       `json:"detail,omitempty"`
+	Instance  string       `json:"instance,omitempty"`
+	Code      string       `json:"code"`
+	Errors    []FieldError `json:"errors,omitempty"`
+	RequestID string       `json:"request_id,omitempty"`
+}
+
+// statusCodes are the codes of errors that are not domain errors
+var statusCodes = map[int]string{
+	fiber.StatusBadRequest:            "bad_request",
+	fiber.StatusUnauthorized:          "unauthorized",
+	fiber.StatusForbidden:             "forbidden",
+	fiber.StatusNotFound:              "not_found",
+	fiber.StatusMethodNotAllowed:      "method_not_allowed",
+	fiber.StatusConflict:              "conflict",
+	fiber.StatusRequestEntityTooLarge: "payload_too_large",
+	fiber.StatusUnprocessableEntity:   "unprocessable_entity",
+	fiber.StatusTooManyRequests:       "too_many_requests",
+	StatusClientClosedRequest:         "request_cancelled",
+	fiber.StatusInternalServerError:   "internal_error",
+	fiber.StatusServiceUnavailable:    "service_unavailable",
+	fiber.StatusGatewayTimeout:        "timeout",
+}
+
+func codeForStatus(status int) string {
+	if code, ok := statusCodes[status]; ok {
+		return code
+	}
+	if status >= fiber.StatusInternalServerError {
+		return "internal_error"
+	}
+	return "bad_request"
 }
 
 func SuccessResponse(c *fiber.Ctx, statusCode int, message string, data interface{}) error {
@@ -19,19 +75,97 @@ func SuccessResponse(c *fiber.Ctx, statusCode int, message string, data interfac
 	})
 }
 
+// ErrorResponse reports an error that is not a domain error, with a code
+// derived from the status. The optional detail is hidden from clients on
+// 5xx responses and logged instead.
 func ErrorResponse(c *fiber.Ctx, statusCode int, message string, err ...string) error {
-	response := Response{
-		Success: false,
-		Message: message,
+	detail := ""
+	if len(err) > 0 {
+		detail = err[0]
 	}
+	return writeError(c, statusCode, codeForStatus(statusCode), message, detail, nil)
+}
 
-	if len(err) > 0 {
-		response.Error = err[0]
+// ValidationErrorResponse reports a failed struct validation with the
+// fields that failed
+func ValidationErrorResponse(c *fiber.Ctx, err error) error {
+	details := ValidationDetails(err)
+	messages := make([]string, 0, len(details))
+	for _, detail := range details {
+		messages = append(messages, detail.Message)
+	}
+	return writeError(c, fiber.StatusBadRequest, "validation_failed", "Validation failed", strings.Join(messages, "; "), details)
+}
+
+// HandleError reports err with the status and code of its domain error,
+// the fields of a failed validation, or as an internal error described by
+// message. It replaces per-handler mapping of errors to statuses; handlers
+// only check for errors they report differently.
+func HandleError(c *fiber.Ctx, err error, message string) error {
+	if IsValidationError(err) {
+		return ValidationErrorResponse(c, err)
 	}
 
-	return c.Status(statusCode).JSON(response)
+	var domainErr *Error
+	if errors.As(err, &domainErr) {
+		// A domain error wrapped as "%w: context" reports the context as detail
+		detail := strings.TrimPrefix(err.Error(), domainErr.Message+": ")
+		if detail == err.Error() {
+			detail = ""
+		}
+		return writeError(c, domainErr.Status, domainErr.Code, capitalize(domainErr.Message), detail, nil)
+	}
+	return ErrorResponse(c, fiber.StatusInternalServerError, message, err.Error())
 }
 
-func ValidationErrorResponse(c *fiber.Ctx, err error) error {
-	return ErrorResponse(c, fiber.StatusBadRequest, "Validation failed", err.Error())
+// writeError sends the error as a problem document to clients that accept
+// one, and in the Response envelope otherwise
+func writeError(c *fiber.Ctx, status int, code, message, detail string, details []FieldError) error {
+	requestID := ""
+	if status >= fiber.StatusInternalServerError {
+		requestID = RequestIDFromContext(c.UserContext())
+		if detail != "" {
+			slog.ErrorContext(c.UserContext(), message, "status", status, "code", code, "error", detail)
+			detail = ""
+		}
+	}
+
+	if c.Accepts(fiber.MIMEApplicationJSON, MIMEProblemJSON) == MIMEProblemJSON {
+		problem := Problem{
+			Type:      "about:blank",
+			Title:     statusText(status),
+			Status:    status,
+			Detail:    message,
+			Instance:  c.Path(),
+			Code:      code,
+			Errors:    details,
+			RequestID: requestID,
+		}
+		if detail != "" && details == nil {
+			problem.Detail = message + ": " + detail
+		}
+		return c.Status(status).JSON(problem, MIMEProblemJSON)
+	}
+
+	return c.Status(status).JSON(Response{
+		Success:   false,
+		Message:   message,
+		Code:      code,
+		Error:     detail,
+		Details:   details,
+		RequestID: requestID,
+	})
+}
+
+func statusText(status int) string {
+	if status == StatusClientClosedRequest {
+		return "Client Closed Request"
+	}
+	return http.StatusText(status)
+}
+
+// capitalize turns an error message into a response message
+func capitalize(message string) string {
+	first, size := utf8.DecodeRuneInString(message)
+	return string(unicode.ToUpper(first)) + message[size:]
 }
@@ -2,6 +2,9 @@ package utils
 
 import (
 	"errors"
+	"fmt"
+	"reflect"
+	"strings"
 
 	"github.com/go-playground/validator/v10"
 )
@@ -20,12 +23,84 @@ var Validator *validator.Validate
 
 func InitValidator() {
 	Validator = validator.New()
+	// Report fields by the names clients send
+	Validator.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
 }
 
 func ValidateStruct(s interface{}) error {
 	return Validator.Struct(s)
 }
 
+// FieldError describes one field that failed validation
+type FieldError struct {
+	// Field is the path of the field in the request, such as "email" or
+	// "preferences[0].type"
+	Field string `json:"field" example:"email"`
+	// Rule is the validation rule that failed, such as "required"
+	Rule    string `json:"rule" example:"email"`
+	Param   string `json:"param,omitempty" example:""`
+	Message string `json:"message" example:"email must be a valid email address"`
+}
+
+// ValidationDetails describes each field of a failed validation
+func ValidationDetails(err error) []FieldError {
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return nil
+	}
+
+	details := make([]FieldError, 0, len(validationErrors))
+	for _, fieldErr := range validationErrors {
+		// The namespace starts with the validated struct's type name
+		_, field, _ := strings.Cut(fieldErr.Namespace(), ".")
+		details = append(details, FieldError{
+			Field:   field,
+			Rule:    fieldErr.Tag(),
+			Param:   fieldErr.Param(),
+			Message: describeFieldError(field, fieldErr),
+		})
+	}
+	return details
+}
+
+func describeFieldError(field string, fieldErr validator.FieldError) string {
+	// Lengths count characters in strings and items in lists
+	unit := ""
+	switch fieldErr.Kind() {
+	case reflect.String:
+		unit = " characters"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		unit = " items"
+	}
+
+	switch fieldErr.Tag() {
+	case "required":
+		return fmt.Sprintf("%s is required", field)
+	case "email":
+		return fmt.Sprintf("%s must be a valid email address", field)
+	case "oneof":
+		return fmt.Sprintf("%s must be one of: %s", field, fieldErr.Param())
+	case "min":
+		return fmt.Sprintf("%s must be at least %s%s", field, fieldErr.Param(), unit)
+	case "max":
+		return fmt.Sprintf("%s must be at most %s%s", field, fieldErr.Param(), unit)
+	case "http_url":
+		return fmt.Sprintf("%s must be an http or https URL", field)
+	case "bcp47_language_tag":
+		return fmt.Sprintf("%s must be a language tag such as en or id-ID", field)
+	case "unique":
+		return fmt.Sprintf("%s must not contain duplicates", field)
+	default:
+		return fmt.Sprintf("%s failed the '%s' rule", field, fieldErr.Tag())
+	}
+}
+
 // IsValidRole checks if the provided role is valid
 func IsValidRole(role string) bool {
 	for _, validRole := range ValidRoles {
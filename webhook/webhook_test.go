@@ -0,0 +1,122 @@
+package webhook
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+)
+
+func TestVerify(t *testing.T) {
+	const secret = "whsec_test"
+	body := []byte(`{"type":"user.verified"}`)
+	sentAt := time.Unix(1_700_000_000, 0)
+
+	signed := func() http.Header {
+		header := http.Header{}
+		SetHeaders(header, secret, "user.verified", "delivery-1", sentAt, body)
+		return header
+	}
+
+	tests := []struct {
+		name   string
+		secret string
+		header func() http.Header
+		body   []byte
+		now    time.Time
+		want   error
+	}{
+		{name: "valid", secret: secret, header: signed, body: body, now: sentAt},
+		{name: "within tolerance", secret: secret, header: signed, body: body, now: sentAt.Add(DefaultTolerance)},
+		{name: "replayed after tolerance", secret: secret, header: signed, body: body, now: sentAt.Add(DefaultTolerance + time.Second), want: ErrStaleTimestamp},
+		{name: "timestamp in the future", secret: secret, header: signed, body: body, now: sentAt.Add(-DefaultTolerance - time.Second), want: ErrStaleTimestamp},
+		{name: "tampered body", secret: secret, header: signed, body: []byte(`{"type":"user.deleted"}`), now: sentAt, want: ErrInvalidSignature},
+		{name: "wrong secret", secret: "whsec_other", header: signed, body: body, now: sentAt, want: ErrInvalidSignature},
+		{
+			name:   "timestamp changed",
+			secret: secret,
+			header: func() http.Header {
+				header := signed()
+				header.Set(HeaderTimestamp, "1700000060")
+				return header
+			},
+			body: body,
+			now:  sentAt,
+			want: ErrInvalidSignature,
+		},
+		{
+			name:   "signature without scheme",
+			secret: secret,
+			header: func() http.Header {
+				header := signed()
+				header.Set(HeaderSignature, header.Get(HeaderSignature)[len(signaturePrefix):])
+				return header
+			},
+			body: body,
+			now:  sentAt,
+			want: ErrInvalidSignature,
+		},
+		{name: "unsigned", secret: secret, header: func() http.Header { return http.Header{} }, body: body, now: sentAt, want: ErrMissingSignature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Verify(tt.secret, tt.header(), tt.body, DefaultTolerance, tt.now)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestIsPublic(t *testing.T) {
+	tests := map[string]bool{
+		"93.184.216.34":   true,
+		"2606:4700::1111": true,
+		"127.0.0.1":       false,
+		"::1":             false,
+		"10.0.0.1":        false,
+		"172.16.5.4":      false,
+		"192.168.1.1":     false,
+		"169.254.169.254": false,
+		"fe80::1":         false,
+		"fd00:ec2::254":   false,
+		"100.64.0.1":      false,
+		"0.0.0.0":         false,
+		"::ffff:10.0.0.1": false,
+		"224.0.0.1":       false,
+	}
+
+	for address, want := range tests {
+		if got := IsPublic(netip.MustParseAddr(address)); got != want {
+			t.Errorf("IsPublic(%s) = %v, want %v", address, got, want)
+		}
+	}
+}
+
+func TestClientRefusesPrivateTargets(t *testing.T) {
+	var received int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received++
+	}))
+	defer server.Close()
+
+	_, err := NewClient(time.Second, false).Post(server.URL, "application/json", nil)
+	if !errors.Is(err, ErrPrivateTarget) {
+		t.Fatalf("expected ErrPrivateTarget, got %v", err)
+	}
+	if received != 0 {
+		t.Fatalf("expected the receiver not to be contacted, got %d requests", received)
+	}
+
+	response, err := NewClient(time.Second, true).Post(server.URL, "application/json", nil)
+	if err != nil {
+		t.Fatalf("expected private targets to be allowed, got %v", err)
+	}
+	response.Body.Close()
+	if received != 1 {
+		t.Fatalf("expected 1 request, got %d", received)
+	}
+}